@@ -0,0 +1,39 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package action
+
+import (
+	"math/big"
+)
+
+// call trace types, in the format of the trace_* api of parity
+const (
+	CallTraceTypeCall         = "call"
+	CallTraceTypeCreate       = "create"
+	CallTraceTypeSelfDestruct = "suicide"
+)
+
+// CallTrace is a call frame of an action, the call frames of an action are flattened in the order of being entered
+type CallTrace struct {
+	// Type is one of call, create and suicide
+	Type string
+	// CallType is the opcode of the call in lower case, like call, staticcall and delegatecall
+	CallType string
+	From     string
+	// To is the callee, or the contract created, or the beneficiary of the self-destructed contract
+	To      string
+	Value   *big.Int
+	Gas     uint64
+	GasUsed uint64
+	Input   []byte
+	// Output is the return data, or the code of the contract created
+	Output []byte
+	Error  string
+	// TraceAddress is the path of the call frame in the call tree, which is empty for the top call frame
+	TraceAddress []uint64
+	// Subtraces is the number of call frames entered from the call frame
+	Subtraces uint64
+}
@@ -164,6 +164,16 @@ func (p *Protocol) handleTransfer(ctx context.Context, elp action.Envelope, sm p
 		Amount:    tsf.Amount(),
 	})
 	receipt.AddTransactionLogs(depositLog...)
+	if protocol.CallTraceEnabled(ctx) {
+		receipt.AddCallTraces(&action.CallTrace{
+			Type:     action.CallTraceTypeCall,
+			CallType: action.CallTraceTypeCall,
+			From:     actionCtx.Caller.String(),
+			To:       recipientAddr.String(),
+			Value:    tsf.Amount(),
+			Input:    tsf.Payload(),
+		})
+	}
 
 	return receipt, nil
 }
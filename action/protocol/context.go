@@ -35,6 +35,10 @@ type (
 
 	vmConfigContextKey struct{}
 
+	callTraceContextKey struct{}
+
+	archiveStateContextKey struct{}
+
 	// TipInfo contains the tip block information
 	TipInfo struct {
 		Height        uint64
@@ -149,6 +153,7 @@ type (
 		UnstakedButNotClearSelfStakeAmount      bool
 		CheckStakingDurationUpperLimit          bool
 		FixRevertSnapshot                       bool
+		EnableStakingReader                     bool
 	}
 
 	// FeatureWithHeightCtx provides feature check functions.
@@ -307,6 +312,7 @@ func WithFeatureCtx(ctx context.Context) context.Context {
 			UnstakedButNotClearSelfStakeAmount:      !g.IsVanuatu(height),
 			CheckStakingDurationUpperLimit:          g.IsVanuatu(height),
 			FixRevertSnapshot:                       g.IsVanuatu(height),
+			EnableStakingReader:                     g.IsWake(height),
 		},
 	)
 }
@@ -395,3 +401,26 @@ func GetVMConfigCtx(ctx context.Context) (vm.Config, bool) {
 	cfg, ok := ctx.Value(vmConfigContextKey{}).(vm.Config)
 	return cfg, ok
 }
+
+// WithCallTraceCtx enables recording the call traces of the actions into their receipts
+func WithCallTraceCtx(ctx context.Context) context.Context {
+	return context.WithValue(ctx, callTraceContextKey{}, true)
+}
+
+// CallTraceEnabled returns whether the call traces of the actions are recorded
+func CallTraceEnabled(ctx context.Context) bool {
+	enabled, ok := ctx.Value(callTraceContextKey{}).(bool)
+	return ok && enabled
+}
+
+// WithArchiveStateCtx marks the state reader as the historical state of an archive node, which reads the states
+// at its height instead of the latest ones
+func WithArchiveStateCtx(ctx context.Context) context.Context {
+	return context.WithValue(ctx, archiveStateContextKey{}, true)
+}
+
+// ArchiveStateEnabled returns whether the state reader is the historical state of an archive node
+func ArchiveStateEnabled(ctx context.Context) bool {
+	enabled, ok := ctx.Value(archiveStateContextKey{}).(bool)
+	return ok && enabled
+}
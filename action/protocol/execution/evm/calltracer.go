@@ -0,0 +1,150 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package evm
+
+import (
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/iotexproject/iotex-address/address"
+
+	"github.com/iotexproject/iotex-core/v2/action"
+)
+
+// callTracer records the call frames of an execution as flat call traces. Like parity, the calls to
+// the precompiled contracts are not recorded.
+type callTracer struct {
+	traces      []*action.CallTrace
+	stack       []*action.CallTrace
+	precompiles map[common.Address]struct{}
+}
+
+var _ vm.EVMLogger = (*callTracer)(nil)
+
+func newCallTracer() *callTracer {
+	return &callTracer{}
+}
+
+// Traces returns the call traces recorded
+func (t *callTracer) Traces() []*action.CallTrace {
+	return t.traces
+}
+
+// CaptureTxStart implements the EVMLogger interface
+func (t *callTracer) CaptureTxStart(gasLimit uint64) {}
+
+// CaptureTxEnd implements the EVMLogger interface
+func (t *callTracer) CaptureTxEnd(restGas uint64) {}
+
+// CaptureStart implements the EVMLogger interface to record the top call frame
+func (t *callTracer) CaptureStart(env *vm.EVM, from common.Address, to common.Address, create bool, input []byte, gas uint64, value *big.Int) {
+	rules := env.ChainConfig().Rules(env.Context.BlockNumber, env.Context.Random != nil, env.Context.Time)
+	t.precompiles = make(map[common.Address]struct{})
+	for _, addr := range vm.ActivePrecompiles(rules) {
+		t.precompiles[addr] = struct{}{}
+	}
+	typ := vm.CALL
+	if create {
+		typ = vm.CREATE
+	}
+	t.push(newCallTrace(typ, from, to, input, gas, value, []uint64{}))
+}
+
+// CaptureEnd implements the EVMLogger interface
+func (t *callTracer) CaptureEnd(output []byte, gasUsed uint64, err error) {
+	t.pop(output, gasUsed, err)
+}
+
+// CaptureEnter implements the EVMLogger interface to record the call frame entered
+func (t *callTracer) CaptureEnter(typ vm.OpCode, from common.Address, to common.Address, input []byte, gas uint64, value *big.Int) {
+	if len(t.stack) == 0 {
+		return
+	}
+	if _, ok := t.precompiles[to]; ok && (typ == vm.CALL || typ == vm.STATICCALL) {
+		// a placeholder to be popped on exit
+		t.stack = append(t.stack, nil)
+		return
+	}
+	parent := t.stack[len(t.stack)-1]
+	if parent == nil {
+		return
+	}
+	traceAddress := make([]uint64, 0, len(parent.TraceAddress)+1)
+	traceAddress = append(append(traceAddress, parent.TraceAddress...), parent.Subtraces)
+	parent.Subtraces++
+	t.push(newCallTrace(typ, from, to, input, gas, value, traceAddress))
+}
+
+// CaptureExit implements the EVMLogger interface
+func (t *callTracer) CaptureExit(output []byte, gasUsed uint64, err error) {
+	t.pop(output, gasUsed, err)
+}
+
+// CaptureState implements the EVMLogger interface
+func (t *callTracer) CaptureState(pc uint64, op vm.OpCode, gas, cost uint64, scope *vm.ScopeContext, rData []byte, depth int, err error) {
+}
+
+// CaptureFault implements the EVMLogger interface
+func (t *callTracer) CaptureFault(pc uint64, op vm.OpCode, gas, cost uint64, scope *vm.ScopeContext, depth int, err error) {
+}
+
+func (t *callTracer) push(trace *action.CallTrace) {
+	t.traces = append(t.traces, trace)
+	t.stack = append(t.stack, trace)
+}
+
+func (t *callTracer) pop(output []byte, gasUsed uint64, err error) {
+	if len(t.stack) == 0 {
+		return
+	}
+	trace := t.stack[len(t.stack)-1]
+	t.stack = t.stack[:len(t.stack)-1]
+	if trace == nil {
+		return
+	}
+	trace.GasUsed = gasUsed
+	if len(output) > 0 {
+		trace.Output = common.CopyBytes(output)
+	}
+	if err != nil {
+		trace.Error = err.Error()
+	}
+}
+
+func newCallTrace(typ vm.OpCode, from common.Address, to common.Address, input []byte, gas uint64, value *big.Int, traceAddress []uint64) *action.CallTrace {
+	trace := &action.CallTrace{
+		From:         evmAddrToString(from),
+		To:           evmAddrToString(to),
+		Gas:          gas,
+		Input:        common.CopyBytes(input),
+		TraceAddress: traceAddress,
+	}
+	switch typ {
+	case vm.CREATE, vm.CREATE2:
+		trace.Type = action.CallTraceTypeCreate
+	case vm.SELFDESTRUCT:
+		trace.Type = action.CallTraceTypeSelfDestruct
+	default:
+		trace.Type = action.CallTraceTypeCall
+		trace.CallType = strings.ToLower(typ.String())
+	}
+	if value != nil {
+		trace.Value = new(big.Int).Set(value)
+	} else {
+		trace.Value = new(big.Int)
+	}
+	return trace
+}
+
+func evmAddrToString(addr common.Address) string {
+	ioAddr, err := address.FromBytes(addr[:])
+	if err != nil {
+		return ""
+	}
+	return ioAddr.String()
+}
@@ -0,0 +1,73 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package evm
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/v2/action"
+)
+
+func TestCallTracer(t *testing.T) {
+	r := require.New(t)
+	var (
+		env = vm.NewEVM(vm.BlockContext{BlockNumber: big.NewInt(1)}, vm.TxContext{}, nil, params.TestChainConfig, vm.Config{})
+		eoa = common.HexToAddress("0x01000000000000000000000000000000000000aa")
+		a   = common.HexToAddress("0x01000000000000000000000000000000000000bb")
+		b   = common.HexToAddress("0x01000000000000000000000000000000000000cc")
+		c   = common.HexToAddress("0x01000000000000000000000000000000000000dd")
+		// sha256 precompile
+		precompile = common.BytesToAddress([]byte{2})
+	)
+	ct := newCallTracer()
+	ct.CaptureStart(env, eoa, a, false, []byte{1}, 100000, big.NewInt(5))
+	ct.CaptureEnter(vm.DELEGATECALL, a, b, []byte{2}, 50000, nil)
+	ct.CaptureEnter(vm.STATICCALL, a, precompile, []byte{3}, 1000, nil)
+	ct.CaptureExit([]byte{4}, 100, nil)
+	ct.CaptureEnter(vm.CREATE2, a, c, []byte{5}, 20000, big.NewInt(1))
+	ct.CaptureExit([]byte{6}, 10000, nil)
+	ct.CaptureExit(nil, 30000, vm.ErrExecutionReverted)
+	ct.CaptureEnter(vm.SELFDESTRUCT, a, eoa, nil, 0, big.NewInt(4))
+	ct.CaptureExit(nil, 0, nil)
+	ct.CaptureEnd([]byte{7}, 80000, nil)
+
+	traces := ct.Traces()
+	r.Len(traces, 4)
+	for i, expected := range []struct {
+		typ, callType string
+		from, to      common.Address
+		traceAddress  []uint64
+		subtraces     uint64
+		gasUsed       uint64
+		output        []byte
+		err           string
+	}{
+		{action.CallTraceTypeCall, "call", eoa, a, []uint64{}, 2, 80000, []byte{7}, ""},
+		{action.CallTraceTypeCall, "delegatecall", a, b, []uint64{0}, 1, 30000, nil, vm.ErrExecutionReverted.Error()},
+		{action.CallTraceTypeCreate, "", a, c, []uint64{0, 0}, 0, 10000, []byte{6}, ""},
+		{action.CallTraceTypeSelfDestruct, "", a, eoa, []uint64{1}, 0, 0, nil, ""},
+	} {
+		trace := traces[i]
+		r.Equal(expected.typ, trace.Type)
+		r.Equal(expected.callType, trace.CallType)
+		r.Equal(evmAddrToString(expected.from), trace.From)
+		r.Equal(evmAddrToString(expected.to), trace.To)
+		r.Equal(expected.traceAddress, trace.TraceAddress)
+		r.Equal(expected.subtraces, trace.Subtraces)
+		r.Equal(expected.gasUsed, trace.GasUsed)
+		r.Equal(expected.output, trace.Output)
+		r.Equal(expected.err, trace.Error)
+		r.NotNil(trace.Value)
+	}
+	r.Equal(big.NewInt(5), traces[0].Value)
+	r.Zero(traces[1].Value.Sign())
+}
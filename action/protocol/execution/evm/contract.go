@@ -189,10 +189,7 @@ func newContract(addr hash.Hash160, account *state.Account, sm protocol.StateMan
 	options := []mptrie.Option{
 		mptrie.KVStoreOption(protocol.NewKVStoreForTrieWithStateManager(ContractKVNameSpace, sm)),
 		mptrie.KeyLengthOption(len(hash.Hash256{})),
-		mptrie.HashFuncOption(func(data []byte) []byte {
-			h := hash.Hash256b(append(addr[:], data...))
-			return h[:]
-		}),
+		mptrie.HashFuncOption(storageHashFunc(addr)),
 	}
 	if account.Root != hash.ZeroHash256 {
 		options = append(options, mptrie.RootHashOption(account.Root[:]))
@@ -211,3 +208,63 @@ func newContract(addr hash.Hash160, account *state.Account, sm protocol.StateMan
 	c.trie = tr
 	return c, nil
 }
+
+// ContractStorageProof returns the value and the merkle proof of a storage slot of the contract
+func ContractStorageProof(sm protocol.StateManager, addr hash.Hash160, root hash.Hash256, key hash.Hash256) ([]byte, [][]byte, error) {
+	options := []mptrie.Option{
+		mptrie.KVStoreOption(protocol.NewKVStoreForTrieWithStateManager(ContractKVNameSpace, sm)),
+		mptrie.KeyLengthOption(len(hash.Hash256{})),
+		mptrie.HashFuncOption(storageHashFunc(addr)),
+	}
+	if root != hash.ZeroHash256 {
+		options = append(options, mptrie.RootHashOption(root[:]))
+	}
+	tr, err := mptrie.New(options...)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to create storage trie for contract %x", addr)
+	}
+	if err := tr.Start(context.Background()); err != nil {
+		return nil, nil, err
+	}
+	proof, err := tr.Proof(key[:])
+	if err != nil {
+		return nil, nil, err
+	}
+	value, err := tr.Get(key[:])
+	switch errors.Cause(err) {
+	case nil:
+	case trie.ErrNotExist:
+		value = nil
+	default:
+		return nil, nil, err
+	}
+	return value, proof, nil
+}
+
+// VerifyContractStorageProof verifies the merkle proof of a storage slot against the storage root of the contract,
+// and returns the value of the slot
+func VerifyContractStorageProof(addr hash.Hash160, root hash.Hash256, key hash.Hash256, proof [][]byte) ([]byte, error) {
+	rootHash := root[:]
+	if root == hash.ZeroHash256 {
+		// zero root indicates an empty storage trie
+		tr, err := mptrie.New(mptrie.KeyLengthOption(len(hash.Hash256{})), mptrie.HashFuncOption(storageHashFunc(addr)))
+		if err != nil {
+			return nil, err
+		}
+		if err := tr.Start(context.Background()); err != nil {
+			return nil, err
+		}
+		if rootHash, err = tr.RootHash(); err != nil {
+			return nil, err
+		}
+	}
+	return mptrie.VerifyProof(rootHash, key[:], proof, storageHashFunc(addr))
+}
+
+// storageHashFunc returns the hash func of the storage trie, which is salted with the contract address
+func storageHashFunc(addr hash.Hash160) mptrie.HashFunc {
+	return func(data []byte) []byte {
+		h := hash.Hash256b(append(addr[:], data...))
+		return h[:]
+	}
+}
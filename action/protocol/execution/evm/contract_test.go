@@ -19,6 +19,7 @@ import (
 	"github.com/iotexproject/iotex-core/v2/action/protocol"
 	accountutil "github.com/iotexproject/iotex-core/v2/action/protocol/account/util"
 	"github.com/iotexproject/iotex-core/v2/db/batch"
+	"github.com/iotexproject/iotex-core/v2/db/trie"
 	"github.com/iotexproject/iotex-core/v2/state"
 	"github.com/iotexproject/iotex-core/v2/test/identityset"
 	"github.com/iotexproject/iotex-core/v2/test/mock/mock_chainmanager"
@@ -238,3 +239,43 @@ func TestSnapshot(t *testing.T) {
 		testfunc(true)
 	})
 }
+
+func TestContractStorageProof(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	sm, err := initMockStateManager(ctrl)
+	require.NoError(err)
+	addr := hash.BytesToHash160(identityset.Address(28).Bytes())
+
+	// empty storage
+	value, proof, err := ContractStorageProof(sm, addr, hash.ZeroHash256, _k1b)
+	require.NoError(err)
+	require.Nil(value)
+	_, err = VerifyContractStorageProof(addr, hash.ZeroHash256, _k1b, proof)
+	require.Equal(trie.ErrNotExist, errors.Cause(err))
+
+	s, err := state.NewAccount()
+	require.NoError(err)
+	c, err := newContract(addr, s, sm, false)
+	require.NoError(err)
+	require.NoError(c.SetState(_k1b, _v1b[:]))
+	require.NoError(c.SetState(_k2b, _v2b[:]))
+	require.NoError(c.Commit())
+	root := c.SelfState().Root
+
+	value, proof, err = ContractStorageProof(sm, addr, root, _k1b)
+	require.NoError(err)
+	require.Equal(_v1b[:], value)
+	value, err = VerifyContractStorageProof(addr, root, _k1b, proof)
+	require.NoError(err)
+	require.Equal(_v1b[:], value)
+	// the storage trie is salted with the contract address
+	_, err = VerifyContractStorageProof(hash.BytesToHash160(identityset.Address(29).Bytes()), root, _k1b, proof)
+	require.Error(err)
+
+	value, proof, err = ContractStorageProof(sm, addr, root, _k3b)
+	require.NoError(err)
+	require.Nil(value)
+	_, err = VerifyContractStorageProof(addr, root, _k3b, proof)
+	require.Equal(trie.ErrNotExist, errors.Cause(err))
+}
@@ -94,6 +94,8 @@ type (
 		featureCtx  protocol.FeatureCtx
 		actionCtx   protocol.ActionCtx
 		helperCtx   HelperContext
+		// stakingReader is nil if the staking reader is not enabled
+		stakingReader *stakingReader
 	}
 
 	stateDB interface {
@@ -216,6 +218,7 @@ func newParams(
 		featureCtx,
 		actionCtx,
 		helperCtx,
+		nil,
 	}, nil
 }
 
@@ -254,6 +257,12 @@ func ExecuteContract(
 	if err != nil {
 		return nil, nil, err
 	}
+	ps.stakingReader = newStakingReader(ctx, sm)
+	var ct *callTracer
+	if protocol.CallTraceEnabled(ctx) && ps.evmConfig.Tracer == nil {
+		ct = newCallTracer()
+		ps.evmConfig.Tracer = ct
+	}
 	retval, depositGas, remainingGas, contractAddress, statusCode, err := executeInEVM(ctx, ps, stateDB)
 	if err != nil {
 		return nil, nil, err
@@ -308,6 +317,9 @@ func ExecuteContract(
 		ps.featureCtx.AddOutOfGasToTransactionLog && receipt.Status == uint64(iotextypes.ReceiptStatus_ErrCodeStoreOutOfGas) {
 		receipt.AddTransactionLogs(stateDB.TransactionLogs()...)
 	}
+	if ct != nil {
+		receipt.AddCallTraces(ct.Traces()...)
+	}
 	stateDB.clear()
 
 	if ps.featureCtx.SetRevertMessageToReceipt && receipt.Status == uint64(iotextypes.ReceiptStatus_ErrExecutionReverted) && retval != nil && bytes.Equal(retval[:4], _revertSelector) {
@@ -453,6 +465,12 @@ func executeInEVM(ctx context.Context, evmParams *Params, stateDB stateDB) ([]by
 		accessList types.AccessList
 	)
 	evm := vm.NewEVM(evmParams.context, evmParams.txCtx, stateDB, chainConfig, evmParams.evmConfig)
+	if tracer := evmParams.evmConfig.Tracer; tracer != nil {
+		tracer.CaptureTxStart(evmParams.gas)
+		defer func() {
+			tracer.CaptureTxEnd(remainingGas)
+		}()
+	}
 	if g.IsOkhotsk(blockHeight) {
 		accessList = evmParams.accessList
 	}
@@ -488,6 +506,11 @@ func executeInEVM(ctx context.Context, evmParams *Params, stateDB stateDB) ([]by
 				contractRawAddress = contractAddress.String()
 			}
 		}
+	} else if sp := evmParams.stakingReader; sp != nil && *evmParams.contract == _stakingReaderAddr {
+		stateDB.SetNonce(evmParams.txCtx.Origin, stateDB.GetNonce(evmParams.txCtx.Origin)+1)
+		// the go-ethereum fork has no per-EVM precompiles, so only the executions sent directly to the staking
+		// reader are served, the calls from contracts go to the account without code as before
+		ret, remainingGas, evmErr = runStakingReader(evm, sp, executor, evmParams.data, remainingGas, amount)
 	} else {
 		stateDB.SetNonce(evmParams.txCtx.Origin, stateDB.GetNonce(evmParams.txCtx.Origin)+1)
 		// process contract
@@ -634,8 +657,6 @@ func SimulateExecution(
 	if err := ex.SanityCheck(); err != nil {
 		return nil, nil, err
 	}
-	bcCtx := protocol.MustGetBlockchainCtx(ctx)
-	g := genesis.MustExtractGenesisContext(ctx)
 	ctx = protocol.WithActionCtx(
 		ctx,
 		protocol.ActionCtx{
@@ -657,16 +678,53 @@ func SimulateExecution(
 			return nil, nil, err
 		}
 	}
-	ctx = protocol.WithFeatureCtx(protocol.WithBlockCtx(
-		ctx,
-		protocol.BlockCtx{
-			BlockHeight:    bcCtx.Tip.Height + 1,
-			BlockTimeStamp: bcCtx.Tip.Timestamp.Add(g.BlockInterval),
-			GasLimit:       g.BlockGasLimitByHeight(bcCtx.Tip.Height + 1),
-			Producer:       zeroAddr,
-			BaseFee:        protocol.CalcBaseFee(g.Blockchain, &bcCtx.Tip),
-			ExcessBlobGas:  protocol.CalcExcessBlobGas(bcCtx.Tip.ExcessBlobGas, bcCtx.Tip.BlobGasUsed),
-		},
-	))
+	ctx = withSimulationBlockCtx(ctx, zeroAddr, cfg)
+	if cfg.StateOverride != nil {
+		if err := cfg.StateOverride(ctx, sm); err != nil {
+			return nil, nil, err
+		}
+	}
 	return ExecuteContract(ctx, sm, ex)
 }
+
+// withSimulationBlockCtx sets the context of the block following the tip, which the execution is simulated in
+func withSimulationBlockCtx(ctx context.Context, producer address.Address, cfg *protocol.SimulateOptionConfig) context.Context {
+	var (
+		bcCtx = protocol.MustGetBlockchainCtx(ctx)
+		g     = genesis.MustExtractGenesisContext(ctx)
+	)
+	blkCtx := protocol.BlockCtx{
+		BlockHeight:    bcCtx.Tip.Height + 1,
+		BlockTimeStamp: bcCtx.Tip.Timestamp.Add(g.BlockInterval),
+		GasLimit:       g.BlockGasLimitByHeight(bcCtx.Tip.Height + 1),
+		Producer:       producer,
+		BaseFee:        protocol.CalcBaseFee(g.Blockchain, &bcCtx.Tip),
+		ExcessBlobGas:  protocol.CalcExcessBlobGas(bcCtx.Tip.ExcessBlobGas, bcCtx.Tip.BlobGasUsed),
+	}
+	if cfg.BlockOverride != nil {
+		cfg.BlockOverride(&blkCtx)
+	}
+	return protocol.WithFeatureCtx(protocol.WithBlockCtx(ctx, blkCtx))
+}
+
+// SimulationPrecompiles returns the addresses of the precompiles active in the block which the execution is
+// simulated in
+func SimulationPrecompiles(ctx context.Context, opts ...protocol.SimulateOption) ([]common.Address, error) {
+	cfg := &protocol.SimulateOptionConfig{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	ctx = withSimulationBlockCtx(ctx, nil, cfg)
+	var (
+		blkCtx    = protocol.MustGetBlockCtx(ctx)
+		g         = genesis.MustExtractGenesisContext(ctx)
+		helperCtx = mustGetHelperCtx(ctx)
+	)
+	chainConfig, err := getChainConfig(g.Blockchain, blkCtx.BlockHeight, protocol.MustGetBlockchainCtx(ctx).EvmNetworkID, helperCtx.GetBlockTime)
+	if err != nil {
+		return nil, err
+	}
+	rules := chainConfig.Rules(new(big.Int).SetUint64(blkCtx.BlockHeight), g.IsSumatra(blkCtx.BlockHeight), uint64(blkCtx.BlockTimeStamp.Unix()))
+	// copy the addresses, as the slice of go-ethereum is shared
+	return append([]common.Address{}, vm.ActivePrecompiles(rules)...), nil
+}
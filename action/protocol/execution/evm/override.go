@@ -0,0 +1,100 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package evm
+
+import (
+	"bytes"
+	"context"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/v2/action/protocol"
+	"github.com/iotexproject/iotex-core/v2/action/protocol/account/accountpb"
+	accountutil "github.com/iotexproject/iotex-core/v2/action/protocol/account/util"
+	"github.com/iotexproject/iotex-core/v2/state"
+)
+
+type (
+	// OverrideAccount is the set of fields to override of an account, nil fields are left as is
+	OverrideAccount struct {
+		// Nonce is the pending nonce, i.e., the nonce of the next action of the account
+		Nonce     *uint64
+		Code      []byte
+		Balance   *big.Int
+		StateDiff map[common.Hash]common.Hash
+	}
+
+	// StateOverride is the set of accounts to override before a simulation
+	StateOverride map[common.Address]OverrideAccount
+)
+
+// Apply applies the overrides to the state manager, which is supposed to be a working set that is never committed
+func (so StateOverride) Apply(ctx context.Context, sm protocol.StateManager) error {
+	if len(so) == 0 {
+		return nil
+	}
+	stateDB, err := prepareStateDB(ctx, sm)
+	if err != nil {
+		return err
+	}
+	addrs := make([]common.Address, 0, len(so))
+	for addr := range so {
+		addrs = append(addrs, addr)
+	}
+	sort.Slice(addrs, func(i, j int) bool { return bytes.Compare(addrs[i][:], addrs[j][:]) < 0 })
+	for _, evmAddr := range addrs {
+		override := so[evmAddr]
+		if override.Nonce != nil || override.Balance != nil {
+			if err := overrideAccount(stateDB, evmAddr, override); err != nil {
+				return err
+			}
+		}
+		if override.Code != nil {
+			stateDB.SetCode(evmAddr, override.Code)
+		}
+		for k, v := range override.StateDiff {
+			stateDB.SetState(evmAddr, k, v)
+		}
+		if err := stateDB.Error(); err != nil {
+			return errors.Wrapf(err, "failed to override account %s", evmAddr.Hex())
+		}
+	}
+	return stateDB.CommitContracts()
+}
+
+func overrideAccount(stateDB *StateDBAdapter, evmAddr common.Address, override OverrideAccount) error {
+	addr, err := address.FromBytes(evmAddr[:])
+	if err != nil {
+		return err
+	}
+	acct, err := stateDB.accountState(evmAddr)
+	if err != nil {
+		return errors.Wrapf(err, "failed to load account %s", evmAddr.Hex())
+	}
+	if override.Balance != nil {
+		if override.Balance.Sign() < 0 {
+			return errors.Errorf("negative balance override of account %s", evmAddr.Hex())
+		}
+		acct.Balance = new(big.Int).Set(override.Balance)
+	}
+	if override.Nonce != nil {
+		overrideNonce(acct, *override.Nonce)
+	}
+	return accountutil.StoreAccount(stateDB.sm, addr, acct)
+}
+
+// overrideNonce sets the pending nonce of the account, converting it into a zero-nonce account, whose
+// pending nonce equals to the nonce stored
+func overrideNonce(acct *state.Account, nonce uint64) {
+	acPb := acct.ToProto()
+	acPb.Nonce = nonce
+	acPb.Type = accountpb.AccountType_ZERO_NONCE
+	acct.FromProto(acPb)
+}
@@ -0,0 +1,70 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package evm
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/v2/action/protocol"
+	accountutil "github.com/iotexproject/iotex-core/v2/action/protocol/account/util"
+	"github.com/iotexproject/iotex-core/v2/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/v2/test/identityset"
+)
+
+func TestStateOverride(t *testing.T) {
+	r := require.New(t)
+	ctrl := gomock.NewController(t)
+	sm, err := initMockStateManager(ctrl)
+	r.NoError(err)
+
+	var (
+		g   = genesis.TestDefault()
+		ctx = protocol.WithFeatureCtx(protocol.WithBlockCtx(
+			genesis.WithGenesisContext(protocol.WithActionCtx(context.Background(), protocol.ActionCtx{
+				Caller:   identityset.Address(27),
+				ReadOnly: true,
+			}), g),
+			protocol.BlockCtx{BlockHeight: g.VanuatuBlockHeight},
+		))
+		funded   = identityset.Address(28)
+		contract = common.HexToAddress("0x0000000000000000000000000000000000001234")
+		nonce    = uint64(7)
+		slot     = common.HexToHash("0x01")
+		value    = common.HexToHash("0xabcd")
+	)
+	acct, err := accountutil.LoadOrCreateAccount(sm, funded)
+	r.NoError(err)
+	r.NoError(acct.AddBalance(big.NewInt(100)))
+	r.NoError(accountutil.StoreAccount(sm, funded, acct))
+
+	r.NoError(StateOverride(nil).Apply(ctx, sm))
+	r.ErrorContains(StateOverride{
+		common.BytesToAddress(funded.Bytes()): {Balance: big.NewInt(-1)},
+	}.Apply(ctx, sm), "negative balance override")
+
+	r.NoError(StateOverride{
+		common.BytesToAddress(funded.Bytes()): {Balance: big.NewInt(5), Nonce: &nonce},
+		contract: {
+			Code:      []byte{0x60, 0x00},
+			StateDiff: map[common.Hash]common.Hash{slot: value},
+		},
+	}.Apply(ctx, sm))
+
+	stateDB, err := prepareStateDB(ctx, sm)
+	r.NoError(err)
+	fundedAddr := common.BytesToAddress(funded.Bytes())
+	r.EqualValues(5, stateDB.GetBalance(fundedAddr).Uint64())
+	r.Equal(nonce, stateDB.GetNonce(fundedAddr))
+	r.Equal([]byte{0x60, 0x00}, stateDB.GetCode(contract))
+	r.Equal(value, stateDB.GetState(contract, slot))
+	r.NoError(stateDB.Error())
+}
@@ -0,0 +1,158 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package evm
+
+import (
+	"context"
+	"encoding/hex"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/holiman/uint256"
+	"github.com/iotexproject/iotex-proto/golang/iotexapi"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/iotexproject/iotex-core/v2/action/protocol"
+	stakingabi "github.com/iotexproject/iotex-core/v2/action/protocol/staking/ethabi/v1"
+	"github.com/iotexproject/iotex-core/v2/pkg/log"
+)
+
+const (
+	// _stakingProtocolID is the id of the staking protocol in the registry
+	_stakingProtocolID = "staking"
+	// _stakingReaderBaseGas is the gas of a call to the staking reader
+	_stakingReaderBaseGas = 20000
+	// _stakingReaderWordGas is the gas of each word of the input, which is charged like a cold storage read, as
+	// the input words like bucket indexes are read from the state one by one
+	_stakingReaderWordGas = 2100
+	// _stakingReaderItemGas is the gas of each candidate or bucket requested, which is charged by the pagination
+	// limit or the number of indexes of the call, as the gas is charged before the state is read
+	_stakingReaderItemGas = 2100
+)
+
+type (
+	// NativeStakingReader reads the native staking states in the execution of contracts
+	NativeStakingReader interface {
+		ReadNativeState(context.Context, protocol.StateManager, []byte, ...[]byte) ([]byte, error)
+	}
+
+	// stakingReader serves the read methods of the staking ABI (candidates, buckets by indexes or voter, and
+	// total staking amount) from the native state to the executions sent directly to its address
+	stakingReader struct {
+		ctx    context.Context
+		sm     protocol.StateManager
+		reader NativeStakingReader
+	}
+)
+
+// _stakingReaderAddr is next to the precompiles added by the go-ethereum fork. The staking reader is not a
+// precompile: the precompiles of the fork are a global set shared by all EVMs and not switched by the heights,
+// so it is not registered there, and the calls from contracts to the address don't reach it. It is neither in
+// the access list nor in the precompiles of eth_createAccessList
+var _stakingReaderAddr = common.BytesToAddress([]byte{128, 3})
+
+// newStakingReader returns the staking reader if it is enabled and the staking protocol is registered
+func newStakingReader(ctx context.Context, sm protocol.StateManager) *stakingReader {
+	if !protocol.MustGetFeatureCtx(ctx).EnableStakingReader {
+		return nil
+	}
+	reg, ok := protocol.GetRegistry(ctx)
+	if !ok {
+		return nil
+	}
+	p, ok := reg.Find(_stakingProtocolID)
+	if !ok {
+		return nil
+	}
+	reader, ok := p.(NativeStakingReader)
+	if !ok {
+		return nil
+	}
+	return &stakingReader{
+		ctx:    ctx,
+		sm:     sm,
+		reader: reader,
+	}
+}
+
+// RequiredGas returns the gas of the call, which is charged by the size of the input and the number of the
+// candidates or buckets requested
+func (sp *stakingReader) RequiredGas(input []byte) uint64 {
+	gas := _stakingReaderBaseGas + uint64(len(input)+31)/32*_stakingReaderWordGas
+	if len(input) < 4 {
+		return gas
+	}
+	sctx, err := stakingabi.BuildReadStateRequest(input)
+	if err != nil || len(sctx.Parameters().Arguments) == 0 {
+		return gas
+	}
+	req := &iotexapi.ReadStakingDataRequest{}
+	if err := proto.Unmarshal(sctx.Parameters().Arguments[0], req); err != nil {
+		return gas
+	}
+	return gas + requestedItems(req)*_stakingReaderItemGas
+}
+
+// Run runs the call and returns the ABI encoded result, a call to an unsupported method is reverted
+func (sp *stakingReader) Run(input []byte) ([]byte, error) {
+	if len(input) < 4 {
+		return nil, vm.ErrExecutionReverted
+	}
+	sctx, err := stakingabi.BuildReadStateRequest(input)
+	if err != nil {
+		log.T(sp.ctx).Debug("invalid staking reader call", zap.Error(err))
+		return nil, vm.ErrExecutionReverted
+	}
+	data, err := sp.reader.ReadNativeState(sp.ctx, sp.sm, sctx.Parameters().MethodName, sctx.Parameters().Arguments...)
+	if err != nil {
+		log.T(sp.ctx).Debug("failed to read native staking state", zap.Error(err))
+		return nil, vm.ErrExecutionReverted
+	}
+	ret, err := sctx.EncodeToEth(&iotexapi.ReadStateResponse{Data: data})
+	if err != nil {
+		log.T(sp.ctx).Debug("failed to encode native staking state", zap.Error(err))
+		return nil, vm.ErrExecutionReverted
+	}
+	return hex.DecodeString(ret)
+}
+
+// requestedItems returns the max number of the candidates or buckets returned for the request
+func requestedItems(req *iotexapi.ReadStakingDataRequest) uint64 {
+	switch {
+	case req.GetBuckets() != nil:
+		return uint64(req.GetBuckets().GetPagination().GetLimit())
+	case req.GetBucketsByVoter() != nil:
+		return uint64(req.GetBucketsByVoter().GetPagination().GetLimit())
+	case req.GetBucketsByCandidate() != nil:
+		return uint64(req.GetBucketsByCandidate().GetPagination().GetLimit())
+	case req.GetCandidates() != nil:
+		return uint64(req.GetCandidates().GetPagination().GetLimit())
+	case req.GetBucketsByIndexes() != nil:
+		return uint64(len(req.GetBucketsByIndexes().GetIndex()))
+	default:
+		return 1
+	}
+}
+
+// runStakingReader runs an execution sent to the staking reader in the way EVM runs a precompile
+func runStakingReader(evm *vm.EVM, sp *stakingReader, caller vm.ContractRef, input []byte, gas uint64, value *uint256.Int) (ret []byte, leftOverGas uint64, err error) {
+	if tracer := evm.Config.Tracer; tracer != nil {
+		tracer.CaptureStart(evm, caller.Address(), _stakingReaderAddr, false, input, gas, value.ToBig())
+		defer func(startGas uint64) {
+			tracer.CaptureEnd(ret, startGas-leftOverGas, err)
+		}(gas)
+	}
+	if !value.IsZero() {
+		// the staking reader is not payable
+		return nil, gas, vm.ErrExecutionReverted
+	}
+	ret, leftOverGas, err = vm.RunPrecompiledContract(sp, input, gas)
+	if err != nil && err != vm.ErrExecutionReverted {
+		leftOverGas = 0
+	}
+	return ret, leftOverGas, err
+}
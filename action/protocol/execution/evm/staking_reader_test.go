@@ -0,0 +1,147 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package evm
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/holiman/uint256"
+	"github.com/iotexproject/iotex-proto/golang/iotexapi"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/iotexproject/iotex-core/v2/action/protocol"
+	"github.com/iotexproject/iotex-core/v2/blockchain/genesis"
+)
+
+type nativeStakingReaderFunc func(context.Context, protocol.StateManager, []byte, ...[]byte) ([]byte, error)
+
+func (f nativeStakingReaderFunc) ReadNativeState(ctx context.Context, sm protocol.StateManager, method []byte, args ...[]byte) ([]byte, error) {
+	return f(ctx, sm, method, args...)
+}
+
+func TestStakingReader(t *testing.T) {
+	r := require.New(t)
+	g := genesis.TestDefault()
+	ctx := genesis.WithGenesisContext(context.Background(), g)
+
+	t.Run("gated by the hardfork", func(t *testing.T) {
+		g.WakeBlockHeight = 10
+		ctx := genesis.WithGenesisContext(protocol.WithRegistry(context.Background(), protocol.NewRegistry()), g)
+		r.Nil(newStakingReader(protocol.WithFeatureCtx(protocol.WithBlockCtx(ctx, protocol.BlockCtx{BlockHeight: 9})), nil))
+		// the staking protocol is not registered
+		r.Nil(newStakingReader(protocol.WithFeatureCtx(protocol.WithBlockCtx(ctx, protocol.BlockCtx{BlockHeight: 10})), nil))
+	})
+
+	totalStakingAmount, err := hex.DecodeString("d201114a")
+	r.NoError(err)
+	sp := &stakingReader{
+		ctx: ctx,
+		reader: nativeStakingReaderFunc(func(_ context.Context, _ protocol.StateManager, method []byte, _ ...[]byte) ([]byte, error) {
+			m := iotexapi.ReadStakingDataMethod{}
+			if err := proto.Unmarshal(method, &m); err != nil {
+				return nil, err
+			}
+			if m.Method != iotexapi.ReadStakingDataMethod_TOTAL_STAKING_AMOUNT {
+				return nil, errors.New("unsupported method")
+			}
+			return proto.Marshal(&iotextypes.AccountMeta{Balance: "100000000000000000000"})
+		}),
+	}
+	r.EqualValues(_stakingReaderBaseGas+_stakingReaderWordGas+_stakingReaderItemGas, sp.RequiredGas(totalStakingAmount))
+	ret, err := sp.Run(totalStakingAmount)
+	r.NoError(err)
+	r.Equal("0000000000000000000000000000000000000000000000056bc75e2d63100000", hex.EncodeToString(ret))
+
+	// candidates(1, 2) is charged by the pagination limit
+	candidates, err := hex.DecodeString("c473090600000000000000000000000000000000000000000000000000000000000000010000000000000000000000000000000000000000000000000000000000000002")
+	r.NoError(err)
+	r.EqualValues(_stakingReaderBaseGas+3*_stakingReaderWordGas+2*_stakingReaderItemGas, sp.RequiredGas(candidates))
+	r.EqualValues(_stakingReaderBaseGas+_stakingReaderWordGas, sp.RequiredGas([]byte{0, 0, 0, 0}))
+
+	// invalid call data and unsupported methods are reverted
+	for _, data := range [][]byte{{0xd2, 0x01}, {0, 0, 0, 0}, candidates} {
+		_, err = sp.Run(data)
+		r.Equal(vm.ErrExecutionReverted, err)
+	}
+
+	t.Run("not a precompile of the EVM", func(t *testing.T) {
+		_, ok := vm.PrecompiledContractsCancun[_stakingReaderAddr]
+		r.False(ok)
+		// a contract calling the staking reader with zero value, like a call in the blocks before Wake
+		stateDB, err := state.New(types.EmptyRootHash, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+		r.NoError(err)
+		contract := common.HexToAddress("0x1234")
+		code, err := hex.DecodeString("3660006000376000600036600060006180035af1503d600060003e3d6000f3")
+		r.NoError(err)
+		stateDB.SetCode(contract, code)
+		evm := vm.NewEVM(vm.BlockContext{
+			CanTransfer: CanTransfer,
+			Transfer:    MakeTransfer,
+			BlockNumber: big.NewInt(1),
+			Random:      &common.Hash{},
+		}, vm.TxContext{}, stateDB, params.MergedTestChainConfig, vm.Config{})
+		caller := vm.AccountRef(common.HexToAddress("0x5678"))
+		for _, to := range []common.Address{contract, _stakingReaderAddr} {
+			ret, _, err := evm.Call(caller, to, totalStakingAmount, 100000, uint256.NewInt(0))
+			r.NoError(err)
+			r.Empty(ret)
+			// no account is created at the staking reader address
+			r.False(stateDB.Exist(_stakingReaderAddr))
+		}
+	})
+}
+
+func TestSimulationPrecompiles(t *testing.T) {
+	r := require.New(t)
+	g := genesis.TestDefault()
+	for _, h := range []*uint64{
+		&g.BeringBlockHeight, &g.GreenlandBlockHeight, &g.IcelandBlockHeight,
+		&g.OkhotskBlockHeight, &g.RedseaBlockHeight, &g.SumatraBlockHeight,
+	} {
+		*h = 1
+	}
+	g.VanuatuBlockHeight = 20
+	g.WakeBlockHeight = 11
+	ctx := protocol.WithBlockchainCtx(genesis.WithGenesisContext(context.Background(), g), protocol.BlockchainCtx{
+		Tip: protocol.TipInfo{
+			Height:    9,
+			Timestamp: time.Unix(g.Timestamp+45, 0),
+		},
+		EvmNetworkID: 4689,
+	})
+	ctx = WithHelperCtx(ctx, HelperContext{
+		GetBlockTime: func(h uint64) (time.Time, error) {
+			return time.Unix(g.Timestamp+int64(h)*5, 0), nil
+		},
+	})
+
+	precompiles, err := SimulationPrecompiles(ctx)
+	r.NoError(err)
+	r.Equal(vm.PrecompiledAddressesBerlin, precompiles)
+
+	// the precompiles are the ones of the block overridden
+	precompiles, err = SimulationPrecompiles(ctx, protocol.WithSimulateBlockOverride(func(blkCtx *protocol.BlockCtx) {
+		blkCtx.BlockHeight = 30
+		blkCtx.BlockTimeStamp = time.Unix(g.Timestamp+150, 0)
+	}))
+	r.NoError(err)
+	r.Equal(vm.PrecompiledAddressesCancun, precompiles)
+	// the staking reader is not a precompile
+	r.NotContains(precompiles, _stakingReaderAddr)
+}
@@ -1,6 +1,7 @@
 package protocol
 
 import (
+	"context"
 	"math/big"
 
 	"github.com/iotexproject/go-pkgs/hash"
@@ -99,9 +100,11 @@ type (
 type (
 	SimulateOption       func(*SimulateOptionConfig)
 	SimulateOptionConfig struct {
-		PreOpt     func(StateManager) error
-		Nonce, Gas uint64
-		GasPrice   *big.Int
+		PreOpt        func(StateManager) error
+		StateOverride func(context.Context, StateManager) error
+		BlockOverride func(*BlockCtx)
+		Nonce, Gas    uint64
+		GasPrice      *big.Int
 	}
 )
 
@@ -110,3 +113,17 @@ func WithSimulatePreOpt(fn func(StateManager) error) SimulateOption {
 		so.PreOpt = fn
 	}
 }
+
+// WithSimulateStateOverride overrides the state upon the simulation context, right before the execution
+func WithSimulateStateOverride(fn func(context.Context, StateManager) error) SimulateOption {
+	return func(so *SimulateOptionConfig) {
+		so.StateOverride = fn
+	}
+}
+
+// WithSimulateBlockOverride overrides the block context of the simulation
+func WithSimulateBlockOverride(fn func(*BlockCtx)) SimulateOption {
+	return func(so *SimulateOptionConfig) {
+		so.BlockOverride = fn
+	}
+}
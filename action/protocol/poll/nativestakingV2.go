@@ -119,6 +119,11 @@ func (ns *nativeStakingV2) NextCandidates(ctx context.Context, sr protocol.State
 	return candidates, err
 }
 
+// ApplyProbation applies the probation list of current epoch to the candidates of the epoch starting at given height
+func (ns *nativeStakingV2) ApplyProbation(ctx context.Context, sr protocol.StateReader, candidates state.CandidateList, height uint64) (state.CandidateList, error) {
+	return ns.slasher.ApplyProbation(ctx, sr, candidates, height)
+}
+
 func (ns *nativeStakingV2) ReadState(ctx context.Context, sr protocol.StateReader, method []byte, args ...[]byte) ([]byte, uint64, error) {
 	return ns.slasher.ReadState(ctx, sr, ns.candIndexer, method, args...)
 }
@@ -85,6 +85,14 @@ type (
 		// CalculateUnproductiveDelegates calculates unproductive delegate on current epoch
 		CalculateUnproductiveDelegates(context.Context, protocol.StateReader) ([]string, error)
 	}
+
+	// ProbationApplier applies the probation list of current epoch to the candidates of an epoch
+	ProbationApplier interface {
+		// ApplyProbation returns the candidates with the voting power of the unqualified delegates reduced by the
+		// probation list of current epoch, sorted again, as if they were the candidates of the epoch starting at
+		// the height
+		ApplyProbation(context.Context, protocol.StateReader, state.CandidateList, uint64) (state.CandidateList, error)
+	}
 )
 
 // FindProtocol finds the registered protocol from registry
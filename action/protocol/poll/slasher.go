@@ -357,6 +357,23 @@ func (sh *Slasher) GetABPFromIndexer(ctx context.Context, epochStartHeight uint6
 	return sh.calculateActiveBlockProducer(ctx, blockProducers, epochStartHeight)
 }
 
+// ApplyProbation applies the probation list of current epoch to the candidates of the epoch starting at given height
+func (sh *Slasher) ApplyProbation(
+	ctx context.Context,
+	sr protocol.StateReader,
+	candidates state.CandidateList,
+	epochStartHeight uint64,
+) (state.CandidateList, error) {
+	if !protocol.MustGetFeatureWithHeightCtx(ctx).CalculateProbationList(epochStartHeight) {
+		return candidates, nil
+	}
+	unqualifiedList, _, err := sh.getProbationList(sr, false)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get probation list of current epoch")
+	}
+	return filterCandidates(candidates, unqualifiedList, epochStartHeight)
+}
+
 // GetProbationList returns the probation list at given epoch
 func (sh *Slasher) GetProbationList(ctx context.Context, sr protocol.StateReader, readFromNext bool) (*vote.ProbationList, uint64, error) {
 	rp := rolldpos.MustGetProtocol(protocol.MustGetRegistry(ctx))
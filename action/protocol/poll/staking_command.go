@@ -145,6 +145,16 @@ func (sc *stakingCommand) NextCandidates(ctx context.Context, sr protocol.StateR
 	return sc.stakingV1.NextCandidates(ctx, sr)
 }
 
+// ApplyProbation applies the probation list of current epoch to the candidates of the epoch starting at given height
+func (sc *stakingCommand) ApplyProbation(ctx context.Context, sr protocol.StateReader, candidates state.CandidateList, height uint64) (state.CandidateList, error) {
+	if sc.useV2ByHeight(ctx, height) {
+		if pa, ok := sc.stakingV2.(ProbationApplier); ok {
+			return pa.ApplyProbation(ctx, sr, candidates, height)
+		}
+	}
+	return candidates, nil
+}
+
 func (sc *stakingCommand) ReadState(ctx context.Context, sr protocol.StateReader, method []byte, args ...[]byte) ([]byte, uint64, error) {
 	if sc.useV2(ctx, sr) {
 		res, height, err := sc.stakingV2.ReadState(ctx, sr, method, args...)
@@ -26,6 +26,25 @@ import (
 	"github.com/iotexproject/iotex-core/v2/state"
 )
 
+type (
+	// ProjectedEpochReward is the epoch reward and the foundation bonus projected to be granted to a candidate
+	ProjectedEpochReward struct {
+		EpochReward     *big.Int
+		FoundationBonus *big.Int
+	}
+
+	// epochRewardGrant is a reward to be granted to a candidate at the end of an epoch
+	epochRewardGrant struct {
+		candidate  *state.Candidate
+		rewardType rewardingpb.RewardLog_RewardType
+		// addr is nil if the candidate doesn't have a reward address
+		addr address.Address
+		// rewardAddr is the reward address in the reward log
+		rewardAddr string
+		amount     *big.Int
+	}
+)
+
 // rewardHistory is the dummy struct to record a reward. Only key matters.
 type rewardHistory struct{}
 
@@ -159,9 +178,7 @@ func (p *Protocol) GrantEpochReward(
 ) ([]*action.Log, error) {
 	actionCtx := protocol.MustGetActionCtx(ctx)
 	blkCtx := protocol.MustGetBlockCtx(ctx)
-	featureWithHeightCtx := protocol.MustGetFeatureWithHeightCtx(ctx)
 	rp := rolldpos.MustGetProtocol(protocol.MustGetRegistry(ctx))
-	pp := poll.MustGetProtocol(protocol.MustGetRegistry(ctx))
 	epochNum := rp.GetEpochNum(blkCtx.BlockHeight)
 	if err := p.assertNoRewardYet(ctx, sm, _epochRewardHistoryKeyPrefix, epochNum); err != nil {
 		return nil, err
@@ -169,14 +186,112 @@ func (p *Protocol) GrantEpochReward(
 	if err := p.assertLastBlockInEpoch(blkCtx.BlockHeight, epochNum, rp); err != nil {
 		return nil, err
 	}
+	candidates, err := poll.MustGetProtocol(protocol.MustGetRegistry(ctx)).Candidates(ctx, sm)
+	if err != nil {
+		return nil, err
+	}
+	grants, err := p.epochRewardGrants(ctx, sm, epochNum, candidates)
+	if err != nil {
+		return nil, err
+	}
+	actualTotalReward := big.NewInt(0)
+	rewardLogs := make([]*action.Log, 0)
+	for _, g := range grants {
+		// If reward address doesn't exist, do nothing
+		if g.addr == nil {
+			continue
+		}
+		// If 0 epoch reward due to low productivity, do nothing
+		if g.rewardType == rewardingpb.RewardLog_EPOCH_REWARD && g.amount.Cmp(big.NewInt(0)) == 0 {
+			continue
+		}
+		if err := p.grantToAccount(ctx, sm, g.addr, g.amount); err != nil {
+			return nil, err
+		}
+		rewardLog := rewardingpb.RewardLog{
+			Type:   g.rewardType,
+			Addr:   g.rewardAddr,
+			Amount: g.amount.String(),
+		}
+		data, err := proto.Marshal(&rewardLog)
+		if err != nil {
+			return nil, err
+		}
+		rewardLogs = append(rewardLogs, &action.Log{
+			Address:     p.addr.String(),
+			Topics:      nil,
+			Data:        data,
+			BlockHeight: blkCtx.BlockHeight,
+			ActionHash:  actionCtx.ActionHash,
+		})
+		actualTotalReward = big.NewInt(0).Add(actualTotalReward, g.amount)
+	}
+
+	// Update actual reward
+	if err := p.updateAvailableBalance(ctx, sm, actualTotalReward); err != nil {
+		return nil, err
+	}
+	if err := p.updateRewardHistory(ctx, sm, _epochRewardHistoryKeyPrefix, epochNum); err != nil {
+		return nil, err
+	}
+	return rewardLogs, nil
+}
+
+// ProjectEpochReward returns the epoch reward and the foundation bonus which would be granted to the candidates
+// in the epoch by GrantEpochReward, if they were the candidates of the epoch. The candidates not to be granted
+// are not in the result, which is keyed by the candidate address.
+func (p *Protocol) ProjectEpochReward(
+	ctx context.Context,
+	sr protocol.StateReader,
+	epochNum uint64,
+	candidates state.CandidateList,
+) (map[string]*ProjectedEpochReward, error) {
+	grants, err := p.epochRewardGrants(ctx, sr, epochNum, candidates)
+	if err != nil {
+		return nil, err
+	}
+	projected := make(map[string]*ProjectedEpochReward)
+	for _, g := range grants {
+		if g.addr == nil || g.amount.Sign() == 0 {
+			continue
+		}
+		r, ok := projected[g.candidate.Address]
+		if !ok {
+			r = &ProjectedEpochReward{
+				EpochReward:     big.NewInt(0),
+				FoundationBonus: big.NewInt(0),
+			}
+			projected[g.candidate.Address] = r
+		}
+		switch g.rewardType {
+		case rewardingpb.RewardLog_EPOCH_REWARD:
+			r.EpochReward.Add(r.EpochReward, g.amount)
+		case rewardingpb.RewardLog_FOUNDATION_BONUS:
+			r.FoundationBonus.Add(r.FoundationBonus, g.amount)
+		}
+	}
+	return projected, nil
+}
+
+// epochRewardGrants returns the epoch rewards and then the foundation bonuses of the candidates in the epoch, in
+// the order of being granted
+func (p *Protocol) epochRewardGrants(
+	ctx context.Context,
+	sr protocol.StateReader,
+	epochNum uint64,
+	candidates state.CandidateList,
+) ([]*epochRewardGrant, error) {
+	featureWithHeightCtx := protocol.MustGetFeatureWithHeightCtx(ctx)
+	rp := rolldpos.MustGetProtocol(protocol.MustGetRegistry(ctx))
+	pp := poll.MustGetProtocol(protocol.MustGetRegistry(ctx))
 	a := admin{}
-	if _, err := p.state(ctx, sm, _adminKey, &a); err != nil {
+	if _, err := p.state(ctx, sr, _adminKey, &a); err != nil {
 		return nil, err
 	}
 
 	// Get the delegate list who exempts epoch reward
 	e := exempt{}
-	if _, err := p.state(ctx, sm, _exemptKey, &e); err != nil {
+	if _, err := p.state(ctx, sr, _exemptKey, &e); err != nil {
 		return nil, err
 	}
 	exemptAddrs := make(map[string]interface{})
@@ -184,12 +299,11 @@ func (p *Protocol) GrantEpochReward(
 		exemptAddrs[addr.String()] = nil
 	}
 
-	var err error
 	uqdMap := make(map[string]bool)
 	epochStartHeight := rp.GetEpochHeight(epochNum)
 	if featureWithHeightCtx.GetUnproductiveDelegates(epochStartHeight) {
 		// Get unqualified delegate list
-		uqd, err := pp.CalculateUnproductiveDelegates(ctx, sm)
+		uqd, err := pp.CalculateUnproductiveDelegates(ctx, sr)
 		if err != nil {
 			return nil, err
 		}
@@ -198,45 +312,22 @@ func (p *Protocol) GrantEpochReward(
 		}
 
 	}
-	candidates, err := poll.MustGetProtocol(protocol.MustGetRegistry(ctx)).Candidates(ctx, sm)
+	rewarded, addrs, amounts, err := p.splitEpochReward(epochStartHeight, sr, candidates, a.epochReward, a.numDelegatesForEpochReward, exemptAddrs, uqdMap)
 	if err != nil {
 		return nil, err
 	}
-	addrs, amounts, err := p.splitEpochReward(epochStartHeight, sm, candidates, a.epochReward, a.numDelegatesForEpochReward, exemptAddrs, uqdMap)
-	if err != nil {
-		return nil, err
-	}
-	actualTotalReward := big.NewInt(0)
-	rewardLogs := make([]*action.Log, 0)
+	grants := make([]*epochRewardGrant, 0, len(addrs))
 	for i := range addrs {
-		// If reward address doesn't exist, do nothing
-		if addrs[i] == nil {
-			continue
-		}
-		// If 0 epoch reward due to low productivity, do nothing
-		if amounts[i].Cmp(big.NewInt(0)) == 0 {
-			continue
-		}
-		if err := p.grantToAccount(ctx, sm, addrs[i], amounts[i]); err != nil {
-			return nil, err
+		g := &epochRewardGrant{
+			candidate:  rewarded[i],
+			rewardType: rewardingpb.RewardLog_EPOCH_REWARD,
+			addr:       addrs[i],
+			amount:     amounts[i],
 		}
-		rewardLog := rewardingpb.RewardLog{
-			Type:   rewardingpb.RewardLog_EPOCH_REWARD,
-			Addr:   addrs[i].String(),
-			Amount: amounts[i].String(),
+		if addrs[i] != nil {
+			g.rewardAddr = addrs[i].String()
 		}
-		data, err := proto.Marshal(&rewardLog)
-		if err != nil {
-			return nil, err
-		}
-		rewardLogs = append(rewardLogs, &action.Log{
-			Address:     p.addr.String(),
-			Topics:      nil,
-			Data:        data,
-			BlockHeight: blkCtx.BlockHeight,
-			ActionHash:  actionCtx.ActionHash,
-		})
-		actualTotalReward = big.NewInt(0).Add(actualTotalReward, amounts[i])
+		grants = append(grants, g)
 	}
 
 	// Reward additional bootstrap bonus
@@ -250,46 +341,22 @@ func (p *Protocol) GrantEpochReward(
 				continue
 			}
 			count++
+			g := &epochRewardGrant{
+				candidate:  candidates[i],
+				rewardType: rewardingpb.RewardLog_FOUNDATION_BONUS,
+				rewardAddr: candidates[i].RewardAddress,
+				amount:     a.foundationBonus,
+			}
 			// If reward address doesn't exist, do nothing
 			if candidates[i].RewardAddress == "" {
 				log.S().Warnf("Candidate %s doesn't have a reward address", candidates[i].Address)
-				continue
-			}
-			rewardAddr, err := address.FromString(candidates[i].RewardAddress)
-			if err != nil {
+			} else if g.addr, err = address.FromString(candidates[i].RewardAddress); err != nil {
 				return nil, err
 			}
-			if err := p.grantToAccount(ctx, sm, rewardAddr, a.foundationBonus); err != nil {
-				return nil, err
-			}
-			rewardLog := rewardingpb.RewardLog{
-				Type:   rewardingpb.RewardLog_FOUNDATION_BONUS,
-				Addr:   candidates[i].RewardAddress,
-				Amount: a.foundationBonus.String(),
-			}
-			data, err := proto.Marshal(&rewardLog)
-			if err != nil {
-				return nil, err
-			}
-			rewardLogs = append(rewardLogs, &action.Log{
-				Address:     p.addr.String(),
-				Topics:      nil,
-				Data:        data,
-				BlockHeight: blkCtx.BlockHeight,
-				ActionHash:  actionCtx.ActionHash,
-			})
-			actualTotalReward = big.NewInt(0).Add(actualTotalReward, a.foundationBonus)
+			grants = append(grants, g)
 		}
 	}
-
-	// Update actual reward
-	if err := p.updateAvailableBalance(ctx, sm, actualTotalReward); err != nil {
-		return nil, err
-	}
-	if err := p.updateRewardHistory(ctx, sm, _epochRewardHistoryKeyPrefix, epochNum); err != nil {
-		return nil, err
-	}
-	return rewardLogs, nil
+	return grants, nil
 }
 
 // Claim claims the token from the rewarding fund
@@ -428,15 +495,17 @@ func (p *Protocol) updateRewardHistory(ctx context.Context, sm protocol.StateMan
 	return p.putState(ctx, sm, append(prefix, indexBytes[:]...), &rewardHistory{})
 }
 
+// splitEpochReward splits the epoch reward among the candidates by votes, it returns the candidates splitting the
+// reward, along with their reward addresses and amounts
 func (p *Protocol) splitEpochReward(
 	epochStartHeight uint64,
-	sm protocol.StateManager,
+	sr protocol.StateReader,
 	candidates []*state.Candidate,
 	totalAmount *big.Int,
 	numDelegatesForEpochReward uint64,
 	exemptAddrs map[string]interface{},
 	uqd map[string]bool,
-) ([]address.Address, []*big.Int, error) {
+) ([]*state.Candidate, []address.Address, []*big.Int, error) {
 	filteredCandidates := make([]*state.Candidate, 0)
 	for _, candidate := range candidates {
 		if _, ok := exemptAddrs[candidate.Address]; ok {
@@ -446,7 +515,7 @@ func (p *Protocol) splitEpochReward(
 	}
 	candidates = filteredCandidates
 	if len(candidates) == 0 {
-		return nil, nil, nil
+		return nil, nil, nil, nil
 	}
 	// We at most allow numDelegatesForEpochReward delegates to get the epoch reward
 	if uint64(len(candidates)) > numDelegatesForEpochReward {
@@ -460,7 +529,7 @@ func (p *Protocol) splitEpochReward(
 		if candidate.RewardAddress != "" {
 			rewardAddr, err = address.FromString(candidate.RewardAddress)
 			if err != nil {
-				return nil, nil, err
+				return nil, nil, nil, err
 			}
 		} else {
 			log.S().Warnf("Candidate %s doesn't have a reward address", candidate.Address)
@@ -483,7 +552,7 @@ func (p *Protocol) splitEpochReward(
 		amountPerAddr = big.NewInt(0).Div(big.NewInt(0).Mul(totalAmount, candidate.Votes), totalWeight)
 		amounts = append(amounts, amountPerAddr)
 	}
-	return rewardAddrs, amounts, nil
+	return candidates, rewardAddrs, amounts, nil
 }
 
 func (p *Protocol) assertNoRewardYet(ctx context.Context, sm protocol.StateManager, prefix []byte, index uint64) error {
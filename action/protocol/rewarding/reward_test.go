@@ -216,6 +216,72 @@ func TestProtocol_GrantEpochReward(t *testing.T) {
 		require.NoError(t, err)
 		assert.Equal(t, big.NewInt(4+5), unclaimedBalance)
 	}, true)
+
+	testProtocol(t, func(t *testing.T, ctx context.Context, sm protocol.StateManager, p *Protocol) {
+		_, err := p.Deposit(ctx, sm, big.NewInt(200), iotextypes.TransactionLogType_DEPOSIT_TO_REWARDING_FUND)
+		require.NoError(t, err)
+		a := admin{}
+		_, err = p.state(ctx, sm, _adminKey, &a)
+		require.NoError(t, err)
+		a.foundationBonus = big.NewInt(0)
+		require.NoError(t, p.putState(ctx, sm, _adminKey, &a))
+
+		ctx = protocol.WithFeatureWithHeightCtx(ctx)
+		// Grant epoch reward
+		rewardLogs, err := p.GrantEpochReward(ctx, sm)
+		require.NoError(t, err)
+		// 0 foundation bonus is still granted with the reward logs
+		require.Equal(t, 8, len(rewardLogs))
+		for i, addr := range []int{0, 28, 29, 30, 31} {
+			var rl rewardingpb.RewardLog
+			require.NoError(t, proto.Unmarshal(rewardLogs[3+i].Data, &rl))
+			assert.Equal(t, rewardingpb.RewardLog_FOUNDATION_BONUS, rl.Type)
+			assert.Equal(t, identityset.Address(addr).String(), rl.Addr)
+			assert.Equal(t, "0", rl.Amount)
+		}
+		availableBalance, _, err := p.AvailableBalance(ctx, sm)
+		require.NoError(t, err)
+		assert.Equal(t, big.NewInt(120), availableBalance)
+		unclaimedBalance, _, err := p.UnclaimedBalance(ctx, sm, identityset.Address(29))
+		require.NoError(t, err)
+		assert.Equal(t, big.NewInt(0), unclaimedBalance)
+	}, false)
+}
+
+func TestProtocol_ProjectEpochReward(t *testing.T) {
+	testProtocol(t, func(t *testing.T, ctx context.Context, sm protocol.StateManager, p *Protocol) {
+		ctx = protocol.WithFeatureWithHeightCtx(ctx)
+		candidates, err := poll.MustGetProtocol(protocol.MustGetRegistry(ctx)).Candidates(ctx, sm)
+		require.NoError(t, err)
+		rp := rolldpos.MustGetProtocol(protocol.MustGetRegistry(ctx))
+		epochNum := rp.GetEpochNum(protocol.MustGetBlockCtx(ctx).BlockHeight)
+		projected, err := p.ProjectEpochReward(ctx, sm, epochNum, candidates)
+		require.NoError(t, err)
+		// the projection matches the reward logs of TestProtocol_GrantEpochReward
+		expected := map[string][2]int64{
+			identityset.Address(27).String(): {40, 5},
+			identityset.Address(28).String(): {30, 5},
+			identityset.Address(29).String(): {0, 5},
+			identityset.Address(30).String(): {10, 5},
+			identityset.Address(31).String(): {0, 5},
+		}
+		require.Len(t, projected, len(expected))
+		for addr, amounts := range expected {
+			r, ok := projected[addr]
+			require.True(t, ok)
+			require.Equal(t, big.NewInt(amounts[0]), r.EpochReward)
+			require.Equal(t, big.NewInt(amounts[1]), r.FoundationBonus)
+		}
+
+		// projecting doesn't grant the reward
+		_, err = p.Deposit(ctx, sm, big.NewInt(200), iotextypes.TransactionLogType_DEPOSIT_TO_REWARDING_FUND)
+		require.NoError(t, err)
+		availableBalance, _, err := p.AvailableBalance(ctx, sm)
+		require.NoError(t, err)
+		require.Equal(t, big.NewInt(200), availableBalance)
+		_, err = p.GrantEpochReward(ctx, sm)
+		require.NoError(t, err)
+	}, false)
 }
 
 func TestProtocol_ClaimReward(t *testing.T) {
@@ -44,6 +44,11 @@ type (
 		// BucketTypes returns the active bucket types
 		BucketTypes(height uint64) ([]*ContractStakingBucketType, error)
 	}
+	// ContractStakingIndexerWithHistory defines the interface of contract staking reader which keeps the history of buckets
+	ContractStakingIndexerWithHistory interface {
+		// IndexerAt returns the reader of the buckets at the height
+		IndexerAt(height uint64) (ContractStakingIndexer, error)
+	}
 
 	delayTolerantIndexer struct {
 		ContractStakingIndexer
@@ -249,3 +249,41 @@ func (mr *MockContractStakingIndexerWithBucketTypeMockRecorder) TotalBucketCount
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TotalBucketCount", reflect.TypeOf((*MockContractStakingIndexerWithBucketType)(nil).TotalBucketCount), height)
 }
+
+// MockContractStakingIndexerWithHistory is a mock of ContractStakingIndexerWithHistory interface.
+type MockContractStakingIndexerWithHistory struct {
+	ctrl     *gomock.Controller
+	recorder *MockContractStakingIndexerWithHistoryMockRecorder
+}
+
+// MockContractStakingIndexerWithHistoryMockRecorder is the mock recorder for MockContractStakingIndexerWithHistory.
+type MockContractStakingIndexerWithHistoryMockRecorder struct {
+	mock *MockContractStakingIndexerWithHistory
+}
+
+// NewMockContractStakingIndexerWithHistory creates a new mock instance.
+func NewMockContractStakingIndexerWithHistory(ctrl *gomock.Controller) *MockContractStakingIndexerWithHistory {
+	mock := &MockContractStakingIndexerWithHistory{ctrl: ctrl}
+	mock.recorder = &MockContractStakingIndexerWithHistoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockContractStakingIndexerWithHistory) EXPECT() *MockContractStakingIndexerWithHistoryMockRecorder {
+	return m.recorder
+}
+
+// IndexerAt mocks base method.
+func (m *MockContractStakingIndexerWithHistory) IndexerAt(height uint64) (ContractStakingIndexer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IndexerAt", height)
+	ret0, _ := ret[0].(ContractStakingIndexer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IndexerAt indicates an expected call of IndexerAt.
+func (mr *MockContractStakingIndexerWithHistoryMockRecorder) IndexerAt(height interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IndexerAt", reflect.TypeOf((*MockContractStakingIndexerWithHistory)(nil).IndexerAt), height)
+}
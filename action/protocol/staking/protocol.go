@@ -188,6 +188,14 @@ func ProtocolAddr() address.Address {
 
 // Start starts the protocol
 func (p *Protocol) Start(ctx context.Context, sr protocol.StateReader) (interface{}, error) {
+	c, err := p.createBaseView(ctx, sr)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to start staking protocol")
+	}
+	return c, nil
+}
+
+func (p *Protocol) createBaseView(ctx context.Context, sr protocol.StateReader) (*ViewData, error) {
 	featureCtx := protocol.MustGetFeatureWithHeightCtx(ctx)
 	height, err := sr.Height()
 	if err != nil {
@@ -197,7 +205,7 @@ func (p *Protocol) Start(ctx context.Context, sr protocol.StateReader) (interfac
 	// load view from SR
 	c, _, err := CreateBaseView(sr, featureCtx.ReadStateFromDB(height))
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to start staking protocol")
+		return nil, err
 	}
 
 	if p.needToReadCandsMap(ctx, height) {
@@ -580,16 +588,30 @@ func (p *Protocol) ActiveCandidates(ctx context.Context, sr protocol.StateReader
 
 // ReadState read the state on blockchain via protocol
 func (p *Protocol) ReadState(ctx context.Context, sr protocol.StateReader, method []byte, args ...[]byte) ([]byte, uint64, error) {
-	m := iotexapi.ReadStakingDataMethod{}
-	if err := proto.Unmarshal(method, &m); err != nil {
-		return nil, uint64(0), errors.Wrap(err, "failed to unmarshal method name")
+	m, r, err := parseReadStateRequest(method, args...)
+	if err != nil {
+		return nil, uint64(0), err
 	}
-	if len(args) != 1 {
-		return nil, uint64(0), errors.Errorf("invalid number of arguments %d", len(args))
+
+	// get height arg
+	inputHeight, err := sr.Height()
+	if err != nil {
+		return nil, 0, err
 	}
-	r := iotexapi.ReadStakingDataRequest{}
-	if err := proto.Unmarshal(args[0], &r); err != nil {
-		return nil, uint64(0), errors.Wrap(err, "failed to unmarshal request")
+	epochStartHeight := inputHeight
+	if rp := rolldpos.FindProtocol(protocol.MustGetRegistry(ctx)); rp != nil {
+		epochStartHeight = rp.GetEpochHeight(rp.GetEpochNum(inputHeight))
+	}
+	if blkCtx, ok := protocol.GetBlockCtx(ctx); ok && inputHeight < blkCtx.BlockHeight && protocol.ArchiveStateEnabled(ctx) {
+		// the state reader is at a historical height on an archive node, of which the view is the one at the tip
+		// height, so the candidates and buckets are read from the state and the history of indexers at the height.
+		// Without archive, the state reader reads the latest states, and the candidates and buckets are read from
+		// the indexer at the epoch start height
+		return p.readStateAtHeight(ctx, sr, inputHeight, m, r)
+	}
+	nativeSR, err := ConstructBaseView(sr)
+	if err != nil {
+		return nil, 0, err
 	}
 
 	// stakeSR is the stake state reader including native and contract staking
@@ -600,36 +622,132 @@ func (p *Protocol) ReadState(ctx context.Context, sr protocol.StateReader, metho
 	if p.contractStakingIndexerV2 != nil {
 		indexers = append(indexers, NewDelayTolerantIndexer(p.contractStakingIndexerV2, time.Second))
 	}
-	stakeSR, err := newCompositeStakingStateReader(p.candBucketsIndexer, sr, p.calculateVoteWeight, indexers...)
+	stakeSR := newCompositeStakingStateReader(p.candBucketsIndexer, nativeSR, p.calculateVoteWeight, indexers...)
+
+	var (
+		height uint64
+		resp   proto.Message
+	)
+	if m.GetMethod() == iotexapi.ReadStakingDataMethod_BUCKETS && epochStartHeight != 0 && p.candBucketsIndexer != nil {
+		resp, height, err = p.candBucketsIndexer.GetBuckets(epochStartHeight, r.GetBuckets().GetPagination().GetOffset(), r.GetBuckets().GetPagination().GetLimit())
+	} else {
+		resp, height, err = readState(ctx, nativeSR, stakeSR, m, r)
+	}
 	if err != nil {
-		return nil, 0, err
+		return nil, height, err
+	}
+	data, err := proto.Marshal(resp)
+	if err != nil {
+		return nil, height, err
 	}
+	return data, height, nil
+}
 
-	// get height arg
-	inputHeight, err := sr.Height()
+// ReadNativeState reads the candidates, the buckets by indexes or voter, and the total staked amount of native
+// staking for the execution of contracts. Unlike ReadState, the states are read from the state manager only,
+// including the changes of the previous actions in the block, so the result is the same on all nodes
+func (p *Protocol) ReadNativeState(ctx context.Context, sm protocol.StateManager, method []byte, args ...[]byte) ([]byte, error) {
+	m, r, err := parseReadStateRequest(method, args...)
 	if err != nil {
-		return nil, 0, err
+		return nil, err
 	}
-	epochStartHeight := inputHeight
-	if rp := rolldpos.FindProtocol(protocol.MustGetRegistry(ctx)); rp != nil {
-		epochStartHeight = rp.GetEpochHeight(rp.GetEpochNum(inputHeight))
+	height, err := sm.Height()
+	if err != nil {
+		return nil, err
 	}
-	nativeSR, err := ConstructBaseView(sr)
+	csm, err := NewCandidateStateManager(sm, protocol.MustGetFeatureWithHeightCtx(ctx).ReadStateFromDB(height))
+	if err != nil {
+		return nil, err
+	}
+	csr := &candSR{
+		StateReader: sm,
+		height:      height,
+		view:        csm.DirtyView(),
+	}
+	var resp proto.Message
+	switch m.GetMethod() {
+	case iotexapi.ReadStakingDataMethod_CANDIDATES:
+		resp, _, err = csr.readStateCandidates(ctx, r.GetCandidates())
+	case iotexapi.ReadStakingDataMethod_CANDIDATE_BY_NAME:
+		resp, _, err = csr.readStateCandidateByName(ctx, r.GetCandidateByName())
+	case iotexapi.ReadStakingDataMethod_CANDIDATE_BY_ADDRESS:
+		resp, _, err = csr.readStateCandidateByAddress(ctx, r.GetCandidateByAddress())
+	case iotexapi.ReadStakingDataMethod_BUCKETS_BY_INDEXES:
+		resp, _, err = csr.readStateBucketByIndices(ctx, r.GetBucketsByIndexes())
+	case iotexapi.ReadStakingDataMethod_BUCKETS_BY_VOTER:
+		resp, _, err = csr.readStateBucketsByVoter(ctx, r.GetBucketsByVoter())
+	case iotexapi.ReadStakingDataMethod_TOTAL_STAKING_AMOUNT:
+		resp, _, err = csr.readStateTotalStakingAmount(ctx, r.GetTotalStakingAmount())
+	default:
+		return nil, errors.Errorf("unsupported native staking method %s", m.GetMethod())
+	}
+	if err != nil {
+		return nil, err
+	}
+	return proto.Marshal(resp)
+}
+
+func parseReadStateRequest(method []byte, args ...[]byte) (*iotexapi.ReadStakingDataMethod, *iotexapi.ReadStakingDataRequest, error) {
+	m := iotexapi.ReadStakingDataMethod{}
+	if err := proto.Unmarshal(method, &m); err != nil {
+		return nil, nil, errors.Wrap(err, "failed to unmarshal method name")
+	}
+	if len(args) != 1 {
+		return nil, nil, errors.Errorf("invalid number of arguments %d", len(args))
+	}
+	r := iotexapi.ReadStakingDataRequest{}
+	if err := proto.Unmarshal(args[0], &r); err != nil {
+		return nil, nil, errors.Wrap(err, "failed to unmarshal request")
+	}
+	return &m, &r, nil
+}
+
+func (p *Protocol) readStateAtHeight(ctx context.Context, sr protocol.StateReader, height uint64, m *iotexapi.ReadStakingDataMethod, r *iotexapi.ReadStakingDataRequest) ([]byte, uint64, error) {
+	view, err := p.createBaseView(ctx, sr)
 	if err != nil {
 		return nil, 0, err
 	}
+	nativeSR := &candSR{
+		StateReader: sr,
+		height:      height,
+		view:        view,
+	}
+	indexers := []ContractStakingIndexer{}
+	if p.contractStakingIndexer != nil {
+		indexers = append(indexers, p.contractStakingIndexer)
+	}
+	if p.contractStakingIndexerV2 != nil {
+		indexers = append(indexers, p.contractStakingIndexerV2)
+	}
+	for i, indexer := range indexers {
+		if h, ok := indexer.(ContractStakingIndexerWithHistory); ok {
+			if indexers[i], err = h.IndexerAt(height); err != nil {
+				return nil, 0, err
+			}
+		}
+	}
+	// candidates and buckets are read from the state instead of the native indexer, which keeps the epoch start data only
+	stakeSR := newCompositeStakingStateReader(nil, nativeSR, p.calculateVoteWeight, indexers...)
+	resp, h, err := readState(ctx, nativeSR, stakeSR, m, r)
+	if err != nil {
+		return nil, h, err
+	}
+	data, err := proto.Marshal(resp)
+	if err != nil {
+		return nil, h, err
+	}
+	return data, h, nil
+}
 
+func readState(ctx context.Context, nativeSR CandidateStateReader, stakeSR *compositeStakingStateReader, m *iotexapi.ReadStakingDataMethod, r *iotexapi.ReadStakingDataRequest) (proto.Message, uint64, error) {
 	var (
 		height uint64
 		resp   proto.Message
+		err    error
 	)
 	switch m.GetMethod() {
 	case iotexapi.ReadStakingDataMethod_BUCKETS:
-		if epochStartHeight != 0 && p.candBucketsIndexer != nil {
-			resp, height, err = p.candBucketsIndexer.GetBuckets(epochStartHeight, r.GetBuckets().GetPagination().GetOffset(), r.GetBuckets().GetPagination().GetLimit())
-		} else {
-			resp, height, err = nativeSR.readStateBuckets(ctx, r.GetBuckets())
-		}
+		resp, height, err = nativeSR.readStateBuckets(ctx, r.GetBuckets())
 	case iotexapi.ReadStakingDataMethod_BUCKETS_BY_VOTER:
 		resp, height, err = nativeSR.readStateBucketsByVoter(ctx, r.GetBucketsByVoter())
 	case iotexapi.ReadStakingDataMethod_BUCKETS_BY_CANDIDATE:
@@ -663,14 +781,7 @@ func (p *Protocol) ReadState(ctx context.Context, sr protocol.StateReader, metho
 	default:
 		err = errors.New("corresponding method isn't found")
 	}
-	if err != nil {
-		return nil, height, err
-	}
-	data, err := proto.Marshal(resp)
-	if err != nil {
-		return nil, height, err
-	}
-	return data, height, nil
+	return resp, height, err
 }
 
 // Register registers the protocol with a unique ID
@@ -14,8 +14,11 @@ import (
 
 	"github.com/golang/mock/gomock"
 	"github.com/iotexproject/iotex-address/address"
+	"github.com/iotexproject/iotex-proto/golang/iotexapi"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
 
 	"github.com/iotexproject/iotex-core/v2/action/protocol"
 	"github.com/iotexproject/iotex-core/v2/action/protocol/rolldpos"
@@ -661,3 +664,186 @@ func TestIsSelfStakeBucket(t *testing.T) {
 		r.False(selfStake)
 	})
 }
+
+func TestProtocol_ReadStateAtHeight(t *testing.T) {
+	r := require.New(t)
+	ctrl := gomock.NewController(t)
+	g := genesis.TestDefault()
+	csIndexer := NewMockContractStakingIndexerWithBucketType(ctrl)
+	csHistory := NewMockContractStakingIndexerWithHistory(ctrl)
+	p, err := NewProtocol(HelperCtx{
+		DepositGas:    nil,
+		BlockInterval: getBlockInterval,
+	}, &BuilderConfig{
+		Staking:                  g.Staking,
+		PersistStakingPatchBlock: math.MaxUint64,
+		Revise: ReviseConfig{
+			VoteWeight: g.Staking.VoteWeightCalConsts,
+		},
+	}, nil, struct {
+		ContractStakingIndexerWithBucketType
+		ContractStakingIndexerWithHistory
+	}{csIndexer, csHistory}, nil)
+	r.NoError(err)
+
+	newSM := func() protocol.StateManager {
+		sm := testdb.NewMockStateManagerWithoutHeightFunc(ctrl)
+		sm.EXPECT().Height().Return(uint64(5), nil).AnyTimes()
+		_, err := sm.PutState(
+			&totalBucketCount{count: 0},
+			protocol.NamespaceOption(_stakingNameSpace),
+			protocol.KeyOption(TotalBucketKey),
+		)
+		r.NoError(err)
+		return sm
+	}
+	reg := protocol.NewRegistry()
+	r.NoError(rolldpos.NewProtocol(10, 10, 10).Register(reg))
+	ctx := genesis.WithGenesisContext(protocol.WithRegistry(context.Background(), reg), g)
+	ctx = protocol.WithFeatureWithHeightCtx(ctx)
+	tipCtx := protocol.WithFeatureCtx(protocol.WithBlockCtx(ctx, protocol.BlockCtx{BlockHeight: 5}))
+	nonArchiveCtx := protocol.WithFeatureCtx(protocol.WithBlockCtx(ctx, protocol.BlockCtx{BlockHeight: 10}))
+	historyCtx := protocol.WithArchiveStateCtx(nonArchiveCtx)
+
+	// the view of a state reader at a historical height is the one at the tip height, which is stale
+	stale, err := p.Start(tipCtx, newSM())
+	r.NoError(err)
+	sm := newSM()
+	v, err := p.Start(tipCtx, sm)
+	r.NoError(err)
+	r.NoError(sm.WriteView(_protocolID, v))
+	csm, err := NewCandidateStateManager(sm, false)
+	r.NoError(err)
+	for _, e := range testCandidates {
+		r.NoError(csm.Upsert(e.d))
+	}
+	r.NoError(csm.Commit(tipCtx))
+	r.NoError(sm.WriteView(_protocolID, stale))
+
+	method, err := proto.Marshal(&iotexapi.ReadStakingDataMethod{Method: iotexapi.ReadStakingDataMethod_CANDIDATES})
+	r.NoError(err)
+	arg, err := proto.Marshal(&iotexapi.ReadStakingDataRequest{
+		Request: &iotexapi.ReadStakingDataRequest_Candidates_{
+			Candidates: &iotexapi.ReadStakingDataRequest_Candidates{
+				Pagination: &iotexapi.PaginationParam{Offset: 0, Limit: 100},
+			},
+		},
+	})
+	r.NoError(err)
+	readCandidates := func(ctx context.Context) *iotextypes.CandidateListV2 {
+		data, height, err := p.ReadState(ctx, sm, method, arg)
+		r.NoError(err)
+		r.EqualValues(5, height)
+		var cands iotextypes.CandidateListV2
+		r.NoError(proto.Unmarshal(data, &cands))
+		return &cands
+	}
+	// the candidates are read from the view at the tip height
+	r.Len(readCandidates(tipCtx).Candidates, 0)
+	// the candidates are read from the state and the history of contract indexer at a historical height
+	csHistory.EXPECT().IndexerAt(uint64(5)).Return(NewMockContractStakingIndexer(ctrl), nil).Times(1)
+	r.Len(readCandidates(historyCtx).Candidates, len(testCandidates))
+
+	// without archive, the candidates of a prior epoch are read from the indexer at the epoch start height
+	cbi, err := NewStakingCandidatesBucketsIndexer(db.NewMemKVStore())
+	r.NoError(err)
+	r.NoError(cbi.Start(ctx))
+	indexed := &iotextypes.CandidateListV2{Candidates: []*iotextypes.CandidateV2{testCandidates[0].d.toIoTeXTypes()}}
+	r.NoError(cbi.PutCandidates(1, indexed))
+	pIndexed, err := NewProtocol(HelperCtx{
+		DepositGas:    nil,
+		BlockInterval: getBlockInterval,
+	}, &BuilderConfig{
+		Staking:                  g.Staking,
+		PersistStakingPatchBlock: math.MaxUint64,
+		Revise: ReviseConfig{
+			VoteWeight: g.Staking.VoteWeightCalConsts,
+		},
+	}, cbi, nil, nil)
+	r.NoError(err)
+	data, height, err := pIndexed.ReadState(nonArchiveCtx, sm, method, arg)
+	r.NoError(err)
+	r.EqualValues(1, height)
+	var cands iotextypes.CandidateListV2
+	r.NoError(proto.Unmarshal(data, &cands))
+	r.Len(cands.Candidates, 1)
+	r.Equal(indexed.Candidates[0].Name, cands.Candidates[0].Name)
+}
+
+func TestProtocol_ReadNativeState(t *testing.T) {
+	r := require.New(t)
+	ctrl := gomock.NewController(t)
+	g := genesis.TestDefault()
+	p, err := NewProtocol(HelperCtx{
+		DepositGas:    nil,
+		BlockInterval: getBlockInterval,
+	}, &BuilderConfig{
+		Staking:                  g.Staking,
+		PersistStakingPatchBlock: math.MaxUint64,
+		Revise: ReviseConfig{
+			VoteWeight: g.Staking.VoteWeightCalConsts,
+		},
+	}, nil, nil, nil)
+	r.NoError(err)
+
+	sm := testdb.NewMockStateManagerWithoutHeightFunc(ctrl)
+	sm.EXPECT().Height().Return(uint64(5), nil).AnyTimes()
+	_, err = sm.PutState(
+		&totalBucketCount{count: 0},
+		protocol.NamespaceOption(_stakingNameSpace),
+		protocol.KeyOption(TotalBucketKey),
+	)
+	r.NoError(err)
+	ctx := genesis.WithGenesisContext(protocol.WithRegistry(context.Background(), protocol.NewRegistry()), g)
+	ctx = protocol.WithFeatureCtx(protocol.WithBlockCtx(protocol.WithFeatureWithHeightCtx(ctx), protocol.BlockCtx{BlockHeight: 5}))
+	v, err := p.Start(ctx, sm)
+	r.NoError(err)
+	r.NoError(sm.WriteView(_protocolID, v))
+	csm, err := NewCandidateStateManager(sm, false)
+	r.NoError(err)
+	for _, e := range testCandidates {
+		r.NoError(csm.Upsert(e.d))
+	}
+	r.NoError(csm.Commit(ctx))
+
+	readNativeState := func(m iotexapi.ReadStakingDataMethod_Name, req *iotexapi.ReadStakingDataRequest) ([]byte, error) {
+		method, err := proto.Marshal(&iotexapi.ReadStakingDataMethod{Method: m})
+		r.NoError(err)
+		arg, err := proto.Marshal(req)
+		r.NoError(err)
+		return p.ReadNativeState(ctx, sm, method, arg)
+	}
+	data, err := readNativeState(iotexapi.ReadStakingDataMethod_CANDIDATES, &iotexapi.ReadStakingDataRequest{
+		Request: &iotexapi.ReadStakingDataRequest_Candidates_{
+			Candidates: &iotexapi.ReadStakingDataRequest_Candidates{
+				Pagination: &iotexapi.PaginationParam{Offset: 0, Limit: 100},
+			},
+		},
+	})
+	r.NoError(err)
+	var cands iotextypes.CandidateListV2
+	r.NoError(proto.Unmarshal(data, &cands))
+	r.Len(cands.Candidates, len(testCandidates))
+
+	data, err = readNativeState(iotexapi.ReadStakingDataMethod_CANDIDATE_BY_NAME, &iotexapi.ReadStakingDataRequest{
+		Request: &iotexapi.ReadStakingDataRequest_CandidateByName_{
+			CandidateByName: &iotexapi.ReadStakingDataRequest_CandidateByName{
+				CandName: testCandidates[0].d.Name,
+			},
+		},
+	})
+	r.NoError(err)
+	var cand iotextypes.CandidateV2
+	r.NoError(proto.Unmarshal(data, &cand))
+	r.Equal(testCandidates[0].d.Owner.String(), cand.OwnerAddress)
+
+	// the methods depending on the indexers are not supported
+	_, err = readNativeState(iotexapi.ReadStakingDataMethod_COMPOSITE_BUCKETS, &iotexapi.ReadStakingDataRequest{
+		Request: &iotexapi.ReadStakingDataRequest_Buckets{
+			Buckets: &iotexapi.ReadStakingDataRequest_VoteBuckets{
+				Pagination: &iotexapi.PaginationParam{Offset: 0, Limit: 100},
+			},
+		},
+	})
+	r.ErrorContains(err, "unsupported native staking method")
+}
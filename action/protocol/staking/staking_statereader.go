@@ -29,17 +29,13 @@ type (
 )
 
 // newCompositeStakingStateReader creates a new compositive staking state reader
-func newCompositeStakingStateReader(nativeIndexer *CandidatesBucketsIndexer, sr protocol.StateReader, calculateVoteWeight func(v *VoteBucket, selfStake bool) *big.Int, contractIndexers ...ContractStakingIndexer) (*compositeStakingStateReader, error) {
-	nativeSR, err := ConstructBaseView(sr)
-	if err != nil {
-		return nil, err
-	}
+func newCompositeStakingStateReader(nativeIndexer *CandidatesBucketsIndexer, nativeSR CandidateStateReader, calculateVoteWeight func(v *VoteBucket, selfStake bool) *big.Int, contractIndexers ...ContractStakingIndexer) *compositeStakingStateReader {
 	return &compositeStakingStateReader{
 		contractIndexers:    contractIndexers,
 		nativeIndexer:       nativeIndexer,
 		nativeSR:            nativeSR,
 		calculateVoteWeight: calculateVoteWeight,
-	}, nil
+	}
 }
 
 func (c *compositeStakingStateReader) readStateBuckets(ctx context.Context, req *iotexapi.ReadStakingDataRequest_VoteBuckets) (*iotextypes.VoteBucketList, uint64, error) {
@@ -137,10 +137,11 @@ func TestStakingStateReader(t *testing.T) {
 			}
 			return buckets, nil
 		}).AnyTimes()
-		stakeSR, err := newCompositeStakingStateReader(nil, sf, func(v *VoteBucket, selfStake bool) *big.Int {
+		nativeSR, err := ConstructBaseView(sf)
+		r.NoError(err)
+		stakeSR := newCompositeStakingStateReader(nil, nativeSR, func(v *VoteBucket, selfStake bool) *big.Int {
 			return v.StakedAmount
 		}, contractIndexer)
-		r.NoError(err)
 		r.NotNil(stakeSR)
 
 		reg := protocol.NewRegistry()
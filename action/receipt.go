@@ -34,6 +34,7 @@ type (
 		EffectiveGasPrice  *big.Int
 		logs               []*Log
 		transactionLogs    []*TransactionLog
+		callTraces         []*CallTrace
 		executionRevertMsg string
 	}
 
@@ -163,6 +164,21 @@ func (receipt *Receipt) AddTransactionLogs(logs ...*TransactionLog) *Receipt {
 	return receipt
 }
 
+// CallTraces returns the call traces recorded in receipt, they are not part of the receipt hash
+func (receipt *Receipt) CallTraces() []*CallTrace {
+	return receipt.callTraces
+}
+
+// AddCallTraces add call traces to receipt and filter out nil trace.
+func (receipt *Receipt) AddCallTraces(traces ...*CallTrace) *Receipt {
+	for _, t := range traces {
+		if t != nil {
+			receipt.callTraces = append(receipt.callTraces, t)
+		}
+	}
+	return receipt
+}
+
 // ExecutionRevertMsg returns the list of execution revert error logs stored in receipt.
 func (receipt *Receipt) ExecutionRevertMsg() string {
 	return receipt.executionRevertMsg
@@ -16,6 +16,7 @@ import (
 
 	"github.com/iotexproject/iotex-core/v2/action"
 	"github.com/iotexproject/iotex-core/v2/db"
+	"github.com/iotexproject/iotex-core/v2/pkg/compress"
 	"github.com/iotexproject/iotex-core/v2/pkg/util/assertions"
 	"github.com/iotexproject/iotex-core/v2/test/identityset"
 )
@@ -64,6 +65,38 @@ func TestBlobStore(t *testing.T) {
 		r.Len(acts, 1)
 		r.Equal(act, acts[0])
 	})
+	t.Run("compression", func(t *testing.T) {
+		dir := t.TempDir()
+		newStore := func(compressor string) *actionStore {
+			ap := &actPool{}
+			r.NoError(WithStore(StoreConfig{Datadir: dir, Compressor: compressor}, encode, decode)(ap))
+			return ap.store
+		}
+		body := make([]byte, 1024)
+		acts := []*action.SealedEnvelope{}
+		for i, compressor := range []string{"", "Lz4"} {
+			store := newStore(compressor)
+			r.NoError(store.Open(func(selp *action.SealedEnvelope) error { return nil }))
+			act, err := action.SignedExecution("", identityset.PrivateKey(1), uint64(i+1), big.NewInt(1), 100, big.NewInt(100), body)
+			r.NoError(err)
+			r.NoError(store.Put(act))
+			acts = append(acts, act)
+			r.NoError(store.Close())
+		}
+		// the actions stored before the compression is enabled are loaded as well
+		store := newStore("Lz4")
+		loaded := map[uint64]*action.SealedEnvelope{}
+		r.NoError(store.Open(func(selp *action.SealedEnvelope) error {
+			loaded[selp.Nonce()] = selp
+			return nil
+		}))
+		r.Len(loaded, 2)
+		for _, act := range acts {
+			r.Equal(act, loaded[act.Nonce()])
+		}
+		r.NoError(store.Close())
+		r.ErrorIs(WithStore(StoreConfig{Datadir: dir, Compressor: "Unknown"}, encode, decode)(&actPool{}), compress.ErrUnsupportedCompressor)
+	})
 	t.Run("put", func(t *testing.T) {
 		cfg := actionStoreConfig{
 			Datadir: t.TempDir(),
@@ -58,6 +58,11 @@ type ActPool interface {
 	Reset()
 	// PendingActionMap returns an action map with all accepted actions
 	PendingActionMap() map[string][]*action.SealedEnvelope
+	// PendingAndQueuedActionMap returns the action maps of the pending actions and the accepted actions which are
+	// not yet executable, taken from the same snapshot of the pool
+	PendingAndQueuedActionMap() (map[string][]*action.SealedEnvelope, map[string][]*action.SealedEnvelope)
+	// PendingAndQueuedSize returns the numbers of the pending and the queued actions
+	PendingAndQueuedSize() (uint64, uint64)
 	// Add adds an action into the pool after passing validation
 	Add(ctx context.Context, act *action.SealedEnvelope) error
 	// GetPendingNonce returns pending nonce in pool given an account address
@@ -275,6 +280,56 @@ func (ap *actPool) PendingActionMap() map[string][]*action.SealedEnvelope {
 	return ret
 }
 
+// PendingAndQueuedActionMap returns the action maps of the pending actions and the accepted actions which are not
+// yet executable, e.g., there is a nonce gap or the sender's balance is insufficient. The actions of a sender are
+// read under the lock of its worker, so an action is either pending or queued
+func (ap *actPool) PendingAndQueuedActionMap() (map[string][]*action.SealedEnvelope, map[string][]*action.SealedEnvelope) {
+	var (
+		wg              sync.WaitGroup
+		pendingOfWorker = make([][]*pendingActions, _numWorker)
+		queuedOfWorker  = make([][]*pendingActions, _numWorker)
+		ctx             = ap.context(context.Background())
+		pendingAccounts = uint64(0)
+		queuedAccounts  = uint64(0)
+	)
+	for i := range ap.worker {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			pendingOfWorker[i], queuedOfWorker[i] = ap.worker[i].PendingAndQueuedActions(ctx)
+			atomic.AddUint64(&pendingAccounts, uint64(len(pendingOfWorker[i])))
+			atomic.AddUint64(&queuedAccounts, uint64(len(queuedOfWorker[i])))
+		}(i)
+	}
+	wg.Wait()
+
+	pending := make(map[string][]*action.SealedEnvelope, pendingAccounts)
+	for _, v := range pendingOfWorker {
+		for _, w := range v {
+			pending[w.sender] = w.acts
+		}
+	}
+	queued := make(map[string][]*action.SealedEnvelope, queuedAccounts)
+	for _, v := range queuedOfWorker {
+		for _, w := range v {
+			queued[w.sender] = w.acts
+		}
+	}
+	return pending, queued
+}
+
+// PendingAndQueuedSize returns the numbers of the pending and the queued actions. Unlike PendingAndQueuedActionMap,
+// the actions are counted by the pending nonces kept in the pool, without refetching the account states
+func (ap *actPool) PendingAndQueuedSize() (uint64, uint64) {
+	var numPending, numQueued uint64
+	for _, worker := range ap.worker {
+		pending, queued := worker.PendingAndQueuedSize()
+		numPending += pending
+		numQueued += queued
+	}
+	return numPending, numQueued
+}
+
 func (ap *actPool) Add(ctx context.Context, act *action.SealedEnvelope) error {
 	return ap.add(ctx, act)
 }
@@ -982,6 +982,54 @@ func TestActPool_GetUnconfirmedActs(t *testing.T) {
 	}
 }
 
+func TestActPool_PendingAndQueuedActionMap(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	require := require.New(t)
+	sf := mock_chainmanager.NewMockStateReader(ctrl)
+	// Create actpool
+	apConfig := getActPoolCfg()
+	Ap, err := NewActPool(genesis.TestDefault(), sf, apConfig)
+	require.NoError(err)
+	ap, ok := Ap.(*actPool)
+	require.True(ok)
+	ap.AddActionEnvelopeValidators(protocol.NewGenericValidator(sf, accountutil.AccountState))
+
+	tsf1, err := action.SignedTransfer(_addr1, _priKey1, uint64(1), big.NewInt(10), []byte{}, uint64(100000), big.NewInt(0))
+	require.NoError(err)
+	tsf3, err := action.SignedTransfer(_addr1, _priKey1, uint64(3), big.NewInt(30), []byte{}, uint64(100000), big.NewInt(0))
+	require.NoError(err)
+	tsf4, err := action.SignedTransfer(_addr1, _priKey1, uint64(4), big.NewInt(30), []byte{}, uint64(100000), big.NewInt(0))
+	require.NoError(err)
+	tsf5, err := action.SignedTransfer(_addr1, _priKey2, uint64(1), big.NewInt(30), []byte{}, uint64(100000), big.NewInt(0))
+	require.NoError(err)
+
+	sf.EXPECT().State(gomock.Any(), gomock.Any()).DoAndReturn(func(account interface{}, opts ...protocol.StateOption) (uint64, error) {
+		acct, ok := account.(*state.Account)
+		require.True(ok)
+		require.NoError(acct.AddBalance(big.NewInt(100000000000000000)))
+
+		return 0, nil
+	}).AnyTimes()
+	sf.EXPECT().Height().Return(uint64(1), nil).AnyTimes()
+	ctx := genesis.WithGenesisContext(context.Background(), genesis.TestDefault())
+	require.NoError(ap.Add(ctx, tsf1))
+	require.NoError(ap.Add(ctx, tsf3))
+	require.NoError(ap.Add(ctx, tsf4))
+	require.NoError(ap.Add(ctx, tsf5))
+
+	pending, queued := ap.PendingAndQueuedActionMap()
+	require.Equal(ap.PendingActionMap(), pending)
+	require.Equal(2, len(pending))
+	require.Equal([]*action.SealedEnvelope{tsf1}, pending[_addr1])
+	require.Equal([]*action.SealedEnvelope{tsf5}, pending[_addr2])
+	require.Equal(1, len(queued))
+	require.Equal([]*action.SealedEnvelope{tsf3, tsf4}, queued[_addr1])
+	numPending, numQueued := ap.PendingAndQueuedSize()
+	require.EqualValues(2, numPending)
+	require.EqualValues(2, numQueued)
+	require.Equal(ap.GetSize(), numPending+numQueued)
+}
+
 func TestActPool_GetActionByHash(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	require := require.New(t)
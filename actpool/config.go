@@ -59,4 +59,6 @@ func (ap Config) MinGasPrice() *big.Int {
 // StoreConfig is the configuration for the blob store
 type StoreConfig struct {
 	Datadir string `yaml:"datadir"` // Data directory containing the currently executable blobs
+	// Compressor is the compression used on the stored actions, e.g. Snappy, Zstd or Lz4, empty means no compression
+	Compressor string `yaml:"compressor"`
 }
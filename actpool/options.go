@@ -10,6 +10,9 @@ import (
 	"time"
 
 	"github.com/facebookgo/clock"
+
+	"github.com/iotexproject/iotex-core/v2/action"
+	"github.com/iotexproject/iotex-core/v2/pkg/compress"
 )
 
 // ActQueueOption is the option for actQueue.
@@ -41,9 +44,29 @@ func WithStore(cfg StoreConfig, encode encodeAction, decode decodeAction) func(*
 		if encode == nil || decode == nil {
 			return errors.New("encode and decode functions must be provided")
 		}
+		codec, err := compress.NewCodec(cfg.Compressor, nil)
+		if err != nil {
+			return err
+		}
 		store, err := newActionStore(actionStoreConfig{
 			Datadir: cfg.Datadir,
-		}, encode, decode)
+		}, func(selp *action.SealedEnvelope) ([]byte, error) {
+			data, err := encode(selp)
+			if err != nil {
+				return nil, err
+			}
+			return codec.Compress(data)
+		}, func(blob []byte) (*action.SealedEnvelope, error) {
+			data, err := codec.Decompress(blob)
+			if err == nil {
+				selp, err := decode(data)
+				if err == nil || cfg.Compressor == "" {
+					return selp, err
+				}
+			}
+			// the action may be stored before the compression is enabled
+			return decode(blob)
+		})
 		if err != nil {
 			return err
 		}
@@ -299,6 +299,68 @@ func (worker *queueWorker) PendingActions(ctx context.Context) []*pendingActions
 	return actionArr
 }
 
+// PendingAndQueuedActions returns the pending actions and the accepted actions which are not pending, of the same
+// snapshot of the queues
+func (worker *queueWorker) PendingAndQueuedActions(ctx context.Context) ([]*pendingActions, []*pendingActions) {
+	var (
+		pendingArr = make([]*pendingActions, 0)
+		queuedArr  = make([]*pendingActions, 0)
+	)
+	worker.mu.RLock()
+	defer worker.mu.RUnlock()
+	worker.accountActs.Range(func(from string, queue ActQueue) {
+		if queue.Empty() {
+			return
+		}
+		pd := queue.PendingActs(ctx)
+		pendingNonces := make(map[uint64]struct{}, len(pd))
+		for _, act := range pd {
+			pendingNonces[act.Nonce()] = struct{}{}
+		}
+		var queued []*action.SealedEnvelope
+		for _, act := range queue.AllActs() {
+			if _, ok := pendingNonces[act.Nonce()]; !ok {
+				queued = append(queued, act)
+			}
+		}
+		if len(pd) > 0 {
+			pendingArr = append(pendingArr, &pendingActions{
+				sender: from,
+				acts:   pd,
+			})
+		}
+		if len(queued) > 0 {
+			queuedArr = append(queuedArr, &pendingActions{
+				sender: from,
+				acts:   queued,
+			})
+		}
+	})
+	return pendingArr, queuedArr
+}
+
+// PendingAndQueuedSize returns the numbers of the pending and the queued actions, counted by the nonces tracked in
+// the queues without reading the account states
+func (worker *queueWorker) PendingAndQueuedSize() (uint64, uint64) {
+	var numPending, numQueued uint64
+	worker.mu.RLock()
+	defer worker.mu.RUnlock()
+	worker.accountActs.Range(func(_ string, queue ActQueue) {
+		var (
+			accountNonce, _ = queue.AccountState()
+			pendingNonce    = queue.PendingNonce()
+			size            = uint64(queue.Len())
+			pending         uint64
+		)
+		if pendingNonce > accountNonce {
+			pending = min(pendingNonce-accountNonce, size)
+		}
+		numPending += pending
+		numQueued += size - pending
+	})
+	return numPending, numQueued
+}
+
 // AllActions returns the all actions of sender
 func (worker *queueWorker) AllActions(sender address.Address) ([]*action.SealedEnvelope, bool) {
 	worker.mu.RLock()
@@ -0,0 +1,119 @@
+package api
+
+import (
+	"sync"
+
+	"github.com/iotexproject/go-pkgs/cache/ttl"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/v2/action"
+	apitypes "github.com/iotexproject/iotex-core/v2/api/types"
+	"github.com/iotexproject/iotex-core/v2/pkg/log"
+)
+
+type (
+	// actionListener implements the ActionListener interface
+	actionListener struct {
+		capacity    *listenerCapacity
+		streamMap   *ttl.Cache // all registered <ActionResponder>
+		idGenerator *randID
+		mu          sync.Mutex
+	}
+)
+
+// NewActionListener returns a new listener of pending actions in actpool
+func NewActionListener(c int) apitypes.ActionListener {
+	return newActionListener(newListenerCapacity(c))
+}
+
+func newActionListener(capacity *listenerCapacity) *actionListener {
+	s, _ := ttl.NewCache(ttl.EvictOnErrorOption())
+	capacity.register(s.Count)
+	return &actionListener{
+		capacity:    capacity,
+		streamMap:   s,
+		idGenerator: newIDGenerator(_idSize),
+	}
+}
+
+// Start starts the actionListener
+func (al *actionListener) Start() error {
+	return nil
+}
+
+// Stop stops the actionListener
+func (al *actionListener) Stop() error {
+	// notify all responders to exit
+	al.streamMap.Range(func(_, value interface{}) error {
+		r, ok := value.(apitypes.ActionResponder)
+		if !ok {
+			log.L().Error("streamMap stores a value which is not an ActionResponder")
+			return errorUnsupportedType
+		}
+		r.Exit()
+		return nil
+	})
+	al.streamMap.Reset()
+	apiLimitMtcs.WithLabelValues("actionListener").Set(float64(al.streamMap.Count()))
+	return nil
+}
+
+// OnAdded passes the action newly added into actpool to every responder
+func (al *actionListener) OnAdded(selp *action.SealedEnvelope) {
+	al.streamMap.Range(func(key, value interface{}) error {
+		r, ok := value.(apitypes.ActionResponder)
+		if !ok {
+			log.L().Error("streamMap stores a value which is not an ActionResponder")
+			return errorUnsupportedType
+		}
+		err := r.RespondAction(key.(string), selp)
+		if err != nil {
+			log.L().Debug("responder failed to process pending action", zap.Error(err))
+		}
+		return err
+	})
+}
+
+// OnRemoved does nothing
+func (al *actionListener) OnRemoved(*action.SealedEnvelope) {}
+
+// AddResponder adds a new responder
+func (al *actionListener) AddResponder(responder apitypes.ActionResponder) (string, error) {
+	al.mu.Lock()
+	defer al.mu.Unlock()
+	return al.capacity.add(func() (string, error) {
+		listenerID, i := "", 0
+		for ; i < _idRetry; i++ {
+			listenerID = al.idGenerator.newID()
+			if _, exist := al.streamMap.Get(listenerID); !exist {
+				break
+			}
+		}
+		if i == _idRetry {
+			return "", errors.New("No peer id is available")
+		}
+
+		al.streamMap.Set(listenerID, responder)
+		apiLimitMtcs.WithLabelValues("actionListener").Set(float64(al.streamMap.Count()))
+		return listenerID, nil
+	})
+}
+
+// RemoveResponder delete the responder
+func (al *actionListener) RemoveResponder(listenerID string) (bool, error) {
+	al.mu.Lock()
+	defer al.mu.Unlock()
+	value, exist := al.streamMap.Get(listenerID)
+	if !exist {
+		return false, errListenerNotFound
+	}
+	r, ok := value.(apitypes.ActionResponder)
+	if !ok {
+		log.L().Error("streamMap stores a value which is not an ActionResponder")
+		return false, errListenerNotFound
+	}
+	r.Exit()
+	apiLimitMtcs.WithLabelValues("actionListener").Set(float64(al.streamMap.Count() - 1))
+	return al.streamMap.Delete(listenerID), nil
+}
@@ -0,0 +1,154 @@
+package api
+
+import (
+	"encoding/hex"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/v2/action"
+	"github.com/iotexproject/iotex-core/v2/test/identityset"
+	mock_apitypes "github.com/iotexproject/iotex-core/v2/test/mock/mock_apiresponder"
+	"github.com/iotexproject/iotex-core/v2/testutil"
+)
+
+func TestActionListener(t *testing.T) {
+	r := require.New(t)
+	ctrl := gomock.NewController(t)
+
+	selp, err := action.SignedTransfer(identityset.Address(28).String(), identityset.PrivateKey(27), 1, big.NewInt(10), nil, 100000, big.NewInt(0))
+	r.NoError(err)
+	responder := mock_apitypes.NewMockActionResponder(ctrl)
+	listener := NewActionListener(1)
+	r.NoError(listener.Start())
+
+	t.Run("evictOnError", func(t *testing.T) {
+		id, err := listener.AddResponder(responder)
+		r.NoError(err)
+		responder.EXPECT().RespondAction(id, selp).Return(nil).Times(1)
+		listener.OnAdded(selp)
+		responder.EXPECT().RespondAction(id, selp).Return(errResponderExited).Times(1)
+		listener.OnAdded(selp)
+		// the responder is removed after failure
+		listener.OnAdded(selp)
+		_, err = listener.RemoveResponder(id)
+		r.Equal(errListenerNotFound, err)
+	})
+
+	t.Run("errorCapacityReached", func(t *testing.T) {
+		_, err := listener.AddResponder(responder)
+		r.NoError(err)
+		_, err = listener.AddResponder(mock_apitypes.NewMockActionResponder(ctrl))
+		r.Equal(errorCapacityReached, err)
+		responder.EXPECT().Exit().Times(1)
+		r.NoError(listener.Stop())
+	})
+
+	t.Run("removeResponder", func(t *testing.T) {
+		id, err := listener.AddResponder(responder)
+		r.NoError(err)
+		responder.EXPECT().Exit().Times(1)
+		ret, err := listener.RemoveResponder(id)
+		r.True(ret)
+		r.NoError(err)
+		// OnRemoved is a no-op
+		listener.OnRemoved(selp)
+	})
+	t.Run("sharedCapacity", func(t *testing.T) {
+		capacity := newListenerCapacity(2)
+		chainListener := newChainListener(capacity)
+		actionListener := newActionListener(capacity)
+		_, err := chainListener.AddResponder(mock_apitypes.NewMockResponder(ctrl))
+		r.NoError(err)
+		id, err := actionListener.AddResponder(responder)
+		r.NoError(err)
+		// the subscriptions of blocks and pending actions are counted together
+		_, err = chainListener.AddResponder(mock_apitypes.NewMockResponder(ctrl))
+		r.Equal(errorCapacityReached, err)
+		_, err = actionListener.AddResponder(mock_apitypes.NewMockActionResponder(ctrl))
+		r.Equal(errorCapacityReached, err)
+		responder.EXPECT().Exit().Times(1)
+		_, err = actionListener.RemoveResponder(id)
+		r.NoError(err)
+		_, err = chainListener.AddResponder(mock_apitypes.NewMockResponder(ctrl))
+		r.NoError(err)
+	})
+}
+
+func TestWeb3PendingActionListener(t *testing.T) {
+	r := require.New(t)
+
+	selp, err := action.SignedTransfer(identityset.Address(28).String(), identityset.PrivateKey(27), 1, big.NewInt(10), nil, 100000, big.NewInt(0))
+	r.NoError(err)
+	h, err := selp.Hash()
+	r.NoError(err)
+
+	var (
+		mu       sync.Mutex
+		received []*streamResponse
+		release  = make(chan struct{})
+	)
+	handler := func(in interface{}) (int, error) {
+		<-release
+		mu.Lock()
+		defer mu.Unlock()
+		received = append(received, in.(*streamResponse))
+		return 0, nil
+	}
+	numReceived := func() int {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(received)
+	}
+
+	t.Run("hashOnly", func(t *testing.T) {
+		pl := newWeb3PendingActionListener(handler, 0, false, 2)
+		defer pl.Exit()
+		// the stream is blocked by a slow client, the first action is being sent,
+		// the next two are buffered and the rest are dropped without blocking
+		for i := 0; i < 10; i++ {
+			r.NoError(pl.RespondAction("0x01", selp))
+			if i == 0 {
+				r.NoError(testutil.WaitUntil(10*time.Millisecond, time.Second, func() (bool, error) {
+					return len(pl.actions) == 0, nil
+				}))
+			}
+		}
+		close(release)
+		r.NoError(testutil.WaitUntil(10*time.Millisecond, time.Second, func() (bool, error) {
+			return numReceived() == 3, nil
+		}))
+		time.Sleep(50 * time.Millisecond)
+		r.Equal(3, numReceived())
+		r.Equal("0x01", received[0].id)
+		r.Equal("0x"+hex.EncodeToString(h[:]), received[0].result)
+	})
+
+	t.Run("fullTx", func(t *testing.T) {
+		pl := newWeb3PendingActionListener(handler, 0, true, 2)
+		r.NoError(pl.RespondAction("0x02", selp))
+		r.NoError(testutil.WaitUntil(10*time.Millisecond, time.Second, func() (bool, error) {
+			return numReceived() == 4, nil
+		}))
+		tx, ok := received[3].result.(*getTransactionResult)
+		r.True(ok)
+		r.Nil(tx.blockHash)
+		r.Equal(uint64(1), tx.ethTx.Nonce())
+		pl.Exit()
+		r.Equal(errResponderExited, pl.RespondAction("0x02", selp))
+	})
+
+	t.Run("exitOnStreamError", func(t *testing.T) {
+		pl := newWeb3PendingActionListener(func(interface{}) (int, error) {
+			return 0, errorSend
+		}, 0, false, 2)
+		r.NoError(pl.RespondAction("0x03", selp))
+		r.NoError(testutil.WaitUntil(10*time.Millisecond, time.Second, func() (bool, error) {
+			return pl.RespondAction("0x03", selp) == errResponderExited, nil
+		}))
+	})
+}
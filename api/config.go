@@ -14,10 +14,13 @@ import (
 
 // Config is the api service config
 type Config struct {
-	UseRDS          bool              `yaml:"useRDS"`
-	GRPCPort        int               `yaml:"port"`
-	HTTPPort        int               `yaml:"web3port"`
-	WebSocketPort   int               `yaml:"webSocketPort"`
+	GRPCPort      int `yaml:"port"`
+	HTTPPort      int `yaml:"web3port"`
+	WebSocketPort int `yaml:"webSocketPort"`
+	GraphQLPort   int `yaml:"graphQLPort"`
+	// RedisCacheURL is the address of the redis server keeping the web3 filters created by eth_newFilter and
+	// eth_newBlockFilter, which are kept in memory if the server is not reachable. It is not the cache of the api
+	// responses, which is configured by ResponseCache.
 	RedisCacheURL   string            `yaml:"redisCacheURL"`
 	TpsWindow       int               `yaml:"tpsWindow"`
 	GasStation      gasstation.Config `yaml:"gasStation"`
@@ -31,11 +34,39 @@ type Config struct {
 	ListenerLimit int `yaml:"listenerLimit"`
 	// ReadyDuration is the duration to wait for the server to be ready.
 	ReadyDuration time.Duration `yaml:"readyDuration"`
+	// ResponseCache is the cache of the responses immutable at a height.
+	ResponseCache ResponseCacheConfig `yaml:"responseCache"`
+	// RateLimit is the rate limit of the requests per client IP and API key.
+	RateLimit RateLimitConfig `yaml:"rateLimit"`
+	// SimulateLimit is the limit of a request simulating blocks of calls.
+	SimulateLimit SimulateLimitConfig `yaml:"simulateLimit"`
+	// GraphQL is the limit of the graphql queries.
+	GraphQL GraphQLConfig `yaml:"graphQL"`
+}
+
+// GraphQLConfig is the limit of the graphql queries
+type GraphQLConfig struct {
+	// MaxQuerySize is the max length of a query, which bounds the number of the fields selected in it
+	MaxQuerySize int `yaml:"maxQuerySize"`
+	// MaxQueryCost is the max number of request units of the expensive fields resolved in a query, e.g., blocks,
+	// logs and calls, 0 means no limit
+	MaxQueryCost int `yaml:"maxQueryCost"`
+	// LogsPageSize is the max number of blocks whose logs are returned by a logs query, 0 means no limit
+	LogsPageSize uint64 `yaml:"logsPageSize"`
+}
+
+// SimulateLimitConfig is the limit of a request simulating blocks of calls
+type SimulateLimitConfig struct {
+	// MaxCalls is the max number of calls in all the blocks
+	MaxCalls int `yaml:"maxCalls"`
+	// GasCap is the max gas consumed by all the calls, 0 means no cap
+	GasCap uint64 `yaml:"gasCap"`
+	// Timeout is the max duration of the simulation, 0 means no timeout
+	Timeout time.Duration `yaml:"timeout"`
 }
 
 // DefaultConfig is the default config
 var DefaultConfig = Config{
-	UseRDS:             false,
 	GRPCPort:           14014,
 	HTTPPort:           15014,
 	WebSocketPort:      16014,
@@ -46,4 +77,41 @@ var DefaultConfig = Config{
 	WebsocketRateLimit: 5,
 	ListenerLimit:      5000,
 	ReadyDuration:      time.Second * 30,
+	ResponseCache: ResponseCacheConfig{
+		Backend:     ResponseCacheLRU,
+		Size:        10000,
+		RedisPrefix: "iotex",
+		TTL:         time.Hour,
+	},
+	SimulateLimit: SimulateLimitConfig{
+		MaxCalls: 1000,
+		GasCap:   50000000,
+		Timeout:  5 * time.Second,
+	},
+	GraphQL: GraphQLConfig{
+		MaxQuerySize: 8192,
+		MaxQueryCost: 1000,
+		LogsPageSize: 1000,
+	},
+	RateLimit: RateLimitConfig{
+		Enabled:      false,
+		IPRate:       50,
+		IPBurst:      100,
+		APIKeyHeader: "X-API-Key",
+		APIKeys:      map[string]Quota{},
+		MethodWeights: map[string]int{
+			"eth_getLogs":                       5,
+			"eth_call":                          2,
+			"eth_estimateGas":                   2,
+			"eth_getBlockReceipts":              2,
+			"eth_getProof":                      2,
+			"debug_traceTransaction":            10,
+			"debug_traceCall":                   10,
+			"/iotexapi.APIService/GetLogs":      5,
+			"/iotexapi.APIService/ReadContract": 2,
+		},
+		LogsRangeUnit: 100,
+		MaxClients:    10000,
+		MaxStreams:    10,
+	},
 }
@@ -9,21 +9,26 @@ type (
 	streamContextKey struct{}
 
 	StreamContext struct {
-		listenerIDs map[string]struct{}
+		listenerIDs map[string]func()
 		mutex       sync.Mutex
 	}
 )
 
-func (sc *StreamContext) AddListener(id string) {
+// AddListener adds the listener, and the function called once the listener is removed
+func (sc *StreamContext) AddListener(id string, release func()) {
 	sc.mutex.Lock()
 	defer sc.mutex.Unlock()
-	sc.listenerIDs[id] = struct{}{}
+	sc.listenerIDs[id] = release
 }
 
 func (sc *StreamContext) RemoveListener(id string) {
 	sc.mutex.Lock()
-	defer sc.mutex.Unlock()
+	release, ok := sc.listenerIDs[id]
 	delete(sc.listenerIDs, id)
+	sc.mutex.Unlock()
+	if ok && release != nil {
+		release()
+	}
 }
 
 func (sc *StreamContext) ListenerIDs() []string {
@@ -38,7 +43,7 @@ func (sc *StreamContext) ListenerIDs() []string {
 
 func WithStreamContext(ctx context.Context) context.Context {
 	return context.WithValue(ctx, streamContextKey{}, &StreamContext{
-		listenerIDs: make(map[string]struct{}),
+		listenerIDs: make(map[string]func()),
 	})
 }
 
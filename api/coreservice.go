@@ -12,6 +12,7 @@ import (
 	"fmt"
 	"math"
 	"math/big"
+	"reflect"
 	"strconv"
 	"time"
 
@@ -51,6 +52,7 @@ import (
 	"github.com/iotexproject/iotex-core/v2/action/protocol/rolldpos"
 	"github.com/iotexproject/iotex-core/v2/action/protocol/staking"
 	"github.com/iotexproject/iotex-core/v2/actpool"
+	"github.com/iotexproject/iotex-core/v2/api/extensionpb"
 	logfilter "github.com/iotexproject/iotex-core/v2/api/logfilter"
 	apitypes "github.com/iotexproject/iotex-core/v2/api/types"
 	"github.com/iotexproject/iotex-core/v2/blockchain"
@@ -77,6 +79,16 @@ const (
 	// defaultTraceTimeout is the amount of time a single transaction can execute
 	// by default before being forcefully aborted.
 	defaultTraceTimeout = 5 * time.Second
+	// maxSimulateBlocks is the max number of blocks to simulate in a request
+	maxSimulateBlocks = 256
+	// maxAccessListIterations is the max number of runs to create an access list, as the addresses and slots
+	// accessed may keep changing with the list applied
+	maxAccessListIterations = 16
+	// maxTraceReplayBlocks is the max number of blocks to replay in a call trace filter, if the call traces
+	// are not indexed
+	maxTraceReplayBlocks = 100
+	// maxProjectEpochs is the max number of epochs to project the delegate reward
+	maxProjectEpochs = 100
 )
 
 type (
@@ -85,6 +97,8 @@ type (
 		WithHeight(uint64) CoreServiceReaderWithHeight
 		// Account returns the metadata of an account
 		Account(addr address.Address) (*iotextypes.AccountMeta, *iotextypes.BlockIdentifier, error)
+		// AccountProof returns the merkle proof of an account and its storage slots
+		AccountProof(ctx context.Context, addr address.Address, storageKeys []hash.Hash256) (*apitypes.AccountProof, error)
 		// ChainMeta returns blockchain metadata
 		ChainMeta() (*iotextypes.ChainMeta, string, error)
 		// ServerMeta gets the server metadata
@@ -92,7 +106,7 @@ type (
 		// SendAction is the API to send an action to blockchain.
 		SendAction(ctx context.Context, in *iotextypes.Action) (string, error)
 		// ReadContract reads the state in a contract address specified by the slot
-		ReadContract(ctx context.Context, callerAddr address.Address, sc action.Envelope) (string, *iotextypes.Receipt, error)
+		ReadContract(ctx context.Context, callerAddr address.Address, sc action.Envelope, opts ...protocol.SimulateOption) (string, *iotextypes.Receipt, error)
 		// ReadState reads state on blockchain
 		ReadState(protocolID string, height string, methodName []byte, arguments [][]byte) (*iotexapi.ReadStateResponse, error)
 		// SuggestGasPrice suggests gas price
@@ -111,6 +125,8 @@ type (
 		ElectionBuckets(epochNum uint64) ([]*iotextypes.ElectionBucket, error)
 		// ReceiptByActionHash returns receipt by action hash
 		ReceiptByActionHash(h hash.Hash256) (*action.Receipt, error)
+		// ReceiptsByHeight returns the receipts of the block at the height
+		ReceiptsByHeight(height uint64) ([]*action.Receipt, error)
 		// TransactionLogByActionHash returns transaction log by action hash
 		TransactionLogByActionHash(actHash string) (*iotextypes.TransactionLog, error)
 		// TransactionLogByBlockHeight returns transaction log by block height
@@ -133,6 +149,10 @@ type (
 		PendingActionByActionHash(h hash.Hash256) (*action.SealedEnvelope, error)
 		// ActionsInActPool returns the all Transaction Identifiers in the actpool
 		ActionsInActPool(actHashes []string) ([]*action.SealedEnvelope, error)
+		// ActPoolContent returns the pending and queued actions in the actpool, grouped by sender
+		ActPoolContent() (map[string][]*action.SealedEnvelope, map[string][]*action.SealedEnvelope)
+		// ActPoolStatus returns the numbers of the pending and queued actions in the actpool
+		ActPoolStatus() (uint64, uint64)
 		// BlockByHeightRange returns blocks within the height range
 		BlockByHeightRange(uint64, uint64) ([]*apitypes.BlockWithReceipts, error)
 		// BlockByHeight returns the block and its receipt from block height
@@ -147,6 +167,12 @@ type (
 		EstimateGasForNonExecution(action.Action) (uint64, error)
 		// EstimateExecutionGasConsumption estimate gas consumption for execution action
 		EstimateExecutionGasConsumption(ctx context.Context, sc action.Envelope, callerAddr address.Address, opts ...protocol.SimulateOption) (uint64, []byte, error)
+		// CreateAccessList creates the access list of an execution, and returns the receipt of the execution with the list
+		CreateAccessList(ctx context.Context, callerAddr address.Address, sc action.Envelope, opts ...protocol.SimulateOption) (types.AccessList, *action.Receipt, error)
+		// SimulateBlocks simulates the blocks of calls upon the tip, each call sees the state changes of the calls before it
+		SimulateBlocks(ctx context.Context, blocks []*apitypes.SimulateBlock) ([]*apitypes.SimulatedBlock, error)
+		// ProjectDelegateReward projects the rewards of a bucket voting for the candidate in the upcoming epochs
+		ProjectDelegateReward(ctx context.Context, candidate string, amount *big.Int, duration uint32, autoStake bool, epochs uint64, distributionRatio uint32) (*apitypes.DelegateRewardProjection, error)
 		// LogsInBlockByHash filter logs in the block by hash
 		LogsInBlockByHash(filter *logfilter.LogFilter, blockHash hash.Hash256) ([]*action.Log, error)
 		// LogsInRange filter logs among [start, end] blocks
@@ -161,8 +187,10 @@ type (
 		ReadContractStorage(ctx context.Context, addr address.Address, key []byte) ([]byte, error)
 		// ChainListener returns the instance of Listener
 		ChainListener() apitypes.Listener
+		// ActionListener returns the listener of pending actions in actpool
+		ActionListener() apitypes.ActionListener
 		// SimulateExecution simulates execution
-		SimulateExecution(context.Context, address.Address, action.Envelope) ([]byte, *action.Receipt, error)
+		SimulateExecution(context.Context, address.Address, action.Envelope, ...protocol.SimulateOption) ([]byte, *action.Receipt, error)
 		// SyncingProgress returns the syncing status of node
 		SyncingProgress() (uint64, uint64, uint64)
 		// TipHeight returns the tip of the chain
@@ -175,6 +203,15 @@ type (
 		BlockHashByBlockHeight(blkHeight uint64) (hash.Hash256, error)
 		// TraceTransaction returns the trace result of a transaction
 		TraceTransaction(ctx context.Context, actHash string, config *tracers.TraceConfig) ([]byte, *action.Receipt, any, error)
+		// TraceBlockCalls returns the call traces of the actions in the block at height
+		TraceBlockCalls(ctx context.Context, height uint64) ([]*apitypes.ActionCallTraces, error)
+		// TraceActionCalls returns the call traces of an action
+		TraceActionCalls(ctx context.Context, actHash string) (*apitypes.ActionCallTraces, error)
+		// FilterCallTraces returns the call traces matching the filter, in the order of being executed
+		FilterCallTraces(ctx context.Context, filter *apitypes.CallTraceFilter) ([]*apitypes.ActionCallTraces, error)
+		// TokenTransfers returns the token transfers [start, start+count) from or to the address, of the token if
+		// not nil, and the total number of them
+		TokenTransfers(addr, token address.Address, start, count uint64) ([]*blockindex.TokenTransfer, uint64, error)
 		// TraceCall returns the trace result of a call
 		TraceCall(ctx context.Context,
 			callerAddr address.Address,
@@ -200,6 +237,9 @@ type (
 		dao               blockdao.BlockDAO
 		indexer           blockindex.Indexer
 		bfIndexer         blockindex.BloomFilterIndexer
+		traceIndexer      blockindex.TraceIndexer
+		ttIndexer         blockindex.TokenTransferIndexer
+		shIndexer         blockindex.StakingHistoryIndexer
 		ap                actpool.ActPool
 		gs                *gasstation.GasStation
 		broadcastHandler  BroadcastOutbound
@@ -207,8 +247,9 @@ type (
 		archiveSupported  bool
 		registry          *protocol.Registry
 		chainListener     apitypes.Listener
+		actionListener    apitypes.ActionListener
 		electionCommittee committee.Committee
-		readCache         *ReadCache
+		respCache         *tipResponseCache
 		actionRadio       *ActionRadio
 		apiStats          *nodestats.APILocalStats
 		getBlockTime      evm.GetBlockTime
@@ -255,6 +296,34 @@ func WithArchiveSupport() Option {
 	}
 }
 
+// WithTraceIndexer is the option to serve the call traces from the trace indexer
+func WithTraceIndexer(indexer blockindex.TraceIndexer) Option {
+	return func(svr *coreService) {
+		svr.traceIndexer = indexer
+	}
+}
+
+// WithResponseCache is the option to cache the api responses in the cache, instead of the one in config
+func WithResponseCache(c ResponseCache) Option {
+	return func(svr *coreService) {
+		svr.respCache = newTipResponseCache(c, 0)
+	}
+}
+
+// WithTokenTransferIndexer is the option to serve the token transfers from the token transfer indexer
+func WithTokenTransferIndexer(indexer blockindex.TokenTransferIndexer) Option {
+	return func(svr *coreService) {
+		svr.ttIndexer = indexer
+	}
+}
+
+// WithStakingHistoryIndexer is the option to serve the staking history from the staking history indexer
+func WithStakingHistoryIndexer(indexer blockindex.StakingHistoryIndexer) Option {
+	return func(svr *coreService) {
+		svr.shIndexer = indexer
+	}
+}
+
 type intrinsicGasCalculator interface {
 	IntrinsicGas() (uint64, error)
 }
@@ -278,7 +347,7 @@ func newCoreService(
 	getBlockTime evm.GetBlockTime,
 	opts ...Option,
 ) (CoreService, error) {
-	if cfg == (Config{}) {
+	if reflect.DeepEqual(cfg, Config{}) {
 		log.L().Warn("API server is not configured.")
 		cfg = DefaultConfig
 	}
@@ -287,26 +356,36 @@ func newCoreService(
 		return nil, errors.New("range query upper limit cannot be less than tps window")
 	}
 
+	// the subscriptions of blocks, logs and pending actions share the listener limit
+	listenerCapacity := newListenerCapacity(cfg.ListenerLimit)
 	core := coreService{
-		bc:            chain,
-		bs:            bs,
-		sf:            sf,
-		dao:           dao,
-		indexer:       indexer,
-		bfIndexer:     bfIndexer,
-		ap:            actPool,
-		cfg:           cfg,
-		registry:      registry,
-		chainListener: NewChainListener(cfg.ListenerLimit),
-		gs:            gasstation.NewGasStation(chain, dao, cfg.GasStation),
-		readCache:     NewReadCache(),
-		getBlockTime:  getBlockTime,
+		bc:             chain,
+		bs:             bs,
+		sf:             sf,
+		dao:            dao,
+		indexer:        indexer,
+		bfIndexer:      bfIndexer,
+		ap:             actPool,
+		cfg:            cfg,
+		registry:       registry,
+		chainListener:  newChainListener(listenerCapacity),
+		actionListener: newActionListener(listenerCapacity),
+		gs:             gasstation.NewGasStation(chain, dao, cfg.GasStation),
+		getBlockTime:   getBlockTime,
 	}
 
 	for _, opt := range opts {
 		opt(&core)
 	}
+	if core.respCache == nil {
+		respCache, err := NewResponseCache(cfg.ResponseCache)
+		if err != nil {
+			return nil, err
+		}
+		core.respCache = newTipResponseCache(respCache, 0)
+	}
 
+	actPool.AddSubscriber(core.actionListener)
 	if core.broadcastHandler != nil {
 		core.actionRadio = NewActionRadio(core.broadcastHandler, core.bc.ChainID(), WithMessageBatch())
 		actPool.AddSubscriber(core.actionRadio)
@@ -341,6 +420,61 @@ func (core *coreService) Account(addr address.Address) (*iotextypes.AccountMeta,
 	return core.acccount(ctx, tipHeight, state, pendingNonce, addr)
 }
 
+// AccountProof returns the merkle proof of an account and its storage slots
+func (core *coreService) AccountProof(ctx context.Context, addr address.Address, storageKeys []hash.Hash256) (*apitypes.AccountProof, error) {
+	ctx, span := tracer.NewSpan(ctx, "coreService.AccountProof")
+	defer span.End()
+	ctx = genesis.WithGenesisContext(ctx, core.bc.Genesis())
+	ws, err := core.sf.WorkingSet(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	height, err := ws.Height()
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	// the working set is created on top of the tip
+	return core.accountProof(ctx, ws, height-1, addr, storageKeys)
+}
+
+func (core *coreService) accountProof(ctx context.Context, ws protocol.StateManager, height uint64, addr address.Address, storageKeys []hash.Hash256) (*apitypes.AccountProof, error) {
+	prover, ok := ws.(factory.StateProver)
+	if !ok {
+		return nil, status.Error(codes.Unimplemented, "state proof is not supported")
+	}
+	addrHash := hash.BytesToHash160(addr.Bytes())
+	account, err := accountutil.AccountState(ctx, ws, addr)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	stateRoot, accountProof, err := prover.StateProof(protocol.LegacyKeyOption(addrHash))
+	if err != nil {
+		if errors.Cause(err) == factory.ErrNotSupported {
+			return nil, status.Error(codes.Unimplemented, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	storageProof := make([]*apitypes.StorageProof, 0, len(storageKeys))
+	for _, key := range storageKeys {
+		value, proof, err := evm.ContractStorageProof(ws, addrHash, account.Root, key)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		storageProof = append(storageProof, &apitypes.StorageProof{
+			Key:   key,
+			Value: value,
+			Proof: proof,
+		})
+	}
+	return &apitypes.AccountProof{
+		Height:       height,
+		StateRoot:    stateRoot,
+		Account:      account,
+		AccountProof: accountProof,
+		StorageProof: storageProof,
+	}, nil
+}
+
 func (core *coreService) acccount(ctx context.Context, height uint64, state *state.Account, pendingNonce uint64, addr address.Address) (*iotextypes.AccountMeta, *iotextypes.BlockIdentifier, error) {
 	if core.indexer == nil {
 		return nil, nil, status.Error(codes.NotFound, blockindex.ErrActionIndexNA.Error())
@@ -533,29 +667,29 @@ func (core *coreService) validateChainID(chainID uint32) error {
 }
 
 // ReadContract reads the state in a contract address specified by the slot
-func (core *coreService) ReadContract(ctx context.Context, callerAddr address.Address, elp action.Envelope) (string, *iotextypes.Receipt, error) {
+func (core *coreService) ReadContract(ctx context.Context, callerAddr address.Address, elp action.Envelope, opts ...protocol.SimulateOption) (string, *iotextypes.Receipt, error) {
 	log.Logger("api").Debug("receive read smart contract request")
 	exec, ok := elp.Action().(*action.Execution)
 	if !ok {
 		return "", nil, status.Error(codes.InvalidArgument, "expecting action.Execution")
 	}
-	var (
-		tipHeight = core.bc.TipHeight()
-		hdBytes   = append(byteutil.Uint64ToBytesBigEndian(tipHeight), []byte(exec.Contract())...)
-		key       = hash.Hash160b(append(hdBytes, exec.Data()...))
-	)
-	return core.readContract(ctx, key, tipHeight, false, callerAddr, elp)
+	return core.readContract(ctx, core.bc.TipHeight(), false, callerAddr, elp, exec, opts...)
 }
 
 func (core *coreService) readContract(
 	ctx context.Context,
-	key hash.Hash160,
 	height uint64,
 	archive bool,
 	callerAddr address.Address,
-	elp action.Envelope) (string, *iotextypes.Receipt, error) {
+	elp action.Envelope,
+	exec *action.Execution,
+	opts ...protocol.SimulateOption) (string, *iotextypes.Receipt, error) {
+	// the result of a simulation with overrides is not cached
+	cacheable := len(opts) == 0
+	gas := elp.Gas()
+	key := readContractKey(height, callerAddr, gas, exec)
 	// TODO: either moving readcache into the upper layer or change the storage format
-	if d, ok := core.readCache.Get(key); ok {
+	if d, ok := core.respCache.Get(height, key); ok && cacheable {
 		res := iotexapi.ReadContractResponse{}
 		if err := proto.Unmarshal(d, &res); err == nil {
 			return res.Data, res.Receipt, nil
@@ -568,7 +702,7 @@ func (core *coreService) readContract(
 	if elp.Gas() == 0 || blockGasLimit < elp.Gas() {
 		elp.SetGas(blockGasLimit)
 	}
-	retval, receipt, err := core.simulateExecution(ctx, height, archive, callerAddr, elp)
+	retval, receipt, stateHeight, err := core.simulateExecutionWithHeight(ctx, height, archive, callerAddr, elp, opts...)
 	if err != nil {
 		return "", nil, status.Error(codes.Internal, err.Error())
 	}
@@ -576,18 +710,53 @@ func (core *coreService) readContract(
 		Data:    hex.EncodeToString(retval),
 		Receipt: receipt.ConvertToReceiptPb(),
 	}
+	if !cacheable || g.BlockGasLimitByHeight(stateHeight) != blockGasLimit {
+		return res.Data, res.Receipt, nil
+	}
+	if stateHeight != height {
+		// a block is committed after the height is read, the result is cached at the height of the state read
+		key = readContractKey(stateHeight, callerAddr, gas, exec)
+	}
 	if d, err := proto.Marshal(&res); err == nil {
-		core.readCache.Put(key, d)
+		core.respCache.Put(stateHeight, key, d)
 	}
 	return res.Data, res.Receipt, nil
 }
 
+// readContractKey returns the cache key of the read, which covers all the inputs of the simulation
+func readContractKey(height uint64, callerAddr address.Address, gas uint64, exec *action.Execution) hash.Hash160 {
+	var caller, value []byte
+	if callerAddr != nil {
+		caller = callerAddr.Bytes()
+	}
+	if exec.Amount() != nil {
+		value = exec.Amount().Bytes()
+	}
+	return (&ReadKey{
+		Name:   "readContract",
+		Height: strconv.FormatUint(height, 10),
+		Args: [][]byte{
+			[]byte(exec.Contract()),
+			caller,
+			value,
+			byteutil.Uint64ToBytesBigEndian(gas),
+			exec.Data(),
+		},
+	}).Hash()
+}
+
 // ReadState reads state on blockchain
 func (core *coreService) ReadState(protocolID string, height string, methodName []byte, arguments [][]byte) (*iotexapi.ReadStateResponse, error) {
 	p, ok := core.registry.Find(protocolID)
 	if !ok {
 		return nil, status.Errorf(codes.Internal, "protocol %s isn't registered", protocolID)
 	}
+	if _, ok := p.(*staking.Protocol); ok {
+		var method iotexapi.ReadStakingDataMethod
+		if err := proto.Unmarshal(methodName, &method); err == nil && method.GetMethod() == extensionpb.ReadStakingDataMethodHistory {
+			return core.readStakingHistory(arguments)
+		}
+	}
 	data, readStateHeight, err := core.readState(context.Background(), p, height, methodName, arguments...)
 	if err != nil {
 		return nil, status.Error(codes.NotFound, err.Error())
@@ -752,33 +921,60 @@ func (core *coreService) RawBlocks(startHeight uint64, count uint64, withReceipt
 	}
 	var res []*iotexapi.BlockInfo
 	for height := startHeight; height <= endHeight; height++ {
-		blk, err := core.dao.GetBlockByHeight(height)
+		blkInfo, err := core.rawBlock(height, withReceipts, withTransactionLogs)
 		if err != nil {
 			return nil, status.Error(codes.NotFound, err.Error())
 		}
-		var receiptsPb []*iotextypes.Receipt
-		if withReceipts && height > 0 {
-			receipts, err := core.dao.GetReceipts(height)
-			if err != nil {
-				return nil, status.Error(codes.NotFound, err.Error())
-			}
-			for _, receipt := range receipts {
-				receiptsPb = append(receiptsPb, receipt.ConvertToReceiptPb())
-			}
+		res = append(res, blkInfo)
+	}
+	return res, nil
+}
+
+func (core *coreService) rawBlock(height uint64, withReceipts bool, withTransactionLogs bool) (*iotexapi.BlockInfo, error) {
+	blk, receipts, err := core.blockAndReceipts(height, withReceipts)
+	if err != nil {
+		return nil, err
+	}
+	var receiptsPb []*iotextypes.Receipt
+	for _, receipt := range receipts {
+		receiptsPb = append(receiptsPb, receipt.ConvertToReceiptPb())
+	}
+	var transactionLogs *iotextypes.TransactionLogs
+	if withTransactionLogs {
+		if transactionLogs, err = core.dao.TransactionLogs(height); err != nil {
+			return nil, err
 		}
-		var transactionLogs *iotextypes.TransactionLogs
-		if withTransactionLogs {
-			if transactionLogs, err = core.dao.TransactionLogs(height); err != nil {
-				return nil, status.Error(codes.NotFound, err.Error())
-			}
+	}
+	return &iotexapi.BlockInfo{
+		Block:           blk.ConvertToBlockPb(),
+		Receipts:        receiptsPb,
+		TransactionLogs: transactionLogs,
+	}, nil
+}
+
+// blockAndReceipts returns the block at the height, and its receipts if withReceipts is true
+func (core *coreService) blockAndReceipts(height uint64, withReceipts bool) (*block.Block, []*action.Receipt, error) {
+	key := responseCacheKey(_blockNS, strconv.AppendBool(nil, withReceipts))
+	if d, ok := core.respCache.Get(height, key); ok {
+		store, err := (&block.Deserializer{}).SetEvmNetworkID(core.EVMNetworkID()).DeserializeBlockStore(d)
+		if err == nil {
+			return store.Block, store.Receipts, nil
 		}
-		res = append(res, &iotexapi.BlockInfo{
-			Block:           blk.ConvertToBlockPb(),
-			Receipts:        receiptsPb,
-			TransactionLogs: transactionLogs,
-		})
 	}
-	return res, nil
+	blk, err := core.dao.GetBlockByHeight(height)
+	if err != nil {
+		return nil, nil, err
+	}
+	var receipts []*action.Receipt
+	if withReceipts && height > 0 {
+		if receipts, err = core.dao.GetReceipts(height); err != nil {
+			return nil, nil, err
+		}
+	}
+	if d, err := (&block.Store{Block: blk, Receipts: receipts}).Serialize(); err == nil {
+		core.respCache.Put(height, key, d)
+	}
+	return blk, receipts, nil
 }
 
 // ChainListener returns the instance of Listener
@@ -786,6 +982,11 @@ func (core *coreService) ChainListener() apitypes.Listener {
 	return core.chainListener
 }
 
+// ActionListener returns the listener of pending actions in actpool
+func (core *coreService) ActionListener() apitypes.ActionListener {
+	return core.actionListener
+}
+
 // ElectionBuckets returns the native election buckets.
 func (core *coreService) ElectionBuckets(epochNum uint64) ([]*iotextypes.ElectionBucket, error) {
 	if core.electionCommittee == nil {
@@ -912,9 +1113,13 @@ func (core *coreService) TipHeight() uint64 {
 
 // Start starts the API server
 func (core *coreService) Start(_ context.Context) error {
+	core.respCache.tip.Store(core.bc.TipHeight())
 	if err := core.chainListener.Start(); err != nil {
 		return errors.Wrap(err, "failed to start blockchain listener")
 	}
+	if err := core.actionListener.Start(); err != nil {
+		return errors.Wrap(err, "failed to start action listener")
+	}
 	if core.actionRadio != nil {
 		if err := core.actionRadio.Start(); err != nil {
 			return errors.Wrap(err, "failed to start action radio")
@@ -930,26 +1135,42 @@ func (core *coreService) Stop(_ context.Context) error {
 			return errors.Wrap(err, "failed to stop action radio")
 		}
 	}
+	if err := core.actionListener.Stop(); err != nil {
+		return errors.Wrap(err, "failed to stop action listener")
+	}
 	return core.chainListener.Stop()
 }
 
 func (core *coreService) readState(ctx context.Context, p protocol.Protocol, height string, methodName []byte, arguments ...[]byte) ([]byte, uint64, error) {
+	tipHeight := core.bc.TipHeight()
+	readHeight := tipHeight
+	if height != "" {
+		inputHeight, err := strconv.ParseUint(height, 0, 64)
+		if err != nil {
+			return nil, 0, err
+		}
+		// the candidates and buckets are indexed by epoch on a non-archive node, so they are read at the epoch
+		// start height, and other states are read at the exact height
+		if rp := rolldpos.FindProtocol(core.registry); rp != nil && !core.archiveSupported && isEpochIndexedRead(p, methodName) {
+			tipEpochNum := rp.GetEpochNum(tipHeight)
+			inputEpochNum := rp.GetEpochNum(inputHeight)
+			if inputEpochNum < tipEpochNum {
+				inputHeight = rp.GetEpochHeight(inputEpochNum)
+			}
+		}
+		if inputHeight < tipHeight {
+			readHeight = inputHeight
+		}
+	}
 	key := ReadKey{
 		Name:   p.Name(),
-		Height: height,
+		Height: strconv.FormatUint(readHeight, 10),
 		Method: methodName,
 		Args:   arguments,
 	}
-	tipHeight := core.bc.TipHeight()
-	if height == "" {
-		key.Height = strconv.FormatUint(tipHeight, 10)
-	}
-	if d, ok := core.readCache.Get(key.Hash()); ok {
-		h := tipHeight
-		if height != "" {
-			h, _ = strconv.ParseUint(height, 0, 64)
-		}
-		return d, h, nil
+	// the height of the state read is cached along with the response, as it may differ from the read height
+	if d, ok := core.respCache.Get(readHeight, key.Hash()); ok && len(d) >= 8 {
+		return d[8:], byteutil.BytesToUint64BigEndian(d[:8]), nil
 	}
 
 	// TODO: need to complete the context
@@ -962,42 +1183,44 @@ func (core *coreService) readState(ctx context.Context, p protocol.Protocol, hei
 	)
 	ctx = protocol.WithFeatureCtx(protocol.WithFeatureWithHeightCtx(ctx))
 
-	if height != "" {
-		inputHeight, err := strconv.ParseUint(height, 0, 64)
+	var sr protocol.StateReader = core.sf
+	if readHeight < tipHeight {
+		// old data, wrap to history state reader
+		historySR, err := core.sf.WorkingSetAtHeight(ctx, readHeight)
 		if err != nil {
 			return nil, 0, err
 		}
-		rp := rolldpos.FindProtocol(core.registry)
-		if rp != nil {
-			tipEpochNum := rp.GetEpochNum(tipHeight)
-			inputEpochNum := rp.GetEpochNum(inputHeight)
-			if inputEpochNum < tipEpochNum {
-				inputHeight = rp.GetEpochHeight(inputEpochNum)
-			}
-		}
-		if inputHeight < tipHeight {
-			// old data, wrap to history state reader
-			historySR, err := core.sf.WorkingSetAtHeight(ctx, inputHeight)
-			if err != nil {
-				return nil, 0, err
-			}
-			d, h, err := p.ReadState(ctx, historySR, methodName, arguments...)
-			if err == nil {
-				key.Height = strconv.FormatUint(h, 10)
-				core.readCache.Put(key.Hash(), d)
-			}
-			return d, h, err
+		sr = historySR
+		if core.archiveSupported {
+			ctx = protocol.WithArchiveStateCtx(ctx)
 		}
 	}
 	// TODO: need to distinguish user error and system error
-	d, h, err := p.ReadState(ctx, core.sf, methodName, arguments...)
+	d, h, err := p.ReadState(ctx, sr, methodName, arguments...)
 	if err == nil {
-		key.Height = strconv.FormatUint(h, 10)
-		core.readCache.Put(key.Hash(), d)
+		core.respCache.Put(readHeight, key.Hash(), append(byteutil.Uint64ToBytesBigEndian(h), d...))
 	}
 	return d, h, err
 }
 
+// isEpochIndexedRead returns whether the read is served from the staking candidates and buckets indexer, which
+// keeps the candidates and buckets at the start height of each epoch
+func isEpochIndexedRead(p protocol.Protocol, methodName []byte) bool {
+	if _, ok := p.(*staking.Protocol); !ok {
+		return false
+	}
+	var method iotexapi.ReadStakingDataMethod
+	if err := proto.Unmarshal(methodName, &method); err != nil {
+		return false
+	}
+	switch method.GetMethod() {
+	case iotexapi.ReadStakingDataMethod_CANDIDATES, iotexapi.ReadStakingDataMethod_BUCKETS:
+		return true
+	default:
+		return false
+	}
+}
+
 func (core *coreService) getActionsFromIndex(start, count uint64) ([]*iotexapi.ActionInfo, error) {
 	hashes, err := core.indexer.GetActionHashFromIndex(start, count)
 	if err != nil {
@@ -1245,17 +1468,12 @@ func (core *coreService) getBlockByHeight(height uint64) (*apitypes.BlockWithRec
 	if height > core.bc.TipHeight() {
 		return nil, ErrNotFound
 	}
-	blk, err := core.dao.GetBlockByHeight(height)
+	blk, receipts, err := core.blockAndReceipts(height, true)
 	if err != nil {
 		return nil, errors.Wrap(ErrNotFound, err.Error())
 	}
-	receipts := []*action.Receipt{}
-	if blk.Height() > 0 {
-		var err error
-		receipts, err = core.dao.GetReceipts(height)
-		if err != nil {
-			return nil, errors.Wrap(ErrNotFound, err.Error())
-		}
+	if receipts == nil {
+		receipts = []*action.Receipt{}
 	}
 	return &apitypes.BlockWithReceipts{
 		Block:    blk,
@@ -1499,7 +1717,7 @@ func (core *coreService) logsInBlock(filter *logfilter.LogFilter, blockNumber ui
 		return []*action.Log{}, nil
 	}
 
-	receipts, err := core.dao.GetReceipts(blockNumber)
+	receipts, err := core.ReceiptsByHeight(blockNumber)
 	if err != nil {
 		return nil, err
 	}
@@ -1507,6 +1725,35 @@ func (core *coreService) logsInBlock(filter *logfilter.LogFilter, blockNumber ui
 	return filter.MatchLogs(receipts), nil
 }
 
+// ReceiptsByHeight returns the receipts of the block at the height
+func (core *coreService) ReceiptsByHeight(height uint64) ([]*action.Receipt, error) {
+	key := responseCacheKey(_receiptsNS)
+	if d, ok := core.respCache.Get(height, key); ok {
+		receiptsPb := &iotextypes.Receipts{}
+		if err := proto.Unmarshal(d, receiptsPb); err == nil {
+			receipts := make([]*action.Receipt, 0, len(receiptsPb.Receipts))
+			for _, receiptPb := range receiptsPb.Receipts {
+				receipt := &action.Receipt{}
+				receipt.ConvertFromReceiptPb(receiptPb)
+				receipts = append(receipts, receipt)
+			}
+			return receipts, nil
+		}
+	}
+	receipts, err := core.dao.GetReceipts(height)
+	if err != nil {
+		return nil, err
+	}
+	receiptsPb := &iotextypes.Receipts{}
+	for _, receipt := range receipts {
+		receiptsPb.Receipts = append(receiptsPb.Receipts, receipt.ConvertToReceiptPb())
+	}
+	if d, err := proto.Marshal(receiptsPb); err == nil {
+		core.respCache.Put(height, key, d)
+	}
+	return receipts, nil
+}
+
 // LogsInRange filter logs among [start, end] blocks
 func (core *coreService) LogsInRange(filter *logfilter.LogFilter, start, end, paginationSize uint64) ([]*action.Log, []hash.Hash256, error) {
 	start, end, err := core.correctQueryRange(start, end)
@@ -1659,12 +1906,16 @@ func (core *coreService) EstimateMigrateStakeGasConsumption(ctx context.Context,
 
 // EstimateExecutionGasConsumption estimate gas consumption for execution action
 func (core *coreService) EstimateExecutionGasConsumption(ctx context.Context, elp action.Envelope, callerAddr address.Address, opts ...protocol.SimulateOption) (uint64, []byte, error) {
+	return core.estimateExecutionGasConsumption(ctx, core.bc.TipHeight(), false, elp, callerAddr, opts...)
+}
+
+func (core *coreService) estimateExecutionGasConsumption(ctx context.Context, height uint64, archive bool, elp action.Envelope, callerAddr address.Address, opts ...protocol.SimulateOption) (uint64, []byte, error) {
 	var (
 		g             = core.bc.Genesis()
-		blockGasLimit = g.BlockGasLimitByHeight(core.bc.TipHeight())
+		blockGasLimit = g.BlockGasLimitByHeight(height)
 	)
 	elp.SetGas(blockGasLimit)
-	enough, receipt, retval, err := core.isGasLimitEnough(ctx, callerAddr, elp, opts...)
+	enough, receipt, retval, err := core.isGasLimitEnough(ctx, height, archive, callerAddr, elp, opts...)
 	if err != nil {
 		return 0, nil, status.Error(codes.Internal, err.Error())
 	}
@@ -1679,7 +1930,7 @@ func (core *coreService) EstimateExecutionGasConsumption(ctx context.Context, el
 	}
 	estimatedGas := receipt.GasConsumed
 	elp.SetGas(estimatedGas)
-	enough, _, _, err = core.isGasLimitEnough(ctx, callerAddr, elp, opts...)
+	enough, _, _, err = core.isGasLimitEnough(ctx, height, archive, callerAddr, elp, opts...)
 	if err != nil && err != action.ErrInsufficientFunds {
 		return 0, nil, status.Error(codes.Internal, err.Error())
 	}
@@ -1689,7 +1940,7 @@ func (core *coreService) EstimateExecutionGasConsumption(ctx context.Context, el
 		for low <= high {
 			mid := (low + high) / 2
 			elp.SetGas(mid)
-			enough, _, _, err = core.isGasLimitEnough(ctx, callerAddr, elp, opts...)
+			enough, _, _, err = core.isGasLimitEnough(ctx, height, archive, callerAddr, elp, opts...)
 			if err != nil && err != action.ErrInsufficientFunds {
 				return 0, nil, status.Error(codes.Internal, err.Error())
 			}
@@ -1705,104 +1956,506 @@ func (core *coreService) EstimateExecutionGasConsumption(ctx context.Context, el
 	return estimatedGas, nil, nil
 }
 
-func (core *coreService) isGasLimitEnough(
-	ctx context.Context,
-	caller address.Address,
-	elp action.Envelope,
-	opts ...protocol.SimulateOption,
-) (bool, *action.Receipt, []byte, error) {
-	ctx, span := tracer.NewSpan(ctx, "Server.isGasLimitEnough")
-	defer span.End()
-	ret, receipt, err := core.simulateExecution(ctx, core.bc.TipHeight(), false, caller, elp, opts...)
-	if err != nil {
-		return false, nil, nil, err
-	}
-	return receipt.Status == uint64(iotextypes.ReceiptStatus_Success), receipt, ret, nil
+// CreateAccessList creates the access list of an execution, and returns the receipt of the execution with the list
+func (core *coreService) CreateAccessList(ctx context.Context, callerAddr address.Address, elp action.Envelope, opts ...protocol.SimulateOption) (types.AccessList, *action.Receipt, error) {
+	return core.createAccessList(ctx, core.bc.TipHeight(), false, callerAddr, elp, opts...)
 }
 
-func (core *coreService) getProductivityByEpoch(
-	rp *rolldpos.Protocol,
-	epochNum uint64,
-	tipHeight uint64,
-	abps state.CandidateList,
-) (uint64, map[string]uint64, error) {
-	num, produce, err := rp.ProductivityByEpoch(epochNum, tipHeight, func(start uint64, end uint64) (map[string]uint64, error) {
-		return blockchain.Productivity(core.bc, start, end)
-	})
+// createAccessList simulates the execution with the access list tracer repeatedly, with the access list generated
+// by the previous run applied, until the addresses and slots accessed are the same as the list applied
+func (core *coreService) createAccessList(ctx context.Context, height uint64, archive bool, callerAddr address.Address, elp action.Envelope, opts ...protocol.SimulateOption) (types.AccessList, *action.Receipt, error) {
+	exec, ok := elp.Action().(*action.Execution)
+	if !ok {
+		return nil, nil, status.Error(codes.InvalidArgument, "expecting action.Execution")
+	}
+	var (
+		g             = core.bc.Genesis()
+		blockGasLimit = g.BlockGasLimitByHeight(height)
+		gasLimit      = elp.Gas()
+		from          = common.BytesToAddress(callerAddr.Bytes())
+		to            common.Address
+	)
+	if gasLimit == 0 || gasLimit > blockGasLimit {
+		gasLimit = blockGasLimit
+	}
+	// precompiles are always warm, no need to be in the list
+	precompiles, err := core.simulationPrecompiles(ctx, height, archive, opts...)
 	if err != nil {
-		return 0, nil, status.Error(codes.NotFound, err.Error())
+		return nil, nil, status.Error(codes.Internal, err.Error())
 	}
-	// check if there is any active block producer who didn't prodcue any block
-	for _, abp := range abps {
-		if _, ok := produce[abp.Address]; !ok {
-			produce[abp.Address] = 0
+	if exec.Contract() != action.EmptyAddress {
+		contract, err := address.FromString(exec.Contract())
+		if err != nil {
+			return nil, nil, status.Error(codes.InvalidArgument, err.Error())
 		}
+		to = common.BytesToAddress(contract.Bytes())
+	}
+	prevTracer := logger.NewAccessListTracer(elp.AccessList(), from, to, precompiles)
+	for i := 0; ; i++ {
+		if i >= maxAccessListIterations {
+			return nil, nil, status.Errorf(codes.ResourceExhausted, "access list doesn't converge in %d runs", maxAccessListIterations)
+		}
+		acl := prevTracer.AccessList()
+		tracer := logger.NewAccessListTracer(acl, from, to, precompiles)
+		ex := (&action.EnvelopeBuilder{}).SetTxType(action.AccessListTxType).SetAction(exec).
+			SetGasLimit(gasLimit).SetAccessList(acl).Build()
+		_, receipt, err := core.simulateExecution(protocol.WithVMConfigCtx(ctx, vm.Config{
+			Tracer:    tracer,
+			NoBaseFee: true,
+		}), height, archive, callerAddr, ex, opts...)
+		if err != nil {
+			return nil, nil, status.Error(codes.Internal, err.Error())
+		}
+		if to == (common.Address{}) && receipt.ContractAddress != "" {
+			// the contract deployed is warm as the recipient, start over with it excluded from the list
+			contract, err := address.FromString(receipt.ContractAddress)
+			if err != nil {
+				return nil, nil, status.Error(codes.Internal, err.Error())
+			}
+			to = common.BytesToAddress(contract.Bytes())
+			prevTracer = logger.NewAccessListTracer(elp.AccessList(), from, to, precompiles)
+			continue
+		}
+		if tracer.Equal(prevTracer) {
+			return acl, receipt, nil
+		}
+		prevTracer = tracer
 	}
-	return num, produce, nil
 }
 
-func (core *coreService) checkActionIndex() error {
-	if core.indexer == nil {
-		return errors.New("no action index")
-	}
-	return nil
+// SimulateBlocks simulates the blocks of calls upon the tip, each call sees the state changes of the calls before it
+func (core *coreService) SimulateBlocks(ctx context.Context, blocks []*apitypes.SimulateBlock) ([]*apitypes.SimulatedBlock, error) {
+	return core.simulateBlocks(ctx, core.bc.TipHeight(), false, blocks)
 }
 
-func (core *coreService) getProtocolAccount(ctx context.Context, addr string) (*iotextypes.AccountMeta, *iotextypes.BlockIdentifier, error) {
-	span := tracer.SpanFromContext(ctx)
-	defer span.End()
+// simulateBlocks simulates the blocks following the block at height, upon a working set which is never committed.
+// Actions other than executions, like staking actions, are run by their protocols as well.
+func (core *coreService) simulateBlocks(ctx context.Context, height uint64, archive bool, blocks []*apitypes.SimulateBlock) ([]*apitypes.SimulatedBlock, error) {
+	if len(blocks) > maxSimulateBlocks {
+		return nil, status.Errorf(codes.InvalidArgument, "too many blocks to simulate, max %d", maxSimulateBlocks)
+	}
+	limit := core.cfg.SimulateLimit
+	calls := 0
+	for _, blk := range blocks {
+		calls += len(blk.Calls)
+	}
+	if calls > limit.MaxCalls {
+		return nil, status.Errorf(codes.InvalidArgument, "too many calls to simulate, max %d", limit.MaxCalls)
+	}
+	if limit.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, limit.Timeout)
+		defer cancel()
+	}
 	var (
-		balance string
-		out     *iotexapi.ReadStateResponse
 		err     error
+		ws      protocol.StateManager
+		gasUsed uint64
 	)
-	switch addr {
-	case address.RewardingPoolAddr:
-		if out, err = core.ReadState("rewarding", "", []byte("TotalBalance"), nil); err != nil {
-			return nil, nil, err
-		}
-		val, ok := new(big.Int).SetString(string(out.GetData()), 10)
-		if !ok {
-			return nil, nil, errors.New("balance convert error")
-		}
-		balance = val.String()
-	case address.StakingBucketPoolAddr:
-		methodName, err := proto.Marshal(&iotexapi.ReadStakingDataMethod{
-			Method: iotexapi.ReadStakingDataMethod_TOTAL_STAKING_AMOUNT,
-		})
+	if archive {
+		ctx, err = core.bc.ContextAtHeight(ctx, height)
 		if err != nil {
-			return nil, nil, err
+			return nil, status.Error(codes.Internal, err.Error())
 		}
-		arg, err := proto.Marshal(&iotexapi.ReadStakingDataRequest{
-			Request: &iotexapi.ReadStakingDataRequest_TotalStakingAmount_{
-				TotalStakingAmount: &iotexapi.ReadStakingDataRequest_TotalStakingAmount{},
-			},
-		})
+		ws, err = core.sf.WorkingSetAtHeight(ctx, height)
+	} else {
+		ctx, err = core.bc.Context(ctx)
 		if err != nil {
-			return nil, nil, err
-		}
-		if out, err = core.ReadState("staking", "", methodName, [][]byte{arg}); err != nil {
-			return nil, nil, err
-		}
-		acc := iotextypes.AccountMeta{}
-		if err := proto.Unmarshal(out.GetData(), &acc); err != nil {
-			return nil, nil, errors.Wrap(err, "failed to unmarshal account meta")
+			return nil, status.Error(codes.Internal, err.Error())
 		}
-		balance = acc.GetBalance()
-	default:
-		return nil, nil, errors.Errorf("invalid address %s", addr)
+		ws, err = core.sf.WorkingSet(ctx)
 	}
-	return &iotextypes.AccountMeta{
-		Address: addr,
-		Balance: balance,
-	}, out.GetBlockIdentifier(), nil
-}
-
-// ActionsInActPool returns the all Transaction Identifiers in the actpool
-func (core *coreService) ActionsInActPool(actHashes []string) ([]*action.SealedEnvelope, error) {
-	var ret []*action.SealedEnvelope
-	if len(actHashes) == 0 {
-		for _, sealeds := range core.ap.PendingActionMap() {
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	sim, err := factory.NewSimulator(ws)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	zeroAddr, err := address.FromString(address.ZeroAddress)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		g       = core.bc.Genesis()
+		bcCtx   = protocol.MustGetBlockchainCtx(ctx)
+		parent  = bcCtx.Tip
+		results = make([]*apitypes.SimulatedBlock, 0, len(blocks))
+	)
+	ctx = evm.WithHelperCtx(protocol.WithRegistry(ctx, core.registry), evm.HelperContext{
+		GetBlockHash:   core.dao.GetBlockHash,
+		GetBlockTime:   core.getBlockTime,
+		DepositGasFunc: rewarding.DepositGas,
+	})
+	for _, blk := range blocks {
+		cfg := &protocol.SimulateOptionConfig{}
+		for _, opt := range blk.Options {
+			opt(cfg)
+		}
+		blkCtx := protocol.BlockCtx{
+			BlockHeight:    parent.Height + 1,
+			BlockTimeStamp: parent.Timestamp.Add(g.BlockInterval),
+			GasLimit:       g.BlockGasLimitByHeight(parent.Height + 1),
+			Producer:       zeroAddr,
+			BaseFee:        protocol.CalcBaseFee(g.Blockchain, &parent),
+			ExcessBlobGas:  protocol.CalcExcessBlobGas(parent.ExcessBlobGas, parent.BlobGasUsed),
+		}
+		if cfg.BlockOverride != nil {
+			cfg.BlockOverride(&blkCtx)
+		}
+		if blkCtx.BlockHeight <= parent.Height {
+			return nil, status.Errorf(codes.InvalidArgument, "block number %d is not higher than %d", blkCtx.BlockHeight, parent.Height)
+		}
+		if !blkCtx.BlockTimeStamp.After(parent.Timestamp) {
+			return nil, status.Errorf(codes.InvalidArgument, "block timestamp %d is not later than %d", blkCtx.BlockTimeStamp.Unix(), parent.Timestamp.Unix())
+		}
+		bcCtx.Tip = parent
+		bctx := protocol.WithFeatureCtx(protocol.WithBlockCtx(protocol.WithBlockchainCtx(ctx, bcCtx), blkCtx))
+		if err := sim.NextBlock(bctx); err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		if cfg.StateOverride != nil {
+			if err := cfg.StateOverride(protocol.WithActionCtx(bctx, protocol.ActionCtx{
+				Caller:   zeroAddr,
+				ReadOnly: true,
+			}), ws); err != nil {
+				return nil, status.Error(codes.InvalidArgument, err.Error())
+			}
+		}
+		result := &apitypes.SimulatedBlock{
+			ParentHash: parent.Hash,
+			Height:     blkCtx.BlockHeight,
+			Timestamp:  blkCtx.BlockTimeStamp,
+			GasLimit:   blkCtx.GasLimit,
+			BaseFee:    blkCtx.BaseFee,
+			Calls:      make([]*apitypes.SimulatedCall, 0, len(blk.Calls)),
+		}
+		var logIndex uint32
+		for i, call := range blk.Calls {
+			if err := ctx.Err(); err != nil {
+				return nil, status.Errorf(codes.DeadlineExceeded, "simulation is aborted at call %d of block %d: %s", i, blkCtx.BlockHeight, err.Error())
+			}
+			retval, receipt, err := sim.RunAction(bctx, call.Caller, call.Envelope)
+			if err != nil {
+				return nil, status.Errorf(codes.InvalidArgument, "failed to simulate call %d of block %d: %s", i, blkCtx.BlockHeight, err.Error())
+			}
+			if gasUsed += receipt.GasConsumed; limit.GasCap > 0 && gasUsed > limit.GasCap {
+				return nil, status.Errorf(codes.ResourceExhausted, "gas consumed by the calls exceeds the cap %d", limit.GasCap)
+			}
+			receipt.BlockHeight = blkCtx.BlockHeight
+			logIndex = receipt.UpdateIndex(uint32(i), logIndex)
+			for _, l := range receipt.Logs() {
+				l.BlockHeight = blkCtx.BlockHeight
+			}
+			result.GasUsed += receipt.GasConsumed
+			result.Calls = append(result.Calls, &apitypes.SimulatedCall{
+				ActionHash: receipt.ActionHash,
+				ReturnData: retval,
+				Receipt:    receipt,
+			})
+		}
+		// the simulated block is not built, its hash is derived from the parent hash and the height
+		result.Hash = hash.Hash256b(append(parent.Hash[:], byteutil.Uint64ToBytesBigEndian(result.Height)...))
+		parent = protocol.TipInfo{
+			Height:        result.Height,
+			GasUsed:       result.GasUsed,
+			Hash:          result.Hash,
+			Timestamp:     result.Timestamp,
+			BaseFee:       result.BaseFee,
+			ExcessBlobGas: blkCtx.ExcessBlobGas,
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// ProjectDelegateReward projects the rewards of a bucket voting for the candidate in the upcoming epochs. The bucket
+// is created upon the tip in a simulated block, then the rewarding rules are applied to the candidates of each
+// epoch, assuming the productivity of the delegates in current epoch lasts. The delegate distributes the percentage
+// distributionRatio of its rewards to the voters by votes, which is declared off chain and not enforced by the protocol.
+func (core *coreService) ProjectDelegateReward(
+	ctx context.Context,
+	candidate string,
+	amount *big.Int,
+	duration uint32,
+	autoStake bool,
+	epochs uint64,
+	distributionRatio uint32,
+) (*apitypes.DelegateRewardProjection, error) {
+	if epochs == 0 || epochs > maxProjectEpochs {
+		return nil, status.Errorf(codes.InvalidArgument, "number of epochs should be in [1, %d]", maxProjectEpochs)
+	}
+	if distributionRatio > 100 {
+		return nil, status.Error(codes.InvalidArgument, "distribution ratio should be in [0, 100]")
+	}
+	if amount == nil || amount.Sign() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "amount should be positive")
+	}
+	var (
+		rp  = rolldpos.FindProtocol(core.registry)
+		pp  = poll.FindProtocol(core.registry)
+		rwd = rewarding.FindProtocol(core.registry)
+		stk = staking.FindProtocol(core.registry)
+	)
+	if rp == nil || pp == nil || rwd == nil || stk == nil {
+		return nil, status.Error(codes.Unavailable, "delegate reward projection is not supported")
+	}
+	ctx, err := core.bc.Context(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	ws, err := core.sf.WorkingSet(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	sim, err := factory.NewSimulator(ws)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	zeroAddr, err := address.FromString(address.ZeroAddress)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		g      = core.bc.Genesis()
+		parent = protocol.MustGetBlockchainCtx(ctx).Tip
+		blkCtx = protocol.BlockCtx{
+			BlockHeight:    parent.Height + 1,
+			BlockTimeStamp: parent.Timestamp.Add(g.BlockInterval),
+			GasLimit:       g.BlockGasLimitByHeight(parent.Height + 1),
+			Producer:       zeroAddr,
+			BaseFee:        protocol.CalcBaseFee(g.Blockchain, &parent),
+			ExcessBlobGas:  protocol.CalcExcessBlobGas(parent.ExcessBlobGas, parent.BlobGasUsed),
+		}
+	)
+	ctx = protocol.WithFeatureCtx(protocol.WithBlockCtx(protocol.WithRegistry(ctx, core.registry), blkCtx))
+	if err := sim.NextBlock(ctx); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	// the bucket is created by a virtual voter, who is given the amount and the gas fee in the simulated block
+	cs, err := action.NewCreateStake(candidate, amount.String(), duration, autoStake, nil)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	gasPrice := big.NewInt(0)
+	if blkCtx.BaseFee != nil {
+		gasPrice.Set(blkCtx.BaseFee)
+	}
+	elp := (&action.EnvelopeBuilder{}).SetGasPrice(gasPrice).SetAction(cs).Build()
+	gas, err := elp.IntrinsicGas()
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	elp.SetGas(gas)
+	voterHash := hash.Hash160b([]byte("delegate reward projection"))
+	voter, err := address.FromBytes(voterHash[:])
+	if err != nil {
+		return nil, err
+	}
+	acct, err := accountutil.LoadAccount(ws, voter)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if err := acct.AddBalance(new(big.Int).Add(amount, new(big.Int).Mul(gasPrice, new(big.Int).SetUint64(gas)))); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if err := accountutil.StoreAccount(ws, voter, acct); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	_, receipt, err := sim.RunAction(ctx, voter, elp)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if receipt.Status != uint64(iotextypes.ReceiptStatus_Success) {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to create the bucket, receipt status %d", receipt.Status)
+	}
+	// the changes of the staking view are kept in the working set until being committed, which can't be done on the
+	// simulated working set as the base view is shared with the factory, so read the candidates from the dirty view
+	csm, err := staking.NewCandidateStateManager(ws, protocol.MustGetFeatureWithHeightCtx(ctx).ReadStateFromDB(blkCtx.BlockHeight))
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	sr := &stakingDirtyViewReader{StateReader: ws, name: stk.Name(), view: csm.DirtyView()}
+	var (
+		bucket     = staking.NewVoteBucket(nil, voter, amount, duration, blkCtx.BlockTimeStamp, autoStake)
+		startEpoch = rp.GetEpochNum(blkCtx.BlockHeight) + 1
+		projection = &apitypes.DelegateRewardProjection{
+			BucketVotes: staking.CalculateVoteWeight(g.Staking.VoteWeightCalConsts, bucket, false),
+			Epochs:      make([]*apitypes.EpochRewardProjection, 0, epochs),
+		}
+	)
+	// the bucket starts to count from the next epoch, of which the candidates are calculated at the start
+	for epochNum := startEpoch; epochNum < startEpoch+epochs; epochNum++ {
+		epochStartHeight := rp.GetEpochHeight(epochNum)
+		candidates, err := pp.CalculateCandidatesByHeight(ctx, sr, epochStartHeight)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		result := &apitypes.EpochRewardProjection{
+			EpochNumber:     epochNum,
+			CandidateVotes:  big.NewInt(0),
+			EpochReward:     big.NewInt(0),
+			FoundationBonus: big.NewInt(0),
+			BucketReward:    big.NewInt(0),
+		}
+		projection.Epochs = append(projection.Epochs, result)
+		var cand *state.Candidate
+		for _, c := range candidates {
+			if string(c.CanName) == candidate {
+				cand = c
+				break
+			}
+		}
+		if cand == nil {
+			// the candidate is not active, or doesn't have enough votes
+			continue
+		}
+		result.CandidateVotes.Set(cand.Votes)
+		if pa, ok := pp.(poll.ProbationApplier); ok {
+			if candidates, err = pa.ApplyProbation(ctx, sr, candidates, epochStartHeight); err != nil {
+				return nil, status.Error(codes.Internal, err.Error())
+			}
+			for _, c := range candidates {
+				if c.Address == cand.Address {
+					result.Probation = c.Votes.Cmp(cand.Votes) < 0
+					break
+				}
+			}
+		}
+		rewards, err := rwd.ProjectEpochReward(ctx, sr, epochNum, candidates)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		r, ok := rewards[cand.Address]
+		if !ok {
+			continue
+		}
+		result.EpochReward.Set(r.EpochReward)
+		result.FoundationBonus.Set(r.FoundationBonus)
+		if cand.Votes.Sign() > 0 {
+			result.BucketReward.Add(r.EpochReward, r.FoundationBonus)
+			result.BucketReward.Mul(result.BucketReward, projection.BucketVotes)
+			result.BucketReward.Mul(result.BucketReward, new(big.Int).SetUint64(uint64(distributionRatio)))
+			result.BucketReward.Div(result.BucketReward, new(big.Int).Mul(cand.Votes, big.NewInt(100)))
+		}
+	}
+	return projection, nil
+}
+
+// stakingDirtyViewReader reads the staking view with the uncommitted changes
+type stakingDirtyViewReader struct {
+	protocol.StateReader
+	name string
+	view *staking.ViewData
+}
+
+func (sr *stakingDirtyViewReader) ReadView(name string) (interface{}, error) {
+	if name == sr.name {
+		return sr.view, nil
+	}
+	return sr.StateReader.ReadView(name)
+}
+
+func (core *coreService) isGasLimitEnough(
+	ctx context.Context,
+	height uint64,
+	archive bool,
+	caller address.Address,
+	elp action.Envelope,
+	opts ...protocol.SimulateOption,
+) (bool, *action.Receipt, []byte, error) {
+	ctx, span := tracer.NewSpan(ctx, "Server.isGasLimitEnough")
+	defer span.End()
+	ret, receipt, err := core.simulateExecution(ctx, height, archive, caller, elp, opts...)
+	if err != nil {
+		return false, nil, nil, err
+	}
+	return receipt.Status == uint64(iotextypes.ReceiptStatus_Success), receipt, ret, nil
+}
+
+func (core *coreService) getProductivityByEpoch(
+	rp *rolldpos.Protocol,
+	epochNum uint64,
+	tipHeight uint64,
+	abps state.CandidateList,
+) (uint64, map[string]uint64, error) {
+	num, produce, err := rp.ProductivityByEpoch(epochNum, tipHeight, func(start uint64, end uint64) (map[string]uint64, error) {
+		return blockchain.Productivity(core.bc, start, end)
+	})
+	if err != nil {
+		return 0, nil, status.Error(codes.NotFound, err.Error())
+	}
+	// check if there is any active block producer who didn't prodcue any block
+	for _, abp := range abps {
+		if _, ok := produce[abp.Address]; !ok {
+			produce[abp.Address] = 0
+		}
+	}
+	return num, produce, nil
+}
+
+func (core *coreService) checkActionIndex() error {
+	if core.indexer == nil {
+		return errors.New("no action index")
+	}
+	return nil
+}
+
+func (core *coreService) getProtocolAccount(ctx context.Context, addr string) (*iotextypes.AccountMeta, *iotextypes.BlockIdentifier, error) {
+	span := tracer.SpanFromContext(ctx)
+	defer span.End()
+	var (
+		balance string
+		out     *iotexapi.ReadStateResponse
+		err     error
+	)
+	switch addr {
+	case address.RewardingPoolAddr:
+		if out, err = core.ReadState("rewarding", "", []byte("TotalBalance"), nil); err != nil {
+			return nil, nil, err
+		}
+		val, ok := new(big.Int).SetString(string(out.GetData()), 10)
+		if !ok {
+			return nil, nil, errors.New("balance convert error")
+		}
+		balance = val.String()
+	case address.StakingBucketPoolAddr:
+		methodName, err := proto.Marshal(&iotexapi.ReadStakingDataMethod{
+			Method: iotexapi.ReadStakingDataMethod_TOTAL_STAKING_AMOUNT,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		arg, err := proto.Marshal(&iotexapi.ReadStakingDataRequest{
+			Request: &iotexapi.ReadStakingDataRequest_TotalStakingAmount_{
+				TotalStakingAmount: &iotexapi.ReadStakingDataRequest_TotalStakingAmount{},
+			},
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		if out, err = core.ReadState("staking", "", methodName, [][]byte{arg}); err != nil {
+			return nil, nil, err
+		}
+		acc := iotextypes.AccountMeta{}
+		if err := proto.Unmarshal(out.GetData(), &acc); err != nil {
+			return nil, nil, errors.Wrap(err, "failed to unmarshal account meta")
+		}
+		balance = acc.GetBalance()
+	default:
+		return nil, nil, errors.Errorf("invalid address %s", addr)
+	}
+	return &iotextypes.AccountMeta{
+		Address: addr,
+		Balance: balance,
+	}, out.GetBlockIdentifier(), nil
+}
+
+// ActionsInActPool returns the all Transaction Identifiers in the actpool
+func (core *coreService) ActionsInActPool(actHashes []string) ([]*action.SealedEnvelope, error) {
+	var ret []*action.SealedEnvelope
+	if len(actHashes) == 0 {
+		for _, sealeds := range core.ap.PendingActionMap() {
 			ret = append(ret, sealeds...)
 		}
 		return ret, nil
@@ -1822,6 +2475,19 @@ func (core *coreService) ActionsInActPool(actHashes []string) ([]*action.SealedE
 	return ret, nil
 }
 
+// ActPoolContent returns the pending and queued actions in the actpool, grouped by sender.
+// Pending actions are ready to be included in the next block, while queued actions are not
+// executable yet due to a nonce gap or insufficient balance. Actions of a sender are sorted by nonce.
+// Both maps are taken from the same snapshot of the actpool.
+func (core *coreService) ActPoolContent() (map[string][]*action.SealedEnvelope, map[string][]*action.SealedEnvelope) {
+	return core.ap.PendingAndQueuedActionMap()
+}
+
+// ActPoolStatus returns the numbers of the pending and queued actions in the actpool
+func (core *coreService) ActPoolStatus() (uint64, uint64) {
+	return core.ap.PendingAndQueuedSize()
+}
+
 // Genesis returns the genesis of the chain
 func (core *coreService) Genesis() genesis.Genesis {
 	return core.bc.Genesis()
@@ -1851,18 +2517,18 @@ func (core *coreService) ReadContractStorage(ctx context.Context, addr address.A
 }
 
 func (core *coreService) ReceiveBlock(blk *block.Block) error {
-	core.readCache.Clear()
+	core.respCache.ReceiveTip(blk.Height())
 	return core.chainListener.ReceiveBlock(blk)
 }
 
-func (core *coreService) SimulateExecution(ctx context.Context, addr address.Address, elp action.Envelope) ([]byte, *action.Receipt, error) {
+func (core *coreService) SimulateExecution(ctx context.Context, addr address.Address, elp action.Envelope, opts ...protocol.SimulateOption) ([]byte, *action.Receipt, error) {
 	var (
 		g             = core.bc.Genesis()
 		tipHeight     = core.bc.TipHeight()
 		blockGasLimit = g.BlockGasLimitByHeight(tipHeight)
 	)
 	elp.SetGas(blockGasLimit)
-	return core.simulateExecution(ctx, tipHeight, false, addr, elp)
+	return core.simulateExecution(ctx, tipHeight, false, addr, elp, opts...)
 }
 
 // SyncingProgress returns the syncing status of node
@@ -1873,20 +2539,45 @@ func (core *coreService) SyncingProgress() (uint64, uint64, uint64) {
 
 // TraceTransaction returns the trace result of transaction
 func (core *coreService) TraceTransaction(ctx context.Context, actHash string, config *tracers.TraceConfig) ([]byte, *action.Receipt, any, error) {
-	actInfo, err := core.Action(util.Remove0xPrefix(actHash), false)
+	if !core.archiveSupported {
+		return nil, nil, nil, ErrArchiveNotSupported
+	}
+	h, err := hash.HexStringToHash256(util.Remove0xPrefix(actHash))
 	if err != nil {
-		return nil, nil, nil, err
+		return nil, nil, nil, status.Error(codes.InvalidArgument, err.Error())
 	}
-	act, err := (&action.Deserializer{}).SetEvmNetworkID(core.EVMNetworkID()).ActionToSealedEnvelope(actInfo.Action)
+	selp, blk, actIndex, err := core.ActionByActionHash(h)
 	if err != nil {
 		return nil, nil, nil, err
 	}
-	if _, ok := act.Action().(*action.Execution); !ok {
+	if _, ok := selp.Action().(*action.Execution); !ok {
 		return nil, nil, nil, errors.New("the type of action is not supported")
 	}
-	addr, _ := address.FromString(address.ZeroAddress)
-	return core.traceTx(ctx, new(tracers.Context), config, func(ctx context.Context) ([]byte, *action.Receipt, error) {
-		return core.simulateExecution(ctx, core.bc.TipHeight(), false, addr, act.Envelope)
+	ctx, ws, err := core.workingSetBeforeAction(ctx, blk, actIndex)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	intrinsicGas, err := selp.IntrinsicGas()
+	if err != nil {
+		return nil, nil, nil, status.Error(codes.Internal, err.Error())
+	}
+	// replay the action in the context of the block, as it was executed in the block
+	ctx = protocol.WithActionCtx(ctx, protocol.ActionCtx{
+		Caller:       selp.SenderAddress(),
+		ActionHash:   h,
+		GasPrice:     selp.GasPrice(),
+		IntrinsicGas: intrinsicGas,
+		Nonce:        selp.Nonce(),
+	})
+	blkHash := blk.HashBlock()
+	txctx := &tracers.Context{
+		BlockHash:   common.BytesToHash(blkHash[:]),
+		BlockNumber: new(big.Int).SetUint64(blk.Height()),
+		TxIndex:     int(actIndex),
+		TxHash:      common.BytesToHash(h[:]),
+	}
+	return core.traceTx(ctx, txctx, config, func(ctx context.Context) ([]byte, *action.Receipt, error) {
+		return evm.ExecuteContract(ctx, ws, selp.Envelope)
 	})
 }
 
@@ -1900,24 +2591,462 @@ func (core *coreService) TraceCall(ctx context.Context,
 	gasLimit uint64,
 	data []byte,
 	config *tracers.TraceConfig) ([]byte, *action.Receipt, any, error) {
+	height, archive, err := core.traceCallHeight(blkNumOrHash)
+	if err != nil {
+		return nil, nil, nil, err
+	}
 	var (
 		g             = core.bc.Genesis()
-		blockGasLimit = g.BlockGasLimitByHeight(core.bc.TipHeight())
+		blockGasLimit = g.BlockGasLimitByHeight(height)
 	)
-	if gasLimit == 0 {
+	if gasLimit == 0 || gasLimit > blockGasLimit {
 		gasLimit = blockGasLimit
 	}
-	ctx, err := core.bc.Context(ctx)
-	if err != nil {
-		return nil, nil, nil, err
-	}
 	elp := (&action.EnvelopeBuilder{}).SetAction(action.NewExecution(contractAddress, amount, data)).
 		SetGasLimit(gasLimit).Build()
 	return core.traceTx(ctx, new(tracers.Context), config, func(ctx context.Context) ([]byte, *action.Receipt, error) {
-		return core.simulateExecution(ctx, core.bc.TipHeight(), false, callerAddr, elp)
+		return core.simulateExecution(ctx, height, archive, callerAddr, elp)
 	})
 }
 
+// traceCallHeight resolves the block number or hash of a trace call, the call
+// runs against the archive state if the block is not the tip
+func (core *coreService) traceCallHeight(blkNumOrHash any) (uint64, bool, error) {
+	var (
+		tipHeight = core.bc.TipHeight()
+		height    uint64
+	)
+	switch v := blkNumOrHash.(type) {
+	case nil:
+		return tipHeight, false, nil
+	case uint64:
+		height = v
+	case string:
+		if v == "" {
+			return tipHeight, false, nil
+		}
+		h, err := hash.HexStringToHash256(util.Remove0xPrefix(v))
+		if err != nil {
+			return 0, false, status.Error(codes.InvalidArgument, err.Error())
+		}
+		if height, err = core.dao.GetBlockHeight(h); err != nil {
+			return 0, false, errors.Wrap(ErrNotFound, err.Error())
+		}
+	default:
+		return 0, false, status.Errorf(codes.InvalidArgument, "invalid block number or hash %v", blkNumOrHash)
+	}
+	if height > tipHeight {
+		return 0, false, status.Errorf(codes.InvalidArgument, "height %d is higher than tip height %d", height, tipHeight)
+	}
+	if height == tipHeight {
+		return tipHeight, false, nil
+	}
+	if !core.archiveSupported {
+		return 0, false, ErrArchiveNotSupported
+	}
+	return height, true, nil
+}
+
+// workingSetBeforeAction returns the working set right before the idx-th action
+// of the block is executed, or after all actions are executed if idx equals to
+// the number of actions, it requires the archive-mode state
+func (core *coreService) workingSetBeforeAction(ctx context.Context, blk *block.Block, idx uint32) (context.Context, protocol.StateManager, error) {
+	var (
+		g      = core.bc.Genesis()
+		height = blk.Height()
+	)
+	if height == 0 || int(idx) > len(blk.Actions) {
+		return nil, nil, status.Errorf(codes.InvalidArgument, "invalid action index %d in block %d", idx, height)
+	}
+	ctx, err := core.bc.ContextAtHeight(ctx, height-1)
+	if err != nil {
+		return nil, nil, status.Error(codes.Internal, err.Error())
+	}
+	ctx = protocol.WithFeatureCtx(protocol.WithBlockCtx(protocol.WithRegistry(ctx, core.registry), protocol.BlockCtx{
+		BlockHeight:    height,
+		BlockTimeStamp: blk.Timestamp(),
+		GasLimit:       g.BlockGasLimitByHeight(height),
+		Producer:       blk.PublicKey().Address(),
+		BaseFee:        blk.BaseFee(),
+		ExcessBlobGas:  blk.ExcessBlobGas(),
+	}))
+	ctx = evm.WithHelperCtx(ctx, evm.HelperContext{
+		GetBlockHash:   core.dao.GetBlockHash,
+		GetBlockTime:   core.getBlockTime,
+		DepositGasFunc: rewarding.DepositGas,
+	})
+	ws, err := core.sf.WorkingSetAtHeight(ctx, height-1, blk.Actions[:idx]...)
+	if err != nil {
+		return nil, nil, status.Error(codes.Internal, err.Error())
+	}
+	return ctx, ws, nil
+}
+
+// TraceBlockCalls returns the call traces of the actions in the block at height
+func (core *coreService) TraceBlockCalls(ctx context.Context, height uint64) ([]*apitypes.ActionCallTraces, error) {
+	if height == 0 || height > core.bc.TipHeight() {
+		return nil, errors.Wrapf(ErrNotFound, "block %d", height)
+	}
+	blk, err := core.dao.GetBlockByHeight(height)
+	if err != nil {
+		return nil, errors.Wrap(ErrNotFound, err.Error())
+	}
+	return core.blockCallTraces(ctx, blk, uint32(len(blk.Actions)))
+}
+
+// TraceActionCalls returns the call traces of an action
+func (core *coreService) TraceActionCalls(ctx context.Context, actHash string) (*apitypes.ActionCallTraces, error) {
+	h, err := hash.HexStringToHash256(util.Remove0xPrefix(actHash))
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	_, blk, actIndex, err := core.ActionByActionHash(h)
+	if err != nil {
+		return nil, err
+	}
+	traces, err := core.blockCallTraces(ctx, blk, actIndex+1)
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range traces {
+		if t.Index == actIndex {
+			return t, nil
+		}
+	}
+	return &apitypes.ActionCallTraces{
+		BlockHeight: blk.Height(),
+		BlockHash:   blk.HashBlock(),
+		ActionHash:  h,
+		Index:       actIndex,
+	}, nil
+}
+
+// FilterCallTraces returns the call traces matching the filter, in the order of being executed
+func (core *coreService) FilterCallTraces(ctx context.Context, filter *apitypes.CallTraceFilter) ([]*apitypes.ActionCallTraces, error) {
+	start, end := filter.FromBlock, filter.ToBlock
+	if start == 0 {
+		start = 1
+	}
+	if tip := core.bc.TipHeight(); end > tip {
+		end = tip
+	}
+	if start > end {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid block range [%d, %d]", filter.FromBlock, filter.ToBlock)
+	}
+	var (
+		heights []uint64
+		err     error
+		indexed = core.callTracesIndexed(start) && core.callTracesIndexed(end)
+	)
+	switch {
+	case !indexed && end-start+1 > maxTraceReplayBlocks:
+		return nil, status.Errorf(codes.InvalidArgument, "block range exceeds the limit %d of the blocks not indexed", maxTraceReplayBlocks)
+	case indexed && end-start+1 > core.cfg.RangeQueryLimit:
+		return nil, status.Error(codes.InvalidArgument, "range exceeds the limit")
+	case indexed && len(filter.FromAddresses)+len(filter.ToAddresses) > 0:
+		// the blocks having call traces from or to the addresses, and the gaps whose call traces are unknown
+		gaps, err := core.traceGaps(start, end)
+		if err != nil {
+			return nil, err
+		}
+		if heights, err = core.traceIndexer.HeightsByAddress(append(append([]address.Address{}, filter.FromAddresses...), filter.ToAddresses...), start, end); err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		heights = mergeHeights(heights, gaps)
+	case indexed:
+		if _, err = core.traceGaps(start, end); err != nil {
+			return nil, err
+		}
+		fallthrough
+	default:
+		for h := start; h <= end; h++ {
+			heights = append(heights, h)
+		}
+	}
+	var (
+		fromAddrs = addressSet(filter.FromAddresses)
+		toAddrs   = addressSet(filter.ToAddresses)
+		skipped   uint64
+		count     uint64
+		ret       []*apitypes.ActionCallTraces
+	)
+	for _, h := range heights {
+		blk, err := core.dao.GetBlockByHeight(h)
+		if err != nil {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		traces, err := core.blockCallTraces(ctx, blk, uint32(len(blk.Actions)))
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range traces {
+			var matched []*action.CallTrace
+			for _, trace := range t.Traces {
+				if !addressMatched(fromAddrs, trace.From) || !addressMatched(toAddrs, trace.To) {
+					continue
+				}
+				if skipped < filter.After {
+					skipped++
+					continue
+				}
+				matched = append(matched, trace)
+				count++
+				if filter.Count > 0 && count == filter.Count {
+					break
+				}
+			}
+			if len(matched) > 0 {
+				ret = append(ret, &apitypes.ActionCallTraces{
+					BlockHeight: t.BlockHeight,
+					BlockHash:   t.BlockHash,
+					ActionHash:  t.ActionHash,
+					Index:       t.Index,
+					Traces:      matched,
+				})
+			}
+			if filter.Count > 0 && count == filter.Count {
+				return ret, nil
+			}
+		}
+	}
+	return ret, nil
+}
+
+// traceGaps returns the heights of the blocks in range [start, end] indexed without call traces, whose call traces
+// are replayed, so the number of them is bounded like the range of the blocks not indexed
+func (core *coreService) traceGaps(start, end uint64) ([]uint64, error) {
+	gaps, err := core.traceIndexer.GapHeights(start, end)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if len(gaps) > maxTraceReplayBlocks {
+		return nil, status.Errorf(codes.InvalidArgument, "block range has %d blocks not indexed with call traces, exceeding the limit %d", len(gaps), maxTraceReplayBlocks)
+	}
+	return gaps, nil
+}
+
+// mergeHeights merges two ascending lists of heights into one without duplicates
+func mergeHeights(a, b []uint64) []uint64 {
+	ret := make([]uint64, 0, len(a)+len(b))
+	for len(a) > 0 || len(b) > 0 {
+		var h uint64
+		switch {
+		case len(b) == 0 || (len(a) > 0 && a[0] < b[0]):
+			h, a = a[0], a[1:]
+		case len(a) == 0 || b[0] < a[0]:
+			h, b = b[0], b[1:]
+		default:
+			h, a, b = a[0], a[1:], b[1:]
+		}
+		ret = append(ret, h)
+	}
+	return ret
+}
+
+func addressSet(addrs []address.Address) map[string]struct{} {
+	if len(addrs) == 0 {
+		return nil
+	}
+	set := make(map[string]struct{}, len(addrs))
+	for _, addr := range addrs {
+		set[addr.String()] = struct{}{}
+	}
+	return set
+}
+
+func addressMatched(set map[string]struct{}, addr string) bool {
+	if set == nil {
+		return true
+	}
+	_, ok := set[addr]
+	return ok
+}
+
+// TokenTransfers returns the token transfers [start, start+count) from or to the address, of the token if not nil,
+// and the total number of them
+func (core *coreService) TokenTransfers(addr, token address.Address, start, count uint64) ([]*blockindex.TokenTransfer, uint64, error) {
+	if core.ttIndexer == nil {
+		return nil, 0, status.Error(codes.Unavailable, blockindex.ErrTokenTransferIndexNA.Error())
+	}
+	if count == 0 {
+		return nil, 0, status.Error(codes.InvalidArgument, "count must be greater than zero")
+	}
+	if count > core.cfg.RangeQueryLimit {
+		return nil, 0, status.Error(codes.InvalidArgument, "range exceeds the limit")
+	}
+	total, err := core.ttIndexer.TokenTransferCount(addr, token)
+	if err != nil {
+		return nil, 0, status.Error(codes.Internal, err.Error())
+	}
+	transfers, err := core.ttIndexer.TokenTransfers(addr, token, start, count)
+	if err != nil {
+		return nil, 0, status.Error(codes.Internal, err.Error())
+	}
+	return transfers, total, nil
+}
+
+// readStakingHistory reads the events of a bucket or a candidate from the staking history indexer, the block
+// identifier of the response is the height of the indexer after reading
+func (core *coreService) readStakingHistory(arguments [][]byte) (*iotexapi.ReadStateResponse, error) {
+	if core.shIndexer == nil {
+		return nil, status.Error(codes.Unavailable, blockindex.ErrStakingHistoryIndexNA.Error())
+	}
+	if len(arguments) != 1 {
+		return nil, status.Error(codes.InvalidArgument, "staking history requires exactly one argument")
+	}
+	req := &extensionpb.ReadStakingHistoryRequest{}
+	if err := proto.Unmarshal(arguments[0], req); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if req.Count == 0 {
+		return nil, status.Error(codes.InvalidArgument, "count must be greater than zero")
+	}
+	if req.Count > core.cfg.RangeQueryLimit {
+		return nil, status.Error(codes.InvalidArgument, "range exceeds the limit")
+	}
+	var (
+		events []*blockindex.StakingEvent
+		total  uint64
+		err    error
+	)
+	if req.Candidate != "" {
+		cand, addrErr := address.FromString(req.Candidate)
+		if addrErr != nil {
+			return nil, status.Error(codes.InvalidArgument, addrErr.Error())
+		}
+		events, total, err = core.shIndexer.CandidateHistory(cand, req.Start, req.Count)
+	} else {
+		events, total, err = core.shIndexer.BucketHistory(req.BucketIndex, req.Start, req.Count)
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	height, err := core.shIndexer.Height()
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	list := &extensionpb.StakingEventList{
+		Total:       total,
+		Events:      make([]*extensionpb.StakingEvent, 0, len(events)),
+		StartHeight: core.shIndexer.StartHeight(),
+	}
+	for _, se := range events {
+		list.Events = append(list.Events, stakingEventToPb(se))
+	}
+	data, err := proto.Marshal(list)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	blkHash, err := core.dao.GetBlockHash(height)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &iotexapi.ReadStateResponse{
+		Data: data,
+		BlockIdentifier: &iotextypes.BlockIdentifier{
+			Height: height,
+			Hash:   hex.EncodeToString(blkHash[:]),
+		},
+	}, nil
+}
+
+// callTracesIndexed returns whether the call traces of the block at height are indexed
+func (core *coreService) callTracesIndexed(height uint64) bool {
+	if core.traceIndexer == nil || height < core.traceIndexer.StartHeight() {
+		return false
+	}
+	tip, err := core.traceIndexer.Height()
+	return err == nil && height <= tip
+}
+
+// blockCallTraces returns the call traces of the first n actions in the block, they are read from the trace
+// indexer if indexed, otherwise the actions are replayed upon the archive-mode state
+func (core *coreService) blockCallTraces(ctx context.Context, blk *block.Block, n uint32) ([]*apitypes.ActionCallTraces, error) {
+	var (
+		height  = blk.Height()
+		blkHash = blk.HashBlock()
+		ret     []*apitypes.ActionCallTraces
+	)
+	if core.callTracesIndexed(height) {
+		traces, err := core.traceIndexer.BlockTraces(height)
+		switch errors.Cause(err) {
+		case nil:
+			return blockIndexedCallTraces(blk, traces, n)
+		case blockindex.ErrCallTraceNotRecorded:
+			// the block is indexed without call traces, replay it
+		default:
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+	}
+	if n == 0 {
+		return nil, nil
+	}
+	if !core.archiveSupported {
+		return nil, ErrArchiveNotSupported
+	}
+	_, ws, err := core.workingSetBeforeAction(protocol.WithCallTraceCtx(ctx), blk, n)
+	if err != nil {
+		return nil, err
+	}
+	rs, ok := ws.(interface {
+		Receipts() ([]*action.Receipt, error)
+	})
+	if !ok {
+		return nil, status.Errorf(codes.Internal, "cannot get receipts from state manager of type %T", ws)
+	}
+	receipts, err := rs.Receipts()
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	for i, receipt := range receipts {
+		if len(receipt.CallTraces()) == 0 {
+			continue
+		}
+		ret = append(ret, &apitypes.ActionCallTraces{
+			BlockHeight: height,
+			BlockHash:   blkHash,
+			ActionHash:  receipt.ActionHash,
+			Index:       uint32(i),
+			Traces:      receipt.CallTraces(),
+		})
+	}
+	return ret, nil
+}
+
+// blockIndexedCallTraces returns the call traces of the first n actions in the block from the indexed ones
+func blockIndexedCallTraces(blk *block.Block, traces []*blockindex.ActionTraces, n uint32) ([]*apitypes.ActionCallTraces, error) {
+	var (
+		height  = blk.Height()
+		blkHash = blk.HashBlock()
+		ret     []*apitypes.ActionCallTraces
+	)
+	indices := make(map[hash.Hash256]uint32, len(blk.Actions))
+	for i, selp := range blk.Actions {
+		h, err := selp.Hash()
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		indices[h] = uint32(i)
+	}
+	for _, t := range traces {
+		idx, ok := indices[t.ActionHash]
+		if !ok {
+			return nil, status.Errorf(codes.Internal, "action %x is not in block %d", t.ActionHash, height)
+		}
+		if idx >= n {
+			break
+		}
+		ret = append(ret, &apitypes.ActionCallTraces{
+			BlockHeight: height,
+			BlockHash:   blkHash,
+			ActionHash:  t.ActionHash,
+			Index:       idx,
+			Traces:      t.Traces,
+		})
+	}
+	return ret, nil
+}
+
 // Track tracks the api call
 func (core *coreService) Track(ctx context.Context, start time.Time, method string, size int64, success bool) {
 	if core.apiStats == nil {
@@ -1980,6 +3109,19 @@ func (core *coreService) simulateExecution(
 	addr address.Address,
 	elp action.Envelope,
 	opts ...protocol.SimulateOption) ([]byte, *action.Receipt, error) {
+	retval, receipt, _, err := core.simulateExecutionWithHeight(ctx, height, archive, addr, elp, opts...)
+	return retval, receipt, err
+}
+
+// simulateExecutionWithHeight simulates the execution and returns the height of the state it is simulated upon,
+// which is the tip height when the working set is created if not archive
+func (core *coreService) simulateExecutionWithHeight(
+	ctx context.Context,
+	height uint64,
+	archive bool,
+	addr address.Address,
+	elp action.Envelope,
+	opts ...protocol.SimulateOption) ([]byte, *action.Receipt, uint64, error) {
 	var (
 		err error
 		ws  protocol.StateManager
@@ -1987,22 +3129,29 @@ func (core *coreService) simulateExecution(
 	if archive {
 		ctx, err = core.bc.ContextAtHeight(ctx, height)
 		if err != nil {
-			return nil, nil, status.Error(codes.Internal, err.Error())
+			return nil, nil, 0, status.Error(codes.Internal, err.Error())
 		}
 		ws, err = core.sf.WorkingSetAtHeight(ctx, height)
 	} else {
 		ctx, err = core.bc.Context(ctx)
 		if err != nil {
-			return nil, nil, status.Error(codes.Internal, err.Error())
+			return nil, nil, 0, status.Error(codes.Internal, err.Error())
 		}
 		ws, err = core.sf.WorkingSet(ctx)
+		if err == nil {
+			// the working set is upon the tip, which may be higher than the height read before
+			var wsHeight uint64
+			if wsHeight, err = ws.Height(); err == nil {
+				height = wsHeight - 1
+			}
+		}
 	}
 	if err != nil {
-		return nil, nil, status.Error(codes.Internal, err.Error())
+		return nil, nil, 0, status.Error(codes.Internal, err.Error())
 	}
 	state, err := accountutil.AccountState(ctx, ws, addr)
 	if err != nil {
-		return nil, nil, status.Error(codes.InvalidArgument, err.Error())
+		return nil, nil, 0, status.Error(codes.InvalidArgument, err.Error())
 	}
 	var pendingNonce uint64
 	ctx = protocol.WithFeatureCtx(protocol.WithBlockCtx(ctx, protocol.BlockCtx{
@@ -2019,7 +3168,26 @@ func (core *coreService) simulateExecution(
 		GetBlockTime:   core.getBlockTime,
 		DepositGasFunc: rewarding.DepositGas,
 	})
-	return evm.SimulateExecution(ctx, ws, addr, elp, opts...)
+	retval, receipt, err := evm.SimulateExecution(ctx, ws, addr, elp, opts...)
+	return retval, receipt, height, err
+}
+
+// simulationPrecompiles returns the precompiles active in the block which the execution upon height is simulated in
+func (core *coreService) simulationPrecompiles(ctx context.Context, height uint64, archive bool, opts ...protocol.SimulateOption) ([]common.Address, error) {
+	var err error
+	if archive {
+		ctx, err = core.bc.ContextAtHeight(ctx, height)
+	} else {
+		ctx, err = core.bc.Context(ctx)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return evm.SimulationPrecompiles(evm.WithHelperCtx(ctx, evm.HelperContext{
+		GetBlockHash:   core.dao.GetBlockHash,
+		GetBlockTime:   core.getBlockTime,
+		DepositGasFunc: rewarding.DepositGas,
+	}), opts...)
 }
 
 func filterReceipts(receipts []*action.Receipt, actHash hash.Hash256) *action.Receipt {
@@ -2030,3 +3198,34 @@ func filterReceipts(receipts []*action.Receipt, actHash hash.Hash256) *action.Re
 	}
 	return nil
 }
+
+// blockReceipts returns copies of the receipts in the order of the actions in the block, with the tx and log indexes
+// in the block, and the effective gas price filled for the receipts stored before it was recorded
+func blockReceipts(blk *block.Block, receipts []*action.Receipt) []*action.Receipt {
+	receiptMap := make(map[hash.Hash256]*action.Receipt, len(receipts))
+	for _, r := range receipts {
+		receiptMap[r.ActionHash] = r
+	}
+	var (
+		ret      = make([]*action.Receipt, 0, len(blk.Actions))
+		logIndex uint32
+	)
+	for i, selp := range blk.Actions {
+		actHash, err := selp.Hash()
+		if err != nil {
+			continue
+		}
+		r, ok := receiptMap[actHash]
+		if !ok {
+			continue
+		}
+		receipt := &action.Receipt{}
+		receipt.ConvertFromReceiptPb(r.ConvertToReceiptPb())
+		logIndex = receipt.UpdateIndex(uint32(i), logIndex)
+		if receipt.EffectiveGasPrice == nil {
+			receipt.EffectiveGasPrice = selp.EffectiveGasPrice(blk.BaseFee())
+		}
+		ret = append(ret, receipt)
+	}
+	return ret
+}
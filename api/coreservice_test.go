@@ -14,11 +14,14 @@ import (
 	"time"
 
 	. "github.com/agiledragon/gomonkey/v2"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/eth/tracers"
 	"github.com/ethereum/go-ethereum/eth/tracers/logger"
 	"github.com/golang/mock/gomock"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
@@ -33,8 +36,11 @@ import (
 	"github.com/iotexproject/iotex-core/v2/action/protocol"
 	accountutil "github.com/iotexproject/iotex-core/v2/action/protocol/account/util"
 	"github.com/iotexproject/iotex-core/v2/action/protocol/execution/evm"
+	"github.com/iotexproject/iotex-core/v2/action/protocol/staking"
 	"github.com/iotexproject/iotex-core/v2/actpool"
+	"github.com/iotexproject/iotex-core/v2/api/extensionpb"
 	"github.com/iotexproject/iotex-core/v2/api/logfilter"
+	apitypes "github.com/iotexproject/iotex-core/v2/api/types"
 	"github.com/iotexproject/iotex-core/v2/blockchain"
 	"github.com/iotexproject/iotex-core/v2/blockchain/block"
 	"github.com/iotexproject/iotex-core/v2/blockchain/blockdao"
@@ -42,14 +48,17 @@ import (
 	"github.com/iotexproject/iotex-core/v2/blockchain/genesis"
 	"github.com/iotexproject/iotex-core/v2/blockindex"
 	"github.com/iotexproject/iotex-core/v2/db"
+	"github.com/iotexproject/iotex-core/v2/db/trie"
 	"github.com/iotexproject/iotex-core/v2/server/itx/nodestats"
 	"github.com/iotexproject/iotex-core/v2/state"
+	"github.com/iotexproject/iotex-core/v2/state/factory"
 	"github.com/iotexproject/iotex-core/v2/test/identityset"
 	mock_apitypes "github.com/iotexproject/iotex-core/v2/test/mock/mock_apiresponder"
 	"github.com/iotexproject/iotex-core/v2/test/mock/mock_blockchain"
 	"github.com/iotexproject/iotex-core/v2/test/mock/mock_blockdao"
 	"github.com/iotexproject/iotex-core/v2/test/mock/mock_blockindex"
 	"github.com/iotexproject/iotex-core/v2/test/mock/mock_blocksync"
+	"github.com/iotexproject/iotex-core/v2/test/mock/mock_chainmanager"
 	"github.com/iotexproject/iotex-core/v2/test/mock/mock_envelope"
 	"github.com/iotexproject/iotex-core/v2/test/mock/mock_factory"
 	"github.com/iotexproject/iotex-core/v2/testutil"
@@ -177,7 +186,7 @@ func getTopicsAddress(addr []string, topics [][]string) (*iotexapi.LogsFilter, e
 	return &filter, nil
 }
 
-func setupTestCoreService() (CoreService, blockchain.Blockchain, blockdao.BlockDAO, actpool.ActPool, func()) {
+func setupTestCoreService(opts ...Option) (CoreService, blockchain.Blockchain, blockdao.BlockDAO, actpool.ActPool, func()) {
 	cfg := newConfig()
 
 	// TODO (zhi): revise
@@ -197,9 +206,9 @@ func setupTestCoreService() (CoreService, blockchain.Blockchain, blockdao.BlockD
 		panic(err)
 	}
 
-	opts := []Option{WithBroadcastOutbound(func(ctx context.Context, chainID uint32, msg proto.Message) error {
+	opts = append(opts, WithBroadcastOutbound(func(ctx context.Context, chainID uint32, msg proto.Message) error {
 		return nil
-	})}
+	}))
 	svr, err := newCoreService(cfg.api, bc, nil, sf, dao, indexer, bfIndexer, ap, registry, func(u uint64) (time.Time, error) { return time.Time{}, nil }, opts...)
 	if err != nil {
 		panic(err)
@@ -491,9 +500,11 @@ func TestEstimateExecutionGasConsumption(t *testing.T) {
 		p = p.ApplyFuncReturn(accountutil.AccountStateWithHeight, nil, uint64(0), errors.New(t.Name()))
 
 		bc.EXPECT().Genesis().Return(genesis.Genesis{}).Times(1)
-		bc.EXPECT().TipHeight().Return(uint64(1)).Times(2)
+		bc.EXPECT().TipHeight().Return(uint64(1)).Times(1)
 		bc.EXPECT().Context(gomock.Any()).Return(ctx, nil).Times(1)
-		sf.EXPECT().WorkingSet(gomock.Any()).Return(nil, nil).Times(1)
+		ws := mock_chainmanager.NewMockStateManager(ctrl)
+		ws.EXPECT().Height().Return(uint64(2), nil).Times(1)
+		sf.EXPECT().WorkingSet(gomock.Any()).Return(ws, nil).Times(1)
 		elp := (&action.EnvelopeBuilder{}).SetAction(&action.Execution{}).Build()
 		_, _, err := cs.EstimateExecutionGasConsumption(ctx, elp, &address.AddrV1{})
 		require.ErrorContains(err, t.Name())
@@ -510,6 +521,8 @@ func TestEstimateExecutionGasConsumption(t *testing.T) {
 			"isGasLimitEnough",
 			func(
 				context.Context,
+				uint64,
+				bool,
 				address.Address,
 				*action.Envelope,
 				...protocol.SimulateOption,
@@ -538,6 +551,8 @@ func TestEstimateExecutionGasConsumption(t *testing.T) {
 				"isGasLimitEnough",
 				func(
 					context.Context,
+					uint64,
+					bool,
 					address.Address,
 					*action.Envelope,
 					...protocol.SimulateOption,
@@ -568,6 +583,8 @@ func TestEstimateExecutionGasConsumption(t *testing.T) {
 				"isGasLimitEnough",
 				func(
 					context.Context,
+					uint64,
+					bool,
 					address.Address,
 					*action.Envelope,
 					...protocol.SimulateOption,
@@ -610,7 +627,7 @@ func TestTraceTransaction(t *testing.T) {
 	require := require.New(t)
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
-	svr, bc, _, ap, cleanCallback := setupTestCoreService()
+	svr, bc, _, ap, cleanCallback := setupTestCoreService(WithArchiveSupport())
 	defer cleanCallback()
 	ctx := context.Background()
 	tsf, err := action.SignedExecution(identityset.Address(29).String(),
@@ -618,9 +635,16 @@ func TestTraceTransaction(t *testing.T) {
 		big.NewInt(testutil.TestGasPriceInt64), []byte{})
 	require.NoError(err)
 	tsfhash, err := tsf.Hash()
+	// a contract creation pushing COINBASE, TIMESTAMP, NUMBER and GASPRICE
+	create, err := action.SignedExecution("", identityset.PrivateKey(29), 2, big.NewInt(0), testutil.TestGasLimit,
+		big.NewInt(testutil.TestGasPriceInt64), []byte{0x41, 0x42, 0x43, 0x3a, 0x00})
+	require.NoError(err)
+	createHash, err := create.Hash()
+	require.NoError(err)
 
 	blk1Time := testutil.TimestampNow()
 	require.NoError(ap.Add(ctx, tsf))
+	require.NoError(ap.Add(ctx, create))
 	blk, err := bc.MintNewBlock(blk1Time)
 	require.NoError(err)
 	require.NoError(bc.CommitBlock(blk))
@@ -639,6 +663,109 @@ func TestTraceTransaction(t *testing.T) {
 	require.Equal(uint64(0x2710), receipt.GasConsumed)
 	require.Empty(receipt.ExecutionRevertMsg())
 	require.Equal(0, len(traces.(*logger.StructLogger).StructLogs()))
+
+	t.Run("in the context of the block", func(t *testing.T) {
+		_, receipt, traces, err := svr.TraceTransaction(ctx, hex.EncodeToString(createHash[:]), cfg)
+		require.NoError(err)
+		require.Equal(uint64(1), receipt.Status)
+		require.Equal(blk.Receipts[1].GasConsumed, receipt.GasConsumed)
+		logs := traces.(*logger.StructLogger).StructLogs()
+		require.Len(logs, 5)
+		stack := logs[4].Stack
+		require.Len(stack, 4)
+		require.Equal(blk.PublicKey().Address().Bytes(), stack[0].Bytes())
+		require.EqualValues(blk1Time.Unix(), stack[1].Uint64())
+		require.Equal(blk.Height(), stack[2].Uint64())
+		require.EqualValues(testutil.TestGasPriceInt64, stack[3].Uint64())
+	})
+
+	t.Run("ArchiveNotSupported", func(t *testing.T) {
+		svr, _, _, _, cleanCallback := setupTestCoreService()
+		defer cleanCallback()
+		_, _, _, err := svr.TraceTransaction(ctx, hex.EncodeToString(tsfhash[:]), cfg)
+		require.ErrorIs(err, ErrArchiveNotSupported)
+	})
+}
+
+func TestTraceBlockCalls(t *testing.T) {
+	require := require.New(t)
+	svr, bc, _, ap, cleanCallback := setupTestCoreService(WithArchiveSupport())
+	defer cleanCallback()
+	ctx := context.Background()
+	tsf, err := action.SignedTransfer(identityset.Address(30).String(),
+		identityset.PrivateKey(29), 1, big.NewInt(1), []byte{1, 2}, testutil.TestGasLimit,
+		big.NewInt(testutil.TestGasPriceInt64))
+	require.NoError(err)
+	exec, err := action.SignedExecution(identityset.Address(31).String(),
+		identityset.PrivateKey(29), 2, big.NewInt(0), testutil.TestGasLimit,
+		big.NewInt(testutil.TestGasPriceInt64), []byte{})
+	require.NoError(err)
+	tsfHash, err := tsf.Hash()
+	require.NoError(err)
+	execHash, err := exec.Hash()
+	require.NoError(err)
+	require.NoError(ap.Add(ctx, tsf))
+	require.NoError(ap.Add(ctx, exec))
+	blk, err := bc.MintNewBlock(testutil.TimestampNow())
+	require.NoError(err)
+	require.NoError(bc.CommitBlock(blk))
+
+	traces, err := svr.TraceBlockCalls(ctx, blk.Height())
+	require.NoError(err)
+	require.Len(traces, 2)
+	for i, h := range []hash.Hash256{tsfHash, execHash} {
+		require.Equal(blk.Height(), traces[i].BlockHeight)
+		require.Equal(blk.HashBlock(), traces[i].BlockHash)
+		require.Equal(h, traces[i].ActionHash)
+		require.EqualValues(i, traces[i].Index)
+		require.Len(traces[i].Traces, 1)
+		require.Equal(identityset.Address(29).String(), traces[i].Traces[0].From)
+		require.Empty(traces[i].Traces[0].TraceAddress)
+	}
+	require.Equal(identityset.Address(30).String(), traces[0].Traces[0].To)
+	require.Equal(big.NewInt(1), traces[0].Traces[0].Value)
+	require.Equal([]byte{1, 2}, traces[0].Traces[0].Input)
+	require.Equal(identityset.Address(31).String(), traces[1].Traces[0].To)
+	require.Equal(action.CallTraceTypeCall, traces[1].Traces[0].CallType)
+
+	actTraces, err := svr.TraceActionCalls(ctx, hex.EncodeToString(execHash[:]))
+	require.NoError(err)
+	require.Equal(traces[1], actTraces)
+
+	filtered, err := svr.FilterCallTraces(ctx, &apitypes.CallTraceFilter{
+		FromBlock:   1,
+		ToBlock:     blk.Height(),
+		ToAddresses: []address.Address{identityset.Address(31)},
+	})
+	require.NoError(err)
+	require.Greater(len(filtered), 1)
+	require.Equal(traces[1], filtered[len(filtered)-1])
+	filtered, err = svr.FilterCallTraces(ctx, &apitypes.CallTraceFilter{
+		FromBlock:     blk.Height(),
+		ToBlock:       blk.Height(),
+		FromAddresses: []address.Address{identityset.Address(29)},
+		After:         1,
+		Count:         1,
+	})
+	require.NoError(err)
+	require.Equal([]*apitypes.ActionCallTraces{traces[1]}, filtered)
+	_, err = svr.FilterCallTraces(ctx, &apitypes.CallTraceFilter{
+		FromBlock: blk.Height(),
+		ToBlock:   blk.Height() + maxTraceReplayBlocks,
+	})
+	require.NoError(err)
+	_, err = svr.FilterCallTraces(ctx, &apitypes.CallTraceFilter{
+		FromBlock: blk.Height() + 1,
+		ToBlock:   blk.Height() + 1,
+	})
+	require.ErrorContains(err, "invalid block range")
+
+	t.Run("ArchiveNotSupported", func(t *testing.T) {
+		svr, _, _, _, cleanCallback := setupTestCoreService()
+		defer cleanCallback()
+		_, err := svr.TraceBlockCalls(ctx, 1)
+		require.ErrorIs(err, ErrArchiveNotSupported)
+	})
 }
 
 func TestTraceCall(t *testing.T) {
@@ -677,6 +804,120 @@ func TestTraceCall(t *testing.T) {
 	require.Equal(uint64(0x2710), receipt.GasConsumed)
 	require.Empty(receipt.ExecutionRevertMsg())
 	require.Equal(0, len(traces.(*logger.StructLogger).StructLogs()))
+
+	t.Run("ArchiveNotSupported", func(t *testing.T) {
+		_, _, _, err := svr.TraceCall(ctx,
+			identityset.Address(29), blk.Height()-1,
+			identityset.Address(29).String(),
+			0, big.NewInt(0), testutil.TestGasLimit,
+			[]byte{}, cfg)
+		require.ErrorIs(err, ErrArchiveNotSupported)
+	})
+}
+
+func TestAccountProof(t *testing.T) {
+	require := require.New(t)
+	svr, bc, _, _, cleanCallback := setupTestCoreService(WithArchiveSupport())
+	defer cleanCallback()
+	ctx := context.Background()
+
+	verify := func(proof *apitypes.AccountProof, addr address.Address) {
+		addrHash := hash.BytesToHash160(addr.Bytes())
+		value, err := factory.VerifyStateProof(proof.StateRoot, factory.AccountKVNamespace, addrHash[:], proof.AccountProof)
+		require.NoError(err)
+		account := &state.Account{}
+		require.NoError(account.Deserialize(value))
+		require.Equal(proof.Account.Balance, account.Balance)
+		require.Equal(proof.Account.PendingNonce(), account.PendingNonce())
+		for _, sp := range proof.StorageProof {
+			_, err := evm.VerifyContractStorageProof(addrHash, proof.Account.Root, sp.Key, sp.Proof)
+			require.Equal(trie.ErrNotExist, errors.Cause(err))
+		}
+	}
+	t.Run("Tip", func(t *testing.T) {
+		proof, err := svr.AccountProof(ctx, identityset.Address(27), []hash.Hash256{hash.ZeroHash256})
+		require.NoError(err)
+		require.Equal(bc.TipHeight(), proof.Height)
+		require.Len(proof.StorageProof, 1)
+		verify(proof, identityset.Address(27))
+	})
+	t.Run("Height", func(t *testing.T) {
+		proof, err := svr.WithHeight(1).AccountProof(ctx, identityset.Address(27), nil)
+		require.NoError(err)
+		require.Equal(uint64(1), proof.Height)
+		verify(proof, identityset.Address(27))
+		tipProof, err := svr.AccountProof(ctx, identityset.Address(27), nil)
+		require.NoError(err)
+		require.NotEqual(tipProof.StateRoot, proof.StateRoot)
+	})
+	t.Run("NonExistAccount", func(t *testing.T) {
+		addrHash := hash.Hash160b([]byte("nonexist"))
+		addr, err := address.FromBytes(addrHash[:])
+		require.NoError(err)
+		proof, err := svr.AccountProof(ctx, addr, nil)
+		require.NoError(err)
+		_, err = factory.VerifyStateProof(proof.StateRoot, factory.AccountKVNamespace, addrHash[:], proof.AccountProof)
+		require.Equal(trie.ErrNotExist, errors.Cause(err))
+	})
+	t.Run("ArchiveNotSupported", func(t *testing.T) {
+		svr, _, _, _, cleanCallback := setupTestCoreService()
+		defer cleanCallback()
+		_, err := svr.WithHeight(1).AccountProof(ctx, identityset.Address(27), nil)
+		require.ErrorIs(err, ErrArchiveNotSupported)
+	})
+}
+
+func TestCoreServiceReaderWithHeight(t *testing.T) {
+	require := require.New(t)
+	svr, bc, _, _, cleanCallback := setupTestCoreService(WithArchiveSupport())
+	defer cleanCallback()
+	ctx := context.Background()
+	require.True(bc.TipHeight() > 1)
+	addr := identityset.Address(27)
+
+	t.Run("PendingNonce", func(t *testing.T) {
+		tipAccount, _, err := svr.Account(addr)
+		require.NoError(err)
+		nonce, err := svr.WithHeight(bc.TipHeight()).PendingNonce(addr)
+		require.NoError(err)
+		require.Equal(tipAccount.PendingNonce, nonce)
+		nonce, err = svr.WithHeight(1).PendingNonce(addr)
+		require.NoError(err)
+		require.LessOrEqual(nonce, tipAccount.PendingNonce)
+	})
+	t.Run("ReadContractStorage", func(t *testing.T) {
+		val, err := svr.WithHeight(1).ReadContractStorage(ctx, addr, make([]byte, 32))
+		require.NoError(err)
+		require.Equal(make([]byte, 32), val)
+	})
+	t.Run("EstimateExecutionGasConsumption", func(t *testing.T) {
+		elp := (&action.EnvelopeBuilder{}).SetAction(action.NewExecution("", big.NewInt(0), []byte{})).Build()
+		estimatedGas, _, err := svr.WithHeight(1).EstimateExecutionGasConsumption(ctx, elp, identityset.Address(29))
+		require.NoError(err)
+		require.Equal(uint64(10000), estimatedGas)
+	})
+	t.Run("ReadState", func(t *testing.T) {
+		res, err := svr.WithHeight(1).ReadState("rewarding", []byte("TotalBalance"), nil)
+		require.NoError(err)
+		require.Equal(uint64(1), res.BlockIdentifier.Height)
+		// the rewarding states are read at the exact height rather than the epoch start height
+		res, err = svr.WithHeight(bc.TipHeight()-1).ReadState("rewarding", []byte("TotalBalance"), nil)
+		require.NoError(err)
+		require.Equal(bc.TipHeight()-1, res.BlockIdentifier.Height)
+	})
+	t.Run("ArchiveNotSupported", func(t *testing.T) {
+		svr, _, _, _, cleanCallback := setupTestCoreService()
+		defer cleanCallback()
+		reader := svr.WithHeight(1)
+		_, err := reader.PendingNonce(addr)
+		require.ErrorIs(err, ErrArchiveNotSupported)
+		_, err = reader.ReadContractStorage(ctx, addr, make([]byte, 32))
+		require.ErrorIs(err, ErrArchiveNotSupported)
+		_, _, err = reader.EstimateExecutionGasConsumption(ctx, nil, addr)
+		require.ErrorIs(err, ErrArchiveNotSupported)
+		_, err = reader.ReadState("rewarding", []byte("TotalBalance"), nil)
+		require.ErrorIs(err, ErrArchiveNotSupported)
+	})
 }
 
 func TestProofAndCompareReverseActions(t *testing.T) {
@@ -992,28 +1233,131 @@ func TestReceiveBlock(t *testing.T) {
 
 	listener := mock_apitypes.NewMockListener(ctrl)
 	cs := &coreService{
-		readCache:     &ReadCache{},
+		respCache:     newTipResponseCache(NewLRUResponseCache(8), 0),
 		chainListener: listener,
 	}
 
 	t.Run("FailedToReceiveBlock", func(t *testing.T) {
-		p := NewPatches()
-		defer p.Reset()
-
-		p = p.ApplyMethodReturn(cs.readCache, "Clear")
 		listener.EXPECT().ReceiveBlock(gomock.Any()).Return(errors.New(t.Name())).Times(1)
 		err := cs.ReceiveBlock(&block.Block{})
 		require.ErrorContains(err, t.Name())
 	})
 
 	t.Run("ReceiveBlockSuccess", func(t *testing.T) {
+		listener.EXPECT().ReceiveBlock(gomock.Any()).Return(nil).Times(1)
+		err := cs.ReceiveBlock(&block.Block{})
+		require.NoError(err)
+	})
+}
+
+func TestResponseCache(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var (
+		bc       = mock_blockchain.NewMockBlockchain(ctrl)
+		dao      = mock_blockdao.NewMockBlockDAO(ctrl)
+		listener = mock_apitypes.NewMockListener(ctrl)
+		cs       = &coreService{
+			bc:            bc,
+			dao:           dao,
+			chainListener: listener,
+			respCache:     newTipResponseCache(NewLRUResponseCache(16), 0),
+		}
+	)
+	blk, err := block.NewTestingBuilder().
+		SetHeight(3).
+		SetTimeStamp(testutil.TimestampNow()).
+		SetReceipts([]*action.Receipt{{Status: 1, BlockHeight: 3, GasConsumed: 21000}}).
+		SignAndBuild(identityset.PrivateKey(27))
+	require.NoError(err)
+	receipts := []*action.Receipt{(&action.Receipt{Status: 1, BlockHeight: 3, GasConsumed: 21000}).AddLogs(&action.Log{
+		Address:     identityset.Address(1).String(),
+		Topics:      action.Topics{hash.Hash256b([]byte("topic"))},
+		BlockHeight: 3,
+	})}
+	bc.EXPECT().TipHeight().Return(uint64(3)).AnyTimes()
+	bc.EXPECT().EvmNetworkID().Return(uint32(4689)).AnyTimes()
+	listener.EXPECT().ReceiveBlock(gomock.Any()).Return(nil).AnyTimes()
+	dao.EXPECT().GetBlockByHeight(uint64(3)).Return(&blk, nil).Times(2)
+	dao.EXPECT().GetReceipts(uint64(3)).Return(receipts, nil).Times(2)
+	require.NoError(cs.ReceiveBlock(&blk))
+
+	for i := 0; i < 2; i++ {
+		res, err := cs.getBlockByHeight(3)
+		require.NoError(err)
+		require.Equal(blk.HashBlock(), res.Block.HashBlock())
+		require.Len(res.Receipts, 1)
+		require.Equal(receipts[0].Hash(), res.Receipts[0].Hash())
+	}
+
+	// the reorg at height 3 reverts the cached responses
+	require.NoError(cs.ReceiveBlock(&blk))
+	_, err = cs.getBlockByHeight(3)
+	require.NoError(err)
+
+	t.Run("readState", func(t *testing.T) {
+		bc.EXPECT().Genesis().Return(genesis.TestDefault()).AnyTimes()
+		p := protocol.NewMockProtocol(ctrl)
+		p.EXPECT().Name().Return("test").AnyTimes()
+		// the state is read at a height lower than the read height, and cached at the read height
+		p.EXPECT().ReadState(gomock.Any(), gomock.Any(), []byte("method")).Return([]byte("state"), uint64(2), nil).Times(1)
+		for i := 0; i < 2; i++ {
+			d, h, err := cs.readState(context.Background(), p, "", []byte("method"))
+			require.NoError(err)
+			require.Equal([]byte("state"), d)
+			require.EqualValues(2, h)
+		}
+	})
+
+	t.Run("readContract", func(t *testing.T) {
 		p := NewPatches()
 		defer p.Reset()
 
-		p = p.ApplyMethodReturn(cs.readCache, "Clear")
-		listener.EXPECT().ReceiveBlock(gomock.Any()).Return(nil).Times(1)
-		err := cs.ReceiveBlock(&block.Block{})
+		// a block is committed after the tip height is read, and the working set is upon height 4
+		p = p.ApplyPrivateMethod(
+			cs,
+			"simulateExecutionWithHeight",
+			func(_ *coreService, _ context.Context, _ uint64, _ bool, _ address.Address, _ action.Envelope, _ ...protocol.SimulateOption) ([]byte, *action.Receipt, uint64, error) {
+				return []byte{1}, &action.Receipt{Status: 1, BlockHeight: 5}, 4, nil
+			},
+		)
+		exec := action.NewExecution(identityset.Address(10).String(), big.NewInt(0), []byte{1})
+		elp := (&action.EnvelopeBuilder{}).SetGasLimit(100000).SetAction(exec).Build()
+		data, _, err := cs.readContract(context.Background(), 3, false, identityset.Address(1), elp, exec)
 		require.NoError(err)
+		require.Equal("01", data)
+		_, ok := cs.respCache.ResponseCache.Get(3, readContractKey(3, identityset.Address(1), 100000, exec))
+		require.False(ok)
+		_, ok = cs.respCache.ResponseCache.Get(4, readContractKey(4, identityset.Address(1), 100000, exec))
+		require.True(ok)
+	})
+
+	t.Run("readContractKey", func(t *testing.T) {
+		contract := identityset.Address(10).String()
+		newElp := func(amount int64, gas uint64, data []byte) (action.Envelope, *action.Execution) {
+			exec := action.NewExecution(contract, big.NewInt(amount), data)
+			return (&action.EnvelopeBuilder{}).SetGasLimit(gas).SetAction(exec).Build(), exec
+		}
+		elp, exec := newElp(0, 100000, []byte{1})
+		key := readContractKey(3, identityset.Address(1), elp.Gas(), exec)
+		require.Equal(key, readContractKey(3, identityset.Address(1), elp.Gas(), exec))
+		require.NotEqual(key, readContractKey(4, identityset.Address(1), elp.Gas(), exec))
+		require.NotEqual(key, readContractKey(3, identityset.Address(2), elp.Gas(), exec))
+		require.NotEqual(key, readContractKey(3, nil, elp.Gas(), exec))
+		for _, c := range []struct {
+			amount int64
+			gas    uint64
+			data   []byte
+		}{
+			{1, 100000, []byte{1}},
+			{0, 200000, []byte{1}},
+			{0, 100000, []byte{2}},
+		} {
+			elp, exec := newElp(c.amount, c.gas, c.data)
+			require.NotEqual(key, readContractKey(3, identityset.Address(1), elp.Gas(), exec))
+		}
 	})
 }
 
@@ -1042,7 +1386,9 @@ func TestSimulateExecution(t *testing.T) {
 		bc.EXPECT().Genesis().Return(genesis.Genesis{}).Times(1)
 		bc.EXPECT().TipHeight().Return(uint64(1)).Times(1)
 		bc.EXPECT().Context(gomock.Any()).Return(ctx, nil).Times(1)
-		sf.EXPECT().WorkingSet(gomock.Any()).Return(nil, nil).Times(1)
+		ws := mock_chainmanager.NewMockStateManager(ctrl)
+		ws.EXPECT().Height().Return(uint64(2), nil).Times(1)
+		sf.EXPECT().WorkingSet(gomock.Any()).Return(ws, nil).Times(1)
 		elp := (&action.EnvelopeBuilder{}).SetAction(&action.Execution{}).Build()
 		_, _, err := cs.SimulateExecution(ctx, &address.AddrV1{}, elp)
 		require.ErrorContains(err, t.Name())
@@ -1194,3 +1540,203 @@ func TestTraceTx(t *testing.T) {
 		require.Empty(tracer)
 	})
 }
+
+func TestTokenTransfers(t *testing.T) {
+	require := require.New(t)
+	testPath, err := testutil.PathOfTempFile("test-token-transfers")
+	require.NoError(err)
+	defer testutil.CleanupPath(testPath)
+	cfg := db.DefaultConfig
+	cfg.DbPath = testPath
+	indexer, err := blockindex.NewTokenTransferIndexer(db.NewBoltDB(cfg))
+	require.NoError(err)
+	ctx := context.Background()
+	require.NoError(indexer.Start(ctx))
+	defer func() {
+		require.NoError(indexer.Stop(ctx))
+	}()
+
+	owner := identityset.Address(28)
+	cs := &coreService{cfg: DefaultConfig}
+	_, _, err = cs.TokenTransfers(owner, nil, 0, 10)
+	require.ErrorContains(err, blockindex.ErrTokenTransferIndexNA.Error())
+
+	WithTokenTransferIndexer(indexer)(cs)
+	_, _, err = cs.TokenTransfers(owner, nil, 0, 0)
+	require.ErrorContains(err, "count must be greater than zero")
+	_, _, err = cs.TokenTransfers(owner, nil, 0, cs.cfg.RangeQueryLimit+1)
+	require.ErrorContains(err, "range exceeds the limit")
+
+	blk, err := block.NewTestingBuilder().
+		SetHeight(1).
+		SetTimeStamp(testutil.TimestampNow()).
+		SetReceipts([]*action.Receipt{(&action.Receipt{Status: uint64(iotextypes.ReceiptStatus_Success)}).AddLogs(&action.Log{
+			Address: identityset.Address(31).String(),
+			Topics: action.Topics{
+				hash.BytesToHash256(crypto.Keccak256([]byte("Transfer(address,address,uint256)"))),
+				hash.BytesToHash256(owner.Bytes()),
+				hash.BytesToHash256(identityset.Address(29).Bytes()),
+			},
+			Data: append(make([]byte, 31), 5),
+		})}).
+		SignAndBuild(identityset.PrivateKey(27))
+	require.NoError(err)
+	require.NoError(indexer.PutBlock(ctx, &blk))
+	transfers, total, err := cs.TokenTransfers(owner, nil, 0, 10)
+	require.NoError(err)
+	require.EqualValues(1, total)
+	require.Len(transfers, 1)
+	require.Equal(identityset.Address(29).String(), transfers[0].To.String())
+	require.EqualValues(5, transfers[0].Amount.Int64())
+}
+
+func TestReadStakingHistory(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	testPath, err := testutil.PathOfTempFile("test-staking-history")
+	require.NoError(err)
+	defer testutil.CleanupPath(testPath)
+	cfg := db.DefaultConfig
+	cfg.DbPath = testPath
+	indexer, err := blockindex.NewStakingHistoryIndexer(db.NewBoltDB(cfg), 1)
+	require.NoError(err)
+	ctx := context.Background()
+	require.NoError(indexer.Start(ctx))
+	defer func() {
+		require.NoError(indexer.Stop(ctx))
+	}()
+
+	registry := protocol.NewRegistry()
+	require.NoError(registry.Register("staking", &staking.Protocol{}))
+	dao := mock_blockdao.NewMockBlockDAO(ctrl)
+	cs := &coreService{cfg: DefaultConfig, registry: registry, dao: dao}
+	cand := identityset.Address(30)
+	method, err := proto.Marshal(&iotexapi.ReadStakingDataMethod{Method: extensionpb.ReadStakingDataMethodHistory})
+	require.NoError(err)
+	readHistory := func(req *extensionpb.ReadStakingHistoryRequest) (*iotexapi.ReadStateResponse, error) {
+		arg, err := proto.Marshal(req)
+		require.NoError(err)
+		return cs.ReadState("staking", "", method, [][]byte{arg})
+	}
+	_, err = readHistory(&extensionpb.ReadStakingHistoryRequest{BucketIndex: 1, Count: 10})
+	require.Equal(codes.Unavailable, status.Code(err))
+
+	WithStakingHistoryIndexer(indexer)(cs)
+	_, err = readHistory(&extensionpb.ReadStakingHistoryRequest{BucketIndex: 1})
+	require.ErrorContains(err, "count must be greater than zero")
+	_, err = readHistory(&extensionpb.ReadStakingHistoryRequest{BucketIndex: 1, Count: cs.cfg.RangeQueryLimit + 1})
+	require.ErrorContains(err, "range exceeds the limit")
+	_, err = readHistory(&extensionpb.ReadStakingHistoryRequest{Candidate: "invalid", Count: 10})
+	require.Equal(codes.InvalidArgument, status.Code(err))
+
+	blk, err := block.NewTestingBuilder().
+		SetHeight(1).
+		SetTimeStamp(testutil.TimestampNow()).
+		SetReceipts([]*action.Receipt{(&action.Receipt{Status: uint64(iotextypes.ReceiptStatus_Success)}).AddLogs(&action.Log{
+			Address: staking.ProtocolAddr().String(),
+			Topics: action.Topics{
+				hash.BytesToHash256([]byte(staking.HandleCreateStake)),
+				hash.BytesToHash256([]byte{1}),
+				hash.BytesToHash256(cand.Bytes()),
+			},
+		})}).
+		SignAndBuild(identityset.PrivateKey(27))
+	require.NoError(err)
+	require.NoError(indexer.PutBlock(ctx, &blk))
+	blkHash := blk.HashBlock()
+	dao.EXPECT().GetBlockHash(uint64(1)).Return(blkHash, nil).Times(2)
+	for _, req := range []*extensionpb.ReadStakingHistoryRequest{
+		{BucketIndex: 1, Count: 10},
+		{Candidate: cand.String(), Count: 10},
+	} {
+		res, err := readHistory(req)
+		require.NoError(err)
+		require.EqualValues(1, res.BlockIdentifier.Height)
+		require.Equal(hex.EncodeToString(blkHash[:]), res.BlockIdentifier.Hash)
+		list := &extensionpb.StakingEventList{}
+		require.NoError(proto.Unmarshal(res.Data, list))
+		require.EqualValues(1, list.Total)
+		require.EqualValues(1, list.StartHeight)
+		require.Len(list.Events, 1)
+		require.Equal(staking.HandleCreateStake, list.Events[0].Type)
+		require.EqualValues(1, list.Events[0].BucketIndex)
+		require.Equal([]string{cand.String()}, list.Events[0].Candidates)
+	}
+}
+
+func TestProjectDelegateReward(t *testing.T) {
+	require := require.New(t)
+	svr, _, _, _, cleanCallback := setupTestCoreService()
+	defer cleanCallback()
+	ctx := context.Background()
+
+	for _, epochs := range []uint64{0, maxProjectEpochs + 1} {
+		_, err := svr.ProjectDelegateReward(ctx, "cand", big.NewInt(100), 0, false, epochs, 90)
+		require.Equal(codes.InvalidArgument, status.Code(err))
+	}
+	for _, amount := range []*big.Int{nil, big.NewInt(0), big.NewInt(-1)} {
+		_, err := svr.ProjectDelegateReward(ctx, "cand", amount, 0, false, 1, 90)
+		require.Equal(codes.InvalidArgument, status.Code(err))
+	}
+	_, err := svr.ProjectDelegateReward(ctx, "cand", big.NewInt(100), 0, false, 1, 101)
+	require.Equal(codes.InvalidArgument, status.Code(err))
+	// the projection needs the native staking protocol
+	_, err = svr.ProjectDelegateReward(ctx, "cand", big.NewInt(100), 0, false, 1, 90)
+	require.Equal(codes.Unavailable, status.Code(err))
+}
+
+func TestSimulateBlocksLimit(t *testing.T) {
+	require := require.New(t)
+	svr, _, _, _, cleanCallback := setupTestCoreService()
+	defer cleanCallback()
+	core := svr.(*coreService)
+	ctx := context.Background()
+
+	newBlocks := func(blocks, calls int) []*apitypes.SimulateBlock {
+		ret := make([]*apitypes.SimulateBlock, blocks)
+		for i := range ret {
+			ret[i] = &apitypes.SimulateBlock{}
+			for j := 0; j < calls; j++ {
+				elp := (&action.EnvelopeBuilder{}).SetGasLimit(100000).SetGasPrice(big.NewInt(0)).
+					SetAction(action.NewExecution(identityset.Address(29).String(), big.NewInt(0), nil)).Build()
+				ret[i].Calls = append(ret[i].Calls, &apitypes.SimulateCall{Caller: identityset.Address(27), Envelope: elp})
+			}
+		}
+		return ret
+	}
+	simulated, err := core.SimulateBlocks(ctx, newBlocks(2, 2))
+	require.NoError(err)
+	require.Len(simulated, 2)
+	gasUsed := simulated[0].GasUsed + simulated[1].GasUsed
+
+	t.Run("max calls", func(t *testing.T) {
+		core.cfg.SimulateLimit.MaxCalls = 3
+		defer func() { core.cfg.SimulateLimit = DefaultConfig.SimulateLimit }()
+		_, err := core.SimulateBlocks(ctx, newBlocks(2, 2))
+		require.Equal(codes.InvalidArgument, status.Code(err))
+	})
+	t.Run("gas cap", func(t *testing.T) {
+		core.cfg.SimulateLimit.GasCap = gasUsed - 1
+		defer func() { core.cfg.SimulateLimit = DefaultConfig.SimulateLimit }()
+		_, err := core.SimulateBlocks(ctx, newBlocks(2, 2))
+		require.Equal(codes.ResourceExhausted, status.Code(err))
+		core.cfg.SimulateLimit.GasCap = gasUsed
+		_, err = core.SimulateBlocks(ctx, newBlocks(2, 2))
+		require.NoError(err)
+	})
+	t.Run("timeout", func(t *testing.T) {
+		cctx, cancel := context.WithCancel(ctx)
+		cancel()
+		_, err := core.SimulateBlocks(cctx, newBlocks(1, 1))
+		require.Equal(codes.DeadlineExceeded, status.Code(err))
+	})
+}
+
+func TestMergeHeights(t *testing.T) {
+	require := require.New(t)
+	require.Empty(mergeHeights(nil, nil))
+	require.Equal([]uint64{1, 2, 3}, mergeHeights([]uint64{1, 2, 3}, nil))
+	require.Equal([]uint64{1, 2, 3}, mergeHeights(nil, []uint64{1, 2, 3}))
+	require.Equal([]uint64{1, 2, 3, 5, 8}, mergeHeights([]uint64{2, 3, 8}, []uint64{1, 3, 5}))
+}
@@ -2,19 +2,24 @@ package api
 
 import (
 	"context"
+	"strconv"
 
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/iotexproject/go-pkgs/hash"
 	"github.com/iotexproject/iotex-address/address"
+	"github.com/iotexproject/iotex-proto/golang/iotexapi"
 	"github.com/iotexproject/iotex-proto/golang/iotextypes"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
 	"github.com/iotexproject/iotex-core/v2/action"
+	"github.com/iotexproject/iotex-core/v2/action/protocol"
 	accountutil "github.com/iotexproject/iotex-core/v2/action/protocol/account/util"
+	"github.com/iotexproject/iotex-core/v2/action/protocol/execution/evm"
+	apitypes "github.com/iotexproject/iotex-core/v2/api/types"
 	"github.com/iotexproject/iotex-core/v2/blockchain/genesis"
 	"github.com/iotexproject/iotex-core/v2/pkg/log"
 	"github.com/iotexproject/iotex-core/v2/pkg/tracer"
-	"github.com/iotexproject/iotex-core/v2/pkg/util/byteutil"
 	"github.com/iotexproject/iotex-core/v2/state"
 )
 
@@ -22,7 +27,14 @@ type (
 	// CoreServiceReaderWithHeight is an interface for state reader at certain height
 	CoreServiceReaderWithHeight interface {
 		Account(address.Address) (*iotextypes.AccountMeta, *iotextypes.BlockIdentifier, error)
-		ReadContract(context.Context, address.Address, action.Envelope) (string, *iotextypes.Receipt, error)
+		ReadContract(context.Context, address.Address, action.Envelope, ...protocol.SimulateOption) (string, *iotextypes.Receipt, error)
+		AccountProof(context.Context, address.Address, []hash.Hash256) (*apitypes.AccountProof, error)
+		ReadContractStorage(context.Context, address.Address, []byte) ([]byte, error)
+		PendingNonce(address.Address) (uint64, error)
+		EstimateExecutionGasConsumption(context.Context, action.Envelope, address.Address, ...protocol.SimulateOption) (uint64, []byte, error)
+		CreateAccessList(context.Context, address.Address, action.Envelope, ...protocol.SimulateOption) (types.AccessList, *action.Receipt, error)
+		ReadState(string, []byte, [][]byte) (*iotexapi.ReadStateResponse, error)
+		SimulateBlocks(context.Context, []*apitypes.SimulateBlock) ([]*apitypes.SimulatedBlock, error)
 	}
 
 	coreServiceReaderWithHeight struct {
@@ -77,7 +89,7 @@ func (core *coreServiceReaderWithHeight) stateAndNonce(addr address.Address) (*s
 	return state, pendingNonce, nil
 }
 
-func (core *coreServiceReaderWithHeight) ReadContract(ctx context.Context, callerAddr address.Address, elp action.Envelope) (string, *iotextypes.Receipt, error) {
+func (core *coreServiceReaderWithHeight) ReadContract(ctx context.Context, callerAddr address.Address, elp action.Envelope, opts ...protocol.SimulateOption) (string, *iotextypes.Receipt, error) {
 	if !core.cs.archiveSupported {
 		return "", nil, ErrArchiveNotSupported
 	}
@@ -86,9 +98,70 @@ func (core *coreServiceReaderWithHeight) ReadContract(ctx context.Context, calle
 	if !ok {
 		return "", nil, status.Error(codes.InvalidArgument, "expecting action.Execution")
 	}
-	var (
-		hdBytes = append(byteutil.Uint64ToBytesBigEndian(core.height), []byte(exec.Contract())...)
-		key     = hash.Hash160b(append(hdBytes, exec.Data()...))
-	)
-	return core.cs.readContract(ctx, key, core.height, true, callerAddr, elp)
+	return core.cs.readContract(ctx, core.height, true, callerAddr, elp, exec, opts...)
+}
+
+func (core *coreServiceReaderWithHeight) AccountProof(ctx context.Context, addr address.Address, storageKeys []hash.Hash256) (*apitypes.AccountProof, error) {
+	if !core.cs.archiveSupported {
+		return nil, ErrArchiveNotSupported
+	}
+	ctx, span := tracer.NewSpan(ctx, "coreServiceReaderWithHeight.AccountProof")
+	defer span.End()
+	ctx = genesis.WithGenesisContext(ctx, core.cs.bc.Genesis())
+	ws, err := core.cs.sf.WorkingSetAtHeight(ctx, core.height)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return core.cs.accountProof(ctx, ws, core.height, addr, storageKeys)
+}
+
+func (core *coreServiceReaderWithHeight) ReadContractStorage(ctx context.Context, addr address.Address, key []byte) ([]byte, error) {
+	if !core.cs.archiveSupported {
+		return nil, ErrArchiveNotSupported
+	}
+	ctx, err := core.cs.bc.ContextAtHeight(ctx, core.height)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	ws, err := core.cs.sf.WorkingSetAtHeight(ctx, core.height)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return evm.ReadContractStorage(ctx, ws, addr, key)
+}
+
+func (core *coreServiceReaderWithHeight) PendingNonce(addr address.Address) (uint64, error) {
+	if !core.cs.archiveSupported {
+		return 0, ErrArchiveNotSupported
+	}
+	_, pendingNonce, err := core.stateAndNonce(addr)
+	return pendingNonce, err
+}
+
+func (core *coreServiceReaderWithHeight) EstimateExecutionGasConsumption(ctx context.Context, elp action.Envelope, callerAddr address.Address, opts ...protocol.SimulateOption) (uint64, []byte, error) {
+	if !core.cs.archiveSupported {
+		return 0, nil, ErrArchiveNotSupported
+	}
+	return core.cs.estimateExecutionGasConsumption(ctx, core.height, true, elp, callerAddr, opts...)
+}
+
+func (core *coreServiceReaderWithHeight) CreateAccessList(ctx context.Context, callerAddr address.Address, elp action.Envelope, opts ...protocol.SimulateOption) (types.AccessList, *action.Receipt, error) {
+	if !core.cs.archiveSupported {
+		return nil, nil, ErrArchiveNotSupported
+	}
+	return core.cs.createAccessList(ctx, core.height, true, callerAddr, elp, opts...)
+}
+
+func (core *coreServiceReaderWithHeight) SimulateBlocks(ctx context.Context, blocks []*apitypes.SimulateBlock) ([]*apitypes.SimulatedBlock, error) {
+	if !core.cs.archiveSupported {
+		return nil, ErrArchiveNotSupported
+	}
+	return core.cs.simulateBlocks(ctx, core.height, true, blocks)
+}
+
+func (core *coreServiceReaderWithHeight) ReadState(protocolID string, methodName []byte, arguments [][]byte) (*iotexapi.ReadStateResponse, error) {
+	if !core.cs.archiveSupported {
+		return nil, ErrArchiveNotSupported
+	}
+	return core.cs.ReadState(protocolID, strconv.FormatUint(core.height, 10), methodName, arguments)
 }
@@ -0,0 +1,181 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             v5.29.3
+// source: api/extensionpb/extension.proto
+
+package extensionpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// ExtensionServiceClient is the client API for ExtensionService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ExtensionServiceClient interface {
+	// GetTokenTransfers returns the XRC20, XRC721 and XRC1155 token transfers from or to an address
+	GetTokenTransfers(ctx context.Context, in *GetTokenTransfersRequest, opts ...grpc.CallOption) (*GetTokenTransfersResponse, error)
+	// GetBlockReceipts returns the receipts of the actions in a block, with the indexes in the block and the cumulative gas
+	GetBlockReceipts(ctx context.Context, in *GetBlockReceiptsRequest, opts ...grpc.CallOption) (*GetBlockReceiptsResponse, error)
+	// ProjectDelegateReward projects the rewards of a hypothetical bucket voting for a candidate in the upcoming epochs
+	ProjectDelegateReward(ctx context.Context, in *ProjectDelegateRewardRequest, opts ...grpc.CallOption) (*ProjectDelegateRewardResponse, error)
+}
+
+type extensionServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewExtensionServiceClient(cc grpc.ClientConnInterface) ExtensionServiceClient {
+	return &extensionServiceClient{cc}
+}
+
+func (c *extensionServiceClient) GetTokenTransfers(ctx context.Context, in *GetTokenTransfersRequest, opts ...grpc.CallOption) (*GetTokenTransfersResponse, error) {
+	out := new(GetTokenTransfersResponse)
+	err := c.cc.Invoke(ctx, "/extensionpb.ExtensionService/GetTokenTransfers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *extensionServiceClient) GetBlockReceipts(ctx context.Context, in *GetBlockReceiptsRequest, opts ...grpc.CallOption) (*GetBlockReceiptsResponse, error) {
+	out := new(GetBlockReceiptsResponse)
+	err := c.cc.Invoke(ctx, "/extensionpb.ExtensionService/GetBlockReceipts", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *extensionServiceClient) ProjectDelegateReward(ctx context.Context, in *ProjectDelegateRewardRequest, opts ...grpc.CallOption) (*ProjectDelegateRewardResponse, error) {
+	out := new(ProjectDelegateRewardResponse)
+	err := c.cc.Invoke(ctx, "/extensionpb.ExtensionService/ProjectDelegateReward", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ExtensionServiceServer is the server API for ExtensionService service.
+// All implementations should embed UnimplementedExtensionServiceServer
+// for forward compatibility
+type ExtensionServiceServer interface {
+	// GetTokenTransfers returns the XRC20, XRC721 and XRC1155 token transfers from or to an address
+	GetTokenTransfers(context.Context, *GetTokenTransfersRequest) (*GetTokenTransfersResponse, error)
+	// GetBlockReceipts returns the receipts of the actions in a block, with the indexes in the block and the cumulative gas
+	GetBlockReceipts(context.Context, *GetBlockReceiptsRequest) (*GetBlockReceiptsResponse, error)
+	// ProjectDelegateReward projects the rewards of a hypothetical bucket voting for a candidate in the upcoming epochs
+	ProjectDelegateReward(context.Context, *ProjectDelegateRewardRequest) (*ProjectDelegateRewardResponse, error)
+}
+
+// UnimplementedExtensionServiceServer should be embedded to have forward compatible implementations.
+type UnimplementedExtensionServiceServer struct {
+}
+
+func (UnimplementedExtensionServiceServer) GetTokenTransfers(context.Context, *GetTokenTransfersRequest) (*GetTokenTransfersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTokenTransfers not implemented")
+}
+func (UnimplementedExtensionServiceServer) GetBlockReceipts(context.Context, *GetBlockReceiptsRequest) (*GetBlockReceiptsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBlockReceipts not implemented")
+}
+func (UnimplementedExtensionServiceServer) ProjectDelegateReward(context.Context, *ProjectDelegateRewardRequest) (*ProjectDelegateRewardResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ProjectDelegateReward not implemented")
+}
+
+// UnsafeExtensionServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ExtensionServiceServer will
+// result in compilation errors.
+type UnsafeExtensionServiceServer interface {
+	mustEmbedUnimplementedExtensionServiceServer()
+}
+
+func RegisterExtensionServiceServer(s grpc.ServiceRegistrar, srv ExtensionServiceServer) {
+	s.RegisterService(&ExtensionService_ServiceDesc, srv)
+}
+
+func _ExtensionService_GetTokenTransfers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTokenTransfersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExtensionServiceServer).GetTokenTransfers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/extensionpb.ExtensionService/GetTokenTransfers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExtensionServiceServer).GetTokenTransfers(ctx, req.(*GetTokenTransfersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExtensionService_GetBlockReceipts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBlockReceiptsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExtensionServiceServer).GetBlockReceipts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/extensionpb.ExtensionService/GetBlockReceipts",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExtensionServiceServer).GetBlockReceipts(ctx, req.(*GetBlockReceiptsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExtensionService_ProjectDelegateReward_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProjectDelegateRewardRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExtensionServiceServer).ProjectDelegateReward(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/extensionpb.ExtensionService/ProjectDelegateReward",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExtensionServiceServer).ProjectDelegateReward(ctx, req.(*ProjectDelegateRewardRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ExtensionService_ServiceDesc is the grpc.ServiceDesc for ExtensionService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ExtensionService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "extensionpb.ExtensionService",
+	HandlerType: (*ExtensionServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetTokenTransfers",
+			Handler:    _ExtensionService_GetTokenTransfers_Handler,
+		},
+		{
+			MethodName: "GetBlockReceipts",
+			Handler:    _ExtensionService_GetBlockReceipts_Handler,
+		},
+		{
+			MethodName: "ProjectDelegateReward",
+			Handler:    _ExtensionService_ProjectDelegateReward_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "api/extensionpb/extension.proto",
+}
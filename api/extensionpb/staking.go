@@ -0,0 +1,15 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package extensionpb
+
+import "github.com/iotexproject/iotex-proto/golang/iotexapi"
+
+// ReadStakingDataMethodHistory is the ReadStakingDataMethod of the ReadState call of the staking protocol to read
+// the events of a bucket or a candidate from the staking history indexer, the argument is a marshaled
+// ReadStakingHistoryRequest and the data returned is a marshaled StakingEventList. The enum is defined in
+// iotex-proto, which is not bumped along with this repo, so the method is defined here with a value far beyond
+// the ones defined there to not collide with the new ones added there
+const ReadStakingDataMethodHistory iotexapi.ReadStakingDataMethod_Name = 100
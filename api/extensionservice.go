@@ -0,0 +1,153 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package api
+
+import (
+	"context"
+	"encoding/hex"
+	"math/big"
+
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/iotexproject/iotex-core/v2/api/extensionpb"
+	apitypes "github.com/iotexproject/iotex-core/v2/api/types"
+	"github.com/iotexproject/iotex-core/v2/blockindex"
+)
+
+// extensionService serves the queries not in the iotexapi, which are backed by the optional indexers
+type extensionService struct {
+	coreService CoreService
+}
+
+func newExtensionService(core CoreService) *extensionService {
+	return &extensionService{
+		coreService: core,
+	}
+}
+
+func (service *extensionService) GetTokenTransfers(_ context.Context, request *extensionpb.GetTokenTransfersRequest) (*extensionpb.GetTokenTransfersResponse, error) {
+	addr, err := address.FromString(request.Address)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	var token address.Address
+	if request.Token != "" {
+		if token, err = address.FromString(request.Token); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+	transfers, total, err := service.coreService.TokenTransfers(addr, token, request.Start, request.Count)
+	if err != nil {
+		return nil, err
+	}
+	res := &extensionpb.GetTokenTransfersResponse{
+		Total:     total,
+		Transfers: make([]*extensionpb.TokenTransfer, 0, len(transfers)),
+	}
+	for _, tt := range transfers {
+		res.Transfers = append(res.Transfers, tokenTransferToPb(tt))
+	}
+	return res, nil
+}
+
+func (service *extensionService) GetBlockReceipts(_ context.Context, request *extensionpb.GetBlockReceiptsRequest) (*extensionpb.GetBlockReceiptsResponse, error) {
+	var (
+		blk *apitypes.BlockWithReceipts
+		err error
+	)
+	if request.Hash != "" {
+		blk, err = service.coreService.BlockByHash(request.Hash)
+	} else {
+		blk, err = service.coreService.BlockByHeight(request.Height)
+	}
+	if err != nil {
+		if errors.Cause(err) == ErrNotFound {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	var (
+		blkHash           = blk.Block.HashBlock()
+		receipts          = blockReceipts(blk.Block, blk.Receipts)
+		cumulativeGasUsed uint64
+	)
+	res := &extensionpb.GetBlockReceiptsResponse{
+		Height:   blk.Block.Height(),
+		Hash:     hex.EncodeToString(blkHash[:]),
+		Receipts: make([]*extensionpb.BlockReceipt, 0, len(receipts)),
+	}
+	for _, r := range receipts {
+		cumulativeGasUsed += r.GasConsumed
+		res.Receipts = append(res.Receipts, &extensionpb.BlockReceipt{
+			Receipt:           r.ConvertToReceiptPb(),
+			CumulativeGasUsed: cumulativeGasUsed,
+		})
+	}
+	return res, nil
+}
+
+func (service *extensionService) ProjectDelegateReward(ctx context.Context, request *extensionpb.ProjectDelegateRewardRequest) (*extensionpb.ProjectDelegateRewardResponse, error) {
+	amount, ok := new(big.Int).SetString(request.Amount, 10)
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid amount %s", request.Amount)
+	}
+	projection, err := service.coreService.ProjectDelegateReward(ctx, request.Candidate, amount, request.Duration, request.AutoStake, request.Epochs, request.DistributionRatio)
+	if err != nil {
+		return nil, err
+	}
+	res := &extensionpb.ProjectDelegateRewardResponse{
+		BucketVotes: projection.BucketVotes.String(),
+		Epochs:      make([]*extensionpb.EpochRewardProjection, 0, len(projection.Epochs)),
+	}
+	for _, e := range projection.Epochs {
+		res.Epochs = append(res.Epochs, &extensionpb.EpochRewardProjection{
+			EpochNumber:     e.EpochNumber,
+			CandidateVotes:  e.CandidateVotes.String(),
+			Probation:       e.Probation,
+			EpochReward:     e.EpochReward.String(),
+			FoundationBonus: e.FoundationBonus.String(),
+			BucketReward:    e.BucketReward.String(),
+		})
+	}
+	return res, nil
+}
+
+func tokenTransferToPb(tt *blockindex.TokenTransfer) *extensionpb.TokenTransfer {
+	pb := &extensionpb.TokenTransfer{
+		Standard:    tt.Standard,
+		Token:       tt.Token.String(),
+		From:        tt.From.String(),
+		To:          tt.To.String(),
+		Amount:      tt.Amount.String(),
+		BlockHeight: tt.BlockHeight,
+		ActionHash:  hex.EncodeToString(tt.ActionHash[:]),
+		LogIndex:    tt.LogIndex,
+	}
+	if tt.TokenID != nil {
+		pb.TokenID = tt.TokenID.String()
+	}
+	return pb
+}
+
+func stakingEventToPb(se *blockindex.StakingEvent) *extensionpb.StakingEvent {
+	pb := &extensionpb.StakingEvent{
+		Type:        se.Type,
+		BucketIndex: se.BucketIndex,
+		BlockHeight: se.BlockHeight,
+		ActionHash:  hex.EncodeToString(se.ActionHash[:]),
+		LogIndex:    se.LogIndex,
+	}
+	for _, cand := range se.Candidates {
+		pb.Candidates = append(pb.Candidates, cand.String())
+	}
+	if se.Owner != nil {
+		pb.Owner = se.Owner.String()
+	}
+	return pb
+}
@@ -0,0 +1,189 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package api
+
+import (
+	"context"
+	"encoding/hex"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/iotexproject/iotex-core/v2/action"
+	"github.com/iotexproject/iotex-core/v2/api/extensionpb"
+	apitypes "github.com/iotexproject/iotex-core/v2/api/types"
+	"github.com/iotexproject/iotex-core/v2/blockchain/block"
+	"github.com/iotexproject/iotex-core/v2/blockindex"
+	"github.com/iotexproject/iotex-core/v2/test/identityset"
+)
+
+func TestExtensionService_GetTokenTransfers(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	core := NewMockCoreService(ctrl)
+	service := newExtensionService(core)
+	ctx := context.Background()
+
+	var (
+		owner = identityset.Address(28)
+		token = identityset.Address(31)
+		tt    = &blockindex.TokenTransfer{
+			Standard:    blockindex.TokenStandardXRC1155,
+			Token:       token,
+			From:        owner,
+			To:          identityset.Address(29),
+			TokenID:     big.NewInt(3),
+			Amount:      big.NewInt(20),
+			BlockHeight: 5,
+			ActionHash:  hash.BytesToHash256([]byte{1}),
+			LogIndex:    2,
+		}
+	)
+	_, err := service.GetTokenTransfers(ctx, &extensionpb.GetTokenTransfersRequest{Address: "invalid", Count: 1})
+	require.Error(err)
+	_, err = service.GetTokenTransfers(ctx, &extensionpb.GetTokenTransfersRequest{Address: owner.String(), Token: "invalid", Count: 1})
+	require.Error(err)
+
+	core.EXPECT().TokenTransfers(owner, token, uint64(0), uint64(1)).Return([]*blockindex.TokenTransfer{tt}, uint64(2), nil)
+	res, err := service.GetTokenTransfers(ctx, &extensionpb.GetTokenTransfersRequest{
+		Address: owner.String(),
+		Token:   token.String(),
+		Count:   1,
+	})
+	require.NoError(err)
+	require.EqualValues(2, res.Total)
+	require.Len(res.Transfers, 1)
+	pb := res.Transfers[0]
+	require.Equal(blockindex.TokenStandardXRC1155, pb.Standard)
+	require.Equal(token.String(), pb.Token)
+	require.Equal(owner.String(), pb.From)
+	require.Equal(identityset.Address(29).String(), pb.To)
+	require.Equal("3", pb.TokenID)
+	require.Equal("20", pb.Amount)
+	require.EqualValues(5, pb.BlockHeight)
+	require.Equal(hex.EncodeToString(tt.ActionHash[:]), pb.ActionHash)
+	require.EqualValues(2, pb.LogIndex)
+}
+
+func TestExtensionService_GetBlockReceipts(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	core := NewMockCoreService(ctrl)
+	service := newExtensionService(core)
+	ctx := context.Background()
+
+	tsf1, err := action.SignedTransfer(identityset.Address(28).String(), identityset.PrivateKey(27), uint64(1), big.NewInt(10), []byte{}, uint64(100000), big.NewInt(1))
+	require.NoError(err)
+	tsf2, err := action.SignedTransfer(identityset.Address(28).String(), identityset.PrivateKey(27), uint64(2), big.NewInt(10), []byte{}, uint64(100000), big.NewInt(1))
+	require.NoError(err)
+	hash1, err := tsf1.Hash()
+	require.NoError(err)
+	hash2, err := tsf2.Hash()
+	require.NoError(err)
+	receipts := []*action.Receipt{
+		(&action.Receipt{Status: 1, BlockHeight: 3, ActionHash: hash1, GasConsumed: 100}).AddLogs(
+			&action.Log{Address: identityset.Address(31).String(), BlockHeight: 3, ActionHash: hash1},
+		),
+		(&action.Receipt{Status: 1, BlockHeight: 3, ActionHash: hash2, GasConsumed: 50}).AddLogs(
+			&action.Log{Address: identityset.Address(31).String(), BlockHeight: 3, ActionHash: hash2},
+		),
+	}
+	blk, err := block.NewTestingBuilder().
+		SetHeight(3).
+		SetPrevBlockHash(hash.ZeroHash256).
+		SetTimeStamp(time.Now()).
+		SetReceipts(receipts).
+		AddActions(tsf1, tsf2).
+		SignAndBuild(identityset.PrivateKey(0))
+	require.NoError(err)
+	blkHash := blk.HashBlock()
+
+	core.EXPECT().BlockByHeight(uint64(4)).Return(nil, ErrNotFound)
+	_, err = service.GetBlockReceipts(ctx, &extensionpb.GetBlockReceiptsRequest{Height: 4})
+	require.Equal(codes.NotFound, status.Code(err))
+
+	core.EXPECT().BlockByHash(hex.EncodeToString(blkHash[:])).Return(&apitypes.BlockWithReceipts{
+		Block:    &blk,
+		Receipts: receipts,
+	}, nil)
+	res, err := service.GetBlockReceipts(ctx, &extensionpb.GetBlockReceiptsRequest{
+		Height: 4,
+		Hash:   hex.EncodeToString(blkHash[:]),
+	})
+	require.NoError(err)
+	require.EqualValues(3, res.Height)
+	require.Equal(hex.EncodeToString(blkHash[:]), res.Hash)
+	require.Len(res.Receipts, 2)
+	require.EqualValues(100, res.Receipts[0].CumulativeGasUsed)
+	require.EqualValues(150, res.Receipts[1].CumulativeGasUsed)
+	r := res.Receipts[1].Receipt
+	require.Equal(hash2[:], r.ActHash)
+	require.EqualValues(1, r.TxIndex)
+	require.Equal("1", r.EffectiveGasPrice)
+	require.EqualValues(1, r.Logs[0].Index)
+}
+
+func TestExtensionService_ProjectDelegateReward(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	core := NewMockCoreService(ctrl)
+	service := newExtensionService(core)
+	ctx := context.Background()
+
+	_, err := service.ProjectDelegateReward(ctx, &extensionpb.ProjectDelegateRewardRequest{Candidate: "cand", Amount: "invalid", Epochs: 1})
+	require.Equal(codes.InvalidArgument, status.Code(err))
+
+	amount := big.NewInt(1000)
+	core.EXPECT().ProjectDelegateReward(gomock.Any(), "cand", amount, uint32(91), true, uint64(2), uint32(90)).Return(&apitypes.DelegateRewardProjection{
+		BucketVotes: big.NewInt(1200),
+		Epochs: []*apitypes.EpochRewardProjection{
+			{
+				EpochNumber:     3,
+				CandidateVotes:  big.NewInt(12000),
+				EpochReward:     big.NewInt(100),
+				FoundationBonus: big.NewInt(20),
+				BucketReward:    big.NewInt(12),
+			},
+			{
+				EpochNumber:     4,
+				CandidateVotes:  big.NewInt(12000),
+				Probation:       true,
+				EpochReward:     big.NewInt(0),
+				FoundationBonus: big.NewInt(20),
+				BucketReward:    big.NewInt(2),
+			},
+		},
+	}, nil)
+	res, err := service.ProjectDelegateReward(ctx, &extensionpb.ProjectDelegateRewardRequest{
+		Candidate:         "cand",
+		Amount:            "1000",
+		Duration:          91,
+		AutoStake:         true,
+		Epochs:            2,
+		DistributionRatio: 90,
+	})
+	require.NoError(err)
+	require.Equal("1200", res.BucketVotes)
+	require.Len(res.Epochs, 2)
+	require.EqualValues(3, res.Epochs[0].EpochNumber)
+	require.Equal("12000", res.Epochs[0].CandidateVotes)
+	require.False(res.Epochs[0].Probation)
+	require.Equal("100", res.Epochs[0].EpochReward)
+	require.Equal("20", res.Epochs[0].FoundationBonus)
+	require.Equal("12", res.Epochs[0].BucketReward)
+	require.True(res.Epochs[1].Probation)
+	require.Equal("0", res.Epochs[1].EpochReward)
+	require.Equal("2", res.Epochs[1].BucketReward)
+}
@@ -0,0 +1,1310 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package api
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/graph-gophers/graphql-go"
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/iotexproject/iotex-proto/golang/iotexapi"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/iotexproject/iotex-core/v2/action"
+	"github.com/iotexproject/iotex-core/v2/api/logfilter"
+	"github.com/iotexproject/iotex-core/v2/blockchain/block"
+)
+
+const (
+	// _graphQLMaxDepth is the max depth of the nested fields of a query
+	_graphQLMaxDepth = 10
+	// _graphQLDefaultLimit is the number of the staking entities returned if the limit is not supplied
+	_graphQLDefaultLimit = 100
+)
+
+type (
+	// graphQLHandler serves the graphql queries over http
+	graphQLHandler struct {
+		core    CoreService
+		cfg     GraphQLConfig
+		schema  *graphql.Schema
+		limiter *RateLimiter
+	}
+
+	// gqlQueryCost is the cost of the expensive fields resolved in a query, which is taken from the quota of the
+	// client as well
+	gqlQueryCost struct {
+		limiter   *RateLimiter
+		client    ClientInfo
+		remaining atomic.Int64
+		limited   bool
+	}
+
+	gqlQueryCostContextKey struct{}
+
+	graphQLRequest struct {
+		Query         string                 `json:"query"`
+		OperationName string                 `json:"operationName"`
+		Variables     map[string]interface{} `json:"variables"`
+	}
+
+	// gqlResolver resolves the root query
+	gqlResolver struct {
+		core         CoreService
+		logsPageSize uint64
+	}
+
+	// gqlState reads the states at a past block if archive, otherwise at the tip
+	gqlState struct {
+		core    CoreService
+		height  uint64
+		archive bool
+	}
+
+	gqlAccount struct {
+		gqlState
+		addr address.Address
+	}
+
+	gqlBlock struct {
+		core     CoreService
+		blk      *block.Block
+		receipts []*action.Receipt
+	}
+
+	gqlTransaction struct {
+		core    CoreService
+		selp    *action.SealedEnvelope
+		ethTx   *types.Transaction
+		receipt *action.Receipt
+		blk     *block.Block
+	}
+
+	gqlLog struct {
+		core CoreService
+		log  *action.Log
+	}
+
+	gqlCallResult struct {
+		data    []byte
+		gasUsed uint64
+		status  uint64
+	}
+
+	gqlSyncState struct {
+		start, curr, highest uint64
+	}
+
+	gqlCandidate struct {
+		gqlState
+		cand *iotextypes.CandidateV2
+	}
+
+	gqlBucket struct {
+		bucket *iotextypes.VoteBucket
+	}
+
+	gqlBlockProducer struct {
+		bp *iotexapi.BlockProducerInfo
+	}
+
+	gqlEpoch struct {
+		epoch     *iotextypes.EpochData
+		numBlocks uint64
+		producers []*iotexapi.BlockProducerInfo
+	}
+
+	gqlRewardPool struct {
+		gqlState
+	}
+
+	gqlCallData struct {
+		From  *common.Address
+		To    *common.Address
+		Gas   *hexutil.Uint64
+		Value *hexutil.Big
+		Data  *hexutil.Bytes
+	}
+
+	gqlFilterCriteria struct {
+		FromBlock *hexutil.Uint64
+		ToBlock   *hexutil.Uint64
+		Addresses *[]common.Address
+		Topics    *[][]common.Hash
+	}
+
+	gqlBlockFilterCriteria struct {
+		Addresses *[]common.Address
+		Topics    *[][]common.Hash
+	}
+
+	gqlPagination struct {
+		Offset *int32
+		Limit  *int32
+	}
+)
+
+var errGQLQueryTooComplex = status.Error(codes.ResourceExhausted, "graphql query is too complex")
+
+// NewGraphQLHandler creates a handler serving the graphql queries upon the core service
+func NewGraphQLHandler(core CoreService, cfg GraphQLConfig, limiter *RateLimiter) (http.Handler, error) {
+	schema, err := graphql.ParseSchema(_graphQLSchema, &gqlResolver{
+		core:         core,
+		logsPageSize: cfg.LogsPageSize,
+	}, graphql.MaxDepth(_graphQLMaxDepth))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse graphql schema")
+	}
+	return &graphQLHandler{
+		core:    core,
+		cfg:     cfg,
+		schema:  schema,
+		limiter: limiter,
+	}, nil
+}
+
+func (h *graphQLHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	var (
+		ctx   = withHTTPRequest(req.Context(), req)
+		start = time.Now()
+		in    graphQLRequest
+	)
+	if err := json.NewDecoder(req.Body).Decode(&in); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if h.cfg.MaxQuerySize > 0 && len(in.Query) > h.cfg.MaxQuerySize {
+		http.Error(w, errGQLQueryTooComplex.Error(), http.StatusBadRequest)
+		return
+	}
+	cost := &gqlQueryCost{
+		limiter: h.limiter,
+		limited: h.cfg.MaxQueryCost > 0,
+	}
+	cost.remaining.Store(int64(h.cfg.MaxQueryCost))
+	if h.limiter != nil {
+		cost.client = h.limiter.clientFromContext(ctx)
+		if err := h.limiter.Allow(cost.client, "graphql", h.limiter.Cost("graphql", 0)); err != nil {
+			http.Error(w, err.Error(), http.StatusTooManyRequests)
+			return
+		}
+	}
+	ctx = context.WithValue(ctx, gqlQueryCostContextKey{}, cost)
+	res := h.schema.Exec(ctx, in.Query, in.OperationName, in.Variables)
+	data, err := json.Marshal(res)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if _, err := w.Write(data); err != nil {
+		return
+	}
+	h.core.Track(ctx, start, "graphql", int64(len(data)), len(res.Errors) == 0)
+}
+
+// chargeGQLField takes the cost of an expensive field of the method from the quota of the query and the client,
+// n is the number of the entities resolved
+func chargeGQLField(ctx context.Context, method string, logsRange uint64, n int) error {
+	cost, ok := ctx.Value(gqlQueryCostContextKey{}).(*gqlQueryCost)
+	if !ok {
+		return nil
+	}
+	units := n
+	if cost.limiter != nil {
+		units *= cost.limiter.Cost(method, logsRange)
+	}
+	if cost.limited && cost.remaining.Add(-int64(units)) < 0 {
+		return errGQLQueryTooComplex
+	}
+	return cost.limiter.Allow(cost.client, method, units)
+}
+
+func (r *gqlResolver) state(blk *hexutil.Uint64) gqlState {
+	return newGQLState(r.core, blk)
+}
+
+func newGQLState(core CoreService, blk *hexutil.Uint64) gqlState {
+	if blk == nil || uint64(*blk) >= core.TipHeight() {
+		return gqlState{core: core}
+	}
+	return gqlState{core: core, height: uint64(*blk), archive: true}
+}
+
+// Block returns the block by number or by hash, or the latest block
+func (r *gqlResolver) Block(ctx context.Context, args struct {
+	Number *hexutil.Uint64
+	Hash   *common.Hash
+}) (*gqlBlock, error) {
+	if err := chargeGQLField(ctx, "eth_getBlockByNumber", 0, 1); err != nil {
+		return nil, err
+	}
+	var (
+		blk *gqlBlock
+		err error
+	)
+	switch {
+	case args.Hash != nil:
+		blk, err = gqlBlockByHash(r.core, *args.Hash)
+	case args.Number != nil:
+		blk, err = gqlBlockByHeight(r.core, uint64(*args.Number))
+	default:
+		blk, err = gqlBlockByHeight(r.core, r.core.TipHeight())
+	}
+	if errors.Cause(err) == ErrNotFound {
+		return nil, nil
+	}
+	return blk, err
+}
+
+// Blocks returns the blocks in the range
+func (r *gqlResolver) Blocks(ctx context.Context, args struct {
+	From hexutil.Uint64
+	To   *hexutil.Uint64
+}) ([]*gqlBlock, error) {
+	to := r.core.TipHeight()
+	if args.To != nil && uint64(*args.To) < to {
+		to = uint64(*args.To)
+	}
+	from := uint64(args.From)
+	if from > to {
+		return []*gqlBlock{}, nil
+	}
+	if err := chargeGQLField(ctx, "eth_getBlockByNumber", 0, int(to-from+1)); err != nil {
+		return nil, err
+	}
+	blks, err := r.core.BlockByHeightRange(from, to-from+1)
+	if err != nil {
+		return nil, err
+	}
+	ret := make([]*gqlBlock, 0, len(blks))
+	for _, blk := range blks {
+		ret = append(ret, &gqlBlock{r.core, blk.Block, blk.Receipts})
+	}
+	return ret, nil
+}
+
+// Transaction returns the confirmed or pending transaction by hash
+func (r *gqlResolver) Transaction(ctx context.Context, args struct{ Hash common.Hash }) (*gqlTransaction, error) {
+	if err := chargeGQLField(ctx, "eth_getTransactionByHash", 0, 1); err != nil {
+		return nil, err
+	}
+	tx, err := gqlTransactionByHash(r.core, hash.Hash256(args.Hash))
+	if errors.Cause(err) == ErrNotFound {
+		return nil, nil
+	}
+	return tx, err
+}
+
+// Logs returns the logs in the range matching the filter
+func (r *gqlResolver) Logs(ctx context.Context, args struct{ Filter gqlFilterCriteria }) ([]*gqlLog, error) {
+	from, to := r.core.TipHeight(), r.core.TipHeight()
+	if args.Filter.FromBlock != nil {
+		from = uint64(*args.Filter.FromBlock)
+	}
+	if args.Filter.ToBlock != nil {
+		to = uint64(*args.Filter.ToBlock)
+	}
+	var logsRange uint64
+	if to >= from {
+		logsRange = to - from + 1
+	}
+	if err := chargeGQLField(ctx, "eth_getLogs", logsRange, 1); err != nil {
+		return nil, err
+	}
+	filter, err := newGQLLogFilter(args.Filter.Addresses, args.Filter.Topics)
+	if err != nil {
+		return nil, err
+	}
+	logs, _, err := r.core.LogsInRange(filter, from, to, r.logsPageSize)
+	if err != nil {
+		return nil, err
+	}
+	return newGQLLogs(r.core, logs), nil
+}
+
+// Account returns the account at the block
+func (r *gqlResolver) Account(args struct {
+	Address common.Address
+	Block   *hexutil.Uint64
+}) (*gqlAccount, error) {
+	addr, err := address.FromBytes(args.Address.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	return &gqlAccount{r.state(args.Block), addr}, nil
+}
+
+// Call executes the call at the block
+func (r *gqlResolver) Call(ctx context.Context, args struct {
+	Data  gqlCallData
+	Block *hexutil.Uint64
+}) (*gqlCallResult, error) {
+	if err := chargeGQLField(ctx, "eth_call", 0, 1); err != nil {
+		return nil, err
+	}
+	return r.state(args.Block).call(ctx, &args.Data)
+}
+
+// EstimateGas estimates the gas of the transaction at the latest block
+func (r *gqlResolver) EstimateGas(ctx context.Context, args struct{ Data gqlCallData }) (hexutil.Uint64, error) {
+	if err := chargeGQLField(ctx, "eth_estimateGas", 0, 1); err != nil {
+		return 0, err
+	}
+	return r.state(nil).estimateGas(ctx, &args.Data)
+}
+
+// GasPrice returns the suggested gas price
+func (r *gqlResolver) GasPrice() (hexutil.Big, error) {
+	price, err := r.core.SuggestGasPrice()
+	if err != nil {
+		return hexutil.Big{}, err
+	}
+	return hexutil.Big(*new(big.Int).SetUint64(price)), nil
+}
+
+// ChainID returns the chain id of the transactions
+func (r *gqlResolver) ChainID() hexutil.Big {
+	return hexutil.Big(*big.NewInt(int64(r.core.EVMNetworkID())))
+}
+
+// Syncing returns the synchronization state, nil if the node is synced
+func (r *gqlResolver) Syncing() *gqlSyncState {
+	start, curr, highest := r.core.SyncingProgress()
+	if curr >= highest {
+		return nil
+	}
+	return &gqlSyncState{start, curr, highest}
+}
+
+// Candidates returns the staking candidates at the block
+func (r *gqlResolver) Candidates(args struct {
+	Offset *int32
+	Limit  *int32
+	Block  *hexutil.Uint64
+}) ([]*gqlCandidate, error) {
+	s := r.state(args.Block)
+	var cands iotextypes.CandidateListV2
+	if err := s.readStaking(iotexapi.ReadStakingDataMethod_CANDIDATES, &iotexapi.ReadStakingDataRequest{
+		Request: &iotexapi.ReadStakingDataRequest_Candidates_{
+			Candidates: &iotexapi.ReadStakingDataRequest_Candidates{
+				Pagination: (&gqlPagination{args.Offset, args.Limit}).param(),
+			},
+		},
+	}, &cands); err != nil {
+		return nil, err
+	}
+	ret := make([]*gqlCandidate, 0, len(cands.Candidates))
+	for _, cand := range cands.Candidates {
+		ret = append(ret, &gqlCandidate{s, cand})
+	}
+	return ret, nil
+}
+
+// Candidate returns the staking candidate by name
+func (r *gqlResolver) Candidate(args struct {
+	Name  string
+	Block *hexutil.Uint64
+}) (*gqlCandidate, error) {
+	s := r.state(args.Block)
+	var cand iotextypes.CandidateV2
+	if err := s.readStaking(iotexapi.ReadStakingDataMethod_CANDIDATE_BY_NAME, &iotexapi.ReadStakingDataRequest{
+		Request: &iotexapi.ReadStakingDataRequest_CandidateByName_{
+			CandidateByName: &iotexapi.ReadStakingDataRequest_CandidateByName{CandName: args.Name},
+		},
+	}, &cand); err != nil {
+		return nil, err
+	}
+	if cand.Name == "" {
+		return nil, nil
+	}
+	return &gqlCandidate{s, &cand}, nil
+}
+
+// Buckets returns the staking buckets of the indexes, or all the buckets
+func (r *gqlResolver) Buckets(args struct {
+	Indexes *[]hexutil.Uint64
+	Offset  *int32
+	Limit   *int32
+	Block   *hexutil.Uint64
+}) ([]*gqlBucket, error) {
+	s := r.state(args.Block)
+	if args.Indexes == nil {
+		return s.buckets(iotexapi.ReadStakingDataMethod_COMPOSITE_BUCKETS, &iotexapi.ReadStakingDataRequest{
+			Request: &iotexapi.ReadStakingDataRequest_Buckets{
+				Buckets: &iotexapi.ReadStakingDataRequest_VoteBuckets{
+					Pagination: (&gqlPagination{args.Offset, args.Limit}).param(),
+				},
+			},
+		})
+	}
+	indexes := make([]uint64, 0, len(*args.Indexes))
+	for _, index := range *args.Indexes {
+		indexes = append(indexes, uint64(index))
+	}
+	return s.buckets(iotexapi.ReadStakingDataMethod_COMPOSITE_BUCKETS_BY_INDEXES, &iotexapi.ReadStakingDataRequest{
+		Request: &iotexapi.ReadStakingDataRequest_BucketsByIndexes{
+			BucketsByIndexes: &iotexapi.ReadStakingDataRequest_VoteBucketsByIndexes{Index: indexes},
+		},
+	})
+}
+
+// Epoch returns the epoch by number, or the current epoch
+func (r *gqlResolver) Epoch(args struct{ Number *hexutil.Uint64 }) (*gqlEpoch, error) {
+	var num uint64
+	if args.Number != nil {
+		num = uint64(*args.Number)
+	} else {
+		meta, _, err := r.core.ChainMeta()
+		if err != nil {
+			return nil, err
+		}
+		num = meta.GetEpoch().GetNum()
+	}
+	epoch, numBlks, producers, err := r.core.EpochMeta(num)
+	if err != nil {
+		return nil, err
+	}
+	if epoch == nil {
+		return nil, nil
+	}
+	return &gqlEpoch{epoch, numBlks, producers}, nil
+}
+
+// RewardPool returns the reward pool at the block
+func (r *gqlResolver) RewardPool(args struct{ Block *hexutil.Uint64 }) *gqlRewardPool {
+	return &gqlRewardPool{r.state(args.Block)}
+}
+
+func (s gqlState) account(addr address.Address) (*iotextypes.AccountMeta, error) {
+	var (
+		meta *iotextypes.AccountMeta
+		err  error
+	)
+	if s.archive {
+		meta, _, err = s.core.WithHeight(s.height).Account(addr)
+	} else {
+		meta, _, err = s.core.Account(addr)
+	}
+	return meta, err
+}
+
+func (s gqlState) pendingNonce(addr address.Address) (uint64, error) {
+	if s.archive {
+		return s.core.WithHeight(s.height).PendingNonce(addr)
+	}
+	return s.core.PendingNonce(addr)
+}
+
+func (s gqlState) storage(ctx context.Context, addr address.Address, key []byte) ([]byte, error) {
+	if s.archive {
+		return s.core.WithHeight(s.height).ReadContractStorage(ctx, addr, key)
+	}
+	return s.core.ReadContractStorage(ctx, addr, key)
+}
+
+func (s gqlState) readState(protocolID string, method []byte, args [][]byte) (*iotexapi.ReadStateResponse, error) {
+	if s.archive {
+		return s.core.WithHeight(s.height).ReadState(protocolID, method, args)
+	}
+	return s.core.ReadState(protocolID, "", method, args)
+}
+
+func (s gqlState) readStaking(method iotexapi.ReadStakingDataMethod_Name, req *iotexapi.ReadStakingDataRequest, res proto.Message) error {
+	methodData, err := proto.Marshal(&iotexapi.ReadStakingDataMethod{Method: method})
+	if err != nil {
+		return err
+	}
+	arg, err := proto.Marshal(req)
+	if err != nil {
+		return err
+	}
+	out, err := s.readState("staking", methodData, [][]byte{arg})
+	if err != nil {
+		return err
+	}
+	return proto.Unmarshal(out.GetData(), res)
+}
+
+func (s gqlState) buckets(method iotexapi.ReadStakingDataMethod_Name, req *iotexapi.ReadStakingDataRequest) ([]*gqlBucket, error) {
+	var buckets iotextypes.VoteBucketList
+	if err := s.readStaking(method, req, &buckets); err != nil {
+		return nil, err
+	}
+	ret := make([]*gqlBucket, 0, len(buckets.Buckets))
+	for _, bucket := range buckets.Buckets {
+		ret = append(ret, &gqlBucket{bucket})
+	}
+	return ret, nil
+}
+
+func (s gqlState) readBalance(protocolID string, method string, args ...[]byte) (hexutil.Big, error) {
+	out, err := s.readState(protocolID, []byte(method), args)
+	if err != nil {
+		return hexutil.Big{}, err
+	}
+	return parseGQLBigInt(string(out.GetData()))
+}
+
+func (s gqlState) call(ctx context.Context, data *gqlCallData) (*gqlCallResult, error) {
+	from, elp, err := data.envelope()
+	if err != nil {
+		return nil, err
+	}
+	var (
+		ret     string
+		receipt *iotextypes.Receipt
+	)
+	if s.archive {
+		ret, receipt, err = s.core.WithHeight(s.height).ReadContract(ctx, from, elp)
+	} else {
+		ret, receipt, err = s.core.ReadContract(ctx, from, elp)
+	}
+	if err != nil {
+		return nil, err
+	}
+	retval, err := hex.DecodeString(ret)
+	if err != nil {
+		return nil, err
+	}
+	res := &gqlCallResult{data: retval, gasUsed: receipt.GetGasConsumed()}
+	if receipt.GetStatus() == uint64(iotextypes.ReceiptStatus_Success) {
+		res.status = 1
+	}
+	return res, nil
+}
+
+func (s gqlState) estimateGas(ctx context.Context, data *gqlCallData) (hexutil.Uint64, error) {
+	from, elp, err := data.envelope()
+	if err != nil {
+		return 0, err
+	}
+	var gas uint64
+	if s.archive {
+		gas, _, err = s.core.WithHeight(s.height).EstimateExecutionGasConsumption(ctx, elp, from)
+	} else {
+		gas, _, err = s.core.EstimateExecutionGasConsumption(ctx, elp, from)
+	}
+	if err != nil {
+		return 0, err
+	}
+	return hexutil.Uint64(gas), nil
+}
+
+// envelope returns the caller and the execution of the call data
+func (data *gqlCallData) envelope() (address.Address, action.Envelope, error) {
+	var (
+		from     = common.Address{}
+		to       string
+		gasLimit uint64
+		value    = big.NewInt(0)
+		input    []byte
+	)
+	if data.From != nil {
+		from = *data.From
+	}
+	caller, err := address.FromBytes(from.Bytes())
+	if err != nil {
+		return nil, nil, err
+	}
+	if data.To != nil {
+		contract, err := address.FromBytes(data.To.Bytes())
+		if err != nil {
+			return nil, nil, err
+		}
+		to = contract.String()
+	}
+	if data.Gas != nil {
+		gasLimit = uint64(*data.Gas)
+	}
+	if data.Value != nil {
+		value = data.Value.ToInt()
+	}
+	if data.Data != nil {
+		input = *data.Data
+	}
+	elp := (&action.EnvelopeBuilder{}).SetAction(action.NewExecution(to, value, input)).
+		SetGasLimit(gasLimit).Build()
+	return caller, elp, nil
+}
+
+func (p *gqlPagination) param() *iotexapi.PaginationParam {
+	param := &iotexapi.PaginationParam{Limit: _graphQLDefaultLimit}
+	if p.Offset != nil && *p.Offset > 0 {
+		param.Offset = uint32(*p.Offset)
+	}
+	if p.Limit != nil && *p.Limit > 0 {
+		param.Limit = uint32(*p.Limit)
+	}
+	return param
+}
+
+func gqlBlockByHeight(core CoreService, height uint64) (*gqlBlock, error) {
+	blk, err := core.BlockByHeight(height)
+	if err != nil {
+		return nil, err
+	}
+	return &gqlBlock{core, blk.Block, blk.Receipts}, nil
+}
+
+func gqlBlockByHash(core CoreService, h common.Hash) (*gqlBlock, error) {
+	blk, err := core.BlockByHash(hex.EncodeToString(h[:]))
+	if err != nil {
+		return nil, err
+	}
+	return &gqlBlock{core, blk.Block, blk.Receipts}, nil
+}
+
+func gqlTransactionByHash(core CoreService, actHash hash.Hash256) (*gqlTransaction, error) {
+	selp, blk, _, err := core.ActionByActionHash(actHash)
+	if err != nil {
+		if errors.Cause(err) != ErrNotFound {
+			return nil, err
+		}
+		if selp, err = core.PendingActionByActionHash(actHash); err != nil {
+			return nil, err
+		}
+		return newGQLTransaction(core, selp, nil, nil)
+	}
+	receipt, err := core.ReceiptByActionHash(actHash)
+	if err != nil {
+		return nil, err
+	}
+	return newGQLTransaction(core, selp, receipt, blk)
+}
+
+func newGQLTransaction(core CoreService, selp *action.SealedEnvelope, receipt *action.Receipt, blk *block.Block) (*gqlTransaction, error) {
+	var blkHash *hash.Hash256
+	if blk != nil {
+		h := blk.HashBlock()
+		blkHash = &h
+	}
+	tx, err := newGetTransactionResult(blkHash, selp, receipt, core.EVMNetworkID())
+	if err != nil {
+		return nil, err
+	}
+	return &gqlTransaction{
+		core:    core,
+		selp:    selp,
+		ethTx:   tx.ethTx,
+		receipt: receipt,
+		blk:     blk,
+	}, nil
+}
+
+func newGQLLogs(core CoreService, logs []*action.Log) []*gqlLog {
+	ret := make([]*gqlLog, 0, len(logs))
+	for _, l := range logs {
+		ret = append(ret, &gqlLog{core, l})
+	}
+	return ret
+}
+
+func newGQLLogFilter(addrs *[]common.Address, topics *[][]common.Hash) (*logfilter.LogFilter, error) {
+	filter := iotexapi.LogsFilter{}
+	if addrs != nil {
+		for _, addr := range *addrs {
+			ioAddr, err := address.FromBytes(addr.Bytes())
+			if err != nil {
+				return nil, err
+			}
+			filter.Address = append(filter.Address, ioAddr.String())
+		}
+	}
+	if topics != nil {
+		for _, tp := range *topics {
+			topic := make([][]byte, 0, len(tp))
+			for _, h := range tp {
+				topic = append(topic, h.Bytes())
+			}
+			filter.Topics = append(filter.Topics, &iotexapi.Topics{Topic: topic})
+		}
+	}
+	return logfilter.NewLogFilter(&filter), nil
+}
+
+func parseGQLBigInt(s string) (hexutil.Big, error) {
+	if s == "" {
+		return hexutil.Big{}, nil
+	}
+	n, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		return hexutil.Big{}, errors.Wrapf(errUnkownType, "int: %s", s)
+	}
+	return hexutil.Big(*n), nil
+}
+
+func gqlAddress(addr address.Address) common.Address {
+	return common.BytesToAddress(addr.Bytes())
+}
+
+func (a *gqlAccount) Address() common.Address {
+	return gqlAddress(a.addr)
+}
+
+func (a *gqlAccount) Balance() (hexutil.Big, error) {
+	meta, err := a.account(a.addr)
+	if err != nil {
+		return hexutil.Big{}, err
+	}
+	return parseGQLBigInt(meta.GetBalance())
+}
+
+func (a *gqlAccount) TransactionCount() (hexutil.Uint64, error) {
+	nonce, err := a.pendingNonce(a.addr)
+	if err != nil {
+		return 0, err
+	}
+	return hexutil.Uint64(nonce), nil
+}
+
+func (a *gqlAccount) Code() (hexutil.Bytes, error) {
+	meta, err := a.account(a.addr)
+	if err != nil {
+		return nil, err
+	}
+	return meta.GetContractByteCode(), nil
+}
+
+func (a *gqlAccount) Storage(ctx context.Context, args struct{ Slot common.Hash }) (common.Hash, error) {
+	val, err := a.storage(ctx, a.addr, args.Slot.Bytes())
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return common.BytesToHash(val), nil
+}
+
+func (a *gqlAccount) UnclaimedReward() (hexutil.Big, error) {
+	return a.readBalance("rewarding", "UnclaimedBalance", []byte(a.addr.String()))
+}
+
+func (a *gqlAccount) Buckets(args gqlPagination) ([]*gqlBucket, error) {
+	return a.buckets(iotexapi.ReadStakingDataMethod_COMPOSITE_BUCKETS_BY_VOTER, &iotexapi.ReadStakingDataRequest{
+		Request: &iotexapi.ReadStakingDataRequest_BucketsByVoter{
+			BucketsByVoter: &iotexapi.ReadStakingDataRequest_VoteBucketsByVoter{
+				VoterAddress: a.addr.String(),
+				Pagination:   args.param(),
+			},
+		},
+	})
+}
+
+func (b *gqlBlock) state() gqlState {
+	h := hexutil.Uint64(b.blk.Height())
+	return newGQLState(b.core, &h)
+}
+
+func (b *gqlBlock) Number() hexutil.Uint64 {
+	return hexutil.Uint64(b.blk.Height())
+}
+
+func (b *gqlBlock) Hash() common.Hash {
+	if b.blk.Height() == 0 {
+		return common.Hash(block.GenesisHash())
+	}
+	return common.Hash(b.blk.HashBlock())
+}
+
+func (b *gqlBlock) Parent(ctx context.Context) (*gqlBlock, error) {
+	if b.blk.Height() == 0 {
+		return nil, nil
+	}
+	if err := chargeGQLField(ctx, "eth_getBlockByNumber", 0, 1); err != nil {
+		return nil, err
+	}
+	return gqlBlockByHeight(b.core, b.blk.Height()-1)
+}
+
+func (b *gqlBlock) TransactionsRoot() common.Hash {
+	return common.Hash(b.blk.TxRoot())
+}
+
+func (b *gqlBlock) TransactionCount() hexutil.Uint64 {
+	return hexutil.Uint64(len(b.blk.Actions))
+}
+
+func (b *gqlBlock) StateRoot() common.Hash {
+	return common.Hash(b.blk.DeltaStateDigest())
+}
+
+func (b *gqlBlock) ReceiptsRoot() common.Hash {
+	return common.Hash(b.blk.ReceiptRoot())
+}
+
+func (b *gqlBlock) Miner() (*gqlAccount, error) {
+	if b.blk.Height() == 0 {
+		return nil, nil
+	}
+	producer, err := address.FromString(b.blk.ProducerAddress())
+	if err != nil {
+		return nil, err
+	}
+	return &gqlAccount{b.state(), producer}, nil
+}
+
+func (b *gqlBlock) GasLimit() hexutil.Uint64 {
+	var gasLimit uint64
+	for _, selp := range b.blk.Actions {
+		gasLimit += selp.Gas()
+	}
+	return hexutil.Uint64(gasLimit)
+}
+
+func (b *gqlBlock) GasUsed() hexutil.Uint64 {
+	var gasUsed uint64
+	for _, r := range b.receipts {
+		gasUsed += r.GasConsumed
+	}
+	return hexutil.Uint64(gasUsed)
+}
+
+func (b *gqlBlock) BaseFeePerGas() *hexutil.Big {
+	if b.blk.BaseFee() == nil {
+		return nil
+	}
+	return (*hexutil.Big)(b.blk.BaseFee())
+}
+
+func (b *gqlBlock) Timestamp() hexutil.Uint64 {
+	return hexutil.Uint64(b.blk.Timestamp().Unix())
+}
+
+func (b *gqlBlock) LogsBloom() hexutil.Bytes {
+	if bloom := b.blk.LogsBloomfilter(); bloom != nil {
+		return bloom.Bytes()
+	}
+	return make([]byte, types.BloomByteLength)
+}
+
+func (b *gqlBlock) Transactions() ([]*gqlTransaction, error) {
+	ret := make([]*gqlTransaction, 0, len(b.blk.Actions))
+	for i := range b.blk.Actions {
+		tx, err := b.transactionAt(i)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, tx)
+	}
+	return ret, nil
+}
+
+func (b *gqlBlock) TransactionAt(args struct{ Index hexutil.Uint64 }) (*gqlTransaction, error) {
+	if uint64(args.Index) >= uint64(len(b.blk.Actions)) {
+		return nil, nil
+	}
+	return b.transactionAt(int(args.Index))
+}
+
+func (b *gqlBlock) transactionAt(i int) (*gqlTransaction, error) {
+	if i >= len(b.receipts) {
+		return nil, errors.Errorf("missing the receipt of action %d in block %d", i, b.blk.Height())
+	}
+	return newGQLTransaction(b.core, b.blk.Actions[i], b.receipts[i], b.blk)
+}
+
+func (b *gqlBlock) Logs(args struct{ Filter gqlBlockFilterCriteria }) ([]*gqlLog, error) {
+	filter, err := newGQLLogFilter(args.Filter.Addresses, args.Filter.Topics)
+	if err != nil {
+		return nil, err
+	}
+	return newGQLLogs(b.core, filter.MatchLogs(b.receipts)), nil
+}
+
+func (b *gqlBlock) Account(args struct{ Address common.Address }) (*gqlAccount, error) {
+	addr, err := address.FromBytes(args.Address.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	return &gqlAccount{b.state(), addr}, nil
+}
+
+func (b *gqlBlock) Call(ctx context.Context, args struct{ Data gqlCallData }) (*gqlCallResult, error) {
+	if err := chargeGQLField(ctx, "eth_call", 0, 1); err != nil {
+		return nil, err
+	}
+	return b.state().call(ctx, &args.Data)
+}
+
+func (b *gqlBlock) EstimateGas(ctx context.Context, args struct{ Data gqlCallData }) (hexutil.Uint64, error) {
+	if err := chargeGQLField(ctx, "eth_estimateGas", 0, 1); err != nil {
+		return 0, err
+	}
+	return b.state().estimateGas(ctx, &args.Data)
+}
+
+func (t *gqlTransaction) latest(addr address.Address) *gqlAccount {
+	return &gqlAccount{gqlState{core: t.core}, addr}
+}
+
+func (t *gqlTransaction) Hash() (common.Hash, error) {
+	if t.receipt != nil {
+		return common.Hash(t.receipt.ActionHash), nil
+	}
+	h, err := t.selp.Hash()
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return common.Hash(h), nil
+}
+
+func (t *gqlTransaction) Nonce() hexutil.Uint64 {
+	return hexutil.Uint64(t.selp.Nonce())
+}
+
+func (t *gqlTransaction) Index() *hexutil.Uint64 {
+	if t.receipt == nil {
+		return nil
+	}
+	index := hexutil.Uint64(t.receipt.TxIndex)
+	return &index
+}
+
+func (t *gqlTransaction) From() *gqlAccount {
+	return t.latest(t.selp.SenderAddress())
+}
+
+func (t *gqlTransaction) To() (*gqlAccount, error) {
+	if t.ethTx.To() == nil {
+		return nil, nil
+	}
+	to, err := address.FromBytes(t.ethTx.To().Bytes())
+	if err != nil {
+		return nil, err
+	}
+	return t.latest(to), nil
+}
+
+func (t *gqlTransaction) Value() hexutil.Big {
+	return hexutil.Big(*t.ethTx.Value())
+}
+
+func (t *gqlTransaction) GasPrice() hexutil.Big {
+	return hexutil.Big(*t.ethTx.GasPrice())
+}
+
+func (t *gqlTransaction) MaxFeePerGas() *hexutil.Big {
+	if t.ethTx.Type() != types.DynamicFeeTxType && t.ethTx.Type() != types.BlobTxType {
+		return nil
+	}
+	return (*hexutil.Big)(t.ethTx.GasFeeCap())
+}
+
+func (t *gqlTransaction) MaxPriorityFeePerGas() *hexutil.Big {
+	if t.ethTx.Type() != types.DynamicFeeTxType && t.ethTx.Type() != types.BlobTxType {
+		return nil
+	}
+	return (*hexutil.Big)(t.ethTx.GasTipCap())
+}
+
+func (t *gqlTransaction) Gas() hexutil.Uint64 {
+	return hexutil.Uint64(t.ethTx.Gas())
+}
+
+func (t *gqlTransaction) InputData() hexutil.Bytes {
+	return t.ethTx.Data()
+}
+
+func (t *gqlTransaction) Block(ctx context.Context) (*gqlBlock, error) {
+	if t.blk == nil {
+		return nil, nil
+	}
+	if err := chargeGQLField(ctx, "eth_getBlockByNumber", 0, 1); err != nil {
+		return nil, err
+	}
+	return gqlBlockByHeight(t.core, t.blk.Height())
+}
+
+func (t *gqlTransaction) Status() *hexutil.Uint64 {
+	if t.receipt == nil {
+		return nil
+	}
+	var status hexutil.Uint64
+	if t.receipt.Status == uint64(iotextypes.ReceiptStatus_Success) {
+		status = 1
+	}
+	return &status
+}
+
+func (t *gqlTransaction) GasUsed() *hexutil.Uint64 {
+	if t.receipt == nil {
+		return nil
+	}
+	gasUsed := hexutil.Uint64(t.receipt.GasConsumed)
+	return &gasUsed
+}
+
+func (t *gqlTransaction) EffectiveGasPrice() *hexutil.Big {
+	if t.receipt == nil {
+		return nil
+	}
+	if t.receipt.EffectiveGasPrice != nil {
+		return (*hexutil.Big)(t.receipt.EffectiveGasPrice)
+	}
+	return (*hexutil.Big)(t.ethTx.GasPrice())
+}
+
+func (t *gqlTransaction) CreatedContract() (*gqlAccount, error) {
+	if t.receipt == nil || t.receipt.ContractAddress == "" || t.ethTx.To() != nil {
+		return nil, nil
+	}
+	contract, err := address.FromString(t.receipt.ContractAddress)
+	if err != nil {
+		return nil, err
+	}
+	return t.latest(contract), nil
+}
+
+func (t *gqlTransaction) Logs() *[]*gqlLog {
+	if t.receipt == nil {
+		return nil
+	}
+	logs := newGQLLogs(t.core, t.receipt.Logs())
+	return &logs
+}
+
+func (t *gqlTransaction) R() hexutil.Big {
+	_, r, _ := t.ethTx.RawSignatureValues()
+	return hexutil.Big(*r)
+}
+
+func (t *gqlTransaction) S() hexutil.Big {
+	_, _, s := t.ethTx.RawSignatureValues()
+	return hexutil.Big(*s)
+}
+
+func (t *gqlTransaction) V() hexutil.Big {
+	v, _, _ := t.ethTx.RawSignatureValues()
+	return hexutil.Big(*v)
+}
+
+func (t *gqlTransaction) Type() hexutil.Uint64 {
+	return hexutil.Uint64(t.ethTx.Type())
+}
+
+func (l *gqlLog) Index() hexutil.Uint64 {
+	return hexutil.Uint64(l.log.Index)
+}
+
+func (l *gqlLog) Account() (*gqlAccount, error) {
+	addr, err := address.FromString(l.log.Address)
+	if err != nil {
+		return nil, err
+	}
+	return &gqlAccount{gqlState{core: l.core}, addr}, nil
+}
+
+func (l *gqlLog) Topics() []common.Hash {
+	topics := make([]common.Hash, 0, len(l.log.Topics))
+	for _, topic := range l.log.Topics {
+		topics = append(topics, common.Hash(topic))
+	}
+	return topics
+}
+
+func (l *gqlLog) Data() hexutil.Bytes {
+	return l.log.Data
+}
+
+func (l *gqlLog) Transaction() (*gqlTransaction, error) {
+	return gqlTransactionByHash(l.core, l.log.ActionHash)
+}
+
+func (c *gqlCallResult) Data() hexutil.Bytes {
+	return c.data
+}
+
+func (c *gqlCallResult) GasUsed() hexutil.Uint64 {
+	return hexutil.Uint64(c.gasUsed)
+}
+
+func (c *gqlCallResult) Status() hexutil.Uint64 {
+	return hexutil.Uint64(c.status)
+}
+
+func (s *gqlSyncState) StartingBlock() hexutil.Uint64 {
+	return hexutil.Uint64(s.start)
+}
+
+func (s *gqlSyncState) CurrentBlock() hexutil.Uint64 {
+	return hexutil.Uint64(s.curr)
+}
+
+func (s *gqlSyncState) HighestBlock() hexutil.Uint64 {
+	return hexutil.Uint64(s.highest)
+}
+
+func (c *gqlCandidate) ID() string {
+	return c.cand.GetId()
+}
+
+func (c *gqlCandidate) Name() string {
+	return c.cand.GetName()
+}
+
+func (c *gqlCandidate) Owner() string {
+	return c.cand.GetOwnerAddress()
+}
+
+func (c *gqlCandidate) Operator() string {
+	return c.cand.GetOperatorAddress()
+}
+
+func (c *gqlCandidate) Reward() string {
+	return c.cand.GetRewardAddress()
+}
+
+func (c *gqlCandidate) TotalWeightedVotes() (hexutil.Big, error) {
+	return parseGQLBigInt(c.cand.GetTotalWeightedVotes())
+}
+
+func (c *gqlCandidate) SelfStakeBucketIndex() hexutil.Uint64 {
+	return hexutil.Uint64(c.cand.GetSelfStakeBucketIdx())
+}
+
+func (c *gqlCandidate) SelfStakingTokens() (hexutil.Big, error) {
+	return parseGQLBigInt(c.cand.GetSelfStakingTokens())
+}
+
+func (c *gqlCandidate) Buckets(args gqlPagination) ([]*gqlBucket, error) {
+	return c.buckets(iotexapi.ReadStakingDataMethod_COMPOSITE_BUCKETS_BY_CANDIDATE, &iotexapi.ReadStakingDataRequest{
+		Request: &iotexapi.ReadStakingDataRequest_BucketsByCandidate{
+			BucketsByCandidate: &iotexapi.ReadStakingDataRequest_VoteBucketsByCandidate{
+				CandName:   c.cand.GetName(),
+				Pagination: args.param(),
+			},
+		},
+	})
+}
+
+func (b *gqlBucket) Index() hexutil.Uint64 {
+	return hexutil.Uint64(b.bucket.GetIndex())
+}
+
+func (b *gqlBucket) Candidate() string {
+	return b.bucket.GetCandidateAddress()
+}
+
+func (b *gqlBucket) Owner() string {
+	return b.bucket.GetOwner()
+}
+
+func (b *gqlBucket) ContractAddress() *string {
+	if b.bucket.GetContractAddress() == "" {
+		return nil
+	}
+	contract := b.bucket.GetContractAddress()
+	return &contract
+}
+
+func (b *gqlBucket) StakedAmount() (hexutil.Big, error) {
+	return parseGQLBigInt(b.bucket.GetStakedAmount())
+}
+
+func (b *gqlBucket) StakedDuration() hexutil.Uint64 {
+	return hexutil.Uint64(b.bucket.GetStakedDuration())
+}
+
+func (b *gqlBucket) StakedDurationBlockNumber() hexutil.Uint64 {
+	return hexutil.Uint64(b.bucket.GetStakedDurationBlockNumber())
+}
+
+func (b *gqlBucket) AutoStake() bool {
+	return b.bucket.GetAutoStake()
+}
+
+func (b *gqlBucket) CreateTime() hexutil.Uint64 {
+	return hexutil.Uint64(b.bucket.GetCreateTime().GetSeconds())
+}
+
+func (b *gqlBucket) StakeStartTime() hexutil.Uint64 {
+	return hexutil.Uint64(b.bucket.GetStakeStartTime().GetSeconds())
+}
+
+func (b *gqlBucket) UnstakeStartTime() hexutil.Uint64 {
+	return hexutil.Uint64(b.bucket.GetUnstakeStartTime().GetSeconds())
+}
+
+func (b *gqlBucket) CreateBlockHeight() hexutil.Uint64 {
+	return hexutil.Uint64(b.bucket.GetCreateBlockHeight())
+}
+
+func (b *gqlBucket) StakeStartBlockHeight() hexutil.Uint64 {
+	return hexutil.Uint64(b.bucket.GetStakeStartBlockHeight())
+}
+
+func (b *gqlBucket) UnstakeStartBlockHeight() hexutil.Uint64 {
+	return hexutil.Uint64(b.bucket.GetUnstakeStartBlockHeight())
+}
+
+func (b *gqlBucket) EndorsementExpireBlockHeight() hexutil.Uint64 {
+	return hexutil.Uint64(b.bucket.GetEndorsementExpireBlockHeight())
+}
+
+func (p *gqlBlockProducer) Address() string {
+	return p.bp.GetAddress()
+}
+
+func (p *gqlBlockProducer) Votes() (hexutil.Big, error) {
+	return parseGQLBigInt(p.bp.GetVotes())
+}
+
+func (p *gqlBlockProducer) Active() bool {
+	return p.bp.GetActive()
+}
+
+func (p *gqlBlockProducer) Production() hexutil.Uint64 {
+	return hexutil.Uint64(p.bp.GetProduction())
+}
+
+func (e *gqlEpoch) Number() hexutil.Uint64 {
+	return hexutil.Uint64(e.epoch.GetNum())
+}
+
+func (e *gqlEpoch) Height() hexutil.Uint64 {
+	return hexutil.Uint64(e.epoch.GetHeight())
+}
+
+func (e *gqlEpoch) GravityChainStartHeight() hexutil.Uint64 {
+	return hexutil.Uint64(e.epoch.GetGravityChainStartHeight())
+}
+
+func (e *gqlEpoch) BlockCount() hexutil.Uint64 {
+	return hexutil.Uint64(e.numBlocks)
+}
+
+func (e *gqlEpoch) BlockProducers() []*gqlBlockProducer {
+	ret := make([]*gqlBlockProducer, 0, len(e.producers))
+	for _, bp := range e.producers {
+		ret = append(ret, &gqlBlockProducer{bp})
+	}
+	return ret
+}
+
+func (p *gqlRewardPool) TotalBalance() (hexutil.Big, error) {
+	return p.readBalance("rewarding", "TotalBalance")
+}
+
+func (p *gqlRewardPool) AvailableBalance() (hexutil.Big, error) {
+	return p.readBalance("rewarding", "AvailableBalance")
+}
@@ -0,0 +1,294 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package api
+
+// _graphQLSchema follows the schema of EIP-1767, and extends it with the staking and rewarding entities of IoTeX
+const _graphQLSchema = `
+    # Bytes32 is a 32 byte binary string, represented as 0x-prefixed hexadecimal.
+    scalar Bytes32
+    # Address is a 20 byte address, represented as 0x-prefixed hexadecimal.
+    scalar Address
+    # Bytes is an arbitrary length binary string, represented as 0x-prefixed hexadecimal.
+    scalar Bytes
+    # BigInt is a large integer, input as an integer literal or a 0x-prefixed hexadecimal string,
+    # output as a 0x-prefixed hexadecimal string.
+    scalar BigInt
+    # Long is a 64 bit unsigned integer, input as an integer literal or a 0x-prefixed hexadecimal string,
+    # output as a 0x-prefixed hexadecimal string.
+    scalar Long
+
+    schema {
+        query: Query
+    }
+
+    # Account is an account at a particular block.
+    type Account {
+        # Address is the address owning the account.
+        address: Address!
+        # Balance is the balance of the account, in rau.
+        balance: BigInt!
+        # TransactionCount is the nonce of the account.
+        transactionCount: Long!
+        # Code is the code of the contract account.
+        code: Bytes!
+        # Storage is the value in the storage slot of the contract account.
+        storage(slot: Bytes32!): Bytes32!
+        # UnclaimedReward is the block rewards of the account not claimed yet, in rau.
+        unclaimedReward: BigInt!
+        # Buckets is the native and contract staking buckets owned by the account.
+        buckets(offset: Int, limit: Int): [Bucket!]!
+    }
+
+    # Log is an event log.
+    type Log {
+        # Index is the index of the log in the block.
+        index: Long!
+        # Account is the contract account which emits the log, at the latest block.
+        account: Account!
+        # Topics is a list of 0-4 indexed topics of the log.
+        topics: [Bytes32!]!
+        # Data is the unindexed data of the log.
+        data: Bytes!
+        # Transaction is the transaction which emits the log.
+        transaction: Transaction!
+    }
+
+    # Transaction is a transaction, or an IoTeX native action in the transaction format.
+    type Transaction {
+        # Hash is the hash of the transaction.
+        hash: Bytes32!
+        # Nonce is the nonce of the sender of the transaction.
+        nonce: Long!
+        # Index is the index of the transaction in the block, null if the transaction is pending.
+        index: Long
+        # From is the sender of the transaction, at the latest block.
+        from: Account!
+        # To is the recipient of the transaction at the latest block, null for contract creation.
+        to: Account
+        # Value is the value sent along with the transaction, in rau.
+        value: BigInt!
+        # GasPrice is the gas price of the transaction, in rau.
+        gasPrice: BigInt!
+        # MaxFeePerGas is the fee cap per gas of a dynamic fee transaction, in rau.
+        maxFeePerGas: BigInt
+        # MaxPriorityFeePerGas is the tip cap per gas of a dynamic fee transaction, in rau.
+        maxPriorityFeePerGas: BigInt
+        # Gas is the gas limit of the transaction.
+        gas: Long!
+        # InputData is the data supplied to the target of the transaction.
+        inputData: Bytes!
+        # Block is the block of the transaction, null if the transaction is pending.
+        block: Block
+        # Status is 1 if the transaction succeeded, 0 if it failed, null if it is pending.
+        status: Long
+        # GasUsed is the gas consumed by the transaction, null if it is pending.
+        gasUsed: Long
+        # EffectiveGasPrice is the gas price deducted from the sender, null if it is pending.
+        effectiveGasPrice: BigInt
+        # CreatedContract is the contract created by the transaction at the latest block.
+        createdContract: Account
+        # Logs is the logs emitted by the transaction, null if it is pending.
+        logs: [Log!]
+        r: BigInt!
+        s: BigInt!
+        v: BigInt!
+        # Type is the type of the transaction envelope.
+        type: Long!
+    }
+
+    # BlockFilterCriteria is the log filter applied to a single block.
+    input BlockFilterCriteria {
+        # Addresses are the contracts emitting the logs, any contract if empty.
+        addresses: [Address!]
+        # Topics are the alternative topics at each position, any topic at the position if empty.
+        topics: [[Bytes32!]!]
+    }
+
+    # Block is a block.
+    type Block {
+        # Number is the height of the block.
+        number: Long!
+        # Hash is the hash of the block.
+        hash: Bytes32!
+        # Parent is the parent of the block.
+        parent: Block
+        # TransactionsRoot is the merkle root of the transactions in the block.
+        transactionsRoot: Bytes32!
+        # TransactionCount is the number of transactions in the block.
+        transactionCount: Long!
+        # StateRoot is the digest of the state changes of the block.
+        stateRoot: Bytes32!
+        # ReceiptsRoot is the merkle root of the receipts of the block.
+        receiptsRoot: Bytes32!
+        # Miner is the producer of the block, null for the genesis block.
+        miner: Account
+        # GasLimit is the total gas limit of the transactions in the block.
+        gasLimit: Long!
+        # GasUsed is the gas consumed by the transactions in the block.
+        gasUsed: Long!
+        # BaseFeePerGas is the base fee per gas of the block.
+        baseFeePerGas: BigInt
+        # Timestamp is the unix timestamp of the block.
+        timestamp: Long!
+        # LogsBloom is the bloom filter of the logs in the block.
+        logsBloom: Bytes!
+        # Transactions is the transactions in the block.
+        transactions: [Transaction!]!
+        # TransactionAt is the transaction at the index in the block, null if out of bounds.
+        transactionAt(index: Long!): Transaction
+        # Logs is the logs in the block matching the filter.
+        logs(filter: BlockFilterCriteria!): [Log!]!
+        # Account is the account at the block.
+        account(address: Address!): Account!
+        # Call executes a call at the block.
+        call(data: CallData!): CallResult
+        # EstimateGas estimates the gas of a transaction at the block.
+        estimateGas(data: CallData!): Long!
+    }
+
+    # CallData is the call to a contract.
+    input CallData {
+        # From is the caller, the zero address if not supplied.
+        from: Address
+        # To is the contract called, a contract creation if not supplied.
+        to: Address
+        # Gas is the gas limit of the call.
+        gas: Long
+        # Value is the value sent along with the call, in rau.
+        value: BigInt
+        # Data is the data sent to the contract.
+        data: Bytes
+    }
+
+    # CallResult is the result of a call.
+    type CallResult {
+        # Data is the return data of the call.
+        data: Bytes!
+        # GasUsed is the gas consumed by the call.
+        gasUsed: Long!
+        # Status is 1 if the call succeeded, 0 if it failed.
+        status: Long!
+    }
+
+    # FilterCriteria is the log filter applied to a range of blocks.
+    input FilterCriteria {
+        # FromBlock is the first block of the range, the latest block if not supplied.
+        fromBlock: Long
+        # ToBlock is the last block of the range, the latest block if not supplied.
+        toBlock: Long
+        # Addresses are the contracts emitting the logs, any contract if empty.
+        addresses: [Address!]
+        # Topics are the alternative topics at each position, any topic at the position if empty.
+        topics: [[Bytes32!]!]
+    }
+
+    # SyncState is the synchronization state of the node.
+    type SyncState {
+        startingBlock: Long!
+        currentBlock: Long!
+        highestBlock: Long!
+    }
+
+    # Candidate is a staking candidate, the addresses are io-prefixed.
+    type Candidate {
+        id: String!
+        name: String!
+        owner: String!
+        operator: String!
+        reward: String!
+        # TotalWeightedVotes is the weighted votes of the buckets staked to the candidate, in rau.
+        totalWeightedVotes: BigInt!
+        selfStakeBucketIndex: Long!
+        selfStakingTokens: BigInt!
+        # Buckets is the native and contract staking buckets staked to the candidate.
+        buckets(offset: Int, limit: Int): [Bucket!]!
+    }
+
+    # Bucket is a native or contract staking bucket, the addresses are io-prefixed.
+    type Bucket {
+        index: Long!
+        # Candidate is the address of the candidate the bucket is staked to.
+        candidate: String!
+        owner: String!
+        # ContractAddress is the staking contract of the bucket, null for a native bucket.
+        contractAddress: String
+        stakedAmount: BigInt!
+        # StakedDuration is the staked duration in days of a native bucket.
+        stakedDuration: Long!
+        # StakedDurationBlockNumber is the staked duration in blocks of a contract bucket.
+        stakedDurationBlockNumber: Long!
+        autoStake: Boolean!
+        # CreateTime, StakeStartTime and UnstakeStartTime are unix timestamps of a native bucket.
+        createTime: Long!
+        stakeStartTime: Long!
+        unstakeStartTime: Long!
+        createBlockHeight: Long!
+        stakeStartBlockHeight: Long!
+        unstakeStartBlockHeight: Long!
+        endorsementExpireBlockHeight: Long!
+    }
+
+    # BlockProducer is a block producer in an epoch, the address is io-prefixed.
+    type BlockProducer {
+        address: String!
+        votes: BigInt!
+        active: Boolean!
+        # Production is the number of blocks produced in the epoch.
+        production: Long!
+    }
+
+    # Epoch is an epoch of the consensus.
+    type Epoch {
+        number: Long!
+        # Height is the first block of the epoch.
+        height: Long!
+        gravityChainStartHeight: Long!
+        # BlockCount is the number of blocks produced in the epoch.
+        blockCount: Long!
+        blockProducers: [BlockProducer!]!
+    }
+
+    # RewardPool is the pool of the block and epoch rewards.
+    type RewardPool {
+        # TotalBalance is the balance of the pool, in rau.
+        totalBalance: BigInt!
+        # AvailableBalance is the balance of the pool not granted yet, in rau.
+        availableBalance: BigInt!
+    }
+
+    type Query {
+        # Block fetches a block by number or by hash, the latest block if neither is supplied.
+        block(number: Long, hash: Bytes32): Block
+        # Blocks returns the blocks between two numbers inclusively, to the latest block if to is not supplied.
+        blocks(from: Long!, to: Long): [Block!]!
+        # Transaction returns a confirmed or pending transaction by hash.
+        transaction(hash: Bytes32!): Transaction
+        # Logs returns the logs matching the filter.
+        logs(filter: FilterCriteria!): [Log!]!
+        # Account returns the account at the block, the latest block if not supplied.
+        account(address: Address!, block: Long): Account!
+        # Call executes a call at the block, the latest block if not supplied.
+        call(data: CallData!, block: Long): CallResult
+        # EstimateGas estimates the gas of a transaction at the latest block.
+        estimateGas(data: CallData!): Long!
+        # GasPrice returns the suggested gas price.
+        gasPrice: BigInt!
+        # ChainID returns the chain ID of the transactions.
+        chainID: BigInt!
+        # Syncing returns the synchronization state, null if the node is synced.
+        syncing: SyncState
+        # Candidates returns the staking candidates at the block, the latest block if not supplied.
+        candidates(offset: Int, limit: Int, block: Long): [Candidate!]!
+        # Candidate returns the staking candidate by name, null if not found.
+        candidate(name: String!, block: Long): Candidate
+        # Buckets returns the staking buckets of the indexes, or all the buckets if indexes are not supplied.
+        buckets(indexes: [Long!], offset: Int, limit: Int, block: Long): [Bucket!]!
+        # Epoch returns the epoch by number, the current epoch if not supplied.
+        epoch(number: Long): Epoch
+        # RewardPool returns the reward pool at the block, the latest block if not supplied.
+        rewardPool(block: Long): RewardPool!
+    }
+`
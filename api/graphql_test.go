@@ -0,0 +1,237 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package api
+
+import (
+	"encoding/hex"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-proto/golang/iotexapi"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/iotexproject/iotex-core/v2/action"
+	apitypes "github.com/iotexproject/iotex-core/v2/api/types"
+	"github.com/iotexproject/iotex-core/v2/blockchain/block"
+	"github.com/iotexproject/iotex-core/v2/test/identityset"
+)
+
+func TestGraphQL(t *testing.T) {
+	r := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	core := NewMockCoreService(ctrl)
+	core.EXPECT().Track(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return().AnyTimes()
+	core.EXPECT().EVMNetworkID().Return(uint32(4689)).AnyTimes()
+	core.EXPECT().TipHeight().Return(uint64(10)).AnyTimes()
+	handler, err := NewGraphQLHandler(core, DefaultConfig.GraphQL, nil)
+	r.NoError(err)
+	post := func(handler http.Handler, q string) (int, gjson.Result) {
+		body := fmt.Sprintf(`{"query":%q}`, q)
+		req := httptest.NewRequest(http.MethodPost, "http://url.com", strings.NewReader(body))
+		req.RemoteAddr = "1.2.3.4:5678"
+		resp := httptest.NewRecorder()
+		handler.ServeHTTP(resp, req)
+		data, err := io.ReadAll(resp.Body)
+		r.NoError(err)
+		return resp.Code, gjson.ParseBytes(data)
+	}
+	query := func(q string) gjson.Result {
+		code, res := post(handler, q)
+		r.Equal(http.StatusOK, code)
+		r.False(res.Get("errors").Exists(), res.Raw)
+		return res.Get("data")
+	}
+
+	t.Run("block", func(t *testing.T) {
+		tsf, err := action.SignedTransfer(identityset.Address(28).String(), identityset.PrivateKey(27), uint64(1), big.NewInt(10), []byte{}, uint64(100000), big.NewInt(0))
+		r.NoError(err)
+		tsfHash, err := tsf.Hash()
+		r.NoError(err)
+		receipts := []*action.Receipt{
+			{Status: uint64(iotextypes.ReceiptStatus_Success), BlockHeight: 1, ActionHash: tsfHash, GasConsumed: 10000},
+		}
+		blk, err := block.NewTestingBuilder().
+			SetHeight(1).
+			SetPrevBlockHash(hash.ZeroHash256).
+			SetTimeStamp(time.Unix(1700000000, 0)).
+			SetReceipts(receipts).
+			AddActions(tsf).
+			SignAndBuild(identityset.PrivateKey(0))
+		r.NoError(err)
+		core.EXPECT().BlockByHeight(uint64(1)).Return(&apitypes.BlockWithReceipts{
+			Block:    &blk,
+			Receipts: receipts,
+		}, nil).Times(1)
+		blkHash := blk.HashBlock()
+
+		res := query(`{block(number: 1) {number hash timestamp gasUsed transactionCount transactions {hash nonce index value status from {address}}}}`)
+		r.Equal("0x1", res.Get("block.number").String())
+		r.Equal("0x"+hex.EncodeToString(blkHash[:]), res.Get("block.hash").String())
+		r.Equal("0x6553f100", res.Get("block.timestamp").String())
+		r.Equal("0x2710", res.Get("block.gasUsed").String())
+		r.Equal("0x1", res.Get("block.transactionCount").String())
+		r.Equal("0x"+hex.EncodeToString(tsfHash[:]), res.Get("block.transactions.0.hash").String())
+		r.Equal("0x1", res.Get("block.transactions.0.nonce").String())
+		r.Equal("0x0", res.Get("block.transactions.0.index").String())
+		r.Equal("0xa", res.Get("block.transactions.0.value").String())
+		r.Equal("0x1", res.Get("block.transactions.0.status").String())
+		r.Equal(strings.ToLower(identityset.Address(27).Hex()), res.Get("block.transactions.0.from.address").String())
+
+		core.EXPECT().BlockByHeight(uint64(5)).Return(nil, ErrNotFound).Times(1)
+		res = query(`{block(number: "0x5") {number}}`)
+		r.Equal("null", res.Get("block").Raw)
+	})
+
+	t.Run("account", func(t *testing.T) {
+		addr := identityset.Address(1)
+		core.EXPECT().Account(addr).Return(&iotextypes.AccountMeta{Balance: "1000"}, nil, nil).Times(1)
+		core.EXPECT().PendingNonce(addr).Return(uint64(3), nil).Times(1)
+		core.EXPECT().ReadState("rewarding", "", []byte("UnclaimedBalance"), [][]byte{[]byte(addr.String())}).
+			Return(&iotexapi.ReadStateResponse{Data: []byte("20")}, nil).Times(1)
+		res := query(fmt.Sprintf(`{account(address: "%s") {balance transactionCount unclaimedReward}}`, addr.Hex()))
+		r.Equal("0x3e8", res.Get("account.balance").String())
+		r.Equal("0x3", res.Get("account.transactionCount").String())
+		r.Equal("0x14", res.Get("account.unclaimedReward").String())
+
+		// the account at a past block is read with height
+		coreWithHeight := NewMockCoreServiceReaderWithHeight(ctrl)
+		core.EXPECT().WithHeight(uint64(5)).Return(coreWithHeight).Times(1)
+		coreWithHeight.EXPECT().Account(addr).Return(&iotextypes.AccountMeta{Balance: "10"}, nil, nil).Times(1)
+		res = query(fmt.Sprintf(`{account(address: "%s", block: 5) {balance}}`, addr.Hex()))
+		r.Equal("0xa", res.Get("account.balance").String())
+	})
+
+	t.Run("staking", func(t *testing.T) {
+		cands := &iotextypes.CandidateListV2{
+			Candidates: []*iotextypes.CandidateV2{
+				{
+					Name:               "cand1",
+					OwnerAddress:       identityset.Address(1).String(),
+					TotalWeightedVotes: "1200",
+					SelfStakingTokens:  "1000",
+				},
+			},
+		}
+		candsData, err := proto.Marshal(cands)
+		r.NoError(err)
+		buckets := &iotextypes.VoteBucketList{
+			Buckets: []*iotextypes.VoteBucket{
+				{Index: 7, CandidateAddress: identityset.Address(1).String(), StakedAmount: "1000", AutoStake: true},
+			},
+		}
+		bucketsData, err := proto.Marshal(buckets)
+		r.NoError(err)
+		core.EXPECT().ReadState("staking", "", gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ string, _ string, method []byte, args [][]byte) (*iotexapi.ReadStateResponse, error) {
+				var m iotexapi.ReadStakingDataMethod
+				r.NoError(proto.Unmarshal(method, &m))
+				var req iotexapi.ReadStakingDataRequest
+				r.NoError(proto.Unmarshal(args[0], &req))
+				switch m.GetMethod() {
+				case iotexapi.ReadStakingDataMethod_CANDIDATES:
+					r.EqualValues(2, req.GetCandidates().GetPagination().GetLimit())
+					return &iotexapi.ReadStateResponse{Data: candsData}, nil
+				case iotexapi.ReadStakingDataMethod_COMPOSITE_BUCKETS_BY_CANDIDATE:
+					r.Equal("cand1", req.GetBucketsByCandidate().GetCandName())
+					r.EqualValues(_graphQLDefaultLimit, req.GetBucketsByCandidate().GetPagination().GetLimit())
+					return &iotexapi.ReadStateResponse{Data: bucketsData}, nil
+				}
+				return nil, ErrNotFound
+			}).Times(2)
+		res := query(`{candidates(limit: 2) {name owner totalWeightedVotes buckets {index stakedAmount autoStake contractAddress}}}`)
+		r.Equal("cand1", res.Get("candidates.0.name").String())
+		r.Equal(identityset.Address(1).String(), res.Get("candidates.0.owner").String())
+		r.Equal("0x4b0", res.Get("candidates.0.totalWeightedVotes").String())
+		r.Equal("0x7", res.Get("candidates.0.buckets.0.index").String())
+		r.Equal("0x3e8", res.Get("candidates.0.buckets.0.stakedAmount").String())
+		r.True(res.Get("candidates.0.buckets.0.autoStake").Bool())
+		r.Equal("null", res.Get("candidates.0.buckets.0.contractAddress").Raw)
+	})
+
+	t.Run("epoch", func(t *testing.T) {
+		core.EXPECT().ChainMeta().Return(&iotextypes.ChainMeta{Epoch: &iotextypes.EpochData{Num: 3}}, "", nil).Times(1)
+		core.EXPECT().EpochMeta(uint64(3)).Return(&iotextypes.EpochData{Num: 3, Height: 721}, uint64(2), []*iotexapi.BlockProducerInfo{
+			{Address: identityset.Address(2).String(), Votes: "100", Active: true, Production: 2},
+		}, nil).Times(1)
+		res := query(`{epoch {number height blockCount blockProducers {address votes active production}}}`)
+		r.Equal("0x3", res.Get("epoch.number").String())
+		r.Equal("0x2d1", res.Get("epoch.height").String())
+		r.Equal("0x2", res.Get("epoch.blockCount").String())
+		r.Equal(identityset.Address(2).String(), res.Get("epoch.blockProducers.0.address").String())
+		r.Equal("0x64", res.Get("epoch.blockProducers.0.votes").String())
+	})
+
+	t.Run("chain", func(t *testing.T) {
+		core.EXPECT().SuggestGasPrice().Return(uint64(1000000000000), nil).Times(1)
+		core.EXPECT().SyncingProgress().Return(uint64(1), uint64(10), uint64(10)).Times(1)
+		res := query(`{chainID gasPrice syncing {currentBlock}}`)
+		r.Equal("0x1251", res.Get("chainID").String())
+		r.Equal("0xe8d4a51000", res.Get("gasPrice").String())
+		r.Equal("null", res.Get("syncing").Raw)
+	})
+
+	t.Run("logs", func(t *testing.T) {
+		logs := []*action.Log{{Address: identityset.Address(10).String(), BlockHeight: 3, Index: 2}}
+		core.EXPECT().LogsInRange(gomock.Any(), uint64(1), uint64(5), DefaultConfig.GraphQL.LogsPageSize).Return(logs, nil, nil).Times(1)
+		res := query(`{logs(filter: {fromBlock: 1, toBlock: 5}) {index}}`)
+		r.Equal("0x2", res.Get("logs.0.index").String())
+	})
+
+	t.Run("limits", func(t *testing.T) {
+		cfg := DefaultConfig.GraphQL
+		cfg.MaxQuerySize = 100
+		cfg.MaxQueryCost = 5
+		limited, err := NewGraphQLHandler(core, cfg, nil)
+		r.NoError(err)
+		code, _ := post(limited, `{blocks(from: 1, to: 2) {number hash transactionCount gasUsed gasLimit timestamp stateRoot receiptsRoot}}`)
+		r.Equal(http.StatusBadRequest, code)
+		// the blocks of the range exceed the cost of a query
+		code, res := post(limited, `{blocks(from: 1) {number}}`)
+		r.Equal(http.StatusOK, code)
+		r.Contains(res.Get("errors.0.message").String(), "graphql query is too complex")
+		core.EXPECT().SuggestGasPrice().Return(uint64(1), nil).Times(1)
+		core.EXPECT().BlockByHeightRange(uint64(9), uint64(2)).Return(nil, nil).Times(1)
+		core.EXPECT().BlockByHeightRange(uint64(1), uint64(2)).Return(nil, nil).Times(1)
+		code, res = post(limited, `{a: gasPrice b: blocks(from: 9) {number} c: blocks(from: 1, to: 2) {number}}`)
+		r.Equal(http.StatusOK, code)
+		r.False(res.Get("errors").Exists(), res.Raw)
+		// the expensive fields selected multiple times are charged each time
+		core.EXPECT().BlockByHeightRange(uint64(8), uint64(3)).Return(nil, nil).Times(1)
+		code, res = post(limited, `{a: blocks(from: 8) {number} b: blocks(from: 8) {number}}`)
+		r.Equal(http.StatusOK, code)
+		r.Contains(res.Get("errors.0.message").String(), "graphql query is too complex")
+
+		// the expensive fields are charged from the quota of the client
+		rlCfg := DefaultConfig.RateLimit
+		rlCfg.Enabled = true
+		rlCfg.IPRate = 0.001
+		rlCfg.IPBurst = 10
+		limited, err = NewGraphQLHandler(core, DefaultConfig.GraphQL, NewRateLimiter(rlCfg))
+		r.NoError(err)
+		code, res = post(limited, `{blocks(from: 1) {number}}`)
+		r.Equal(http.StatusOK, code)
+		r.Contains(res.Get("errors.0.message").String(), "rate limit exceeded")
+	})
+
+	t.Run("method not allowed", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "http://url.com", nil)
+		resp := httptest.NewRecorder()
+		handler.ServeHTTP(resp, req)
+		r.Equal(http.StatusMethodNotAllowed, resp.Code)
+	})
+}
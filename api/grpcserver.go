@@ -38,6 +38,7 @@ import (
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/iotexproject/iotex-core/v2/action"
+	"github.com/iotexproject/iotex-core/v2/api/extensionpb"
 	"github.com/iotexproject/iotex-core/v2/api/logfilter"
 	apitypes "github.com/iotexproject/iotex-core/v2/api/types"
 	"github.com/iotexproject/iotex-core/v2/blockchain/block"
@@ -81,8 +82,8 @@ func RecoveryInterceptor() grpc_recovery.Option {
 	})
 }
 
-// NewGRPCServer creates a new grpc server
-func NewGRPCServer(core CoreService, bds *blockDAOService, grpcPort int) *GRPCServer {
+// NewGRPCServer creates a new grpc server, a nil limiter means no rate limit
+func NewGRPCServer(core CoreService, bds *blockDAOService, grpcPort int, limiter *RateLimiter) *GRPCServer {
 	if grpcPort == 0 {
 		return nil
 	}
@@ -92,11 +93,13 @@ func NewGRPCServer(core CoreService, bds *blockDAOService, grpcPort int) *GRPCSe
 			grpc_prometheus.StreamServerInterceptor,
 			otelgrpc.StreamServerInterceptor(),
 			grpc_recovery.StreamServerInterceptor(RecoveryInterceptor()),
+			limiter.StreamServerInterceptor(),
 		)),
 		grpc.UnaryInterceptor(grpc_middleware.ChainUnaryServer(
 			grpc_prometheus.UnaryServerInterceptor,
 			otelgrpc.UnaryServerInterceptor(),
 			grpc_recovery.UnaryServerInterceptor(RecoveryInterceptor()),
+			limiter.UnaryServerInterceptor(),
 		)),
 		grpc.KeepaliveEnforcementPolicy(kaep),
 		grpc.KeepaliveParams(kasp),
@@ -105,6 +108,7 @@ func NewGRPCServer(core CoreService, bds *blockDAOService, grpcPort int) *GRPCSe
 	//serviceName: grpc.health.v1.Health
 	grpc_health_v1.RegisterHealthServer(gSvr, health.NewServer())
 	iotexapi.RegisterAPIServiceServer(gSvr, newGRPCHandler(core))
+	extensionpb.RegisterExtensionServiceServer(gSvr, newExtensionService(core))
 	if bds != nil {
 		blockdaopb.RegisterBlockDAOServiceServer(gSvr, bds)
 	}
@@ -2150,7 +2150,7 @@ func TestGrpcServer_GetEpochMetaIntegrity(t *testing.T) {
 
 		coreService, ok := svr.core.(*coreService)
 		require.True(ok)
-		coreService.readCache.Clear()
+		coreService.respCache.Revert(0)
 		res, err := grpcHandler.GetEpochMeta(context.Background(), &iotexapi.GetEpochMetaRequest{EpochNumber: test.EpochNumber})
 		require.NoError(err)
 		require.Equal(test.epochData.Num, res.EpochData.Num)
@@ -2622,7 +2622,7 @@ func TestGrpcServer_TraceTransactionStructLogsIntegrity(t *testing.T) {
 	require := require.New(t)
 	cfg := newConfig()
 	cfg.api.GRPCPort = testutil.RandomPort()
-	svr, bc, _, _, _, actPool, bfIndexFile, err := createServerV2(cfg, true)
+	svr, bc, _, _, _, actPool, bfIndexFile, err := createServerV2(cfg, true, WithArchiveSupport())
 	require.NoError(err)
 	grpcHandler := newGRPCHandler(svr.core)
 	defer func() {
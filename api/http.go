@@ -69,7 +69,7 @@ func (handler *hTTPHandler) ServeHTTP(w http.ResponseWriter, req *http.Request)
 		return
 	}
 
-	ctx, span := tracer.NewSpan(req.Context(), "http")
+	ctx, span := tracer.NewSpan(withHTTPRequest(req.Context(), req), "http")
 	defer span.End()
 	if err := handler.msgHandler.HandlePOSTReq(ctx, req.Body,
 		apitypes.NewResponseWriter(
@@ -28,18 +28,30 @@ var (
 type (
 	// chainListener implements the Listener interface
 	chainListener struct {
-		maxCapacity int
+		capacity    *listenerCapacity
 		streamMap   *ttl.Cache // all registered <Responder, chan error>
 		idGenerator *randID
 		mu          sync.Mutex
 	}
+
+	// listenerCapacity limits the total number of responders of the listeners sharing it
+	listenerCapacity struct {
+		maxCapacity int
+		counts      []func() int
+		mu          sync.Mutex
+	}
 )
 
 // NewChainListener returns a new blockchain chainListener
 func NewChainListener(c int) apitypes.Listener {
+	return newChainListener(newListenerCapacity(c))
+}
+
+func newChainListener(capacity *listenerCapacity) *chainListener {
 	s, _ := ttl.NewCache(ttl.EvictOnErrorOption())
+	capacity.register(s.Count)
 	return &chainListener{
-		maxCapacity: c,
+		capacity:    capacity,
 		streamMap:   s,
 		idGenerator: newIDGenerator(_idSize),
 	}
@@ -89,25 +101,23 @@ func (cl *chainListener) ReceiveBlock(blk *block.Block) error {
 func (cl *chainListener) AddResponder(responder apitypes.Responder) (string, error) {
 	cl.mu.Lock()
 	defer cl.mu.Unlock()
-	if cl.streamMap.Count() >= cl.maxCapacity {
-		return "", errorCapacityReached
-	}
-
-	listenerID, i := "", 0
-	// An new id is assumed to be found, because combinations (2^62) is far larger than capacity
-	for ; i < _idRetry; i++ {
-		listenerID = cl.idGenerator.newID()
-		if _, exist := cl.streamMap.Get(listenerID); !exist {
-			break
+	return cl.capacity.add(func() (string, error) {
+		listenerID, i := "", 0
+		// An new id is assumed to be found, because combinations (2^62) is far larger than capacity
+		for ; i < _idRetry; i++ {
+			listenerID = cl.idGenerator.newID()
+			if _, exist := cl.streamMap.Get(listenerID); !exist {
+				break
+			}
+		}
+		if i == _idRetry {
+			return "", errors.New("No peer id is available")
 		}
-	}
-	if i == _idRetry {
-		return "", errors.New("No peer id is available")
-	}
 
-	cl.streamMap.Set(listenerID, responder)
-	apiLimitMtcs.WithLabelValues("listener").Set(float64(cl.streamMap.Count()))
-	return listenerID, nil
+		cl.streamMap.Set(listenerID, responder)
+		apiLimitMtcs.WithLabelValues("listener").Set(float64(cl.streamMap.Count()))
+		return listenerID, nil
+	})
 }
 
 // RemoveResponder delete the responder
@@ -128,6 +138,31 @@ func (cl *chainListener) RemoveResponder(listenerID string) (bool, error) {
 	return cl.streamMap.Delete(listenerID), nil
 }
 
+func newListenerCapacity(c int) *listenerCapacity {
+	return &listenerCapacity{maxCapacity: c}
+}
+
+// register registers the counter of the responders of a listener
+func (lc *listenerCapacity) register(count func() int) {
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+	lc.counts = append(lc.counts, count)
+}
+
+// add calls add to add a responder if the total number of responders hasn't reached the capacity
+func (lc *listenerCapacity) add(add func() (string, error)) (string, error) {
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+	total := 0
+	for _, count := range lc.counts {
+		total += count()
+	}
+	if total >= lc.maxCapacity {
+		return "", errorCapacityReached
+	}
+	return add()
+}
+
 type randID struct {
 	length uint8
 }
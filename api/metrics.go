@@ -7,8 +7,13 @@ var (
 		Name: "iotex_api_limit_metrics",
 		Help: "api limit metrics.",
 	}, []string{"limit"})
+	pendingActionDropMtc = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "iotex_api_pending_action_drop",
+		Help: "number of pending actions dropped by slow subscriptions.",
+	})
 )
 
 func init() {
 	prometheus.MustRegister(apiLimitMtcs)
+	prometheus.MustRegister(pendingActionDropMtc)
 }
@@ -10,6 +10,7 @@ import (
 	reflect "reflect"
 	time "time"
 
+	types0 "github.com/ethereum/go-ethereum/core/types"
 	tracers "github.com/ethereum/go-ethereum/eth/tracers"
 	gomock "github.com/golang/mock/gomock"
 	hash "github.com/iotexproject/go-pkgs/hash"
@@ -20,6 +21,7 @@ import (
 	types "github.com/iotexproject/iotex-core/v2/api/types"
 	block "github.com/iotexproject/iotex-core/v2/blockchain/block"
 	genesis "github.com/iotexproject/iotex-core/v2/blockchain/genesis"
+	blockindex "github.com/iotexproject/iotex-core/v2/blockindex"
 	iotexapi "github.com/iotexproject/iotex-proto/golang/iotexapi"
 	iotextypes "github.com/iotexproject/iotex-proto/golang/iotextypes"
 )
@@ -63,6 +65,51 @@ func (mr *MockCoreServiceMockRecorder) Account(addr interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Account", reflect.TypeOf((*MockCoreService)(nil).Account), addr)
 }
 
+// AccountProof mocks base method.
+func (m *MockCoreService) AccountProof(ctx context.Context, addr address.Address, storageKeys []hash.Hash256) (*types.AccountProof, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AccountProof", ctx, addr, storageKeys)
+	ret0, _ := ret[0].(*types.AccountProof)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AccountProof indicates an expected call of AccountProof.
+func (mr *MockCoreServiceMockRecorder) AccountProof(ctx, addr, storageKeys interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AccountProof", reflect.TypeOf((*MockCoreService)(nil).AccountProof), ctx, addr, storageKeys)
+}
+
+// ActPoolContent mocks base method.
+func (m *MockCoreService) ActPoolContent() (map[string][]*action.SealedEnvelope, map[string][]*action.SealedEnvelope) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ActPoolContent")
+	ret0, _ := ret[0].(map[string][]*action.SealedEnvelope)
+	ret1, _ := ret[1].(map[string][]*action.SealedEnvelope)
+	return ret0, ret1
+}
+
+// ActPoolContent indicates an expected call of ActPoolContent.
+func (mr *MockCoreServiceMockRecorder) ActPoolContent() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActPoolContent", reflect.TypeOf((*MockCoreService)(nil).ActPoolContent))
+}
+
+// ActPoolStatus mocks base method.
+func (m *MockCoreService) ActPoolStatus() (uint64, uint64) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ActPoolStatus")
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(uint64)
+	return ret0, ret1
+}
+
+// ActPoolStatus indicates an expected call of ActPoolStatus.
+func (mr *MockCoreServiceMockRecorder) ActPoolStatus() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActPoolStatus", reflect.TypeOf((*MockCoreService)(nil).ActPoolStatus))
+}
+
 // Action mocks base method.
 func (m *MockCoreService) Action(actionHash string, checkPending bool) (*iotexapi.ActionInfo, error) {
 	m.ctrl.T.Helper()
@@ -95,6 +142,20 @@ func (mr *MockCoreServiceMockRecorder) ActionByActionHash(h interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActionByActionHash", reflect.TypeOf((*MockCoreService)(nil).ActionByActionHash), h)
 }
 
+// ActionListener mocks base method.
+func (m *MockCoreService) ActionListener() types.ActionListener {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ActionListener")
+	ret0, _ := ret[0].(types.ActionListener)
+	return ret0
+}
+
+// ActionListener indicates an expected call of ActionListener.
+func (mr *MockCoreServiceMockRecorder) ActionListener() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActionListener", reflect.TypeOf((*MockCoreService)(nil).ActionListener))
+}
+
 // Actions mocks base method.
 func (m *MockCoreService) Actions(start, count uint64) ([]*iotexapi.ActionInfo, error) {
 	m.ctrl.T.Helper()
@@ -259,6 +320,27 @@ func (mr *MockCoreServiceMockRecorder) ChainMeta() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChainMeta", reflect.TypeOf((*MockCoreService)(nil).ChainMeta))
 }
 
+// CreateAccessList mocks base method.
+func (m *MockCoreService) CreateAccessList(ctx context.Context, callerAddr address.Address, sc action.Envelope, opts ...protocol.SimulateOption) (types0.AccessList, *action.Receipt, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, callerAddr, sc}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CreateAccessList", varargs...)
+	ret0, _ := ret[0].(types0.AccessList)
+	ret1, _ := ret[1].(*action.Receipt)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CreateAccessList indicates an expected call of CreateAccessList.
+func (mr *MockCoreServiceMockRecorder) CreateAccessList(ctx, callerAddr, sc interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, callerAddr, sc}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAccessList", reflect.TypeOf((*MockCoreService)(nil).CreateAccessList), varargs...)
+}
+
 // EVMNetworkID mocks base method.
 func (m *MockCoreService) EVMNetworkID() uint32 {
 	m.ctrl.T.Helper()
@@ -392,6 +474,21 @@ func (mr *MockCoreServiceMockRecorder) FeeHistory(ctx, blocks, lastBlock, reward
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FeeHistory", reflect.TypeOf((*MockCoreService)(nil).FeeHistory), ctx, blocks, lastBlock, rewardPercentiles)
 }
 
+// FilterCallTraces mocks base method.
+func (m *MockCoreService) FilterCallTraces(ctx context.Context, filter *types.CallTraceFilter) ([]*types.ActionCallTraces, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FilterCallTraces", ctx, filter)
+	ret0, _ := ret[0].([]*types.ActionCallTraces)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FilterCallTraces indicates an expected call of FilterCallTraces.
+func (mr *MockCoreServiceMockRecorder) FilterCallTraces(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FilterCallTraces", reflect.TypeOf((*MockCoreService)(nil).FilterCallTraces), ctx, filter)
+}
+
 // Genesis mocks base method.
 func (m *MockCoreService) Genesis() genesis.Genesis {
 	m.ctrl.T.Helper()
@@ -467,6 +564,21 @@ func (mr *MockCoreServiceMockRecorder) PendingNonce(arg0 interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PendingNonce", reflect.TypeOf((*MockCoreService)(nil).PendingNonce), arg0)
 }
 
+// ProjectDelegateReward mocks base method.
+func (m *MockCoreService) ProjectDelegateReward(ctx context.Context, candidate string, amount *big.Int, duration uint32, autoStake bool, epochs uint64, distributionRatio uint32) (*types.DelegateRewardProjection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectDelegateReward", ctx, candidate, amount, duration, autoStake, epochs, distributionRatio)
+	ret0, _ := ret[0].(*types.DelegateRewardProjection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProjectDelegateReward indicates an expected call of ProjectDelegateReward.
+func (mr *MockCoreServiceMockRecorder) ProjectDelegateReward(ctx, candidate, amount, duration, autoStake, epochs, distributionRatio interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectDelegateReward", reflect.TypeOf((*MockCoreService)(nil).ProjectDelegateReward), ctx, candidate, amount, duration, autoStake, epochs, distributionRatio)
+}
+
 // RawBlocks mocks base method.
 func (m *MockCoreService) RawBlocks(startHeight, count uint64, withReceipts, withTransactionLogs bool) ([]*iotexapi.BlockInfo, error) {
 	m.ctrl.T.Helper()
@@ -483,9 +595,13 @@ func (mr *MockCoreServiceMockRecorder) RawBlocks(startHeight, count, withReceipt
 }
 
 // ReadContract mocks base method.
-func (m *MockCoreService) ReadContract(ctx context.Context, callerAddr address.Address, sc action.Envelope) (string, *iotextypes.Receipt, error) {
+func (m *MockCoreService) ReadContract(ctx context.Context, callerAddr address.Address, sc action.Envelope, opts ...protocol.SimulateOption) (string, *iotextypes.Receipt, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ReadContract", ctx, callerAddr, sc)
+	varargs := []interface{}{ctx, callerAddr, sc}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ReadContract", varargs...)
 	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(*iotextypes.Receipt)
 	ret2, _ := ret[2].(error)
@@ -493,9 +609,10 @@ func (m *MockCoreService) ReadContract(ctx context.Context, callerAddr address.A
 }
 
 // ReadContract indicates an expected call of ReadContract.
-func (mr *MockCoreServiceMockRecorder) ReadContract(ctx, callerAddr, sc interface{}) *gomock.Call {
+func (mr *MockCoreServiceMockRecorder) ReadContract(ctx, callerAddr, sc interface{}, opts ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadContract", reflect.TypeOf((*MockCoreService)(nil).ReadContract), ctx, callerAddr, sc)
+	varargs := append([]interface{}{ctx, callerAddr, sc}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadContract", reflect.TypeOf((*MockCoreService)(nil).ReadContract), varargs...)
 }
 
 // ReadContractStorage mocks base method.
@@ -543,6 +660,21 @@ func (mr *MockCoreServiceMockRecorder) ReceiptByActionHash(h interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReceiptByActionHash", reflect.TypeOf((*MockCoreService)(nil).ReceiptByActionHash), h)
 }
 
+// ReceiptsByHeight mocks base method.
+func (m *MockCoreService) ReceiptsByHeight(height uint64) ([]*action.Receipt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReceiptsByHeight", height)
+	ret0, _ := ret[0].([]*action.Receipt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReceiptsByHeight indicates an expected call of ReceiptsByHeight.
+func (mr *MockCoreServiceMockRecorder) ReceiptsByHeight(height interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReceiptsByHeight", reflect.TypeOf((*MockCoreService)(nil).ReceiptsByHeight), height)
+}
+
 // ReceiveBlock mocks base method.
 func (m *MockCoreService) ReceiveBlock(blk *block.Block) error {
 	m.ctrl.T.Helper()
@@ -591,9 +723,13 @@ func (mr *MockCoreServiceMockRecorder) ServerMeta() *gomock.Call {
 }
 
 // SimulateExecution mocks base method.
-func (m *MockCoreService) SimulateExecution(arg0 context.Context, arg1 address.Address, arg2 action.Envelope) ([]byte, *action.Receipt, error) {
+func (m *MockCoreService) SimulateExecution(arg0 context.Context, arg1 address.Address, arg2 action.Envelope, arg3 ...protocol.SimulateOption) ([]byte, *action.Receipt, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SimulateExecution", arg0, arg1, arg2)
+	varargs := []interface{}{arg0, arg1, arg2}
+	for _, a := range arg3 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SimulateExecution", varargs...)
 	ret0, _ := ret[0].([]byte)
 	ret1, _ := ret[1].(*action.Receipt)
 	ret2, _ := ret[2].(error)
@@ -601,9 +737,25 @@ func (m *MockCoreService) SimulateExecution(arg0 context.Context, arg1 address.A
 }
 
 // SimulateExecution indicates an expected call of SimulateExecution.
-func (mr *MockCoreServiceMockRecorder) SimulateExecution(arg0, arg1, arg2 interface{}) *gomock.Call {
+func (mr *MockCoreServiceMockRecorder) SimulateExecution(arg0, arg1, arg2 interface{}, arg3 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1, arg2}, arg3...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SimulateExecution", reflect.TypeOf((*MockCoreService)(nil).SimulateExecution), varargs...)
+}
+
+// SimulateBlocks mocks base method.
+func (m *MockCoreService) SimulateBlocks(ctx context.Context, blocks []*types.SimulateBlock) ([]*types.SimulatedBlock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SimulateBlocks", ctx, blocks)
+	ret0, _ := ret[0].([]*types.SimulatedBlock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SimulateBlocks indicates an expected call of SimulateBlocks.
+func (mr *MockCoreServiceMockRecorder) SimulateBlocks(ctx, blocks interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SimulateExecution", reflect.TypeOf((*MockCoreService)(nil).SimulateExecution), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SimulateBlocks", reflect.TypeOf((*MockCoreService)(nil).SimulateBlocks), ctx, blocks)
 }
 
 // Start mocks base method.
@@ -694,6 +846,52 @@ func (mr *MockCoreServiceMockRecorder) TipHeight() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TipHeight", reflect.TypeOf((*MockCoreService)(nil).TipHeight))
 }
 
+// TokenTransfers mocks base method.
+func (m *MockCoreService) TokenTransfers(addr, token address.Address, start, count uint64) ([]*blockindex.TokenTransfer, uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TokenTransfers", addr, token, start, count)
+	ret0, _ := ret[0].([]*blockindex.TokenTransfer)
+	ret1, _ := ret[1].(uint64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// TokenTransfers indicates an expected call of TokenTransfers.
+func (mr *MockCoreServiceMockRecorder) TokenTransfers(addr, token, start, count interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TokenTransfers", reflect.TypeOf((*MockCoreService)(nil).TokenTransfers), addr, token, start, count)
+}
+
+// TraceActionCalls mocks base method.
+func (m *MockCoreService) TraceActionCalls(ctx context.Context, actHash string) (*types.ActionCallTraces, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TraceActionCalls", ctx, actHash)
+	ret0, _ := ret[0].(*types.ActionCallTraces)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TraceActionCalls indicates an expected call of TraceActionCalls.
+func (mr *MockCoreServiceMockRecorder) TraceActionCalls(ctx, actHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TraceActionCalls", reflect.TypeOf((*MockCoreService)(nil).TraceActionCalls), ctx, actHash)
+}
+
+// TraceBlockCalls mocks base method.
+func (m *MockCoreService) TraceBlockCalls(ctx context.Context, height uint64) ([]*types.ActionCallTraces, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TraceBlockCalls", ctx, height)
+	ret0, _ := ret[0].([]*types.ActionCallTraces)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TraceBlockCalls indicates an expected call of TraceBlockCalls.
+func (mr *MockCoreServiceMockRecorder) TraceBlockCalls(ctx, height interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TraceBlockCalls", reflect.TypeOf((*MockCoreService)(nil).TraceBlockCalls), ctx, height)
+}
+
 // TraceCall mocks base method.
 func (m *MockCoreService) TraceCall(ctx context.Context, callerAddr address.Address, blkNumOrHash any, contractAddress string, nonce uint64, amount *big.Int, gasLimit uint64, data []byte, config *tracers.TraceConfig) ([]byte, *action.Receipt, any, error) {
 	m.ctrl.T.Helper()
@@ -8,9 +8,14 @@ import (
 	context "context"
 	reflect "reflect"
 
+	types "github.com/ethereum/go-ethereum/core/types"
 	gomock "github.com/golang/mock/gomock"
+	hash "github.com/iotexproject/go-pkgs/hash"
 	address "github.com/iotexproject/iotex-address/address"
 	action "github.com/iotexproject/iotex-core/v2/action"
+	protocol "github.com/iotexproject/iotex-core/v2/action/protocol"
+	apitypes "github.com/iotexproject/iotex-core/v2/api/types"
+	iotexapi "github.com/iotexproject/iotex-proto/golang/iotexapi"
 	iotextypes "github.com/iotexproject/iotex-proto/golang/iotextypes"
 )
 
@@ -53,10 +58,86 @@ func (mr *MockCoreServiceReaderWithHeightMockRecorder) Account(arg0 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Account", reflect.TypeOf((*MockCoreServiceReaderWithHeight)(nil).Account), arg0)
 }
 
+// AccountProof mocks base method.
+func (m *MockCoreServiceReaderWithHeight) AccountProof(arg0 context.Context, arg1 address.Address, arg2 []hash.Hash256) (*apitypes.AccountProof, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AccountProof", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*apitypes.AccountProof)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AccountProof indicates an expected call of AccountProof.
+func (mr *MockCoreServiceReaderWithHeightMockRecorder) AccountProof(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AccountProof", reflect.TypeOf((*MockCoreServiceReaderWithHeight)(nil).AccountProof), arg0, arg1, arg2)
+}
+
+// CreateAccessList mocks base method.
+func (m *MockCoreServiceReaderWithHeight) CreateAccessList(arg0 context.Context, arg1 address.Address, arg2 action.Envelope, arg3 ...protocol.SimulateOption) (types.AccessList, *action.Receipt, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1, arg2}
+	for _, a := range arg3 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CreateAccessList", varargs...)
+	ret0, _ := ret[0].(types.AccessList)
+	ret1, _ := ret[1].(*action.Receipt)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CreateAccessList indicates an expected call of CreateAccessList.
+func (mr *MockCoreServiceReaderWithHeightMockRecorder) CreateAccessList(arg0, arg1, arg2 interface{}, arg3 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1, arg2}, arg3...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAccessList", reflect.TypeOf((*MockCoreServiceReaderWithHeight)(nil).CreateAccessList), varargs...)
+}
+
+// EstimateExecutionGasConsumption mocks base method.
+func (m *MockCoreServiceReaderWithHeight) EstimateExecutionGasConsumption(arg0 context.Context, arg1 action.Envelope, arg2 address.Address, arg3 ...protocol.SimulateOption) (uint64, []byte, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1, arg2}
+	for _, a := range arg3 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "EstimateExecutionGasConsumption", varargs...)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].([]byte)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// EstimateExecutionGasConsumption indicates an expected call of EstimateExecutionGasConsumption.
+func (mr *MockCoreServiceReaderWithHeightMockRecorder) EstimateExecutionGasConsumption(arg0, arg1, arg2 interface{}, arg3 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1, arg2}, arg3...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EstimateExecutionGasConsumption", reflect.TypeOf((*MockCoreServiceReaderWithHeight)(nil).EstimateExecutionGasConsumption), varargs...)
+}
+
+// PendingNonce mocks base method.
+func (m *MockCoreServiceReaderWithHeight) PendingNonce(arg0 address.Address) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PendingNonce", arg0)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PendingNonce indicates an expected call of PendingNonce.
+func (mr *MockCoreServiceReaderWithHeightMockRecorder) PendingNonce(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PendingNonce", reflect.TypeOf((*MockCoreServiceReaderWithHeight)(nil).PendingNonce), arg0)
+}
+
 // ReadContract mocks base method.
-func (m *MockCoreServiceReaderWithHeight) ReadContract(arg0 context.Context, arg1 address.Address, arg2 action.Envelope) (string, *iotextypes.Receipt, error) {
+func (m *MockCoreServiceReaderWithHeight) ReadContract(arg0 context.Context, arg1 address.Address, arg2 action.Envelope, arg3 ...protocol.SimulateOption) (string, *iotextypes.Receipt, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ReadContract", arg0, arg1, arg2)
+	varargs := []interface{}{arg0, arg1, arg2}
+	for _, a := range arg3 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ReadContract", varargs...)
 	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(*iotextypes.Receipt)
 	ret2, _ := ret[2].(error)
@@ -64,7 +145,53 @@ func (m *MockCoreServiceReaderWithHeight) ReadContract(arg0 context.Context, arg
 }
 
 // ReadContract indicates an expected call of ReadContract.
-func (mr *MockCoreServiceReaderWithHeightMockRecorder) ReadContract(arg0, arg1, arg2 interface{}) *gomock.Call {
+func (mr *MockCoreServiceReaderWithHeightMockRecorder) ReadContract(arg0, arg1, arg2 interface{}, arg3 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1, arg2}, arg3...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadContract", reflect.TypeOf((*MockCoreServiceReaderWithHeight)(nil).ReadContract), varargs...)
+}
+
+// ReadContractStorage mocks base method.
+func (m *MockCoreServiceReaderWithHeight) ReadContractStorage(arg0 context.Context, arg1 address.Address, arg2 []byte) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadContractStorage", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadContractStorage indicates an expected call of ReadContractStorage.
+func (mr *MockCoreServiceReaderWithHeightMockRecorder) ReadContractStorage(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadContractStorage", reflect.TypeOf((*MockCoreServiceReaderWithHeight)(nil).ReadContractStorage), arg0, arg1, arg2)
+}
+
+// ReadState mocks base method.
+func (m *MockCoreServiceReaderWithHeight) ReadState(arg0 string, arg1 []byte, arg2 [][]byte) (*iotexapi.ReadStateResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadState", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*iotexapi.ReadStateResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadState indicates an expected call of ReadState.
+func (mr *MockCoreServiceReaderWithHeightMockRecorder) ReadState(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadState", reflect.TypeOf((*MockCoreServiceReaderWithHeight)(nil).ReadState), arg0, arg1, arg2)
+}
+
+// SimulateBlocks mocks base method.
+func (m *MockCoreServiceReaderWithHeight) SimulateBlocks(arg0 context.Context, arg1 []*apitypes.SimulateBlock) ([]*apitypes.SimulatedBlock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SimulateBlocks", arg0, arg1)
+	ret0, _ := ret[0].([]*apitypes.SimulatedBlock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SimulateBlocks indicates an expected call of SimulateBlocks.
+func (mr *MockCoreServiceReaderWithHeightMockRecorder) SimulateBlocks(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadContract", reflect.TypeOf((*MockCoreServiceReaderWithHeight)(nil).ReadContract), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SimulateBlocks", reflect.TypeOf((*MockCoreServiceReaderWithHeight)(nil).SimulateBlocks), arg0, arg1)
 }
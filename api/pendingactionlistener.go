@@ -0,0 +1,113 @@
+package api
+
+import (
+	"encoding/hex"
+	"sync"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/v2/action"
+	apitypes "github.com/iotexproject/iotex-core/v2/api/types"
+	"github.com/iotexproject/iotex-core/v2/pkg/log"
+)
+
+// _pendingActionBufferSize is the number of pending actions buffered for each subscription,
+// actions are dropped once the buffer is full so that a slow client cannot stall the actpool
+const _pendingActionBufferSize = 1024
+
+var errResponderExited = errors.New("responder has exited")
+
+type (
+	pendingAction struct {
+		id   string
+		selp *action.SealedEnvelope
+	}
+
+	web3PendingActionListener struct {
+		streamHandle streamHandler
+		fullTx       bool
+		chainID      uint32
+		actions      chan *pendingAction
+		quit         chan struct{}
+		once         sync.Once
+	}
+)
+
+// NewWeb3PendingActionListener returns a new websocket pending action listener.
+// If fullTx is true, the whole transaction is streamed, otherwise only the hash
+func NewWeb3PendingActionListener(handler streamHandler, chainID uint32, fullTx bool) apitypes.ActionResponder {
+	return newWeb3PendingActionListener(handler, chainID, fullTx, _pendingActionBufferSize)
+}
+
+func newWeb3PendingActionListener(handler streamHandler, chainID uint32, fullTx bool, bufferSize int) *web3PendingActionListener {
+	pl := &web3PendingActionListener{
+		streamHandle: handler,
+		fullTx:       fullTx,
+		chainID:      chainID,
+		actions:      make(chan *pendingAction, bufferSize),
+		quit:         make(chan struct{}),
+	}
+	go pl.stream()
+	return pl
+}
+
+// RespondAction queues the pending action to be streamed, it never blocks
+func (pl *web3PendingActionListener) RespondAction(id string, selp *action.SealedEnvelope) error {
+	select {
+	case <-pl.quit:
+		return errResponderExited
+	default:
+	}
+	select {
+	case pl.actions <- &pendingAction{id: id, selp: selp}:
+	default:
+		pendingActionDropMtc.Inc()
+		h, _ := selp.Hash()
+		log.L().Debug("subscription buffer is full, drop pending action",
+			zap.String("subscription", id),
+			zap.String("actHash", hex.EncodeToString(h[:])))
+	}
+	return nil
+}
+
+// Exit stops streaming
+func (pl *web3PendingActionListener) Exit() {
+	pl.once.Do(func() {
+		close(pl.quit)
+	})
+}
+
+func (pl *web3PendingActionListener) stream() {
+	for {
+		select {
+		case <-pl.quit:
+			return
+		case act := <-pl.actions:
+			result, err := pl.result(act.selp)
+			if err != nil {
+				logUnassembledAction(act.selp, err)
+				continue
+			}
+			if _, err := pl.streamHandle(&streamResponse{
+				id:     act.id,
+				result: result,
+			}); err != nil {
+				log.L().Info("Error when streaming the pending action", zap.Error(err))
+				pl.Exit()
+				return
+			}
+		}
+	}
+}
+
+func (pl *web3PendingActionListener) result(selp *action.SealedEnvelope) (interface{}, error) {
+	if pl.fullTx {
+		return newGetTransactionResult(nil, selp, nil, pl.chainID)
+	}
+	h, err := selp.Hash()
+	if err != nil {
+		return nil, err
+	}
+	return "0x" + hex.EncodeToString(h[:]), nil
+}
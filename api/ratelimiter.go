@@ -0,0 +1,301 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package api
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/iotexproject/go-pkgs/cache"
+	"github.com/iotexproject/iotex-proto/golang/iotexapi"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/time/rate"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// _limitExceededCode is the JSON-RPC error code of exceeding the request limit, defined in EIP-1474
+	_limitExceededCode = -32005
+)
+
+type (
+	// RateLimitConfig is the config of the rate limits of the api requests. A request costs the weight of its method
+	// from the token bucket of its API key if the key has a quota, otherwise from the token bucket of its client IP
+	RateLimitConfig struct {
+		Enabled bool `yaml:"enabled"`
+		// IPRate is the number of request units refilled per second to the token bucket of a client IP
+		IPRate float64 `yaml:"ipRate"`
+		// IPBurst is the capacity of the token bucket of a client IP
+		IPBurst int `yaml:"ipBurst"`
+		// TrustedProxies is the number of the proxies in front of the server, each appending the address of its peer
+		// to the X-Forwarded-For header. The client IP is the entry appended by the outermost trusted proxy, i.e.,
+		// the TrustedProxies-th entry from the right, as the entries to the left of it may be forged by the client.
+		// 0 means the header is not trusted
+		TrustedProxies int `yaml:"trustedProxies"`
+		// APIKeyHeader is the http header, or the grpc metadata, carrying the API key
+		APIKeyHeader string `yaml:"apiKeyHeader"`
+		// APIKeys is the quotas of the API keys
+		APIKeys map[string]Quota `yaml:"apiKeys"`
+		// MethodWeights is the number of request units of a request of the method, which is 1 if not listed, the
+		// method of the grpc request is the full method name, e.g., /iotexapi.APIService/GetLogs
+		MethodWeights map[string]int `yaml:"methodWeights"`
+		// LogsRangeUnit is the number of blocks in the range of a logs query costing one more request unit, 0 means
+		// the range is not weighted
+		LogsRangeUnit uint64 `yaml:"logsRangeUnit"`
+		// MaxClients is the max number of token buckets of the clients kept
+		MaxClients int `yaml:"maxClients"`
+		// MaxStreams is the max number of the open streams of a client, including the grpc streaming requests and
+		// the web3 subscriptions, 0 means no limit
+		MaxStreams int `yaml:"maxStreams"`
+	}
+
+	// Quota is the rate and burst of the token bucket of an API key
+	Quota struct {
+		Rate  float64 `yaml:"rate"`
+		Burst int     `yaml:"burst"`
+	}
+
+	// ClientInfo identifies the client of a request
+	ClientInfo struct {
+		IP     string
+		APIKey string
+	}
+
+	// RateLimiter limits the rate of the requests of each client with token buckets
+	RateLimiter struct {
+		cfg         RateLimitConfig
+		buckets     cache.LRUCache
+		streamMutex sync.Mutex
+		streams     map[string]int
+	}
+
+	clientInfoContextKey  struct{}
+	httpRequestContextKey struct{}
+)
+
+var (
+	_rateLimitMtc = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "iotex_api_rate_limited",
+		Help: "number of api requests rejected by the rate limiter.",
+	}, []string{"method"})
+
+	// ErrRateLimited indicates the request exceeds the rate limit
+	ErrRateLimited = status.Error(codes.ResourceExhausted, "rate limit exceeded")
+	// ErrStreamLimited indicates the client has too many open streams
+	ErrStreamLimited = status.Error(codes.ResourceExhausted, "too many open streams")
+)
+
+func init() {
+	prometheus.MustRegister(_rateLimitMtc)
+}
+
+// NewRateLimiter creates a new rate limiter, which is nil if the rate limit is not enabled
+func NewRateLimiter(cfg RateLimitConfig) *RateLimiter {
+	if !cfg.Enabled {
+		return nil
+	}
+	maxClients := cfg.MaxClients
+	if maxClients <= 0 {
+		maxClients = DefaultConfig.RateLimit.MaxClients
+	}
+	return &RateLimiter{
+		cfg:     cfg,
+		buckets: cache.NewThreadSafeLruCache(maxClients),
+		streams: make(map[string]int),
+	}
+}
+
+// Cost returns the number of request units of a request of the method, and of the block range if it queries logs
+func (rl *RateLimiter) Cost(method string, logsRange uint64) int {
+	cost := 1
+	if w, ok := rl.cfg.MethodWeights[method]; ok {
+		cost = w
+	}
+	if rl.cfg.LogsRangeUnit > 0 {
+		cost += int(logsRange / rl.cfg.LogsRangeUnit)
+	}
+	return cost
+}
+
+// Allow takes the cost of a request from the token bucket of the client, and returns ErrRateLimited if there are
+// not enough tokens
+func (rl *RateLimiter) Allow(client ClientInfo, method string, cost int) error {
+	if rl == nil || cost <= 0 {
+		return nil
+	}
+	key, r, b := rl.bucketOf(client)
+	if r <= 0 {
+		// no limit
+		return nil
+	}
+	if b < 1 {
+		b = 1
+	}
+	limiter, ok := rl.buckets.Get(key)
+	if !ok {
+		limiter = rate.NewLimiter(r, b)
+		rl.buckets.Add(key, limiter)
+	}
+	// a request costing more than the burst takes the whole bucket
+	if cost > b {
+		cost = b
+	}
+	if !limiter.(*rate.Limiter).AllowN(time.Now(), cost) {
+		_rateLimitMtc.WithLabelValues(method).Inc()
+		return ErrRateLimited
+	}
+	return nil
+}
+
+// AcquireStream opens a stream of the client, and returns the function to close it, or ErrStreamLimited if the
+// client has too many open streams
+func (rl *RateLimiter) AcquireStream(client ClientInfo, method string) (func(), error) {
+	if rl == nil || rl.cfg.MaxStreams <= 0 {
+		return func() {}, nil
+	}
+	key, _, _ := rl.bucketOf(client)
+	rl.streamMutex.Lock()
+	defer rl.streamMutex.Unlock()
+	if rl.streams[key] >= rl.cfg.MaxStreams {
+		_rateLimitMtc.WithLabelValues(method).Inc()
+		return nil, ErrStreamLimited
+	}
+	rl.streams[key]++
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			rl.streamMutex.Lock()
+			defer rl.streamMutex.Unlock()
+			if rl.streams[key]--; rl.streams[key] <= 0 {
+				delete(rl.streams, key)
+			}
+		})
+	}, nil
+}
+
+// bucketOf returns the key, rate and burst of the token bucket of the client
+func (rl *RateLimiter) bucketOf(client ClientInfo) (string, rate.Limit, int) {
+	if quota, ok := rl.cfg.APIKeys[client.APIKey]; ok && client.APIKey != "" {
+		return "key:" + client.APIKey, rate.Limit(quota.Rate), quota.Burst
+	}
+	return "ip:" + client.IP, rate.Limit(rl.cfg.IPRate), rl.cfg.IPBurst
+}
+
+// UnaryServerInterceptor returns the grpc interceptor limiting the rate of the unary requests
+func (rl *RateLimiter) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if rl != nil {
+			var logsRange uint64
+			if in, ok := req.(*iotexapi.GetLogsRequest); ok && in.GetByRange() != nil {
+				if from, to := in.GetByRange().GetFromBlock(), in.GetByRange().GetToBlock(); to >= from {
+					logsRange = to - from + 1
+				}
+			}
+			if err := rl.Allow(rl.grpcClient(ctx), info.FullMethod, rl.Cost(info.FullMethod, logsRange)); err != nil {
+				return nil, err
+			}
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor returns the grpc interceptor limiting the rate and the number of the streaming requests
+func (rl *RateLimiter) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if rl != nil {
+			client := rl.grpcClient(ss.Context())
+			if err := rl.Allow(client, info.FullMethod, rl.Cost(info.FullMethod, 0)); err != nil {
+				return err
+			}
+			release, err := rl.AcquireStream(client, info.FullMethod)
+			if err != nil {
+				return err
+			}
+			defer release()
+		}
+		return handler(srv, ss)
+	}
+}
+
+func (rl *RateLimiter) grpcClient(ctx context.Context) ClientInfo {
+	var client ClientInfo
+	md, _ := metadata.FromIncomingContext(ctx)
+	if keys := md.Get(rl.cfg.APIKeyHeader); len(keys) > 0 {
+		client.APIKey = keys[0]
+	}
+	client.IP = forwardedIP(md.Get("x-forwarded-for"), rl.cfg.TrustedProxies)
+	if client.IP == "" {
+		if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+			client.IP = hostOf(p.Addr.String())
+		}
+	}
+	return client
+}
+
+// clientFromContext returns the client of the http or websocket request in the context, or an empty client if the
+// rate limit is not enabled
+func (rl *RateLimiter) clientFromContext(ctx context.Context) ClientInfo {
+	if rl == nil {
+		return ClientInfo{}
+	}
+	if client, ok := ctx.Value(clientInfoContextKey{}).(ClientInfo); ok {
+		return client
+	}
+	req, ok := ctx.Value(httpRequestContextKey{}).(*http.Request)
+	if !ok {
+		return ClientInfo{}
+	}
+	client := ClientInfo{
+		IP:     forwardedIP(req.Header.Values("X-Forwarded-For"), rl.cfg.TrustedProxies),
+		APIKey: req.Header.Get(rl.cfg.APIKeyHeader),
+	}
+	if client.IP == "" {
+		client.IP = hostOf(req.RemoteAddr)
+	}
+	return client
+}
+
+// WithClientInfo adds the client of the request into the context
+func WithClientInfo(ctx context.Context, client ClientInfo) context.Context {
+	return context.WithValue(ctx, clientInfoContextKey{}, client)
+}
+
+// withHTTPRequest adds the http request into the context, to identify the client of the web3 requests in it
+func withHTTPRequest(ctx context.Context, req *http.Request) context.Context {
+	return context.WithValue(ctx, httpRequestContextKey{}, req)
+}
+
+// forwardedIP returns the entry of the X-Forwarded-For headers appended by the outermost of the trusted proxies,
+// or empty if there are not as many entries as the trusted proxies
+func forwardedIP(forwardedFor []string, trustedProxies int) string {
+	if trustedProxies <= 0 {
+		return ""
+	}
+	var entries []string
+	for _, header := range forwardedFor {
+		entries = append(entries, strings.Split(header, ",")...)
+	}
+	if len(entries) < trustedProxies {
+		return ""
+	}
+	return strings.TrimSpace(entries[len(entries)-trustedProxies])
+}
+
+func hostOf(addr string) string {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return addr
+	}
+	return host
+}
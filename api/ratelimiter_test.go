@@ -0,0 +1,256 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package api
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/iotexproject/iotex-proto/golang/iotexapi"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+func TestRateLimiter(t *testing.T) {
+	r := require.New(t)
+	r.Nil(NewRateLimiter(DefaultConfig.RateLimit))
+	// nil limiter allows any request
+	var nilLimiter *RateLimiter
+	r.NoError(nilLimiter.Allow(ClientInfo{IP: "1.1.1.1"}, "eth_call", 100))
+
+	cfg := DefaultConfig.RateLimit
+	cfg.Enabled = true
+	cfg.IPRate = 0.001
+	cfg.IPBurst = 10
+	cfg.APIKeys = map[string]Quota{
+		"gold":      {Rate: 0.001, Burst: 20},
+		"unlimited": {Rate: 0},
+	}
+	rl := NewRateLimiter(cfg)
+	r.NotNil(rl)
+
+	r.Equal(1, rl.Cost("eth_chainId", 0))
+	r.Equal(5, rl.Cost("eth_getLogs", 0))
+	r.Equal(5, rl.Cost("eth_getLogs", 99))
+	r.Equal(8, rl.Cost("eth_getLogs", 300))
+
+	ip1 := ClientInfo{IP: "1.1.1.1"}
+	for i := 0; i < 2; i++ {
+		r.NoError(rl.Allow(ip1, "eth_getLogs", 5))
+	}
+	r.ErrorIs(rl.Allow(ip1, "eth_chainId", 1), ErrRateLimited)
+	// the bucket of each client IP is separate
+	r.NoError(rl.Allow(ClientInfo{IP: "2.2.2.2"}, "eth_chainId", 1))
+	// a request costing more than the burst takes the whole bucket
+	r.NoError(rl.Allow(ClientInfo{IP: "3.3.3.3"}, "eth_getLogs", 100))
+	r.ErrorIs(rl.Allow(ClientInfo{IP: "3.3.3.3"}, "eth_chainId", 1), ErrRateLimited)
+
+	// the API key with a quota uses its own bucket, regardless of the client IP
+	gold := ClientInfo{IP: "1.1.1.1", APIKey: "gold"}
+	r.NoError(rl.Allow(gold, "eth_call", 20))
+	r.ErrorIs(rl.Allow(ClientInfo{IP: "4.4.4.4", APIKey: "gold"}, "eth_call", 1), ErrRateLimited)
+	for i := 0; i < 100; i++ {
+		r.NoError(rl.Allow(ClientInfo{IP: "1.1.1.1", APIKey: "unlimited"}, "eth_call", 1))
+	}
+	// an unknown API key falls back to the bucket of the client IP
+	r.ErrorIs(rl.Allow(ClientInfo{IP: "1.1.1.1", APIKey: "unknown"}, "eth_chainId", 1), ErrRateLimited)
+	r.Equal(codes.ResourceExhausted, status.Code(ErrRateLimited))
+}
+
+func TestRateLimiterClient(t *testing.T) {
+	r := require.New(t)
+	cfg := DefaultConfig.RateLimit
+	cfg.Enabled = true
+	rl := NewRateLimiter(cfg)
+
+	req := httptest.NewRequest(http.MethodPost, "http://url.com", nil)
+	req.RemoteAddr = "1.2.3.4:5678"
+	req.Header.Set("X-API-Key", "key1")
+	req.Header.Set("X-Forwarded-For", "6.6.6.6, 5.6.7.8")
+	req.Header.Add("X-Forwarded-For", "10.0.0.1")
+	ctx := withHTTPRequest(context.Background(), req)
+	r.Equal(ClientInfo{IP: "1.2.3.4", APIKey: "key1"}, rl.clientFromContext(ctx))
+	// the entries to the left of the one appended by the outermost trusted proxy may be forged
+	rl.cfg.TrustedProxies = 1
+	r.Equal(ClientInfo{IP: "10.0.0.1", APIKey: "key1"}, rl.clientFromContext(ctx))
+	rl.cfg.TrustedProxies = 2
+	r.Equal(ClientInfo{IP: "5.6.7.8", APIKey: "key1"}, rl.clientFromContext(ctx))
+	rl.cfg.TrustedProxies = 4
+	r.Equal(ClientInfo{IP: "1.2.3.4", APIKey: "key1"}, rl.clientFromContext(ctx))
+	client := ClientInfo{IP: "9.9.9.9"}
+	r.Equal(client, rl.clientFromContext(WithClientInfo(ctx, client)))
+	r.Equal(ClientInfo{}, rl.clientFromContext(context.Background()))
+
+	ctx = peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.IPv4(1, 2, 3, 4), Port: 5678}})
+	r.Equal(ClientInfo{IP: "1.2.3.4"}, rl.grpcClient(ctx))
+	ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("x-api-key", "key2", "x-forwarded-for", "6.6.6.6, 5.6.7.8"))
+	rl.cfg.TrustedProxies = 1
+	r.Equal(ClientInfo{IP: "5.6.7.8", APIKey: "key2"}, rl.grpcClient(ctx))
+	rl.cfg.TrustedProxies = 0
+	r.Equal(ClientInfo{IP: "1.2.3.4", APIKey: "key2"}, rl.grpcClient(ctx))
+}
+
+func TestRateLimiterStreams(t *testing.T) {
+	r := require.New(t)
+	var nilLimiter *RateLimiter
+	release, err := nilLimiter.AcquireStream(ClientInfo{IP: "1.1.1.1"}, "eth_subscribe")
+	r.NoError(err)
+	release()
+
+	cfg := DefaultConfig.RateLimit
+	cfg.Enabled = true
+	cfg.MaxStreams = 2
+	cfg.APIKeys = map[string]Quota{"gold": {Rate: 10, Burst: 10}}
+	rl := NewRateLimiter(cfg)
+	ip1 := ClientInfo{IP: "1.1.1.1"}
+	release1, err := rl.AcquireStream(ip1, "eth_subscribe")
+	r.NoError(err)
+	release2, err := rl.AcquireStream(ip1, "eth_subscribe")
+	r.NoError(err)
+	_, err = rl.AcquireStream(ip1, "eth_subscribe")
+	r.ErrorIs(err, ErrStreamLimited)
+	// the streams of each client are counted separately
+	_, err = rl.AcquireStream(ClientInfo{IP: "1.1.1.1", APIKey: "gold"}, "eth_subscribe")
+	r.NoError(err)
+	// a stream is closed once, regardless of the number of calls
+	release1()
+	release1()
+	release3, err := rl.AcquireStream(ip1, "eth_subscribe")
+	r.NoError(err)
+	_, err = rl.AcquireStream(ip1, "eth_subscribe")
+	r.ErrorIs(err, ErrStreamLimited)
+	release2()
+	release3()
+	r.NotContains(rl.streams, "ip:1.1.1.1")
+
+	// the grpc stream is closed once the handler returns
+	interceptor := rl.StreamServerInterceptor()
+	ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.IPv4(2, 2, 2, 2), Port: 5678}})
+	info := &grpc.StreamServerInfo{FullMethod: "/iotexapi.APIService/StreamBlocks"}
+	blocked, done := make(chan struct{}), make(chan error, 2)
+	for i := 0; i < 2; i++ {
+		go func() {
+			done <- interceptor(nil, &testServerStream{ctx: ctx}, info, func(interface{}, grpc.ServerStream) error {
+				<-blocked
+				return nil
+			})
+		}()
+	}
+	r.Eventually(func() bool {
+		rl.streamMutex.Lock()
+		defer rl.streamMutex.Unlock()
+		return rl.streams["ip:2.2.2.2"] == 2
+	}, 5*time.Second, 10*time.Millisecond)
+	err = interceptor(nil, &testServerStream{ctx: ctx}, info, func(interface{}, grpc.ServerStream) error { return nil })
+	r.Equal(codes.ResourceExhausted, status.Code(err))
+	close(blocked)
+	for i := 0; i < 2; i++ {
+		r.NoError(<-done)
+	}
+	r.NoError(interceptor(nil, &testServerStream{ctx: ctx}, info, func(interface{}, grpc.ServerStream) error { return nil }))
+}
+
+type testServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *testServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func TestRateLimiterGRPCInterceptor(t *testing.T) {
+	r := require.New(t)
+	cfg := DefaultConfig.RateLimit
+	cfg.Enabled = true
+	cfg.IPRate = 0.001
+	cfg.IPBurst = 10
+	rl := NewRateLimiter(cfg)
+	interceptor := rl.UnaryServerInterceptor()
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return "ok", nil
+	}
+	ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.IPv4(1, 2, 3, 4), Port: 5678}})
+	info := &grpc.UnaryServerInfo{FullMethod: "/iotexapi.APIService/GetLogs"}
+	req := &iotexapi.GetLogsRequest{
+		Lookup: &iotexapi.GetLogsRequest_ByRange{
+			ByRange: &iotexapi.GetLogsByRange{FromBlock: 1, ToBlock: 300},
+		},
+	}
+	// the request costs 5 + 300/100 = 8
+	res, err := interceptor(ctx, req, info, handler)
+	r.NoError(err)
+	r.Equal("ok", res)
+	_, err = interceptor(ctx, req, info, handler)
+	r.Equal(codes.ResourceExhausted, status.Code(err))
+	_, err = interceptor(ctx, &iotexapi.GetChainMetaRequest{}, &grpc.UnaryServerInfo{FullMethod: "/iotexapi.APIService/GetChainMeta"}, handler)
+	r.NoError(err)
+
+	// nil limiter does not limit the requests
+	var nilLimiter *RateLimiter
+	for i := 0; i < 10; i++ {
+		_, err = nilLimiter.UnaryServerInterceptor()(ctx, req, info, handler)
+		r.NoError(err)
+	}
+}
+
+func TestWeb3RateLimit(t *testing.T) {
+	r := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	core := NewMockCoreService(ctrl)
+	core.EXPECT().Track(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return().AnyTimes()
+	core.EXPECT().EVMNetworkID().Return(uint32(4689)).AnyTimes()
+	core.EXPECT().LogsInRange(gomock.Any(), uint64(1), uint64(900), uint64(0)).Return(nil, nil, nil).Times(1)
+	cfg := DefaultConfig.RateLimit
+	cfg.Enabled = true
+	cfg.IPRate = 0.001
+	cfg.IPBurst = 10
+	svr := newHTTPHandler(NewWeb3Handler(core, "", _defaultBatchRequestLimit, NewRateLimiter(cfg)))
+	post := func(body string) gjson.Result {
+		req := httptest.NewRequest(http.MethodPost, "http://url.com", strings.NewReader(body))
+		req.RemoteAddr = "1.2.3.4:5678"
+		resp := httptest.NewRecorder()
+		svr.ServeHTTP(resp, req)
+		data, err := io.ReadAll(resp.Body)
+		r.NoError(err)
+		return gjson.ParseBytes(data)
+	}
+
+	// the logs query of 900 blocks costs 5 + 900/100 = 14, which is limited to the burst
+	res := post(`{"jsonrpc":"2.0","method":"eth_getLogs","params":[{"fromBlock":"0x1","toBlock":"0x384","topics":["0x1"]}],"id":1}`)
+	r.NotEqual(_limitExceededCode, res.Get("error.code").Int())
+	res = post(`{"jsonrpc":"2.0","method":"net_version","params":[],"id":"a"}`)
+	r.EqualValues(_limitExceededCode, res.Get("error.code").Int())
+	r.Equal("rate limit exceeded", res.Get("error.message").String())
+	r.Equal("a", res.Get("id").String())
+}
+
+func TestWeb3RateLimitProof(t *testing.T) {
+	r := require.New(t)
+	cfg := DefaultConfig.RateLimit
+	cfg.Enabled = true
+	cfg.IPRate = 0.001
+	cfg.IPBurst = 10
+	svr := &web3Handler{limiter: NewRateLimiter(cfg)}
+	// the proof of the account and 4 storage keys costs 2 * 5
+	in := gjson.Parse(`{"params":["0xDa7e12Ef57c236a06117c5e0d04a228e7181CF36", ["0x1", "0x2", "0x3", "0x4"], "latest"]}`)
+	r.NoError(svr.checkRateLimit(context.Background(), "eth_getProof", &in))
+	in = gjson.Parse(`{"params":[]}`)
+	r.Equal(ErrRateLimited, svr.checkRateLimit(context.Background(), "eth_chainId", &in))
+}
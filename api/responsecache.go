@@ -0,0 +1,282 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package api
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/iotexproject/go-pkgs/cache"
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/v2/pkg/log"
+)
+
+const (
+	// ResponseCacheLRU caches the responses in the memory of the node
+	ResponseCacheLRU = "lru"
+	// ResponseCacheRedis caches the responses in a redis-protocol server, which can be shared by the api nodes
+	ResponseCacheRedis = "redis"
+	// ResponseCacheNone disables the response cache
+	ResponseCacheNone = "none"
+
+	// _redisIndexWindow is the number of heights below the latest put height kept in the index of the redis cache,
+	// the responses below the window cannot be reverted, but expire with the ttl
+	_redisIndexWindow = 1024
+)
+
+// namespaces of the cached responses
+const (
+	_blockNS    = "block"
+	_receiptsNS = "receipts"
+)
+
+type (
+	// ReadKey represents a read key
+	ReadKey struct {
+		Name   string   `json:"name,omitempty"`
+		Height string   `json:"height,omitempty"`
+		Method []byte   `json:"method,omitempty"`
+		Args   [][]byte `json:"args,omitempty"`
+	}
+
+	// ResponseCacheConfig is the config of the cache of the api responses
+	ResponseCacheConfig struct {
+		// Backend is the backend of the cache, one of lru, redis and none
+		Backend string `yaml:"backend"`
+		// Size is the max number of responses in the lru cache
+		Size int `yaml:"size"`
+		// RedisURL is the address of the redis-protocol server
+		RedisURL string `yaml:"redisURL"`
+		// RedisPrefix is the prefix of the keys in the redis cache, which separates the chains sharing a server
+		RedisPrefix string `yaml:"redisPrefix"`
+		// TTL is the time to live of the responses in the redis cache
+		TTL time.Duration `yaml:"ttl"`
+	}
+
+	// ResponseCache caches the api responses which are immutable once the block at the height is committed, e.g.,
+	// the blocks, receipts and states at a height
+	ResponseCache interface {
+		// Get returns the response of the key at the height
+		Get(uint64, hash.Hash160) ([]byte, bool)
+		// Put stores the response of the key at the height
+		Put(uint64, hash.Hash160, []byte)
+		// Revert removes the responses at the height and above, which are reverted by a reorg
+		Revert(uint64)
+	}
+
+	responseKey struct {
+		height uint64
+		key    hash.Hash160
+	}
+
+	lruResponseCache struct {
+		c cache.LRUCache
+	}
+
+	redisResponseCache struct {
+		client *redis.Client
+		prefix string
+		ttl    time.Duration
+	}
+
+	noopResponseCache struct{}
+
+	// tipResponseCache tracks the chain tip, and reverts the responses above the new tip on reorg
+	tipResponseCache struct {
+		ResponseCache
+		tip        atomic.Uint64
+		total, hit atomic.Uint64
+	}
+)
+
+var _responseCacheMtc = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "iotex_api_response_cache",
+	Help: "api response cache metrics.",
+}, []string{"result"})
+
+func init() {
+	prometheus.MustRegister(_responseCacheMtc)
+}
+
+// Hash returns the hash of key's json string
+func (k *ReadKey) Hash() hash.Hash160 {
+	b, _ := json.Marshal(k)
+	return hash.Hash160b(b)
+}
+
+// NewResponseCache creates the response cache of the backend in config
+func NewResponseCache(cfg ResponseCacheConfig) (ResponseCache, error) {
+	switch cfg.Backend {
+	case ResponseCacheLRU:
+		return NewLRUResponseCache(cfg.Size), nil
+	case ResponseCacheRedis:
+		client := redis.NewClient(&redis.Options{
+			Addr: cfg.RedisURL,
+		})
+		if err := client.Ping(context.Background()).Err(); err != nil {
+			return nil, errors.Wrapf(err, "failed to connect to redis server %s", cfg.RedisURL)
+		}
+		return NewRedisResponseCache(client, cfg.RedisPrefix, cfg.TTL), nil
+	case ResponseCacheNone, "":
+		return &noopResponseCache{}, nil
+	default:
+		return nil, errors.Errorf("unknown response cache backend %s", cfg.Backend)
+	}
+}
+
+// NewLRUResponseCache creates an in-memory response cache of the size
+func NewLRUResponseCache(size int) ResponseCache {
+	return &lruResponseCache{
+		c: cache.NewThreadSafeLruCache(size),
+	}
+}
+
+func (c *lruResponseCache) Get(height uint64, key hash.Hash160) ([]byte, bool) {
+	v, ok := c.c.Get(responseKey{height, key})
+	if !ok {
+		return nil, false
+	}
+	return v.([]byte), true
+}
+
+func (c *lruResponseCache) Put(height uint64, key hash.Hash160, value []byte) {
+	c.c.Add(responseKey{height, key}, value)
+}
+
+func (c *lruResponseCache) Revert(height uint64) {
+	var keys []cache.Key
+	c.c.Range(func(key cache.Key, _ interface{}) bool {
+		if key.(responseKey).height >= height {
+			keys = append(keys, key)
+		}
+		return true
+	})
+	for _, key := range keys {
+		c.c.Remove(key)
+	}
+}
+
+// NewRedisResponseCache creates a response cache on the redis-protocol server, the keys of the responses are
+// indexed by height in a sorted set, to revert them on reorg
+func NewRedisResponseCache(client *redis.Client, prefix string, ttl time.Duration) ResponseCache {
+	return &redisResponseCache{
+		client: client,
+		prefix: prefix,
+		ttl:    ttl,
+	}
+}
+
+func (c *redisResponseCache) key(height uint64, key hash.Hash160) string {
+	return c.prefix + ":" + strconv.FormatUint(height, 10) + ":" + hex.EncodeToString(key[:])
+}
+
+func (c *redisResponseCache) indexKey() string {
+	return c.prefix + ":heights"
+}
+
+func (c *redisResponseCache) Get(height uint64, key hash.Hash160) ([]byte, bool) {
+	ret, err := c.client.Get(context.Background(), c.key(height, key)).Bytes()
+	if err != nil {
+		if err != redis.Nil {
+			log.L().Debug("failed to get response from redis cache", zap.Error(err))
+		}
+		return nil, false
+	}
+	return ret, true
+}
+
+func (c *redisResponseCache) Put(height uint64, key hash.Hash160, value []byte) {
+	var (
+		ctx = context.Background()
+		k   = c.key(height, key)
+	)
+	_, err := c.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, k, value, c.ttl)
+		pipe.ZAdd(ctx, c.indexKey(), &redis.Z{Score: float64(height), Member: k})
+		if height > _redisIndexWindow {
+			pipe.ZRemRangeByScore(ctx, c.indexKey(), "-inf", "("+strconv.FormatUint(height-_redisIndexWindow, 10))
+		}
+		return nil
+	})
+	if err != nil {
+		log.L().Debug("failed to put response into redis cache", zap.Error(err))
+	}
+}
+
+func (c *redisResponseCache) Revert(height uint64) {
+	var (
+		ctx  = context.Background()
+		from = strconv.FormatUint(height, 10)
+	)
+	keys, err := c.client.ZRangeByScore(ctx, c.indexKey(), &redis.ZRangeBy{Min: from, Max: "+inf"}).Result()
+	if err != nil {
+		log.L().Error("failed to revert redis cache", zap.Uint64("height", height), zap.Error(err))
+		return
+	}
+	_, err = c.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		if len(keys) > 0 {
+			pipe.Unlink(ctx, keys...)
+		}
+		pipe.ZRemRangeByScore(ctx, c.indexKey(), from, "+inf")
+		return nil
+	})
+	if err != nil {
+		log.L().Error("failed to revert redis cache", zap.Uint64("height", height), zap.Error(err))
+	}
+}
+
+func (*noopResponseCache) Get(uint64, hash.Hash160) ([]byte, bool) { return nil, false }
+
+func (*noopResponseCache) Put(uint64, hash.Hash160, []byte) {}
+
+func (*noopResponseCache) Revert(uint64) {}
+
+func newTipResponseCache(c ResponseCache, tip uint64) *tipResponseCache {
+	tc := &tipResponseCache{ResponseCache: c}
+	tc.tip.Store(tip)
+	return tc
+}
+
+// Get returns the response of the key at the height, the responses above the tip are not served, which may be put
+// by another node sharing the cache
+func (c *tipResponseCache) Get(height uint64, key hash.Hash160) ([]byte, bool) {
+	if height > c.tip.Load() {
+		return nil, false
+	}
+	total := c.total.Add(1)
+	d, ok := c.ResponseCache.Get(height, key)
+	if !ok {
+		_responseCacheMtc.WithLabelValues("miss").Inc()
+		return nil, false
+	}
+	_responseCacheMtc.WithLabelValues("hit").Inc()
+	if hit := c.hit.Add(1); hit%100 == 0 {
+		log.Logger("api").Info("API cache hit", zap.Uint64("total", total), zap.Uint64("hit", hit))
+	}
+	return d, true
+}
+
+// ReceiveTip updates the tip, and reverts the responses at the tip and above if the tip is not higher than the
+// previous one, which means the blocks have been reverted
+func (c *tipResponseCache) ReceiveTip(height uint64) {
+	if prev := c.tip.Swap(height); height <= prev {
+		c.Revert(height)
+	}
+}
+
+// responseCacheKey returns the key of the response in the namespace with the arguments
+func responseCacheKey(ns string, args ...[]byte) hash.Hash160 {
+	return (&ReadKey{Name: ns, Args: args}).Hash()
+}
@@ -0,0 +1,110 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadKey(t *testing.T) {
+	r := require.New(t)
+
+	var keys []hash.Hash160
+	for _, v := range []ReadKey{
+		{"staking", "10", []byte("activeBuckets"), [][]byte{[]byte{0, 1}, []byte{2, 3, 4, 5, 6, 7, 8}}},
+		{"staking", "10", []byte("activeBuckets"), [][]byte{[]byte{0, 1, 2}, []byte{3, 4, 5, 6, 7, 8}}},
+		{"staking", "10", []byte("activeBuckets"), [][]byte{[]byte{0, 1, 2, 3}, []byte{4, 5, 6, 7, 8}}},
+		{"staking", "10", []byte("activeBuckets"), [][]byte{[]byte{0, 1, 2, 3, 4, 5}, []byte{6, 7, 8}}},
+		{"staking", "10", []byte("activeBuckets"), [][]byte{[]byte{0, 1, 2, 3, 4, 5, 6, 7}, []byte{8}}},
+	} {
+		keys = append(keys, v.Hash())
+	}
+
+	// all keys are different
+	for i := range keys {
+		k := keys[i]
+		for j := i + 1; j < len(keys); j++ {
+			r.NotEqual(k, keys[j])
+		}
+	}
+}
+
+func TestLRUResponseCache(t *testing.T) {
+	r := require.New(t)
+
+	c := NewLRUResponseCache(8)
+	rcTests := []struct {
+		h uint64
+		k hash.Hash160
+		v []byte
+	}{
+		{1, hash.Hash160b([]byte{1}), []byte{1}},
+		{1, hash.Hash160b([]byte{2}), []byte{2}},
+		{2, hash.Hash160b([]byte{1}), []byte{3}},
+		{3, hash.Hash160b([]byte{2}), []byte{4}},
+	}
+	for _, v := range rcTests {
+		d, ok := c.Get(v.h, v.k)
+		r.False(ok)
+		r.Nil(d)
+		c.Put(v.h, v.k, v.v)
+	}
+	for _, v := range rcTests {
+		d, ok := c.Get(v.h, v.k)
+		r.True(ok)
+		r.Equal(v.v, d)
+	}
+
+	c.Revert(2)
+	for _, v := range rcTests {
+		d, ok := c.Get(v.h, v.k)
+		r.Equal(v.h < 2, ok)
+		if !ok {
+			r.Nil(d)
+		}
+	}
+}
+
+func TestTipResponseCache(t *testing.T) {
+	r := require.New(t)
+
+	c := newTipResponseCache(NewLRUResponseCache(8), 2)
+	k := hash.Hash160b([]byte{1})
+	for h := uint64(1); h <= 3; h++ {
+		c.Put(h, k, []byte{byte(h)})
+	}
+	_, ok := c.Get(2, k)
+	r.True(ok)
+	// the response above the tip is not served
+	_, ok = c.Get(3, k)
+	r.False(ok)
+	c.ReceiveTip(3)
+	d, ok := c.Get(3, k)
+	r.True(ok)
+	r.Equal([]byte{3}, d)
+
+	// reorg to height 2 reverts the responses at height 2 and above
+	c.ReceiveTip(2)
+	for h := uint64(1); h <= 3; h++ {
+		_, ok = c.Get(h, k)
+		r.Equal(h < 2, ok)
+	}
+}
+
+func TestNewResponseCache(t *testing.T) {
+	r := require.New(t)
+
+	c, err := NewResponseCache(DefaultConfig.ResponseCache)
+	r.NoError(err)
+	r.IsType(&lruResponseCache{}, c)
+	c, err = NewResponseCache(ResponseCacheConfig{Backend: ResponseCacheNone})
+	r.NoError(err)
+	c.Put(1, hash.ZeroHash160, []byte{1})
+	_, ok := c.Get(1, hash.ZeroHash160)
+	r.False(ok)
+	_, err = NewResponseCache(ResponseCacheConfig{Backend: ResponseCacheRedis, RedisURL: "127.0.0.1:1"})
+	r.ErrorContains(err, "failed to connect to redis server")
+	_, err = NewResponseCache(ResponseCacheConfig{Backend: "memcached"})
+	r.ErrorContains(err, "unknown response cache backend")
+}
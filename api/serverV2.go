@@ -31,6 +31,7 @@ type ServerV2 struct {
 	grpcServer   *GRPCServer
 	httpSvr      *HTTPServer
 	websocketSvr *HTTPServer
+	graphQLSvr   *HTTPServer
 	tracer       *tracesdk.TracerProvider
 }
 
@@ -52,7 +53,8 @@ func NewServerV2(
 	if err != nil {
 		return nil, err
 	}
-	web3Handler := NewWeb3Handler(coreAPI, cfg.RedisCacheURL, cfg.BatchRequestLimit)
+	rateLimiter := NewRateLimiter(cfg.RateLimit)
+	web3Handler := NewWeb3Handler(coreAPI, cfg.RedisCacheURL, cfg.BatchRequestLimit, rateLimiter)
 
 	tp, err := tracer.NewProvider(
 		tracer.WithServiceName(cfg.Tracer.ServiceName),
@@ -69,11 +71,21 @@ func NewServerV2(
 	limiter := rate.NewLimiter(rate.Limit(cfg.WebsocketRateLimit), 1)
 	wrappedWebsocketHandler := otelhttp.NewHandler(NewWebsocketHandler(coreAPI, web3Handler, limiter), "web3.websocket")
 
+	var graphQLSvr *HTTPServer
+	if cfg.GraphQLPort > 0 {
+		graphQLHandler, err := NewGraphQLHandler(coreAPI, cfg.GraphQL, rateLimiter)
+		if err != nil {
+			return nil, err
+		}
+		graphQLSvr = NewHTTPServer("", cfg.GraphQLPort, otelhttp.NewHandler(graphQLHandler, "graphql"))
+	}
+
 	return &ServerV2{
 		core:         coreAPI,
-		grpcServer:   NewGRPCServer(coreAPI, newBlockDAOService(dao), cfg.GRPCPort),
+		grpcServer:   NewGRPCServer(coreAPI, newBlockDAOService(dao), cfg.GRPCPort, rateLimiter),
 		httpSvr:      NewHTTPServer("", cfg.HTTPPort, wrappedWeb3Handler),
 		websocketSvr: NewHTTPServer("", cfg.WebSocketPort, wrappedWebsocketHandler),
+		graphQLSvr:   graphQLSvr,
 		tracer:       tp,
 	}, nil
 }
@@ -98,6 +110,11 @@ func (svr *ServerV2) Start(ctx context.Context) error {
 			return err
 		}
 	}
+	if svr.graphQLSvr != nil {
+		if err := svr.graphQLSvr.Start(ctx); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -108,6 +125,11 @@ func (svr *ServerV2) Stop(ctx context.Context) error {
 			return errors.Wrap(err, "failed to shutdown api tracer")
 		}
 	}
+	if svr.graphQLSvr != nil {
+		if err := svr.graphQLSvr.Stop(ctx); err != nil {
+			return err
+		}
+	}
 	if svr.websocketSvr != nil {
 		if err := svr.websocketSvr.Stop(ctx); err != nil {
 			return err
@@ -427,7 +427,7 @@ func newConfig() testConfig {
 	return cfg
 }
 
-func createServerV2(cfg testConfig, needActPool bool) (*ServerV2, blockchain.Blockchain, blockdao.BlockDAO, blockindex.Indexer, *protocol.Registry, actpool.ActPool, string, error) {
+func createServerV2(cfg testConfig, needActPool bool, opts ...Option) (*ServerV2, blockchain.Blockchain, blockdao.BlockDAO, blockindex.Indexer, *protocol.Registry, actpool.ActPool, string, error) {
 	// TODO (zhi): revise
 	bc, dao, indexer, bfIndexer, sf, ap, registry, bfIndexFile, err := setupChain(cfg)
 	if err != nil {
@@ -452,9 +452,9 @@ func createServerV2(cfg testConfig, needActPool bool) (*ServerV2, blockchain.Blo
 			return nil, nil, nil, nil, nil, nil, "", err
 		}
 	}
-	opts := []Option{WithBroadcastOutbound(func(ctx context.Context, chainID uint32, msg proto.Message) error {
+	opts = append(opts, WithBroadcastOutbound(func(ctx context.Context, chainID uint32, msg proto.Message) error {
 		return nil
-	})}
+	}))
 	svr, err := NewServerV2(cfg.api, bc, nil, sf, dao, indexer, bfIndexer, ap, registry, func(u uint64) (time.Time, error) { return time.Time{}, nil }, opts...)
 	if err != nil {
 		return nil, nil, nil, nil, nil, nil, "", err
@@ -3,12 +3,18 @@ package apitypes
 import (
 	"encoding/json"
 	"errors"
+	"math/big"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-address/address"
 
 	"github.com/iotexproject/iotex-core/v2/action"
+	"github.com/iotexproject/iotex-core/v2/action/protocol"
 	"github.com/iotexproject/iotex-core/v2/blockchain/block"
+	"github.com/iotexproject/iotex-core/v2/state"
 )
 
 // MaxResponseSize is the max size of response
@@ -35,11 +41,41 @@ type (
 		RemoveResponder(string) (bool, error)
 	}
 
+	// ActionResponder responds to new pending action
+	ActionResponder interface {
+		RespondAction(string, *action.SealedEnvelope) error
+		Exit()
+	}
+
+	// ActionListener pass new pending action in actpool to all responders
+	ActionListener interface {
+		Start() error
+		Stop() error
+		OnAdded(*action.SealedEnvelope)
+		OnRemoved(*action.SealedEnvelope)
+		AddResponder(ActionResponder) (string, error)
+		RemoveResponder(string) (bool, error)
+	}
+
 	// BlockWithReceipts includes block and its receipts
 	BlockWithReceipts struct {
 		Block    *block.Block
 		Receipts []*action.Receipt
 	}
+	// AccountProof is the merkle proof of an account and its storage slots
+	AccountProof struct {
+		Height       uint64
+		StateRoot    []byte
+		Account      *state.Account
+		AccountProof [][]byte
+		StorageProof []*StorageProof
+	}
+	// StorageProof is the merkle proof of a storage slot
+	StorageProof struct {
+		Key   hash.Hash256
+		Value []byte
+		Proof [][]byte
+	}
 	// BlobSidecarResult is the result of get blob sidecar
 	BlobSidecarResult struct {
 		BlobSidecar *types.BlobTxSidecar `json:"blobSidecar"`
@@ -48,6 +84,73 @@ type (
 		TxIndex     uint64               `json:"txIndex"`
 		TxHash      common.Hash          `json:"txHash"`
 	}
+	// SimulateBlock is a block of calls to simulate, the block and state overrides in the options are applied
+	// before the calls
+	SimulateBlock struct {
+		Options []protocol.SimulateOption
+		Calls   []*SimulateCall
+	}
+	// SimulateCall is a call to simulate
+	SimulateCall struct {
+		Caller   address.Address
+		Envelope action.Envelope
+	}
+	// SimulatedBlock is the result of a simulated block
+	SimulatedBlock struct {
+		Hash       hash.Hash256
+		ParentHash hash.Hash256
+		Height     uint64
+		Timestamp  time.Time
+		GasLimit   uint64
+		GasUsed    uint64
+		BaseFee    *big.Int
+		Calls      []*SimulatedCall
+	}
+	// SimulatedCall is the result of a simulated call
+	SimulatedCall struct {
+		ActionHash hash.Hash256
+		ReturnData []byte
+		Receipt    *action.Receipt
+	}
+	// DelegateRewardProjection is the projected rewards of a bucket voting for a candidate in the upcoming epochs
+	DelegateRewardProjection struct {
+		BucketVotes *big.Int
+		Epochs      []*EpochRewardProjection
+	}
+	// EpochRewardProjection is the projected rewards of the candidate and the bucket in an epoch
+	EpochRewardProjection struct {
+		EpochNumber uint64
+		// CandidateVotes is the votes of the candidate including the bucket, before the probation
+		CandidateVotes *big.Int
+		// Probation is true if the votes of the candidate are reduced by the probation list of current epoch
+		Probation       bool
+		EpochReward     *big.Int
+		FoundationBonus *big.Int
+		// BucketReward is the share of the bucket in the rewards distributed by the delegate at the distribution
+		// ratio, which is declared by the delegate off chain
+		BucketReward *big.Int
+	}
+	// ActionCallTraces is the call traces of an action in a block
+	ActionCallTraces struct {
+		BlockHeight uint64
+		BlockHash   hash.Hash256
+		ActionHash  hash.Hash256
+		// Index is the index of the action in the block
+		Index  uint32
+		Traces []*action.CallTrace
+	}
+	// CallTraceFilter is the filter of the call traces in a block range, a call trace matches the filter if it is
+	// from any of the FromAddresses and to any of the ToAddresses, an empty address list matches any address
+	CallTraceFilter struct {
+		FromBlock     uint64
+		ToBlock       uint64
+		FromAddresses []address.Address
+		ToAddresses   []address.Address
+		// After is the number of call traces matched to skip
+		After uint64
+		// Count is the max number of call traces to return, 0 means no limit
+		Count uint64
+	}
 )
 
 // responseWriter for server
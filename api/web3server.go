@@ -7,16 +7,18 @@ import (
 	"fmt"
 	"io"
 	"math/big"
+	"sort"
 	"strconv"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
-	"github.com/ethereum/go-ethereum/eth/tracers"
-	"github.com/ethereum/go-ethereum/eth/tracers/logger"
+	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/iotexproject/go-pkgs/crypto"
 	"github.com/iotexproject/go-pkgs/hash"
 	"github.com/iotexproject/go-pkgs/util"
 	"github.com/iotexproject/iotex-address/address"
+	"github.com/iotexproject/iotex-proto/golang/iotexapi"
 	"github.com/iotexproject/iotex-proto/golang/iotextypes"
 	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus"
@@ -38,6 +40,13 @@ const (
 	_metamaskBalanceContractAddr = "io1k8uw2hrlvnfq8s2qpwwc24ws2ru54heenx8chr"
 	// _defaultBatchRequestLimit is the default maximum number of items in a batch.
 	_defaultBatchRequestLimit = 100 // Maximum number of items in a batch.
+	// _maxPendingTransactions is the maximum number of transactions returned by eth_pendingTransactions.
+	_maxPendingTransactions = 1000
+	// _maxProofStorageKeys is the maximum number of storage keys proved by eth_getProof, each costing a walk of the
+	// storage trie
+	_maxProofStorageKeys = 100
+	// _invalidParamsCode is the JSON-RPC error code of invalid method parameters
+	_invalidParamsCode = -32602
 )
 
 type (
@@ -50,6 +59,7 @@ type (
 		coreService       CoreService
 		cache             apiCache
 		batchRequestLimit int
+		limiter           *RateLimiter
 	}
 )
 
@@ -86,6 +96,7 @@ var (
 	errMsgBatchTooLarge  = errors.New("batch too large")
 	errHTTPNotSupported  = errors.New("http not supported")
 	errPanic             = errors.New("panic")
+	errTooManyKeys       = errors.New("too many storage keys")
 
 	_pendingBlockNumber  = "pending"
 	_latestBlockNumber   = "latest"
@@ -97,12 +108,13 @@ func init() {
 	prometheus.MustRegister(_web3ServerLatency)
 }
 
-// NewWeb3Handler creates a handle to process web3 requests
-func NewWeb3Handler(core CoreService, cacheURL string, batchRequestLimit int) Web3Handler {
+// NewWeb3Handler creates a handle to process web3 requests, a nil limiter means no rate limit
+func NewWeb3Handler(core CoreService, cacheURL string, batchRequestLimit int, limiter *RateLimiter) Web3Handler {
 	return &web3Handler{
 		coreService:       core,
 		cache:             newAPICache(15*time.Minute, cacheURL),
 		batchRequestLimit: batchRequestLimit,
+		limiter:           limiter,
 	}
 }
 
@@ -162,6 +174,13 @@ func (svr *web3Handler) handleWeb3Req(ctx context.Context, web3Req *gjson.Result
 	log.T(ctx).Debug("handleWeb3Req", zap.String("method", method.(string)), zap.String("requestParams", fmt.Sprintf("%+v", web3Req)))
 	_web3ServerMtc.WithLabelValues(method.(string)).Inc()
 	_web3ServerMtc.WithLabelValues("requests_total").Inc()
+	if err = svr.checkRateLimit(ctx, method.(string), web3Req); err != nil {
+		size, err1 = writer.Write(&web3Response{
+			id:  web3ReqID(web3Req),
+			err: err,
+		})
+		return err1
+	}
 	switch method {
 	case "eth_accounts":
 		res, err = svr.ethAccounts()
@@ -215,6 +234,10 @@ func (svr *web3Handler) handleWeb3Req(ctx context.Context, web3Req *gjson.Result
 		res, err = svr.getBlockByNumber(web3Req)
 	case "eth_estimateGas":
 		res, err = svr.estimateGas(ctx, web3Req)
+	case "eth_createAccessList":
+		res, err = svr.createAccessList(ctx, web3Req)
+	case "eth_simulateV1":
+		res, err = svr.simulateV1(ctx, web3Req)
 	case "eth_sendRawTransaction":
 		res, err = svr.sendRawTransaction(ctx, web3Req)
 	case "eth_getTransactionByHash":
@@ -227,8 +250,12 @@ func (svr *web3Handler) handleWeb3Req(ctx context.Context, web3Req *gjson.Result
 		res, err = svr.getBlockTransactionCountByNumber(web3Req)
 	case "eth_getTransactionReceipt":
 		res, err = svr.getTransactionReceipt(web3Req)
+	case "eth_getBlockReceipts":
+		res, err = svr.getBlockReceipts(web3Req)
 	case "eth_getStorageAt":
 		res, err = svr.getStorageAt(web3Req)
+	case "eth_getProof":
+		res, err = svr.getProof(web3Req)
 	case "eth_getFilterLogs":
 		res, err = svr.getFilterLogs(web3Req)
 	case "eth_getFilterChanges":
@@ -248,19 +275,40 @@ func (svr *web3Handler) handleWeb3Req(ctx context.Context, web3Req *gjson.Result
 		if !ok {
 			return errHTTPNotSupported
 		}
-		res, err = svr.subscribe(sc, web3Req, writer)
+		// the subscription is counted as an open stream of the client until unsubscribed or disconnected
+		var release func()
+		if release, err = svr.limiter.AcquireStream(svr.limiter.clientFromContext(ctx), method.(string)); err == nil {
+			if res, err = svr.subscribe(sc, web3Req, writer, release); err != nil {
+				release()
+			}
+		}
 	case "eth_unsubscribe":
-		res, err = svr.unsubscribe(web3Req)
+		res, err = svr.unsubscribe(ctx, web3Req)
 	case "eth_getBlobSidecars":
 		res, err = svr.getBlobSidecars(web3Req)
-	//TODO: enable debug api after archive mode is supported
-	// case "debug_traceTransaction":
-	// 	res, err = svr.traceTransaction(ctx, web3Req)
-	// case "debug_traceCall":
-	// 	res, err = svr.traceCall(ctx, web3Req)
+	case "debug_traceTransaction":
+		res, err = svr.traceTransaction(ctx, web3Req)
+	case "debug_traceCall":
+		res, err = svr.traceCall(ctx, web3Req)
+	case "trace_block":
+		res, err = svr.traceBlock(ctx, web3Req)
+	case "trace_transaction":
+		res, err = svr.traceActionCalls(ctx, web3Req)
+	case "trace_filter":
+		res, err = svr.traceFilter(ctx, web3Req)
+	case "iotex_getTokenTransfers":
+		res, err = svr.getTokenTransfers(web3Req)
+	case "eth_pendingTransactions":
+		res, err = svr.pendingTransactions()
+	case "txpool_content":
+		res, err = svr.txpoolContent()
+	case "txpool_status":
+		res, err = svr.txpoolStatus()
+	case "txpool_inspect":
+		res, err = svr.txpoolInspect()
 	case "eth_coinbase", "eth_getUncleCountByBlockHash", "eth_getUncleCountByBlockNumber",
 		"eth_sign", "eth_signTransaction", "eth_sendTransaction", "eth_getUncleByBlockHashAndIndex",
-		"eth_getUncleByBlockNumberAndIndex", "eth_pendingTransactions":
+		"eth_getUncleByBlockNumberAndIndex":
 		res, err = svr.unimplemented()
 	default:
 		res, err = nil, errors.Wrapf(errors.New("web3 method not found"), "method: %s\n", web3Req.Get("method"))
@@ -413,14 +461,11 @@ func (svr *web3Handler) getBalance(in *gjson.Result) (interface{}, error) {
 		return nil, err
 	}
 	bnParam := in.Get("params.1")
-	bn, err := parseBlockNumber(&bnParam)
+	height, archive, err := svr.parseStateHeight(&bnParam)
 	if err != nil {
 		return nil, err
 	}
-	var (
-		accountMeta     *iotextypes.AccountMeta
-		height, archive = blockNumberToHeight(bn)
-	)
+	var accountMeta *iotextypes.AccountMeta
 	if !archive {
 		accountMeta, _, err = svr.coreService.Account(ioAddr)
 	} else {
@@ -442,9 +487,22 @@ func (svr *web3Handler) getTransactionCount(in *gjson.Result) (interface{}, erro
 	if err != nil {
 		return nil, err
 	}
-	// TODO (liuhaai): returns the nonce in given block height after archive mode is supported
-	// blkNum, err := getStringFromArray(in, 1)
-	pendingNonce, err := svr.coreService.PendingNonce(ioAddr)
+	var (
+		bnParam         = in.Get("params.1")
+		height, archive = uint64(0), false
+	)
+	// the pending nonce in actpool is returned for the pending block
+	if bnParam.String() != _pendingBlockNumber {
+		if height, archive, err = svr.parseStateHeight(&bnParam); err != nil {
+			return nil, err
+		}
+	}
+	var pendingNonce uint64
+	if !archive {
+		pendingNonce, err = svr.coreService.PendingNonce(ioAddr)
+	} else {
+		pendingNonce, err = svr.coreService.WithHeight(height).PendingNonce(ioAddr)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -452,7 +510,11 @@ func (svr *web3Handler) getTransactionCount(in *gjson.Result) (interface{}, erro
 }
 
 func (svr *web3Handler) call(ctx context.Context, in *gjson.Result) (interface{}, error) {
-	callMsg, err := parseCallObject(in)
+	callMsg, err := parseCallObjectWithOverrides(in)
+	if err != nil {
+		return nil, err
+	}
+	height, archive, err := svr.blockNumberOrHashToHeight(callMsg.BlockNumber, callMsg.BlockHash)
 	if err != nil {
 		return nil, err
 	}
@@ -468,7 +530,12 @@ func (svr *web3Handler) call(ctx context.Context, in *gjson.Result) (interface{}
 		if err != nil {
 			return nil, err
 		}
-		states, err := svr.coreService.ReadState("staking", "", sctx.Parameters().MethodName, sctx.Parameters().Arguments)
+		var states *iotexapi.ReadStateResponse
+		if !archive {
+			states, err = svr.coreService.ReadState("staking", "", sctx.Parameters().MethodName, sctx.Parameters().Arguments)
+		} else {
+			states, err = svr.coreService.WithHeight(height).ReadState("staking", sctx.Parameters().MethodName, sctx.Parameters().Arguments)
+		}
 		if err != nil {
 			return nil, err
 		}
@@ -483,7 +550,12 @@ func (svr *web3Handler) call(ctx context.Context, in *gjson.Result) (interface{}
 		if err != nil {
 			return nil, err
 		}
-		states, err := svr.coreService.ReadState("rewarding", "", sctx.Parameters().MethodName, sctx.Parameters().Arguments)
+		var states *iotexapi.ReadStateResponse
+		if !archive {
+			states, err = svr.coreService.ReadState("rewarding", "", sctx.Parameters().MethodName, sctx.Parameters().Arguments)
+		} else {
+			states, err = svr.coreService.WithHeight(height).ReadState("rewarding", sctx.Parameters().MethodName, sctx.Parameters().Arguments)
+		}
 		if err != nil {
 			return nil, err
 		}
@@ -496,14 +568,13 @@ func (svr *web3Handler) call(ctx context.Context, in *gjson.Result) (interface{}
 	var (
 		elp = (&action.EnvelopeBuilder{}).SetAction(action.NewExecution(to, callMsg.Value, data)).
 			SetGasLimit(callMsg.Gas).Build()
-		ret             string
-		receipt         *iotextypes.Receipt
-		height, archive = blockNumberToHeight(callMsg.BlockNumber)
+		ret     string
+		receipt *iotextypes.Receipt
 	)
 	if !archive {
-		ret, receipt, err = svr.coreService.ReadContract(context.Background(), callMsg.From, elp)
+		ret, receipt, err = svr.coreService.ReadContract(context.Background(), callMsg.From, elp, callMsg.simulateOptions()...)
 	} else {
-		ret, receipt, err = svr.coreService.WithHeight(height).ReadContract(context.Background(), callMsg.From, elp)
+		ret, receipt, err = svr.coreService.WithHeight(height).ReadContract(context.Background(), callMsg.From, elp, callMsg.simulateOptions()...)
 	}
 	if err != nil {
 		return nil, err
@@ -518,7 +589,7 @@ func (svr *web3Handler) call(ctx context.Context, in *gjson.Result) (interface{}
 }
 
 func (svr *web3Handler) estimateGas(ctx context.Context, in *gjson.Result) (interface{}, error) {
-	callMsg, err := parseCallObject(in)
+	callMsg, err := parseCallObjectWithOverrides(in)
 	if err != nil {
 		return nil, err
 	}
@@ -531,6 +602,10 @@ func (svr *web3Handler) estimateGas(ctx context.Context, in *gjson.Result) (inte
 		return nil, err
 	}
 
+	height, archive, err := svr.blockNumberOrHashToHeight(callMsg.BlockNumber, callMsg.BlockHash)
+	if err != nil {
+		return nil, err
+	}
 	var (
 		estimatedGas uint64
 		retval       []byte
@@ -538,8 +613,15 @@ func (svr *web3Handler) estimateGas(ctx context.Context, in *gjson.Result) (inte
 	)
 	switch act := elp.Action().(type) {
 	case *action.Execution:
-		estimatedGas, retval, err = svr.coreService.EstimateExecutionGasConsumption(ctx, elp, from)
+		if !archive {
+			estimatedGas, retval, err = svr.coreService.EstimateExecutionGasConsumption(ctx, elp, from, callMsg.simulateOptions()...)
+		} else {
+			estimatedGas, retval, err = svr.coreService.WithHeight(height).EstimateExecutionGasConsumption(ctx, elp, from, callMsg.simulateOptions()...)
+		}
 	case *action.MigrateStake:
+		if archive {
+			return nil, errors.Wrap(errNotImplemented, "estimating gas of migrate stake at a past block is not supported")
+		}
 		estimatedGas, retval, err = svr.coreService.EstimateMigrateStakeGasConsumption(ctx, act, from)
 	default:
 		estimatedGas, err = svr.coreService.EstimateGasForNonExecution(act)
@@ -553,6 +635,99 @@ func (svr *web3Handler) estimateGas(ctx context.Context, in *gjson.Result) (inte
 	return uint64ToHex(estimatedGas), nil
 }
 
+func (svr *web3Handler) createAccessList(ctx context.Context, in *gjson.Result) (interface{}, error) {
+	callMsg, err := parseCallObjectWithOverrides(in)
+	if err != nil {
+		return nil, err
+	}
+	height, archive, err := svr.blockNumberOrHashToHeight(callMsg.BlockNumber, callMsg.BlockHash)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		elp = (&action.EnvelopeBuilder{}).SetTxType(action.AccessListTxType).
+			SetAction(action.NewExecution(callMsg.To, callMsg.Value, callMsg.Data)).
+			SetGasLimit(callMsg.Gas).SetAccessList(callMsg.AccessList).Build()
+		acl     types.AccessList
+		receipt *action.Receipt
+	)
+	if !archive {
+		acl, receipt, err = svr.coreService.CreateAccessList(ctx, callMsg.From, elp, callMsg.simulateOptions()...)
+	} else {
+		acl, receipt, err = svr.coreService.WithHeight(height).CreateAccessList(ctx, callMsg.From, elp, callMsg.simulateOptions()...)
+	}
+	if err != nil {
+		return nil, err
+	}
+	res := &createAccessListResult{
+		AccessList: acl,
+		GasUsed:    uint64ToHex(receipt.GasConsumed),
+	}
+	if acl == nil {
+		res.AccessList = types.AccessList{}
+	}
+	switch receipt.Status {
+	case uint64(iotextypes.ReceiptStatus_Success):
+	case uint64(iotextypes.ReceiptStatus_ErrExecutionReverted):
+		res.Error = "execution reverted"
+		if msg := receipt.ExecutionRevertMsg(); len(msg) > 0 {
+			res.Error += ": " + msg
+		}
+	default:
+		res.Error = fmt.Sprintf("execution failed: status = %d", receipt.Status)
+	}
+	return res, nil
+}
+
+func (svr *web3Handler) simulateV1(ctx context.Context, in *gjson.Result) (interface{}, error) {
+	blockStateCalls, err := parseSimulateBlockStateCalls(in)
+	if err != nil {
+		return nil, err
+	}
+	bnParam := in.Get("params.1")
+	bn, blkHash, err := parseBlockNumberOrHash(&bnParam)
+	if err != nil {
+		return nil, err
+	}
+	height, archive, err := svr.blockNumberOrHashToHeight(bn, blkHash)
+	if err != nil {
+		return nil, err
+	}
+	blocks := make([]*apitypes.SimulateBlock, 0, len(blockStateCalls))
+	for _, bsc := range blockStateCalls {
+		blk := &apitypes.SimulateBlock{
+			Options: simulateOptions(bsc.StateOverride, bsc.BlockOverride),
+			Calls:   make([]*apitypes.SimulateCall, 0, len(bsc.Calls)),
+		}
+		for _, call := range bsc.Calls {
+			tx, err := call.toUnsignedTx(svr.coreService.EVMNetworkID())
+			if err != nil {
+				return nil, err
+			}
+			elp, err := svr.simulateTxToEnvelope(tx)
+			if err != nil {
+				return nil, err
+			}
+			blk.Calls = append(blk.Calls, &apitypes.SimulateCall{Caller: call.From, Envelope: elp})
+		}
+		blocks = append(blocks, blk)
+	}
+	var simulated []*apitypes.SimulatedBlock
+	if !archive {
+		simulated, err = svr.coreService.SimulateBlocks(ctx, blocks)
+	} else {
+		simulated, err = svr.coreService.WithHeight(height).SimulateBlocks(ctx, blocks)
+	}
+	if err != nil {
+		return nil, err
+	}
+	ret := make([]*simulateBlockResult, 0, len(simulated))
+	for _, blk := range simulated {
+		ret = append(ret, &simulateBlockResult{blk})
+	}
+	return ret, nil
+}
+
 func (svr *web3Handler) sendRawTransaction(ctx context.Context, in *gjson.Result) (interface{}, error) {
 	dataStr := in.Get("params.0")
 	if !dataStr.Exists() {
@@ -633,7 +808,17 @@ func (svr *web3Handler) getCode(in *gjson.Result) (interface{}, error) {
 	if err != nil {
 		return nil, err
 	}
-	accountMeta, _, err := svr.coreService.Account(ioAddr)
+	bnParam := in.Get("params.1")
+	height, archive, err := svr.parseStateHeight(&bnParam)
+	if err != nil {
+		return nil, err
+	}
+	var accountMeta *iotextypes.AccountMeta
+	if !archive {
+		accountMeta, _, err = svr.coreService.Account(ioAddr)
+	} else {
+		accountMeta, _, err = svr.coreService.WithHeight(height).Account(ioAddr)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -773,13 +958,18 @@ func (svr *web3Handler) getTransactionReceipt(in *gjson.Result) (interface{}, er
 	if err != nil {
 		return nil, err
 	}
-	receipt, err := svr.coreService.ReceiptByActionHash(actHash)
+	// the receipts of the block are read once for the receipt and the gas consumed up to it
+	receipts, err := svr.coreService.ReceiptsByHeight(blk.Height())
 	if err != nil {
 		if errors.Cause(err) == ErrNotFound {
 			return nil, nil
 		}
 		return nil, err
 	}
+	receipt := filterReceipts(receipts, actHash)
+	if receipt == nil {
+		return nil, nil
+	}
 	to, contractAddr, err := getRecipientAndContractAddrFromAction(selp, receipt)
 	if err != nil {
 		return nil, err
@@ -791,15 +981,96 @@ func (svr *web3Handler) getTransactionReceipt(in *gjson.Result) (interface{}, er
 		logsBloomStr = hex.EncodeToString(logsBloom.Bytes())
 	}
 	return &getReceiptResult{
-		blockHash:       blk.HashBlock(),
-		from:            selp.SenderAddress(),
-		to:              to,
-		contractAddress: contractAddr,
-		logsBloom:       logsBloomStr,
-		receipt:         receipt,
-		txType:          uint(tx.Type()),
+		blockHash:         blk.HashBlock(),
+		from:              selp.SenderAddress(),
+		to:                to,
+		contractAddress:   contractAddr,
+		logsBloom:         logsBloomStr,
+		receipt:           receipt,
+		txType:            uint(tx.Type()),
+		cumulativeGasUsed: gasUsedUpTo(receipts, actHash),
 	}, nil
+}
+
+// gasUsedUpTo returns the gas consumed by the actions in the block up to the action, in the order of the
+// receipts which is the order of the actions, including the actions which are not ethereum transactions
+func gasUsedUpTo(receipts []*action.Receipt, actHash hash.Hash256) uint64 {
+	var gasUsed uint64
+	for _, receipt := range receipts {
+		gasUsed += receipt.GasConsumed
+		if receipt.ActionHash == actHash {
+			break
+		}
+	}
+	return gasUsed
+}
+
+func (svr *web3Handler) getBlockReceipts(in *gjson.Result) (interface{}, error) {
+	blkParam := in.Get("params.0")
+	if !blkParam.Exists() {
+		return nil, errInvalidFormat
+	}
+	var bnOrHash rpc.BlockNumberOrHash
+	if err := bnOrHash.UnmarshalJSON([]byte(blkParam.Raw)); err != nil {
+		return nil, errors.Wrapf(errUnkownType, "block: %s", blkParam.Raw)
+	}
+	var (
+		blk *apitypes.BlockWithReceipts
+		err error
+	)
+	if h, ok := bnOrHash.Hash(); ok {
+		blk, err = svr.coreService.BlockByHash(util.Remove0xPrefix(h.Hex()))
+	} else {
+		bn, _ := bnOrHash.Number()
+		height, archive := blockNumberToHeight(bn)
+		if !archive || bn == rpc.PendingBlockNumber {
+			height = svr.coreService.TipHeight()
+		}
+		blk, err = svr.coreService.BlockByHeight(height)
+	}
+	if err != nil {
+		if errors.Cause(err) == ErrNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
 
+	var (
+		blkHash           = blk.Block.HashBlock()
+		receipts          = blockReceipts(blk.Block, blk.Receipts)
+		ret               = make([]*getReceiptResult, 0, len(receipts))
+		cumulativeGasUsed uint64
+		logsBloomStr      string
+	)
+	if logsBloom := blk.Block.LogsBloomfilter(); logsBloom != nil {
+		logsBloomStr = hex.EncodeToString(logsBloom.Bytes())
+	}
+	for _, receipt := range receipts {
+		// the gas of the actions which are not ethereum transactions is counted as well, as in the receipt of
+		// a single transaction
+		cumulativeGasUsed += receipt.GasConsumed
+		selp := blk.Block.Actions[receipt.TxIndex]
+		tx, err := selp.ToEthTx()
+		if err != nil {
+			logUnassembledAction(selp, err)
+			continue
+		}
+		to, contractAddr, err := getRecipientAndContractAddrFromAction(selp, receipt)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, &getReceiptResult{
+			blockHash:         blkHash,
+			from:              selp.SenderAddress(),
+			to:                to,
+			contractAddress:   contractAddr,
+			logsBloom:         logsBloomStr,
+			receipt:           receipt,
+			txType:            uint(tx.Type()),
+			cumulativeGasUsed: cumulativeGasUsed,
+		})
+	}
+	return ret, nil
 }
 
 func (svr *web3Handler) getBlockTransactionCountByNumber(in *gjson.Result) (interface{}, error) {
@@ -881,13 +1152,66 @@ func (svr *web3Handler) getStorageAt(in *gjson.Result) (interface{}, error) {
 	if err != nil {
 		return nil, err
 	}
-	val, err := svr.coreService.ReadContractStorage(context.Background(), contractAddr, pos)
+	bnParam := in.Get("params.2")
+	height, archive, err := svr.parseStateHeight(&bnParam)
+	if err != nil {
+		return nil, err
+	}
+	var val []byte
+	if !archive {
+		val, err = svr.coreService.ReadContractStorage(context.Background(), contractAddr, pos)
+	} else {
+		val, err = svr.coreService.WithHeight(height).ReadContractStorage(context.Background(), contractAddr, pos)
+	}
 	if err != nil {
 		return nil, err
 	}
 	return "0x" + hex.EncodeToString(val), nil
 }
 
+func (svr *web3Handler) getProof(in *gjson.Result) (interface{}, error) {
+	ethAddr, keys := in.Get("params.0"), in.Get("params.1")
+	if !ethAddr.Exists() || !keys.Exists() || !keys.IsArray() {
+		return nil, errInvalidFormat
+	}
+	ioAddr, err := ethAddrToIoAddr(ethAddr.String())
+	if err != nil {
+		return nil, err
+	}
+	if n := len(keys.Array()); n > _maxProofStorageKeys {
+		return nil, errors.Wrapf(errTooManyKeys, "%d keys exceed the limit %d", n, _maxProofStorageKeys)
+	}
+	storageKeys := make([]hash.Hash256, 0, len(keys.Array()))
+	for _, key := range keys.Array() {
+		pos, err := hexToBytes(key.String())
+		if err != nil {
+			return nil, err
+		}
+		if len(pos) > len(hash.Hash256{}) {
+			return nil, errors.Wrapf(errInvalidFormat, "invalid storage key %s", key.String())
+		}
+		storageKeys = append(storageKeys, hash.BytesToHash256(common.BytesToHash(pos).Bytes()))
+	}
+	bnParam := in.Get("params.2")
+	height, archive, err := svr.parseStateHeight(&bnParam)
+	if err != nil {
+		return nil, err
+	}
+	var proof *apitypes.AccountProof
+	if !archive {
+		proof, err = svr.coreService.AccountProof(context.Background(), ioAddr, storageKeys)
+	} else {
+		proof, err = svr.coreService.WithHeight(height).AccountProof(context.Background(), ioAddr, storageKeys)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &getProofResult{
+		address: common.BytesToAddress(ioAddr.Bytes()),
+		proof:   proof,
+	}, nil
+}
+
 func (svr *web3Handler) newFilter(filter *filterObject) (interface{}, error) {
 	//check the validity of filter before caching
 	if filter == nil {
@@ -1027,36 +1351,38 @@ func (svr *web3Handler) getFilterLogs(in *gjson.Result) (interface{}, error) {
 	return svr.getLogsWithFilter(from, to, filterObj.Address, filterObj.Topics)
 }
 
-func (svr *web3Handler) subscribe(ctx *StreamContext, in *gjson.Result, writer apitypes.Web3ResponseWriter) (interface{}, error) {
+func (svr *web3Handler) subscribe(ctx *StreamContext, in *gjson.Result, writer apitypes.Web3ResponseWriter, release func()) (interface{}, error) {
 	subscription := in.Get("params.0")
 	if !subscription.Exists() {
 		return nil, errInvalidFormat
 	}
 	switch subscription.String() {
 	case "newHeads":
-		return svr.streamBlocks(ctx, writer)
+		return svr.streamBlocks(ctx, writer, release)
 	case "logs":
 		filter, err := parseLogRequest(in.Get("params.1"))
 		if err != nil {
 			return nil, err
 		}
-		return svr.streamLogs(ctx, filter, writer)
+		return svr.streamLogs(ctx, filter, writer, release)
+	case "newPendingTransactions":
+		return svr.streamPendingActions(ctx, in.Get("params.1").Bool(), writer, release)
 	default:
 		return nil, errInvalidFormat
 	}
 }
 
-func (svr *web3Handler) streamBlocks(ctx *StreamContext, writer apitypes.Web3ResponseWriter) (interface{}, error) {
+func (svr *web3Handler) streamBlocks(ctx *StreamContext, writer apitypes.Web3ResponseWriter, release func()) (interface{}, error) {
 	chainListener := svr.coreService.ChainListener()
 	streamID, err := chainListener.AddResponder(NewWeb3BlockListener(writer.Write))
 	if err != nil {
 		return nil, err
 	}
-	ctx.AddListener(streamID)
+	ctx.AddListener(streamID, release)
 	return streamID, nil
 }
 
-func (svr *web3Handler) streamLogs(ctx *StreamContext, filterObj *filterObject, writer apitypes.Web3ResponseWriter) (interface{}, error) {
+func (svr *web3Handler) streamLogs(ctx *StreamContext, filterObj *filterObject, writer apitypes.Web3ResponseWriter, release func()) (interface{}, error) {
 	filter, err := newLogFilterFrom(filterObj.Address, filterObj.Topics)
 	if err != nil {
 		return nil, err
@@ -1066,17 +1392,40 @@ func (svr *web3Handler) streamLogs(ctx *StreamContext, filterObj *filterObject,
 	if err != nil {
 		return nil, err
 	}
-	ctx.AddListener(streamID)
+	ctx.AddListener(streamID, release)
+	return streamID, nil
+}
+
+func (svr *web3Handler) streamPendingActions(ctx *StreamContext, fullTx bool, writer apitypes.Web3ResponseWriter, release func()) (interface{}, error) {
+	actionListener := svr.coreService.ActionListener()
+	responder := NewWeb3PendingActionListener(writer.Write, svr.coreService.EVMNetworkID(), fullTx)
+	streamID, err := actionListener.AddResponder(responder)
+	if err != nil {
+		// stop the streaming goroutine of the responder
+		responder.Exit()
+		return nil, err
+	}
+	ctx.AddListener(streamID, release)
 	return streamID, nil
 }
 
-func (svr *web3Handler) unsubscribe(in *gjson.Result) (interface{}, error) {
+func (svr *web3Handler) unsubscribe(ctx context.Context, in *gjson.Result) (interface{}, error) {
 	id := in.Get("params.0")
 	if !id.Exists() {
 		return nil, errInvalidFormat
 	}
 	chainListener := svr.coreService.ChainListener()
-	return chainListener.RemoveResponder(id.String())
+	ret, err := chainListener.RemoveResponder(id.String())
+	if errors.Cause(err) == errListenerNotFound {
+		actionListener := svr.coreService.ActionListener()
+		ret, err = actionListener.RemoveResponder(id.String())
+	}
+	if err == nil {
+		if sc, ok := StreamFromContext(ctx); ok {
+			sc.RemoveListener(id.String())
+		}
+	}
+	return ret, err
 }
 
 func (svr *web3Handler) getBlobSidecars(in *gjson.Result) (interface{}, error) {
@@ -1104,118 +1453,209 @@ func (svr *web3Handler) traceTransaction(ctx context.Context, in *gjson.Result)
 	if !actHash.Exists() {
 		return nil, errInvalidFormat
 	}
-	var (
-		enableMemory, disableStack, disableStorage, enableReturnData bool
-	)
-	if options.Exists() {
-		enableMemory = options.Get("enableMemory").Bool()
-		disableStack = options.Get("disableStack").Bool()
-		disableStorage = options.Get("disableStorage").Bool()
-		enableReturnData = options.Get("enableReturnData").Bool()
-	}
-	cfg := &tracers.TraceConfig{
-		Config: &logger.Config{
-			EnableMemory:     enableMemory,
-			DisableStack:     disableStack,
-			DisableStorage:   disableStorage,
-			EnableReturnData: enableReturnData,
-		},
-	}
-	if tracer := options.Get("tracer"); tracer.Exists() {
-		cfg.Tracer = new(string)
-		*cfg.Tracer = tracer.String()
-		if tracerConfig := options.Get("tracerConfig"); tracerConfig.Exists() {
-			cfg.TracerConfig = json.RawMessage(tracerConfig.Raw)
-		}
+	retval, receipt, tracer, err := svr.coreService.TraceTransaction(ctx, actHash.String(), parseTraceConfig(&options))
+	if err != nil {
+		return nil, err
 	}
-	retval, receipt, tracer, err := svr.coreService.TraceTransaction(ctx, actHash.String(), cfg)
+	return traceResult(retval, receipt, tracer)
+}
+
+func (svr *web3Handler) traceCall(ctx context.Context, in *gjson.Result) (interface{}, error) {
+	callMsg, err := parseCallObject(in)
 	if err != nil {
 		return nil, err
 	}
-	switch tracer := tracer.(type) {
-	case *logger.StructLogger:
-		return &debugTraceTransactionResult{
-			Failed:      receipt.Status != uint64(iotextypes.ReceiptStatus_Success),
-			Revert:      receipt.ExecutionRevertMsg(),
-			ReturnValue: byteToHex(retval),
-			StructLogs:  fromLoggerStructLogs(tracer.StructLogs()),
-			Gas:         receipt.GasConsumed,
-		}, nil
-	case tracers.Tracer:
-		return tracer.GetResult()
-	default:
-		return nil, fmt.Errorf("unknown tracer type: %T", tracer)
+	var blkNumOrHash any
+	if callMsg.BlockHash != nil {
+		blkNumOrHash = callMsg.BlockHash.Hex()
+	} else if height, archive := blockNumberToHeight(callMsg.BlockNumber); archive {
+		blkNumOrHash = height
+	}
+	options := in.Get("params.2")
+	retval, receipt, tracer, err := svr.coreService.TraceCall(ctx, callMsg.From, blkNumOrHash, callMsg.To, 0, callMsg.Value, callMsg.Gas, callMsg.Data, parseTraceConfig(&options))
+	if err != nil {
+		return nil, err
 	}
+	return traceResult(retval, receipt, tracer)
 }
 
-func (svr *web3Handler) traceCall(ctx context.Context, in *gjson.Result) (interface{}, error) {
-	var (
-		err     error
-		callMsg *callMsg
-	)
-	blkNumOrHashObj, options := in.Get("params.1"), in.Get("params.2")
-	callMsg, err = parseCallObject(in)
+func (svr *web3Handler) traceBlock(ctx context.Context, in *gjson.Result) (interface{}, error) {
+	blkNum := in.Get("params.0")
+	if !blkNum.Exists() {
+		return nil, errInvalidFormat
+	}
+	num, err := svr.parseBlockNumber(blkNum.String())
+	if err != nil {
+		return nil, err
+	}
+	traces, err := svr.coreService.TraceBlockCalls(ctx, num)
 	if err != nil {
+		if errors.Cause(err) == ErrNotFound {
+			return nil, nil
+		}
 		return nil, err
 	}
+	return callTraceResults(traces), nil
+}
 
-	var blkNumOrHash any
-	if blkNumOrHashObj.Exists() {
-		blkNumOrHash = blkNumOrHashObj.Get("blockHash").String()
-		if blkNumOrHash == "" {
-			blkNumOrHash = blkNumOrHashObj.Get("blockNumber").Uint()
+func (svr *web3Handler) traceActionCalls(ctx context.Context, in *gjson.Result) (interface{}, error) {
+	actHash := in.Get("params.0")
+	if !actHash.Exists() {
+		return nil, errInvalidFormat
+	}
+	traces, err := svr.coreService.TraceActionCalls(ctx, actHash.String())
+	if err != nil {
+		if errors.Cause(err) == ErrNotFound {
+			return nil, nil
 		}
+		return nil, err
 	}
+	return callTraceResults([]*apitypes.ActionCallTraces{traces}), nil
+}
 
+func (svr *web3Handler) traceFilter(ctx context.Context, in *gjson.Result) (interface{}, error) {
+	filterObj := in.Get("params.0")
+	if !filterObj.Exists() {
+		return nil, errInvalidFormat
+	}
 	var (
-		enableMemory, disableStack, disableStorage, enableReturnData bool
-		tracerJs, tracerTimeout                                      *string
+		filter = &apitypes.CallTraceFilter{
+			After: filterObj.Get("after").Uint(),
+			Count: filterObj.Get("count").Uint(),
+		}
+		err error
 	)
-	if options.Exists() {
-		enableMemory = options.Get("enableMemory").Bool()
-		disableStack = options.Get("disableStack").Bool()
-		disableStorage = options.Get("disableStorage").Bool()
-		enableReturnData = options.Get("enableReturnData").Bool()
-		trace := options.Get("tracer")
-		if trace.Exists() {
-			tracerJs = new(string)
-			*tracerJs = trace.String()
+	if filter.FromBlock, filter.ToBlock, err = svr.parseBlockRange(filterObj.Get("fromBlock").String(), filterObj.Get("toBlock").String()); err != nil {
+		return nil, err
+	}
+	for _, field := range []struct {
+		name  string
+		addrs *[]address.Address
+	}{
+		{"fromAddress", &filter.FromAddresses},
+		{"toAddress", &filter.ToAddresses},
+	} {
+		for _, addr := range filterObj.Get(field.name).Array() {
+			ioAddr, err := ethAddrToIoAddr(addr.String())
+			if err != nil {
+				return nil, err
+			}
+			*field.addrs = append(*field.addrs, ioAddr)
+		}
+	}
+	traces, err := svr.coreService.FilterCallTraces(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	return callTraceResults(traces), nil
+}
+
+func (svr *web3Handler) getTokenTransfers(in *gjson.Result) (interface{}, error) {
+	filterObj := in.Get("params.0")
+	addrStr, startStr, countStr := filterObj.Get("address"), filterObj.Get("start"), filterObj.Get("count")
+	if !addrStr.Exists() || !countStr.Exists() {
+		return nil, errInvalidFormat
+	}
+	addr, err := ethAddrToIoAddr(addrStr.String())
+	if err != nil {
+		return nil, err
+	}
+	var token address.Address
+	if tokenStr := filterObj.Get("token"); tokenStr.Exists() && tokenStr.String() != "" {
+		if token, err = ethAddrToIoAddr(tokenStr.String()); err != nil {
+			return nil, err
 		}
-		traceTimeout := options.Get("timeout")
-		if traceTimeout.Exists() {
-			tracerTimeout = new(string)
-			*tracerTimeout = traceTimeout.String()
+	}
+	var start uint64
+	if startStr.Exists() {
+		if start, err = hexStringToNumber(startStr.String()); err != nil {
+			return nil, err
 		}
 	}
-	cfg := &tracers.TraceConfig{
-		Tracer:  tracerJs,
-		Timeout: tracerTimeout,
-		Config: &logger.Config{
-			EnableMemory:     enableMemory,
-			DisableStack:     disableStack,
-			DisableStorage:   disableStorage,
-			EnableReturnData: enableReturnData,
-		},
+	count, err := hexStringToNumber(countStr.String())
+	if err != nil {
+		return nil, err
 	}
+	transfers, total, err := svr.coreService.TokenTransfers(addr, token, start, count)
+	if err != nil {
+		return nil, err
+	}
+	ret := &tokenTransfersResult{
+		Total:     uint64ToHex(total),
+		Transfers: make([]*tokenTransferResult, 0, len(transfers)),
+	}
+	for _, tt := range transfers {
+		res, err := newTokenTransferResult(tt)
+		if err != nil {
+			return nil, err
+		}
+		ret.Transfers = append(ret.Transfers, res)
+	}
+	return ret, nil
+}
 
-	retval, receipt, tracer, err := svr.coreService.TraceCall(ctx, callMsg.From, blkNumOrHash, callMsg.To, 0, callMsg.Value, callMsg.Gas, callMsg.Data, cfg)
+func (svr *web3Handler) pendingTransactions() (interface{}, error) {
+	pending, _ := svr.coreService.ActPoolContent()
+	senders := make([]string, 0, len(pending))
+	for sender := range pending {
+		senders = append(senders, sender)
+	}
+	sort.Strings(senders)
+	txs := make([]*getTransactionResult, 0)
+	for _, sender := range senders {
+		for _, selp := range pending[sender] {
+			if len(txs) >= _maxPendingTransactions {
+				return txs, nil
+			}
+			tx, err := svr.assemblePendingTransaction(selp)
+			if err != nil {
+				logUnassembledAction(selp, err)
+				continue
+			}
+			txs = append(txs, tx)
+		}
+	}
+	return txs, nil
+}
+
+func (svr *web3Handler) txpoolContent() (interface{}, error) {
+	pending, queued := svr.coreService.ActPoolContent()
+	pendingTxs, err := svr.groupPendingTransactions(pending)
 	if err != nil {
 		return nil, err
 	}
-	switch tracer := tracer.(type) {
-	case *logger.StructLogger:
-		return &debugTraceTransactionResult{
-			Failed:      receipt.Status != uint64(iotextypes.ReceiptStatus_Success),
-			Revert:      receipt.ExecutionRevertMsg(),
-			ReturnValue: byteToHex(retval),
-			StructLogs:  fromLoggerStructLogs(tracer.StructLogs()),
-			Gas:         receipt.GasConsumed,
-		}, nil
-	case tracers.Tracer:
-		return tracer.GetResult()
-	default:
-		return nil, fmt.Errorf("unknown tracer type: %T", tracer)
+	queuedTxs, err := svr.groupPendingTransactions(queued)
+	if err != nil {
+		return nil, err
 	}
+	return map[string]map[string]map[string]*getTransactionResult{
+		"pending": pendingTxs,
+		"queued":  queuedTxs,
+	}, nil
+}
+
+func (svr *web3Handler) txpoolStatus() (interface{}, error) {
+	numPending, numQueued := svr.coreService.ActPoolStatus()
+	return map[string]string{
+		"pending": uint64ToHex(numPending),
+		"queued":  uint64ToHex(numQueued),
+	}, nil
+}
+
+func (svr *web3Handler) txpoolInspect() (interface{}, error) {
+	pending, queued := svr.coreService.ActPoolContent()
+	pendingTxs, err := inspectPendingTransactions(pending)
+	if err != nil {
+		return nil, err
+	}
+	queuedTxs, err := inspectPendingTransactions(queued)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]map[string]map[string]string{
+		"pending": pendingTxs,
+		"queued":  queuedTxs,
+	}, nil
 }
 
 func (svr *web3Handler) unimplemented() (interface{}, error) {
@@ -43,7 +43,7 @@ func TestWeb3ServerIntegrity(t *testing.T) {
 	ctx := context.Background()
 	web3svr.Start(ctx)
 	defer web3svr.Stop(ctx)
-	handler := newHTTPHandler(NewWeb3Handler(svr.core, "", _defaultBatchRequestLimit))
+	handler := newHTTPHandler(NewWeb3Handler(svr.core, "", _defaultBatchRequestLimit, nil))
 
 	// send request
 	t.Run("eth_gasPrice", func(t *testing.T) {
@@ -165,6 +165,14 @@ func TestWeb3ServerIntegrity(t *testing.T) {
 	t.Run("eth_blobBaseFee", func(t *testing.T) {
 		blobBaseFee(t, handler, bc, dao, actPool)
 	})
+
+	t.Run("eth_createAccessList", func(t *testing.T) {
+		createAccessList(t, handler, bc, dao, actPool)
+	})
+
+	t.Run("eth_simulateV1", func(t *testing.T) {
+		simulateV1(t, handler, bc, dao, actPool)
+	})
 }
 
 func setupTestServer() (*ServerV2, blockchain.Blockchain, blockdao.BlockDAO, actpool.ActPool, func()) {
@@ -267,7 +275,7 @@ func getTransactionCount(t *testing.T, handler *hTTPHandler) {
 		params   string
 		expected int
 	}{
-		{`["0xDa7e12Ef57c236a06117c5e0d04a228e7181CF36", "0x1"]`, 2},
+		{`["0xDa7e12Ef57c236a06117c5e0d04a228e7181CF36", "latest"]`, 2},
 		{`["0xDa7e12Ef57c236a06117c5e0d04a228e7181CF36", "pending"]`, 2},
 	} {
 		result := serveTestHTTP(require, handler, "eth_getTransactionCount", test.params)
@@ -789,7 +797,7 @@ func getCode(t *testing.T, handler *hTTPHandler, bc blockchain.Blockchain, dao b
 	contract, _ := deployContractV2(bc, dao, actPool, identityset.PrivateKey(13), 2, bc.TipHeight(), contractCode)
 	contractAddr, _ := ioAddrToEthAddr(contract)
 
-	result := serveTestHTTP(require, handler, "eth_getCode", fmt.Sprintf(`["%s", "0x1"]`, contractAddr))
+	result := serveTestHTTP(require, handler, "eth_getCode", fmt.Sprintf(`["%s", "latest"]`, contractAddr))
 	actual, ok := result.(string)
 	require.True(ok)
 	require.Contains(contractCode, util.Remove0xPrefix(actual))
@@ -872,3 +880,102 @@ func blobBaseFee(t *testing.T, handler *hTTPHandler, bc blockchain.Blockchain, d
 		require.Equal("0x1", actual)
 	}
 }
+
+func createAccessList(t *testing.T, handler *hTTPHandler, bc blockchain.Blockchain, dao blockdao.BlockDAO, actPool actpool.ActPool) {
+	require := require.New(t)
+	// deploy a contract
+	contractCode := "608060405234801561001057600080fd5b50610150806100206000396000f3fe608060405234801561001057600080fd5b50600436106100365760003560e01c806360fe47b11461003b5780636d4ce63c14610057575b600080fd5b6100556004803603810190610050919061009d565b610075565b005b61005f61007f565b60405161006c91906100d9565b60405180910390f35b8060008190555050565b60008054905090565b60008135905061009781610103565b92915050565b6000602082840312156100b3576100b26100fe565b5b60006100c184828501610088565b91505092915050565b6100d3816100f4565b82525050565b60006020820190506100ee60008301846100ca565b92915050565b6000819050919050565b600080fd5b61010c816100f4565b811461011757600080fd5b5056fea2646970667358221220c86a8c4dd175f55f5732b75b721d714ceb38a835b87c6cf37cf28c790813e19064736f6c63430008070033"
+	contract, _ := deployContractV2(bc, dao, actPool, identityset.PrivateKey(13), 4, bc.TipHeight(), contractCode)
+	contractAddr, _ := ioAddrToEthAddr(contract)
+
+	// set(5) stores into slot 0 of the contract
+	result := serveTestHTTP(require, handler, "eth_createAccessList", fmt.Sprintf(`[{
+		"from": "%s",
+		"to":   "%s",
+		"data": "0x60fe47b10000000000000000000000000000000000000000000000000000000000000005"}]`,
+		identityset.Address(28).Hex(), contractAddr))
+	actual, err := json.Marshal(result)
+	require.NoError(err)
+	res := &createAccessListResult{}
+	require.NoError(json.Unmarshal(actual, res))
+	require.Empty(res.Error)
+	require.Equal(types.AccessList{{
+		Address:     common.HexToAddress(contractAddr),
+		StorageKeys: []common.Hash{{}},
+	}}, res.AccessList)
+	gasUsed, err := hexStringToNumber(res.GasUsed)
+	require.NoError(err)
+	require.Greater(gasUsed, uint64(21000))
+
+	// the contract has no such method
+	result = serveTestHTTP(require, handler, "eth_createAccessList", fmt.Sprintf(`[{
+		"from": "%s",
+		"to":   "%s",
+		"data": "0x12345678"}, "latest"]`,
+		identityset.Address(28).Hex(), contractAddr))
+	actual, err = json.Marshal(result)
+	require.NoError(err)
+	res = &createAccessListResult{}
+	require.NoError(json.Unmarshal(actual, res))
+	require.NotEmpty(res.Error)
+}
+
+func simulateV1(t *testing.T, handler *hTTPHandler, bc blockchain.Blockchain, dao blockdao.BlockDAO, actPool actpool.ActPool) {
+	require := require.New(t)
+	contractCode := "608060405234801561001057600080fd5b50610150806100206000396000f3fe608060405234801561001057600080fd5b50600436106100365760003560e01c806360fe47b11461003b5780636d4ce63c14610057575b600080fd5b6100556004803603810190610050919061009d565b610075565b005b61005f61007f565b60405161006c91906100d9565b60405180910390f35b8060008190555050565b60008054905090565b60008135905061009781610103565b92915050565b6000602082840312156100b3576100b26100fe565b5b60006100c184828501610088565b91505092915050565b6100d3816100f4565b82525050565b60006020820190506100ee60008301846100ca565b92915050565b6000819050919050565b600080fd5b61010c816100f4565b811461011757600080fd5b5056fea2646970667358221220c86a8c4dd175f55f5732b75b721d714ceb38a835b87c6cf37cf28c790813e19064736f6c63430008070033"
+	contract, _ := deployContractV2(bc, dao, actPool, identityset.PrivateKey(13), 5, bc.TipHeight(), contractCode)
+	contractAddr, _ := ioAddrToEthAddr(contract)
+	tip := bc.TipHeight()
+
+	type simulatedCall struct {
+		ReturnData string        `json:"returnData"`
+		Status     string        `json:"status"`
+		Error      *errMessage   `json:"error"`
+		Logs       []interface{} `json:"logs"`
+	}
+	type simulatedBlock struct {
+		Number string           `json:"number"`
+		Calls  []*simulatedCall `json:"calls"`
+	}
+	// set(7) in the 1st block is seen by get() in the 2nd block, and the unknown method reverts
+	result := serveTestHTTP(require, handler, "eth_simulateV1", fmt.Sprintf(`[{"blockStateCalls": [
+		{"calls": [{
+			"from": "%[1]s",
+			"to":   "%[2]s",
+			"data": "0x60fe47b10000000000000000000000000000000000000000000000000000000000000007"}]},
+		{"calls": [{
+			"from": "%[1]s",
+			"to":   "%[2]s",
+			"data": "0x6d4ce63c"}, {
+			"from": "%[1]s",
+			"to":   "%[2]s",
+			"data": "0x12345678"}]}
+	]}, "latest"]`, identityset.Address(28).Hex(), contractAddr))
+	actual, err := json.Marshal(result)
+	require.NoError(err)
+	var blocks []*simulatedBlock
+	require.NoError(json.Unmarshal(actual, &blocks))
+	require.Len(blocks, 2)
+	require.Equal(uint64ToHex(tip+1), blocks[0].Number)
+	require.Equal(uint64ToHex(tip+2), blocks[1].Number)
+	require.Len(blocks[0].Calls, 1)
+	require.Equal("0x1", blocks[0].Calls[0].Status)
+	require.Len(blocks[1].Calls, 2)
+	require.Equal("0x1", blocks[1].Calls[0].Status)
+	require.Equal("0x0000000000000000000000000000000000000000000000000000000000000007", blocks[1].Calls[0].ReturnData)
+	require.Equal("0x0", blocks[1].Calls[1].Status)
+	require.NotNil(blocks[1].Calls[1].Error)
+
+	// the state is not changed by the simulation
+	result = serveTestHTTP(require, handler, "eth_call", fmt.Sprintf(`[{
+		"from": "%s",
+		"to":   "%s",
+		"data": "0x6d4ce63c"}, "latest"]`, identityset.Address(28).Hex(), contractAddr))
+	require.Equal("0x0000000000000000000000000000000000000000000000000000000000000000", result)
+
+	// the block number has to increase
+	result = serveTestHTTP(require, handler, "eth_simulateV1", fmt.Sprintf(`[{"blockStateCalls": [
+		{"blockOverrides": {"number": "%s"}, "calls": []}
+	]}]`, uint64ToHex(tip)))
+	require.Nil(result)
+}
@@ -3,14 +3,19 @@ package api
 import (
 	"encoding/hex"
 	"encoding/json"
+	"fmt"
+	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/iotexproject/go-pkgs/crypto"
 	"github.com/iotexproject/go-pkgs/hash"
 	"github.com/iotexproject/iotex-address/address"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
 	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
@@ -60,13 +65,14 @@ type (
 	}
 
 	getReceiptResult struct {
-		blockHash       hash.Hash256
-		from            address.Address
-		to              *string
-		contractAddress *string
-		logsBloom       string
-		receipt         *action.Receipt
-		txType          uint
+		blockHash         hash.Hash256
+		from              address.Address
+		to                *string
+		contractAddress   *string
+		logsBloom         string
+		receipt           *action.Receipt
+		txType            uint
+		cumulativeGasUsed uint64
 	}
 
 	getLogsResult struct {
@@ -88,6 +94,43 @@ type (
 		StructLogs  []apitypes.StructLog `json:"structLogs"`
 	}
 
+	getProofResult struct {
+		address common.Address
+		proof   *apitypes.AccountProof
+	}
+
+	createAccessListResult struct {
+		AccessList types.AccessList `json:"accessList"`
+		GasUsed    string           `json:"gasUsed"`
+		Error      string           `json:"error,omitempty"`
+	}
+
+	simulateBlockResult struct {
+		blk *apitypes.SimulatedBlock
+	}
+
+	callTraceResult struct {
+		act   *apitypes.ActionCallTraces
+		trace *action.CallTrace
+	}
+
+	tokenTransfersResult struct {
+		Total     string                 `json:"total"`
+		Transfers []*tokenTransferResult `json:"transfers"`
+	}
+
+	tokenTransferResult struct {
+		Standard        string  `json:"standard"`
+		Token           string  `json:"token"`
+		From            string  `json:"from"`
+		To              string  `json:"to"`
+		TokenID         *string `json:"tokenId"`
+		Amount          string  `json:"amount"`
+		BlockNumber     string  `json:"blockNumber"`
+		TransactionHash string  `json:"transactionHash"`
+		LogIndex        string  `json:"logIndex"`
+	}
+
 	feeHistoryResult struct {
 		OldestBlock       string     `json:"oldestBlock"`
 		BaseFeePerGas     []string   `json:"baseFeePerGas"`
@@ -120,7 +163,11 @@ func (obj *web3Response) MarshalJSON() ([]byte, error) {
 		errMsg  string
 	)
 	// error code: https://eth.wiki/json-rpc/json-rpc-error-codes-improvement-proposal
-	if s, ok := status.FromError(obj.err); ok {
+	if errors.Cause(obj.err) == errTooManyKeys {
+		errCode, errMsg = _invalidParamsCode, obj.err.Error()
+	} else if s, ok := status.FromError(obj.err); ok && s.Code() == codes.ResourceExhausted {
+		errCode, errMsg = _limitExceededCode, s.Message()
+	} else if ok {
 		errCode, errMsg = int(s.Code()), s.Message()
 	} else {
 		errCode, errMsg = -32603, obj.err.Error()
@@ -393,7 +440,7 @@ func (obj *getReceiptResult) MarshalJSON() ([]byte, error) {
 		BlockNumber:       uint64ToHex(obj.receipt.BlockHeight),
 		From:              obj.from.Hex(),
 		To:                obj.to,
-		CumulativeGasUsed: uint64ToHex(obj.receipt.GasConsumed),
+		CumulativeGasUsed: uint64ToHex(obj.cumulativeGasUsed),
 		GasUsed:           uint64ToHex(obj.receipt.GasConsumed),
 		ContractAddress:   obj.contractAddress,
 		LogsBloom:         getLogsBloomHex(obj.logsBloom),
@@ -441,6 +488,140 @@ func (obj *getLogsResult) MarshalJSON() ([]byte, error) {
 	})
 }
 
+func (obj *simulateBlockResult) MarshalJSON() ([]byte, error) {
+	if obj.blk == nil {
+		return nil, errInvalidObject
+	}
+	type simulateCallResult struct {
+		ReturnData string           `json:"returnData"`
+		Logs       []*getLogsResult `json:"logs"`
+		GasUsed    string           `json:"gasUsed"`
+		Status     string           `json:"status"`
+		Error      *errMessage      `json:"error,omitempty"`
+	}
+	calls := make([]*simulateCallResult, 0, len(obj.blk.Calls))
+	for _, call := range obj.blk.Calls {
+		res := &simulateCallResult{
+			ReturnData: "0x" + hex.EncodeToString(call.ReturnData),
+			Logs:       make([]*getLogsResult, 0, len(call.Receipt.Logs())),
+			GasUsed:    uint64ToHex(call.Receipt.GasConsumed),
+			Status:     "0x1",
+		}
+		for _, l := range call.Receipt.Logs() {
+			res.Logs = append(res.Logs, &getLogsResult{obj.blk.Hash, l})
+		}
+		switch call.Receipt.Status {
+		case uint64(iotextypes.ReceiptStatus_Success):
+		case uint64(iotextypes.ReceiptStatus_ErrExecutionReverted):
+			res.Status = "0x0"
+			res.Error = &errMessage{Code: 3, Message: "execution reverted", Data: res.ReturnData}
+			if msg := call.Receipt.ExecutionRevertMsg(); len(msg) > 0 {
+				res.Error.Message += ": " + msg
+			}
+		default:
+			res.Status = "0x0"
+			res.Error = &errMessage{Code: -32015, Message: fmt.Sprintf("execution failed: status = %d", call.Receipt.Status)}
+		}
+		calls = append(calls, res)
+	}
+	return json.Marshal(&struct {
+		Number        string                `json:"number"`
+		Hash          string                `json:"hash"`
+		ParentHash    string                `json:"parentHash"`
+		Timestamp     string                `json:"timestamp"`
+		GasLimit      string                `json:"gasLimit"`
+		GasUsed       string                `json:"gasUsed"`
+		BaseFeePerGas *hexutil.Big          `json:"baseFeePerGas,omitempty"`
+		Calls         []*simulateCallResult `json:"calls"`
+	}{
+		Number:        uint64ToHex(obj.blk.Height),
+		Hash:          "0x" + hex.EncodeToString(obj.blk.Hash[:]),
+		ParentHash:    "0x" + hex.EncodeToString(obj.blk.ParentHash[:]),
+		Timestamp:     uint64ToHex(uint64(obj.blk.Timestamp.Unix())),
+		GasLimit:      uint64ToHex(obj.blk.GasLimit),
+		GasUsed:       uint64ToHex(obj.blk.GasUsed),
+		BaseFeePerGas: (*hexutil.Big)(obj.blk.BaseFee),
+		Calls:         calls,
+	})
+}
+
+func (obj *callTraceResult) MarshalJSON() ([]byte, error) {
+	if obj.act == nil || obj.trace == nil {
+		return nil, errInvalidObject
+	}
+	var (
+		trace       = obj.trace
+		from, _     = ioAddrToEthAddr(trace.From)
+		to, _       = ioAddrToEthAddr(trace.To)
+		traceAction interface{}
+		result      interface{}
+	)
+	switch trace.Type {
+	case action.CallTraceTypeCreate:
+		traceAction = &struct {
+			From  string `json:"from"`
+			Value string `json:"value"`
+			Gas   string `json:"gas"`
+			Init  string `json:"init"`
+		}{from, bigIntToHex(trace.Value), uint64ToHex(trace.Gas), byteToHex(trace.Input)}
+		result = &struct {
+			Address string `json:"address"`
+			Code    string `json:"code"`
+			GasUsed string `json:"gasUsed"`
+		}{to, byteToHex(trace.Output), uint64ToHex(trace.GasUsed)}
+	case action.CallTraceTypeSelfDestruct:
+		traceAction = &struct {
+			Address       string `json:"address"`
+			RefundAddress string `json:"refundAddress"`
+			Balance       string `json:"balance"`
+		}{from, to, bigIntToHex(trace.Value)}
+	default:
+		traceAction = &struct {
+			From     string `json:"from"`
+			To       string `json:"to"`
+			Value    string `json:"value"`
+			Gas      string `json:"gas"`
+			Input    string `json:"input"`
+			CallType string `json:"callType"`
+		}{from, to, bigIntToHex(trace.Value), uint64ToHex(trace.Gas), byteToHex(trace.Input), trace.CallType}
+		result = &struct {
+			GasUsed string `json:"gasUsed"`
+			Output  string `json:"output"`
+		}{uint64ToHex(trace.GasUsed), byteToHex(trace.Output)}
+	}
+	traceErr := trace.Error
+	if traceErr != "" {
+		// like parity, the result is omitted for a failed call
+		result = nil
+		if traceErr == vm.ErrExecutionReverted.Error() {
+			traceErr = "Reverted"
+		}
+	}
+	return json.Marshal(&struct {
+		Action              interface{} `json:"action"`
+		BlockHash           string      `json:"blockHash"`
+		BlockNumber         uint64      `json:"blockNumber"`
+		Error               string      `json:"error,omitempty"`
+		Result              interface{} `json:"result"`
+		Subtraces           uint64      `json:"subtraces"`
+		TraceAddress        []uint64    `json:"traceAddress"`
+		TransactionHash     string      `json:"transactionHash"`
+		TransactionPosition uint32      `json:"transactionPosition"`
+		Type                string      `json:"type"`
+	}{
+		Action:              traceAction,
+		BlockHash:           "0x" + hex.EncodeToString(obj.act.BlockHash[:]),
+		BlockNumber:         obj.act.BlockHeight,
+		Error:               traceErr,
+		Result:              result,
+		Subtraces:           trace.Subtraces,
+		TraceAddress:        trace.TraceAddress,
+		TransactionHash:     "0x" + hex.EncodeToString(obj.act.ActionHash[:]),
+		TransactionPosition: obj.act.Index,
+		Type:                trace.Type,
+	})
+}
+
 func (obj *streamResponse) MarshalJSON() ([]byte, error) {
 	return json.Marshal(&struct {
 		Jsonrpc string       `json:"jsonrpc"`
@@ -455,3 +636,59 @@ func (obj *streamResponse) MarshalJSON() ([]byte, error) {
 		},
 	})
 }
+
+func (obj *getProofResult) MarshalJSON() ([]byte, error) {
+	if obj.proof == nil || obj.proof.Account == nil {
+		return nil, errInvalidObject
+	}
+	type storageProofResult struct {
+		Key   string       `json:"key"`
+		Value *hexutil.Big `json:"value"`
+		Proof []string     `json:"proof"`
+	}
+	var (
+		account       = obj.proof.Account
+		storageProofs = make([]storageProofResult, 0, len(obj.proof.StorageProof))
+		// the code hash of an account without code is the hash of empty code
+		codeHash = types.EmptyCodeHash
+	)
+	if len(account.CodeHash) > 0 {
+		codeHash = common.BytesToHash(account.CodeHash)
+	}
+	for _, sp := range obj.proof.StorageProof {
+		storageProofs = append(storageProofs, storageProofResult{
+			Key:   hexutil.Encode(sp.Key[:]),
+			Value: (*hexutil.Big)(new(big.Int).SetBytes(sp.Value)),
+			Proof: proofToHex(sp.Proof),
+		})
+	}
+	return json.Marshal(&struct {
+		Address      common.Address       `json:"address"`
+		AccountProof []string             `json:"accountProof"`
+		Balance      *hexutil.Big         `json:"balance"`
+		CodeHash     common.Hash          `json:"codeHash"`
+		Nonce        hexutil.Uint64       `json:"nonce"`
+		StorageHash  common.Hash          `json:"storageHash"`
+		StorageProof []storageProofResult `json:"storageProof"`
+		StateRoot    string               `json:"stateRoot"`
+		BlockNumber  hexutil.Uint64       `json:"blockNumber"`
+	}{
+		Address:      obj.address,
+		AccountProof: proofToHex(obj.proof.AccountProof),
+		Balance:      (*hexutil.Big)(account.Balance),
+		CodeHash:     codeHash,
+		Nonce:        hexutil.Uint64(account.PendingNonce()),
+		StorageHash:  common.BytesToHash(account.Root[:]),
+		StorageProof: storageProofs,
+		StateRoot:    hexutil.Encode(obj.proof.StateRoot),
+		BlockNumber:  hexutil.Uint64(obj.proof.Height),
+	})
+}
+
+func proofToHex(proof [][]byte) []string {
+	ret := make([]string, 0, len(proof))
+	for _, node := range proof {
+		ret = append(ret, hexutil.Encode(node))
+	}
+	return ret
+}
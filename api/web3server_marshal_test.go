@@ -609,12 +609,13 @@ func TestReceiptObjectMarshal(t *testing.T) {
 	t.Run("ContractCreation", func(t *testing.T) {
 		contractEthaddr, _ := ioAddrToEthAddr(_testContractIoAddr)
 		res, err := json.Marshal(&getReceiptResult{
-			blockHash:       _testBlkHash,
-			from:            _testSenderIoAddr,
-			to:              nil,
-			contractAddress: &contractEthaddr,
-			logsBloom:       "00008000000100000400000000000040000000000000000000000000000000000000000001000200000400000000000000000000001000000000000000001000000000001000000000200000004000000000000000000101000000000000000008000008000208000000000000400000000000000000000000000000000000000000080010000000000200010000000000000500000000000000000000000000004080000000000000001000000800020000000000000000000000000000000000000000000000000000000000000000000800000000000000000000000000000000000000000000000400000000000000000000000000080000400010200000",
-			receipt:         receipt,
+			blockHash:         _testBlkHash,
+			from:              _testSenderIoAddr,
+			to:                nil,
+			contractAddress:   &contractEthaddr,
+			logsBloom:         "00008000000100000400000000000040000000000000000000000000000000000000000001000200000400000000000000000000001000000000000000001000000000001000000000200000004000000000000000000101000000000000000008000008000208000000000000400000000000000000000000000000000000000000080010000000000200010000000000000500000000000000000000000000004080000000000000001000000800020000000000000000000000000000000000000000000000000000000000000000000800000000000000000000000000000000000000000000000400000000000000000000000000080000400010200000",
+			receipt:           receipt,
+			cumulativeGasUsed: receipt.GasConsumed,
 		})
 		require.NoError(err)
 		require.JSONEq(`
@@ -654,12 +655,13 @@ func TestReceiptObjectMarshal(t *testing.T) {
 		})
 		contractEthaddr, _ := ioAddrToEthAddr(_testContractIoAddr)
 		res, err := json.Marshal(&getReceiptResult{
-			blockHash:       _testBlkHash,
-			from:            _testSenderIoAddr,
-			to:              &contractEthaddr,
-			contractAddress: nil,
-			logsBloom:       "00008000000100000400000000000040000000000000000000000000000000000000000001000200000400000000000000000000001000000000000000001000000000001000000000200000004000000000000000000101000000000000000008000008000208000000000000400000000000000000000000000000000000000000080010000000000200010000000000000500000000000000000000000000004080000000000000001000000800020000000000000000000000000000000000000000000000000000000000000000000800000000000000000000000000000000000000000000000400000000000000000000000000080000400010200000",
-			receipt:         receipt,
+			blockHash:         _testBlkHash,
+			from:              _testSenderIoAddr,
+			to:                &contractEthaddr,
+			contractAddress:   nil,
+			logsBloom:         "00008000000100000400000000000040000000000000000000000000000000000000000001000200000400000000000000000000001000000000000000001000000000001000000000200000004000000000000000000101000000000000000008000008000208000000000000400000000000000000000000000000000000000000080010000000000200010000000000000500000000000000000000000000004080000000000000001000000800020000000000000000000000000000000000000000000000000000000000000000000800000000000000000000000000000000000000000000000400000000000000000000000000080000400010200000",
+			receipt:           receipt,
+			cumulativeGasUsed: receipt.GasConsumed,
 		})
 		require.NoError(err)
 		require.JSONEq(`
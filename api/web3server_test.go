@@ -3,6 +3,7 @@ package api
 import (
 	"context"
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"io"
 	"math/big"
@@ -14,6 +15,8 @@ import (
 	"testing"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/eth/tracers"
 	"github.com/ethereum/go-ethereum/eth/tracers/logger"
 	"github.com/golang/mock/gomock"
 	"github.com/pkg/errors"
@@ -23,6 +26,7 @@ import (
 
 	"github.com/iotexproject/go-pkgs/hash"
 	"github.com/iotexproject/go-pkgs/util"
+	"github.com/iotexproject/iotex-address/address"
 	"github.com/iotexproject/iotex-proto/golang/iotexapi"
 	"github.com/iotexproject/iotex-proto/golang/iotextypes"
 
@@ -30,6 +34,8 @@ import (
 	apitypes "github.com/iotexproject/iotex-core/v2/api/types"
 	"github.com/iotexproject/iotex-core/v2/blockchain/block"
 	"github.com/iotexproject/iotex-core/v2/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/v2/blockindex"
+	"github.com/iotexproject/iotex-core/v2/state"
 	"github.com/iotexproject/iotex-core/v2/test/identityset"
 	mock_apitypes "github.com/iotexproject/iotex-core/v2/test/mock/mock_apiresponder"
 	"github.com/iotexproject/iotex-core/v2/testutil"
@@ -102,7 +108,7 @@ func TestHandlePost(t *testing.T) {
 	defer ctrl.Finish()
 	core := NewMockCoreService(ctrl)
 	core.EXPECT().Track(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return().AnyTimes()
-	svr := newHTTPHandler(NewWeb3Handler(core, "", _defaultBatchRequestLimit))
+	svr := newHTTPHandler(NewWeb3Handler(core, "", _defaultBatchRequestLimit, nil))
 	getServerResp := func(svr *hTTPHandler, req *http.Request) *httptest.ResponseRecorder {
 		req.Header.Set("Content-Type", "application/json")
 		resp := httptest.NewRecorder()
@@ -170,7 +176,7 @@ func TestGasPrice(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit}
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, nil}
 	core.EXPECT().SuggestGasPrice().Return(uint64(1), nil)
 	ret, err := web3svr.gasPrice()
 	require.NoError(err)
@@ -186,7 +192,7 @@ func TestGetChainID(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit}
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, nil}
 	core.EXPECT().EVMNetworkID().Return(uint32(1))
 	ret, err := web3svr.getChainID()
 	require.NoError(err)
@@ -198,7 +204,7 @@ func TestGetBlockNumber(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit}
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, nil}
 	core.EXPECT().TipHeight().Return(uint64(1))
 	ret, err := web3svr.getBlockNumber()
 	require.NoError(err)
@@ -210,7 +216,7 @@ func TestGetBlockByNumber(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit}
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, nil}
 
 	tsf, err := action.SignedTransfer(identityset.Address(28).String(), identityset.PrivateKey(27), uint64(1), big.NewInt(10), []byte{}, uint64(100000), big.NewInt(0))
 	require.NoError(err)
@@ -261,10 +267,12 @@ func TestGetBalance(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit}
+	core.EXPECT().TipHeight().Return(uint64(100)).AnyTimes()
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, nil}
 	balance := "111111111111111111"
-	core.EXPECT().WithHeight(gomock.Any()).Return(core).Times(1)
-	core.EXPECT().Account(gomock.Any()).Return(&iotextypes.AccountMeta{Balance: balance}, nil, nil)
+	coreWithHeight := NewMockCoreServiceReaderWithHeight(ctrl)
+	core.EXPECT().WithHeight(gomock.Any()).Return(coreWithHeight).Times(1)
+	coreWithHeight.EXPECT().Account(gomock.Any()).Return(&iotextypes.AccountMeta{Balance: balance}, nil, nil)
 
 	in := gjson.Parse(`{"params":["0xDa7e12Ef57c236a06117c5e0d04a228e7181CF36", "0x1"]}`)
 	ret, err := web3svr.getBalance(&in)
@@ -279,17 +287,28 @@ func TestGetTransactionCount(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit}
-	core.EXPECT().PendingNonce(gomock.Any()).Return(uint64(2), nil)
+	core.EXPECT().TipHeight().Return(uint64(100)).AnyTimes()
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, nil}
+	core.EXPECT().PendingNonce(gomock.Any()).Return(uint64(2), nil).Times(2)
 
 	inNil := gjson.Parse(`{"params":[]}`)
 	_, err := web3svr.getTransactionCount(&inNil)
 	require.EqualError(err, errInvalidFormat.Error())
 
-	in := gjson.Parse(`{"params":["0xDa7e12Ef57c236a06117c5e0d04a228e7181CF36", 1]}`)
+	for _, bn := range []string{`"latest"`, `"pending"`} {
+		in := gjson.Parse(`{"params":["0xDa7e12Ef57c236a06117c5e0d04a228e7181CF36", ` + bn + `]}`)
+		ret, err := web3svr.getTransactionCount(&in)
+		require.NoError(err)
+		require.Equal("0x2", ret.(string))
+	}
+
+	coreWithHeight := NewMockCoreServiceReaderWithHeight(ctrl)
+	core.EXPECT().WithHeight(uint64(1)).Return(coreWithHeight).Times(1)
+	coreWithHeight.EXPECT().PendingNonce(gomock.Any()).Return(uint64(1), nil)
+	in := gjson.Parse(`{"params":["0xDa7e12Ef57c236a06117c5e0d04a228e7181CF36", "0x1"]}`)
 	ret, err := web3svr.getTransactionCount(&in)
 	require.NoError(err)
-	require.Equal("0x2", ret.(string))
+	require.Equal("0x1", ret.(string))
 }
 
 func TestCall(t *testing.T) {
@@ -297,7 +316,8 @@ func TestCall(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit}
+	core.EXPECT().TipHeight().Return(uint64(100)).AnyTimes()
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, nil}
 
 	t.Run("to is StakingProtocol addr", func(t *testing.T) {
 		meta := &iotextypes.AccountMeta{
@@ -305,7 +325,9 @@ func TestCall(t *testing.T) {
 			Balance: "100000000000000000000",
 		}
 		metaBytes, _ := proto.Marshal(meta)
-		core.EXPECT().ReadState(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(&iotexapi.ReadStateResponse{
+		coreWithHeight := NewMockCoreServiceReaderWithHeight(ctrl)
+		core.EXPECT().WithHeight(uint64(1)).Return(coreWithHeight).Times(1)
+		coreWithHeight.EXPECT().ReadState("staking", gomock.Any(), gomock.Any()).Return(&iotexapi.ReadStateResponse{
 			Data: metaBytes,
 		}, nil)
 		in := gjson.Parse(`{"params":[{
@@ -323,7 +345,7 @@ func TestCall(t *testing.T) {
 
 	t.Run("to is RewardingProtocol addr", func(t *testing.T) {
 		amount := big.NewInt(10000)
-		core.EXPECT().ReadState(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(&iotexapi.ReadStateResponse{
+		core.EXPECT().ReadState("rewarding", "", gomock.Any(), gomock.Any()).Return(&iotexapi.ReadStateResponse{
 			Data: []byte(amount.String()),
 		}, nil)
 		in := gjson.Parse(`{"params":[{
@@ -333,7 +355,7 @@ func TestCall(t *testing.T) {
 			"gasPrice": "0xe8d4a51000",
 			"value":    "0x1",
 			"data":     "ad7a672f"
-		   }, "0x1"]}`)
+		   }, "latest"]}`)
 		ret, err := web3svr.call(context.Background(), &in)
 		require.NoError(err)
 		require.Equal("0x0000000000000000000000000000000000000000000000000000000000002710", ret.(string))
@@ -356,6 +378,24 @@ func TestCall(t *testing.T) {
 		require.Equal("0x111111", ret.(string))
 	})
 
+	t.Run("block hash", func(t *testing.T) {
+		blkHash := "0x4d2ee0b0ae4fa7c87b0cebcc9af8e4a3c1b1e5e2df3d1d4d1c0fb4e1dee8f2a0"
+		core.EXPECT().BlockByHash(util.Remove0xPrefix(blkHash)).Return(&apitypes.BlockWithReceipts{
+			Block: &block.Block{Header: block.Header{}},
+		}, nil)
+		coreWithHeight := NewMockCoreServiceReaderWithHeight(ctrl)
+		core.EXPECT().WithHeight(uint64(0)).Return(coreWithHeight).Times(1)
+		coreWithHeight.EXPECT().ReadContract(gomock.Any(), gomock.Any(), gomock.Any()).Return("111111", nil, nil)
+		in := gjson.Parse(`{"params":[{
+			"from":     "",
+			"to":       "0x7c13866F9253DEf79e20034eDD011e1d69E67fe5",
+			"data":     "0x1"
+		   }, {"blockHash": "` + blkHash + `"}]}`)
+		ret, err := web3svr.call(context.Background(), &in)
+		require.NoError(err)
+		require.Equal("0x111111", ret.(string))
+	})
+
 	t.Run("revert call", func(t *testing.T) {
 		receipt := &iotextypes.Receipt{
 			Status:             uint64(iotextypes.ReceiptStatus_ErrExecutionReverted),
@@ -388,13 +428,16 @@ func TestEstimateGas(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit}
+	core.EXPECT().TipHeight().Return(uint64(100)).AnyTimes()
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, nil}
 	core.EXPECT().ChainID().Return(uint32(1)).Times(2)
 	core.EXPECT().EVMNetworkID().Return(uint32(0)).Times(2)
 
 	t.Run("estimate execution", func(t *testing.T) {
 		core.EXPECT().Account(gomock.Any()).Return(&iotextypes.AccountMeta{IsContract: true}, nil, nil)
-		core.EXPECT().EstimateExecutionGasConsumption(gomock.Any(), gomock.Any(), gomock.Any()).Return(uint64(11000), nil, nil)
+		coreWithHeight := NewMockCoreServiceReaderWithHeight(ctrl)
+		core.EXPECT().WithHeight(uint64(1)).Return(coreWithHeight).Times(1)
+		coreWithHeight.EXPECT().EstimateExecutionGasConsumption(gomock.Any(), gomock.Any(), gomock.Any()).Return(uint64(11000), nil, nil)
 
 		in := gjson.Parse(`{"params":[{
 			"from":     "",
@@ -432,7 +475,7 @@ func TestSendRawTransaction(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit}
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, nil}
 	core.EXPECT().Genesis().Return(genesis.TestDefault())
 	core.EXPECT().TipHeight().Return(uint64(0))
 	core.EXPECT().EVMNetworkID().Return(uint32(1))
@@ -459,7 +502,8 @@ func TestGetCode(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit}
+	core.EXPECT().TipHeight().Return(uint64(100)).AnyTimes()
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, nil}
 	code := "608060405234801561001057600080fd5b50610150806100206contractbytecode"
 	data, _ := hex.DecodeString(code)
 	core.EXPECT().Account(gomock.Any()).Return(&iotextypes.AccountMeta{ContractByteCode: data}, nil, nil)
@@ -476,6 +520,16 @@ func TestGetCode(t *testing.T) {
 		require.NoError(err)
 		require.Contains(code, util.Remove0xPrefix(ret.(string)))
 	})
+
+	t.Run("get code at height", func(t *testing.T) {
+		coreWithHeight := NewMockCoreServiceReaderWithHeight(ctrl)
+		core.EXPECT().WithHeight(uint64(10)).Return(coreWithHeight).Times(1)
+		coreWithHeight.EXPECT().Account(gomock.Any()).Return(&iotextypes.AccountMeta{}, nil, nil)
+		in := gjson.Parse(`{"params":["0x7c13866F9253DEf79e20034eDD011e1d69E67fe5", "0xa"]}`)
+		ret, err := web3svr.getCode(&in)
+		require.NoError(err)
+		require.Equal("0x", ret.(string))
+	})
 }
 
 func TestGetNodeInfo(t *testing.T) {
@@ -483,7 +537,7 @@ func TestGetNodeInfo(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit}
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, nil}
 	core.EXPECT().ServerMeta().Return("111", "", "", "222", "")
 	ret, err := web3svr.getNodeInfo()
 	require.NoError(err)
@@ -495,7 +549,7 @@ func TestGetNetworkID(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit}
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, nil}
 	core.EXPECT().EVMNetworkID().Return(uint32(123))
 	ret, err := web3svr.getNetworkID()
 	require.NoError(err)
@@ -507,7 +561,7 @@ func TestIsSyncing(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit}
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, nil}
 	core.EXPECT().SyncingProgress().Return(uint64(1), uint64(2), uint64(3))
 	ret, err := web3svr.isSyncing()
 	require.NoError(err)
@@ -523,7 +577,7 @@ func TestGetBlockTransactionCountByHash(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit}
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, nil}
 
 	tsf, err := action.SignedTransfer(identityset.Address(28).String(), identityset.PrivateKey(27), uint64(1), big.NewInt(10), []byte{}, uint64(100000), big.NewInt(0))
 	require.NoError(err)
@@ -559,7 +613,7 @@ func TestGetBlockByHash(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit}
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, nil}
 
 	tsf, err := action.SignedTransfer(identityset.Address(28).String(), identityset.PrivateKey(27), uint64(1), big.NewInt(10), []byte{}, uint64(100000), big.NewInt(0))
 	require.NoError(err)
@@ -611,7 +665,7 @@ func TestGetTransactionByHash(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit}
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, nil}
 
 	selp, err := action.SignedTransfer(identityset.Address(28).String(), identityset.PrivateKey(27), uint64(1), big.NewInt(10), []byte{}, uint64(100000), big.NewInt(0))
 	require.NoError(err)
@@ -676,12 +730,101 @@ func TestGetTransactionByHash(t *testing.T) {
 	require.Nil(rlt.to)
 }
 
+func TestTxPool(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	core := NewMockCoreService(ctrl)
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, nil}
+
+	tsf1, err := action.SignedTransfer(identityset.Address(28).String(), identityset.PrivateKey(27), uint64(1), big.NewInt(10), []byte{}, uint64(100000), big.NewInt(1))
+	require.NoError(err)
+	tsf2, err := action.SignedTransfer(identityset.Address(28).String(), identityset.PrivateKey(27), uint64(2), big.NewInt(20), []byte{}, uint64(100000), big.NewInt(1))
+	require.NoError(err)
+	exec, err := action.SignedExecution("", identityset.PrivateKey(28), uint64(5), big.NewInt(0), uint64(200000), big.NewInt(2), []byte("test"))
+	require.NoError(err)
+	pending := map[string][]*action.SealedEnvelope{
+		identityset.Address(27).String(): {tsf1, tsf2},
+	}
+	queued := map[string][]*action.SealedEnvelope{
+		identityset.Address(28).String(): {exec},
+	}
+	core.EXPECT().ActPoolContent().Return(pending, queued).AnyTimes()
+	core.EXPECT().ActPoolStatus().Return(uint64(2), uint64(1)).AnyTimes()
+	core.EXPECT().EVMNetworkID().Return(uint32(0)).AnyTimes()
+	sender1 := common.BytesToAddress(identityset.Address(27).Bytes()).Hex()
+	sender2 := common.BytesToAddress(identityset.Address(28).Bytes()).Hex()
+
+	t.Run("eth_pendingTransactions", func(t *testing.T) {
+		ret, err := web3svr.pendingTransactions()
+		require.NoError(err)
+		txs, ok := ret.([]*getTransactionResult)
+		require.True(ok)
+		require.Len(txs, 2)
+		require.Equal(uint64(1), txs[0].ethTx.Nonce())
+		require.Equal(uint64(2), txs[1].ethTx.Nonce())
+	})
+	t.Run("eth_pendingTransactions capped", func(t *testing.T) {
+		core := NewMockCoreService(ctrl)
+		web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, nil}
+		pending := make(map[string][]*action.SealedEnvelope, _maxPendingTransactions)
+		for i := 0; i < _maxPendingTransactions; i++ {
+			pending[fmt.Sprintf("sender%04d", i)] = []*action.SealedEnvelope{tsf1, tsf2}
+		}
+		core.EXPECT().ActPoolContent().Return(pending, nil).Times(1)
+		core.EXPECT().EVMNetworkID().Return(uint32(0)).AnyTimes()
+		ret, err := web3svr.pendingTransactions()
+		require.NoError(err)
+		txs, ok := ret.([]*getTransactionResult)
+		require.True(ok)
+		require.Len(txs, _maxPendingTransactions)
+		require.Equal(uint64(1), txs[0].ethTx.Nonce())
+		require.Equal(uint64(2), txs[_maxPendingTransactions-1].ethTx.Nonce())
+	})
+	t.Run("txpool_content", func(t *testing.T) {
+		ret, err := web3svr.txpoolContent()
+		require.NoError(err)
+		content, ok := ret.(map[string]map[string]map[string]*getTransactionResult)
+		require.True(ok)
+		require.Len(content["pending"], 1)
+		require.Len(content["pending"][sender1], 2)
+		require.Equal(big.NewInt(10), content["pending"][sender1]["1"].ethTx.Value())
+		require.Equal(big.NewInt(20), content["pending"][sender1]["2"].ethTx.Value())
+		require.Nil(content["pending"][sender1]["1"].blockHash)
+		require.Len(content["queued"], 1)
+		require.Nil(content["queued"][sender2]["5"].to)
+	})
+	t.Run("txpool_status", func(t *testing.T) {
+		ret, err := web3svr.txpoolStatus()
+		require.NoError(err)
+		require.Equal(map[string]string{"pending": "0x2", "queued": "0x1"}, ret)
+	})
+	t.Run("txpool_inspect", func(t *testing.T) {
+		ret, err := web3svr.txpoolInspect()
+		require.NoError(err)
+		recipient := common.BytesToAddress(identityset.Address(28).Bytes()).Hex()
+		require.Equal(map[string]map[string]map[string]string{
+			"pending": {
+				sender1: {
+					"1": recipient + ": 10 wei + 100000 gas × 1 wei",
+					"2": recipient + ": 20 wei + 100000 gas × 1 wei",
+				},
+			},
+			"queued": {
+				sender2: {
+					"5": "contract creation: 0 wei + 200000 gas × 2 wei",
+				},
+			},
+		}, ret)
+	})
+}
+
 func TestGetLogs(t *testing.T) {
 	require := require.New(t)
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit}
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, nil}
 
 	logs := []*action.Log{
 		{
@@ -730,12 +873,17 @@ func TestGetTransactionReceipt(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit}
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, nil}
 
 	selp, err := action.SignedTransfer(identityset.Address(28).String(), identityset.PrivateKey(27), uint64(1), big.NewInt(10), []byte{}, uint64(100000), big.NewInt(0))
 	require.NoError(err)
 	txHash, err := selp.Hash()
 	require.NoError(err)
+	// the action preceding the transaction is not an ethereum transaction
+	poll, err := action.Sign((&action.EnvelopeBuilder{}).SetAction(action.NewPutPollResult(1, nil)).Build(), identityset.PrivateKey(0))
+	require.NoError(err)
+	pollHash, err := poll.Hash()
+	require.NoError(err)
 	receipt := &action.Receipt{
 		Status:          1,
 		BlockHeight:     1,
@@ -749,11 +897,14 @@ func TestGetTransactionReceipt(t *testing.T) {
 		SetVersion(111).
 		SetPrevBlockHash(hash.ZeroHash256).
 		SetTimeStamp(time.Now()).
-		AddActions(selp).
+		AddActions(poll, selp).
 		SignAndBuild(identityset.PrivateKey(0))
 	require.NoError(err)
-	core.EXPECT().ActionByActionHash(gomock.Any()).Return(selp, &blk, uint32(0), nil)
-	core.EXPECT().ReceiptByActionHash(gomock.Any()).Return(receipt, nil)
+	core.EXPECT().ActionByActionHash(gomock.Any()).Return(selp, &blk, uint32(1), nil)
+	core.EXPECT().ReceiptsByHeight(uint64(1)).Return([]*action.Receipt{
+		{Status: 1, BlockHeight: 1, ActionHash: pollHash, GasConsumed: 5},
+		receipt,
+	}, nil)
 
 	t.Run("nil params", func(t *testing.T) {
 		inNil := gjson.Parse(`{"params":[]}`)
@@ -770,6 +921,116 @@ func TestGetTransactionReceipt(t *testing.T) {
 		require.Equal(receipt, rlt.receipt)
 		require.Equal("", rlt.logsBloom)
 		require.Nil(blk.Header.LogsBloomfilter())
+		require.EqualValues(6, rlt.cumulativeGasUsed)
+	})
+}
+
+func TestGetBlockReceipts(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	core := NewMockCoreService(ctrl)
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, nil}
+
+	tsf1, err := action.SignedTransfer(identityset.Address(28).String(), identityset.PrivateKey(27), uint64(1), big.NewInt(10), []byte{}, uint64(100000), big.NewInt(1))
+	require.NoError(err)
+	tsf2, err := action.SignedTransfer(identityset.Address(28).String(), identityset.PrivateKey(27), uint64(2), big.NewInt(10), []byte{}, uint64(100000), big.NewInt(2))
+	require.NoError(err)
+	hash1, err := tsf1.Hash()
+	require.NoError(err)
+	hash2, err := tsf2.Hash()
+	require.NoError(err)
+	// the action which is not an ethereum transaction is skipped
+	poll, err := action.Sign((&action.EnvelopeBuilder{}).SetAction(action.NewPutPollResult(1, nil)).Build(), identityset.PrivateKey(0))
+	require.NoError(err)
+	pollHash, err := poll.Hash()
+	require.NoError(err)
+	contract := identityset.Address(31).String()
+	receipts := []*action.Receipt{
+		(&action.Receipt{Status: 1, BlockHeight: 1, ActionHash: hash1, GasConsumed: 100, EffectiveGasPrice: big.NewInt(1)}).AddLogs(
+			&action.Log{Address: contract, BlockHeight: 1, ActionHash: hash1},
+			&action.Log{Address: contract, BlockHeight: 1, ActionHash: hash1},
+		),
+		{Status: 1, BlockHeight: 1, ActionHash: pollHash, GasConsumed: 50},
+		// the receipt stored without the effective gas price and block-level log indexes
+		(&action.Receipt{Status: 1, BlockHeight: 1, ActionHash: hash2, GasConsumed: 200}).AddLogs(
+			&action.Log{Address: contract, BlockHeight: 1, ActionHash: hash2},
+		),
+	}
+	blk, err := block.NewTestingBuilder().
+		SetHeight(1).
+		SetVersion(111).
+		SetPrevBlockHash(hash.ZeroHash256).
+		SetTimeStamp(time.Now()).
+		SetReceipts(receipts).
+		AddActions(tsf1, poll, tsf2).
+		SignAndBuild(identityset.PrivateKey(0))
+	require.NoError(err)
+	blkHash := blk.HashBlock()
+	blkWithReceipts := &apitypes.BlockWithReceipts{
+		Block:    &blk,
+		Receipts: receipts,
+	}
+
+	t.Run("nil params", func(t *testing.T) {
+		inNil := gjson.Parse(`{"params":[]}`)
+		_, err := web3svr.getBlockReceipts(&inNil)
+		require.EqualError(err, errInvalidFormat.Error())
+	})
+
+	t.Run("by number", func(t *testing.T) {
+		core.EXPECT().BlockByHeight(uint64(1)).Return(blkWithReceipts, nil)
+		in := gjson.Parse(`{"params":["0x1"]}`)
+		ret, err := web3svr.getBlockReceipts(&in)
+		require.NoError(err)
+		rlt, ok := ret.([]*getReceiptResult)
+		require.True(ok)
+		require.Len(rlt, 2)
+		require.EqualValues(100, rlt[0].cumulativeGasUsed)
+		require.EqualValues(350, rlt[1].cumulativeGasUsed)
+		require.EqualValues(2, rlt[1].receipt.TxIndex)
+		require.Equal(big.NewInt(2), rlt[1].receipt.EffectiveGasPrice)
+		require.EqualValues(2, rlt[1].receipt.Logs()[0].Index)
+		require.EqualValues(2, rlt[1].receipt.Logs()[0].TxIndex)
+		// the same as the receipt of the single transaction
+		require.Equal(gasUsedUpTo(blockReceipts(&blk, receipts), hash2), rlt[1].cumulativeGasUsed)
+		// the receipts of the block are not modified
+		require.Nil(receipts[2].EffectiveGasPrice)
+		require.Zero(receipts[2].Logs()[0].Index)
+
+		data, err := json.Marshal(rlt[1])
+		require.NoError(err)
+		res := gjson.ParseBytes(data)
+		require.Equal("0x15e", res.Get("cumulativeGasUsed").String())
+		require.Equal("0xc8", res.Get("gasUsed").String())
+		require.Equal("0x2", res.Get("effectiveGasPrice").String())
+		require.Equal("0x2", res.Get("logs.0.logIndex").String())
+		require.Equal("0x2", res.Get("transactionIndex").String())
+	})
+
+	t.Run("by tag", func(t *testing.T) {
+		core.EXPECT().TipHeight().Return(uint64(1))
+		core.EXPECT().BlockByHeight(uint64(1)).Return(blkWithReceipts, nil)
+		in := gjson.Parse(`{"params":["latest"]}`)
+		ret, err := web3svr.getBlockReceipts(&in)
+		require.NoError(err)
+		require.Len(ret, 2)
+	})
+
+	t.Run("by hash", func(t *testing.T) {
+		core.EXPECT().BlockByHash(hex.EncodeToString(blkHash[:])).Return(blkWithReceipts, nil)
+		in := gjson.Parse(fmt.Sprintf(`{"params":["0x%s"]}`, hex.EncodeToString(blkHash[:])))
+		ret, err := web3svr.getBlockReceipts(&in)
+		require.NoError(err)
+		require.Len(ret, 2)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		core.EXPECT().BlockByHeight(uint64(2)).Return(nil, ErrNotFound)
+		in := gjson.Parse(`{"params":["0x2"]}`)
+		ret, err := web3svr.getBlockReceipts(&in)
+		require.NoError(err)
+		require.Nil(ret)
 	})
 }
 
@@ -778,7 +1039,7 @@ func TestGetBlockTransactionCountByNumber(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit}
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, nil}
 
 	tsf, err := action.SignedTransfer(identityset.Address(28).String(), identityset.PrivateKey(27), uint64(1), big.NewInt(10), []byte{}, uint64(100000), big.NewInt(0))
 	require.NoError(err)
@@ -813,7 +1074,7 @@ func TestGetTransactionByBlockHashAndIndex(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit}
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, nil}
 
 	tsf, err := action.SignedTransfer(identityset.Address(28).String(), identityset.PrivateKey(27), uint64(1), big.NewInt(10), []byte{}, uint64(100000), big.NewInt(0))
 	require.NoError(err)
@@ -860,7 +1121,7 @@ func TestGetTransactionByBlockNumberAndIndex(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit}
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, nil}
 
 	tsf, err := action.SignedTransfer(identityset.Address(28).String(), identityset.PrivateKey(27), uint64(1), big.NewInt(10), []byte{}, uint64(100000), big.NewInt(0))
 	require.NoError(err)
@@ -906,7 +1167,8 @@ func TestGetStorageAt(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit}
+	core.EXPECT().TipHeight().Return(uint64(100)).AnyTimes()
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, nil}
 	val := []byte("test")
 	core.EXPECT().ReadContractStorage(gomock.Any(), gomock.Any(), gomock.Any()).Return(val, nil)
 
@@ -914,6 +1176,104 @@ func TestGetStorageAt(t *testing.T) {
 	ret, err := web3svr.getStorageAt(&in)
 	require.NoError(err)
 	require.Equal("0x"+hex.EncodeToString(val), ret.(string))
+
+	coreWithHeight := NewMockCoreServiceReaderWithHeight(ctrl)
+	core.EXPECT().WithHeight(uint64(10)).Return(coreWithHeight).Times(1)
+	coreWithHeight.EXPECT().ReadContractStorage(gomock.Any(), gomock.Any(), gomock.Any()).Return(val, nil)
+	in = gjson.Parse(`{"params":["0x123456789abc", "0", "0xa"]}`)
+	ret, err = web3svr.getStorageAt(&in)
+	require.NoError(err)
+	require.Equal("0x"+hex.EncodeToString(val), ret.(string))
+}
+
+func TestGetProof(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	core := NewMockCoreService(ctrl)
+	core.EXPECT().TipHeight().Return(uint64(100)).AnyTimes()
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, nil}
+	account, err := state.NewAccount()
+	require.NoError(err)
+	require.NoError(account.AddBalance(big.NewInt(100)))
+	proof := &apitypes.AccountProof{
+		Height:       10,
+		StateRoot:    []byte{1, 2, 3},
+		Account:      account,
+		AccountProof: [][]byte{{4, 5}, {6}},
+		StorageProof: []*apitypes.StorageProof{
+			{
+				Key:   hash.BytesToHash256(common.BytesToHash([]byte{1}).Bytes()),
+				Value: []byte{0x10},
+				Proof: [][]byte{{7}},
+			},
+		},
+	}
+
+	t.Run("latest", func(t *testing.T) {
+		core.EXPECT().AccountProof(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, _ address.Address, keys []hash.Hash256) (*apitypes.AccountProof, error) {
+				require.Equal([]hash.Hash256{proof.StorageProof[0].Key}, keys)
+				return proof, nil
+			})
+		in := gjson.Parse(`{"params":["0xDa7e12Ef57c236a06117c5e0d04a228e7181CF36", ["0x1"], "latest"]}`)
+		ret, err := web3svr.getProof(&in)
+		require.NoError(err)
+		res, err := json.Marshal(ret)
+		require.NoError(err)
+		require.JSONEq(`{
+			"address":"0xda7e12ef57c236a06117c5e0d04a228e7181cf36",
+			"accountProof":["0x0405","0x06"],
+			"balance":"0x64",
+			"codeHash":"0xc5d2460186f7233c927e7db2dcc703c0e500b653ca82273b7bfad8045d85a470",
+			"nonce":"0x0",
+			"storageHash":"0x0000000000000000000000000000000000000000000000000000000000000000",
+			"storageProof":[{"key":"0x0000000000000000000000000000000000000000000000000000000000000001","value":"0x10","proof":["0x07"]}],
+			"stateRoot":"0x010203",
+			"blockNumber":"0xa"
+		}`, string(res))
+	})
+	t.Run("height", func(t *testing.T) {
+		coreWithHeight := NewMockCoreServiceReaderWithHeight(ctrl)
+		core.EXPECT().WithHeight(uint64(10)).Return(coreWithHeight).Times(1)
+		contract, err := state.NewAccount()
+		require.NoError(err)
+		codeHash := hash.Hash256b([]byte("code"))
+		contract.CodeHash = codeHash[:]
+		coreWithHeight.EXPECT().AccountProof(gomock.Any(), gomock.Any(), gomock.Any()).Return(&apitypes.AccountProof{
+			Height:  10,
+			Account: contract,
+		}, nil)
+		in := gjson.Parse(`{"params":["0xDa7e12Ef57c236a06117c5e0d04a228e7181CF36", [], "0xa"]}`)
+		ret, err := web3svr.getProof(&in)
+		require.NoError(err)
+		res, err := json.Marshal(ret)
+		require.NoError(err)
+		require.Equal(common.BytesToHash(contract.CodeHash).Hex(), gjson.GetBytes(res, "codeHash").String())
+	})
+	t.Run("invalid params", func(t *testing.T) {
+		for _, params := range []string{
+			`{"params":["0xDa7e12Ef57c236a06117c5e0d04a228e7181CF36"]}`,
+			`{"params":["0xDa7e12Ef57c236a06117c5e0d04a228e7181CF36", "0x1", "latest"]}`,
+			`{"params":["0xDa7e12Ef57c236a06117c5e0d04a228e7181CF36", ["0x000000000000000000000000000000000000000000000000000000000000000001"], "latest"]}`,
+		} {
+			in := gjson.Parse(params)
+			_, err := web3svr.getProof(&in)
+			require.Error(err)
+		}
+	})
+	t.Run("too many storage keys", func(t *testing.T) {
+		keys := make([]string, _maxProofStorageKeys+1)
+		for i := range keys {
+			keys[i] = fmt.Sprintf(`"0x%x"`, i)
+		}
+		in := gjson.Parse(`{"params":["0xDa7e12Ef57c236a06117c5e0d04a228e7181CF36", [` + strings.Join(keys, ",") + `], "latest"]}`)
+		_, err := web3svr.getProof(&in)
+		require.ErrorIs(err, errTooManyKeys)
+		res, err := json.Marshal(&web3Response{id: 1, err: err})
+		require.NoError(err)
+		require.EqualValues(_invalidParamsCode, gjson.GetBytes(res, "error.code").Int())
+	})
 }
 
 func TestNewfilter(t *testing.T) {
@@ -921,7 +1281,7 @@ func TestNewfilter(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, newAPICache(1*time.Second, ""), _defaultBatchRequestLimit}
+	web3svr := &web3Handler{core, newAPICache(1*time.Second, ""), _defaultBatchRequestLimit, nil}
 
 	ret, err := web3svr.newFilter(&filterObject{
 		FromBlock: "1",
@@ -938,7 +1298,7 @@ func TestNewBlockFilter(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, newAPICache(1*time.Second, ""), _defaultBatchRequestLimit}
+	web3svr := &web3Handler{core, newAPICache(1*time.Second, ""), _defaultBatchRequestLimit, nil}
 	core.EXPECT().TipHeight().Return(uint64(123))
 
 	ret, err := web3svr.newBlockFilter()
@@ -951,7 +1311,7 @@ func TestUninstallFilter(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, newAPICache(1*time.Second, ""), _defaultBatchRequestLimit}
+	web3svr := &web3Handler{core, newAPICache(1*time.Second, ""), _defaultBatchRequestLimit, nil}
 
 	require.NoError(web3svr.cache.Set("123456789abc", []byte("test")))
 
@@ -974,7 +1334,7 @@ func TestGetFilterChanges(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, newAPICache(1*time.Second, ""), _defaultBatchRequestLimit}
+	web3svr := &web3Handler{core, newAPICache(1*time.Second, ""), _defaultBatchRequestLimit, nil}
 	core.EXPECT().TipHeight().Return(uint64(0)).Times(3)
 
 	t.Run("log filterType", func(t *testing.T) {
@@ -1059,7 +1419,7 @@ func TestGetFilterLogs(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, newAPICache(1*time.Second, ""), _defaultBatchRequestLimit}
+	web3svr := &web3Handler{core, newAPICache(1*time.Second, ""), _defaultBatchRequestLimit, nil}
 
 	logs := []*action.Log{
 		{
@@ -1115,25 +1475,40 @@ func TestSubscribe(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit}
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, nil}
 
 	listener := mock_apitypes.NewMockListener(ctrl)
-	listener.EXPECT().AddResponder(gomock.Any()).Return("streamid_1", nil).Times(3)
-	core.EXPECT().ChainListener().Return(listener).Times(3)
+	listener.EXPECT().AddResponder(gomock.Any()).Return("streamid_1", nil).Times(4)
+	core.EXPECT().ChainListener().Return(listener).Times(4)
 	writer := mock_apitypes.NewMockWeb3ResponseWriter(ctrl)
 
 	t.Run("newHeads subscription", func(t *testing.T) {
 		in := gjson.Parse(`{"params":["newHeads"]}`)
 		sc, _ := StreamFromContext(WithStreamContext(context.Background()))
-		ret, err := web3svr.subscribe(sc, &in, writer)
+		ret, err := web3svr.subscribe(sc, &in, writer, nil)
 		require.NoError(err)
 		require.Equal("streamid_1", ret.(string))
 	})
 
+	t.Run("eth_subscribe without rate limit", func(t *testing.T) {
+		core.EXPECT().Track(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return()
+		req, err := http.NewRequest(http.MethodPost, "http://url.com", nil)
+		require.NoError(err)
+		ctx := withHTTPRequest(WithStreamContext(context.Background()), req)
+		writer.EXPECT().Write(gomock.Any()).DoAndReturn(func(resp interface{}) (int, error) {
+			require.NoError(resp.(*web3Response).err)
+			require.Equal("streamid_1", resp.(*web3Response).result)
+			return 0, nil
+		})
+		require.NoError(web3svr.HandlePOSTReq(ctx, strings.NewReader(`{"jsonrpc":"2.0","method":"eth_subscribe","params":["newHeads"],"id":1}`), writer))
+		sc, _ := StreamFromContext(ctx)
+		require.Equal([]string{"streamid_1"}, sc.ListenerIDs())
+	})
+
 	t.Run("logs subscription", func(t *testing.T) {
 		in := gjson.Parse(`{"params":["logs",{"fromBlock":"1","fromBlock":"2","address":["0x0000000000000000000000000000000000000001"],"topics":[["0x5f746f70696331"]]}]}`)
 		sc, _ := StreamFromContext(WithStreamContext(context.Background()))
-		ret, err := web3svr.subscribe(sc, &in, writer)
+		ret, err := web3svr.subscribe(sc, &in, writer, nil)
 		require.NoError(err)
 		require.Equal("streamid_1", ret.(string))
 	})
@@ -1141,7 +1516,7 @@ func TestSubscribe(t *testing.T) {
 	t.Run("logs topic not array", func(t *testing.T) {
 		in := gjson.Parse(`{"params":["logs",{"fromBlock":"1","fromBlock":"2","address":["0x0000000000000000000000000000000000000001"],"topics":["0x5f746f70696331"]}]}`)
 		sc, _ := StreamFromContext(WithStreamContext(context.Background()))
-		ret, err := web3svr.subscribe(sc, &in, writer)
+		ret, err := web3svr.subscribe(sc, &in, writer, nil)
 		require.NoError(err)
 		require.Equal("streamid_1", ret.(string))
 	})
@@ -1149,16 +1524,37 @@ func TestSubscribe(t *testing.T) {
 	t.Run("nil params", func(t *testing.T) {
 		inNil := gjson.Parse(`{"params":[]}`)
 		sc, _ := StreamFromContext(WithStreamContext(context.Background()))
-		_, err := web3svr.subscribe(sc, &inNil, writer)
+		_, err := web3svr.subscribe(sc, &inNil, writer, nil)
 		require.EqualError(err, errInvalidFormat.Error())
 	})
 
 	t.Run("nil logs", func(t *testing.T) {
 		inNil := gjson.Parse(`{"params":["logs"]}`)
 		sc, _ := StreamFromContext(WithStreamContext(context.Background()))
-		_, err := web3svr.subscribe(sc, &inNil, writer)
+		_, err := web3svr.subscribe(sc, &inNil, writer, nil)
 		require.EqualError(err, errInvalidFormat.Error())
 	})
+
+	t.Run("newPendingTransactions subscription", func(t *testing.T) {
+		actionListener := mock_apitypes.NewMockActionListener(ctrl)
+		core.EXPECT().ActionListener().Return(actionListener).Times(2)
+		core.EXPECT().EVMNetworkID().Return(uint32(0)).Times(2)
+		for _, params := range []string{`["newPendingTransactions"]`, `["newPendingTransactions", true]`} {
+			var responder apitypes.ActionResponder
+			actionListener.EXPECT().AddResponder(gomock.Any()).DoAndReturn(func(r apitypes.ActionResponder) (string, error) {
+				responder = r
+				return "streamid_2", nil
+			}).Times(1)
+			in := gjson.Parse(`{"params":` + params + `}`)
+			sc, _ := StreamFromContext(WithStreamContext(context.Background()))
+			ret, err := web3svr.subscribe(sc, &in, writer, nil)
+			require.NoError(err)
+			require.Equal("streamid_2", ret.(string))
+			require.Equal([]string{"streamid_2"}, sc.ListenerIDs())
+			require.Equal(in.Get("params.1").Bool(), responder.(*web3PendingActionListener).fullTx)
+			responder.Exit()
+		}
+	})
 }
 
 func TestUnsubscribe(t *testing.T) {
@@ -1166,21 +1562,38 @@ func TestUnsubscribe(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit}
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, nil}
 
 	listener := mock_apitypes.NewMockListener(ctrl)
-	listener.EXPECT().RemoveResponder(gomock.Any()).Return(true, nil)
-	core.EXPECT().ChainListener().Return(listener)
+	listener.EXPECT().RemoveResponder("0x123456789abc").Return(true, nil)
+	listener.EXPECT().RemoveResponder("0x123456789abd").Return(false, errListenerNotFound)
+	core.EXPECT().ChainListener().Return(listener).Times(2)
+	actionListener := mock_apitypes.NewMockActionListener(ctrl)
+	actionListener.EXPECT().RemoveResponder("0x123456789abd").Return(true, nil)
+	core.EXPECT().ActionListener().Return(actionListener)
 
 	t.Run("nil params", func(t *testing.T) {
 		inNil := gjson.Parse(`{"params":[]}`)
-		_, err := web3svr.unsubscribe(&inNil)
+		_, err := web3svr.unsubscribe(context.Background(), &inNil)
 		require.EqualError(err, errInvalidFormat.Error())
 	})
 
 	t.Run("unsubscribe", func(t *testing.T) {
 		in := gjson.Parse(`{"params":["0x123456789abc"]}`)
-		ret, err := web3svr.unsubscribe(&in)
+		ctx := WithStreamContext(context.Background())
+		sc, _ := StreamFromContext(ctx)
+		released := false
+		sc.AddListener("0x123456789abc", func() { released = true })
+		ret, err := web3svr.unsubscribe(ctx, &in)
+		require.NoError(err)
+		require.True(ret.(bool))
+		require.Empty(sc.ListenerIDs())
+		require.True(released)
+	})
+
+	t.Run("unsubscribe pending transactions", func(t *testing.T) {
+		in := gjson.Parse(`{"params":["0x123456789abd"]}`)
+		ret, err := web3svr.unsubscribe(context.Background(), &in)
 		require.NoError(err)
 		require.True(ret.(bool))
 	})
@@ -1206,7 +1619,7 @@ func TestDebugTraceTransaction(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit}
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, nil}
 
 	ctx := context.Background()
 	tsf, err := action.SignedExecution(identityset.Address(29).String(),
@@ -1245,7 +1658,7 @@ func TestDebugTraceCall(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit}
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, nil}
 
 	ctx := context.Background()
 	tsf, err := action.SignedExecution(identityset.Address(29).String(),
@@ -1257,7 +1670,7 @@ func TestDebugTraceCall(t *testing.T) {
 	receipt := &action.Receipt{Status: 1, BlockHeight: 1, ActionHash: tsfhash, GasConsumed: 100000}
 	structLogger := &logger.StructLogger{}
 
-	core.EXPECT().TraceCall(ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().Return([]byte{0x01}, receipt, structLogger, nil)
+	core.EXPECT().TraceCall(ctx, gomock.Any(), nil, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().Return([]byte{0x01}, receipt, structLogger, nil)
 
 	in := gjson.Parse(`{"method":"debug_traceCall","params":[{"from":null,"to":"0x6b175474e89094c44da98b954eedeac495271d0f","data":"0x70a082310000000000000000000000006E0d01A76C3Cf4288372a29124A26D4353EE51BE"}],"id":1,"jsonrpc":"2.0"}`)
 	ret, err := web3svr.traceCall(ctx, &in)
@@ -1269,6 +1682,141 @@ func TestDebugTraceCall(t *testing.T) {
 	require.Equal(uint64(100000), rlt.Gas)
 	require.Empty(rlt.Revert)
 	require.Equal(0, len(rlt.StructLogs))
+
+	t.Run("block number", func(t *testing.T) {
+		core.EXPECT().TraceCall(ctx, gomock.Any(), uint64(10), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return([]byte{0x01}, receipt, structLogger, nil)
+		in := gjson.Parse(`{"method":"debug_traceCall","params":[{"to":"0x6b175474e89094c44da98b954eedeac495271d0f"},"0xa"],"id":1,"jsonrpc":"2.0"}`)
+		_, err := web3svr.traceCall(ctx, &in)
+		require.NoError(err)
+	})
+
+	t.Run("block hash", func(t *testing.T) {
+		blkHash := "0x" + hex.EncodeToString(tsfhash[:])
+		core.EXPECT().TraceCall(ctx, gomock.Any(), blkHash, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return([]byte{0x01}, receipt, structLogger, nil)
+		in := gjson.Parse(`{"method":"debug_traceCall","params":[{"to":"0x6b175474e89094c44da98b954eedeac495271d0f"},{"blockHash":"` + blkHash + `"}],"id":1,"jsonrpc":"2.0"}`)
+		_, err := web3svr.traceCall(ctx, &in)
+		require.NoError(err)
+	})
+
+	t.Run("tracer options", func(t *testing.T) {
+		core.EXPECT().TraceCall(ctx, gomock.Any(), uint64(10), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(1).DoAndReturn(
+			func(_ context.Context, _ address.Address, _ any, _ string, _ uint64, _ *big.Int, _ uint64, _ []byte, config *tracers.TraceConfig) ([]byte, *action.Receipt, any, error) {
+				require.Equal("callTracer", *config.Tracer)
+				require.JSONEq(`{"onlyTopCall":true}`, string(config.TracerConfig))
+				require.True(config.EnableMemory)
+				return []byte{0x01}, receipt, structLogger, nil
+			})
+		in := gjson.Parse(`{"method":"debug_traceCall","params":[{"to":"0x6b175474e89094c44da98b954eedeac495271d0f"},"0xa",{"tracer":"callTracer","tracerConfig":{"onlyTopCall":true},"enableMemory":true}],"id":1,"jsonrpc":"2.0"}`)
+		_, err := web3svr.traceCall(ctx, &in)
+		require.NoError(err)
+	})
+}
+
+func TestTraceBlock(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	core := NewMockCoreService(ctrl)
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, nil}
+
+	ctx := context.Background()
+	traces := []*apitypes.ActionCallTraces{{
+		BlockHeight: 10,
+		BlockHash:   hash.BytesToHash256([]byte{1}),
+		ActionHash:  hash.BytesToHash256([]byte{2}),
+		Index:       1,
+		Traces: []*action.CallTrace{{
+			Type:         action.CallTraceTypeCall,
+			CallType:     "call",
+			From:         identityset.Address(28).String(),
+			To:           identityset.Address(29).String(),
+			Value:        big.NewInt(16),
+			Gas:          30000,
+			GasUsed:      21000,
+			Input:        []byte{1},
+			Output:       []byte{2},
+			TraceAddress: []uint64{},
+			Subtraces:    1,
+		}, {
+			Type:         action.CallTraceTypeCreate,
+			From:         identityset.Address(29).String(),
+			To:           identityset.Address(30).String(),
+			Value:        big.NewInt(0),
+			Gas:          20000,
+			Input:        []byte{3},
+			Error:        "execution reverted",
+			TraceAddress: []uint64{0},
+		}},
+	}}
+
+	t.Run("nil params", func(t *testing.T) {
+		inNil := gjson.Parse(`{"params":[]}`)
+		_, err := web3svr.traceBlock(ctx, &inNil)
+		require.EqualError(err, errInvalidFormat.Error())
+	})
+
+	t.Run("trace block", func(t *testing.T) {
+		core.EXPECT().TraceBlockCalls(ctx, uint64(10)).Return(traces, nil)
+		in := gjson.Parse(`{"params":["0xa"]}`)
+		ret, err := web3svr.traceBlock(ctx, &in)
+		require.NoError(err)
+		data, err := json.Marshal(ret)
+		require.NoError(err)
+		from, to := common.BytesToAddress(identityset.Address(28).Bytes()).Hex(), common.BytesToAddress(identityset.Address(29).Bytes()).Hex()
+		require.JSONEq(`[{
+			"action":{"from":"`+from+`","to":"`+to+`","value":"0x10","gas":"0x7530","input":"0x01","callType":"call"},
+			"blockHash":"0x0000000000000000000000000000000000000000000000000000000000000001","blockNumber":10,
+			"result":{"gasUsed":"0x5208","output":"0x02"},"subtraces":1,"traceAddress":[],
+			"transactionHash":"0x0000000000000000000000000000000000000000000000000000000000000002","transactionPosition":1,"type":"call"
+		},{
+			"action":{"from":"`+to+`","value":"0x0","gas":"0x4e20","init":"0x03"},
+			"blockHash":"0x0000000000000000000000000000000000000000000000000000000000000001","blockNumber":10,
+			"error":"Reverted","result":null,"subtraces":0,"traceAddress":[0],
+			"transactionHash":"0x0000000000000000000000000000000000000000000000000000000000000002","transactionPosition":1,"type":"create"
+		}]`, string(data))
+	})
+
+	t.Run("trace transaction", func(t *testing.T) {
+		core.EXPECT().TraceActionCalls(ctx, "0x02").Return(traces[0], nil)
+		in := gjson.Parse(`{"params":["0x02"]}`)
+		ret, err := web3svr.traceActionCalls(ctx, &in)
+		require.NoError(err)
+		require.Len(ret, 2)
+	})
+
+	t.Run("trace filter", func(t *testing.T) {
+		core.EXPECT().TipHeight().Return(uint64(20)).AnyTimes()
+		core.EXPECT().FilterCallTraces(ctx, &apitypes.CallTraceFilter{
+			FromBlock:   1,
+			ToBlock:     20,
+			ToAddresses: []address.Address{identityset.Address(29)},
+			After:       1,
+			Count:       2,
+		}).Return(traces, nil)
+		in := gjson.Parse(`{"params":[{"fromBlock":"earliest","toBlock":"latest","toAddress":["` + identityset.Address(29).Hex() + `"],"after":1,"count":2}]}`)
+		ret, err := web3svr.traceFilter(ctx, &in)
+		require.NoError(err)
+		require.Len(ret, 2)
+	})
+}
+
+func TestParseTraceConfig(t *testing.T) {
+	require := require.New(t)
+
+	options := gjson.Parse(`{"enableMemory":true,"disableStack":true,"tracer":"callTracer","tracerConfig":{"onlyTopCall":true},"timeout":"10s"}`)
+	cfg := parseTraceConfig(&options)
+	require.True(cfg.Config.EnableMemory)
+	require.True(cfg.Config.DisableStack)
+	require.False(cfg.Config.DisableStorage)
+	require.Equal("callTracer", *cfg.Tracer)
+	require.JSONEq(`{"onlyTopCall":true}`, string(cfg.TracerConfig))
+	require.Equal("10s", *cfg.Timeout)
+
+	empty := gjson.Parse(``)
+	cfg = parseTraceConfig(&empty)
+	require.Nil(cfg.Tracer)
+	require.Nil(cfg.Timeout)
+	require.NotNil(cfg.Config)
 }
 
 func TestResponseIDMatchTypeWithRequest(t *testing.T) {
@@ -1278,7 +1826,7 @@ func TestResponseIDMatchTypeWithRequest(t *testing.T) {
 	core := NewMockCoreService(ctrl)
 	core.EXPECT().TipHeight().Return(uint64(1)).AnyTimes()
 	core.EXPECT().Track(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return().AnyTimes()
-	svr := newHTTPHandler(NewWeb3Handler(core, "", _defaultBatchRequestLimit))
+	svr := newHTTPHandler(NewWeb3Handler(core, "", _defaultBatchRequestLimit, nil))
 	getServerResp := func(svr *hTTPHandler, req *http.Request) *httptest.ResponseRecorder {
 		req.Header.Set("Content-Type", "application/json")
 		resp := httptest.NewRecorder()
@@ -1304,3 +1852,70 @@ func TestResponseIDMatchTypeWithRequest(t *testing.T) {
 		require.Contains(string(bodyBytes), tt.sub)
 	}
 }
+
+func TestGetTokenTransfers(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	core := NewMockCoreService(ctrl)
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, nil}
+
+	var (
+		owner     = identityset.Address(28)
+		token     = identityset.Address(31)
+		ethAddr   = func(addr address.Address) string { return common.BytesToAddress(addr.Bytes()).Hex() }
+		transfers = []*blockindex.TokenTransfer{{
+			Standard:    blockindex.TokenStandardXRC20,
+			Token:       token,
+			From:        owner,
+			To:          identityset.Address(29),
+			Amount:      big.NewInt(16),
+			BlockHeight: 10,
+			ActionHash:  hash.BytesToHash256([]byte{2}),
+			LogIndex:    1,
+		}, {
+			Standard:    blockindex.TokenStandardXRC721,
+			Token:       token,
+			From:        identityset.Address(30),
+			To:          owner,
+			TokenID:     big.NewInt(7),
+			Amount:      big.NewInt(1),
+			BlockHeight: 11,
+			ActionHash:  hash.BytesToHash256([]byte{3}),
+		}}
+	)
+
+	t.Run("nil params", func(t *testing.T) {
+		inNil := gjson.Parse(`{"params":[{}]}`)
+		_, err := web3svr.getTokenTransfers(&inNil)
+		require.EqualError(err, errInvalidFormat.Error())
+	})
+
+	t.Run("get token transfers", func(t *testing.T) {
+		core.EXPECT().TokenTransfers(owner, token, uint64(1), uint64(2)).Return(transfers, uint64(3), nil)
+		in := gjson.Parse(`{"params":[{"address":"` + ethAddr(owner) + `","token":"` + ethAddr(token) + `","start":"0x1","count":"0x2"}]}`)
+		ret, err := web3svr.getTokenTransfers(&in)
+		require.NoError(err)
+		data, err := json.Marshal(ret)
+		require.NoError(err)
+		require.JSONEq(`{"total":"0x3","transfers":[{
+			"standard":"xrc20","token":"`+ethAddr(token)+`","from":"`+ethAddr(owner)+`","to":"`+ethAddr(identityset.Address(29))+`",
+			"tokenId":null,"amount":"0x10","blockNumber":"0xa","logIndex":"0x1",
+			"transactionHash":"0x0000000000000000000000000000000000000000000000000000000000000002"
+		},{
+			"standard":"xrc721","token":"`+ethAddr(token)+`","from":"`+ethAddr(identityset.Address(30))+`","to":"`+ethAddr(owner)+`",
+			"tokenId":"0x7","amount":"0x1","blockNumber":"0xb","logIndex":"0x0",
+			"transactionHash":"0x0000000000000000000000000000000000000000000000000000000000000003"
+		}]}`, string(data))
+	})
+
+	t.Run("all tokens", func(t *testing.T) {
+		core.EXPECT().TokenTransfers(owner, nil, uint64(0), uint64(10)).Return(nil, uint64(0), nil)
+		in := gjson.Parse(`{"params":[{"address":"` + ethAddr(owner) + `","count":"0xa"}]}`)
+		ret, err := web3svr.getTokenTransfers(&in)
+		require.NoError(err)
+		data, err := json.Marshal(ret)
+		require.NoError(err)
+		require.JSONEq(`{"total":"0x0","transfers":[]}`, string(data))
+	})
+}
@@ -10,8 +10,10 @@ import (
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/common/math"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/eth/tracers"
 	"github.com/ethereum/go-ethereum/eth/tracers/logger"
 	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/go-redis/redis/v8"
@@ -26,9 +28,12 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/iotexproject/iotex-core/v2/action"
+	"github.com/iotexproject/iotex-core/v2/action/protocol"
+	"github.com/iotexproject/iotex-core/v2/action/protocol/execution/evm"
 	logfilter "github.com/iotexproject/iotex-core/v2/api/logfilter"
 	apitypes "github.com/iotexproject/iotex-core/v2/api/types"
 	"github.com/iotexproject/iotex-core/v2/blockchain/block"
+	"github.com/iotexproject/iotex-core/v2/blockindex"
 	"github.com/iotexproject/iotex-core/v2/pkg/log"
 	"github.com/iotexproject/iotex-core/v2/pkg/util/addrutil"
 )
@@ -92,10 +97,7 @@ func (svr *web3Handler) getBlockWithTransactions(blk *block.Block, receipts []*a
 		if isDetailed {
 			tx, err := svr.assembleConfirmedTransaction(blk.HashBlock(), selp, receipts[i])
 			if err != nil {
-				if errors.Cause(err) != errUnsupportedAction {
-					h, _ := selp.Hash()
-					log.Logger("api").Error("failed to get info from action", zap.Error(err), zap.String("actHash", hex.EncodeToString(h[:])))
-				}
+				logUnassembledAction(selp, err)
 				continue
 			}
 			transactions = append(transactions, tx)
@@ -129,6 +131,67 @@ func (svr *web3Handler) assemblePendingTransaction(selp *action.SealedEnvelope)
 	return newGetTransactionResult(nil, selp, nil, svr.coreService.EVMNetworkID())
 }
 
+// groupPendingTransactions converts actions in actpool into a sender -> nonce -> transaction map
+func (svr *web3Handler) groupPendingTransactions(acts map[string][]*action.SealedEnvelope) (map[string]map[string]*getTransactionResult, error) {
+	ret := make(map[string]map[string]*getTransactionResult, len(acts))
+	for sender, selps := range acts {
+		ethAddr, err := ioAddrToEthAddr(sender)
+		if err != nil {
+			return nil, err
+		}
+		txs := make(map[string]*getTransactionResult, len(selps))
+		for _, selp := range selps {
+			tx, err := svr.assemblePendingTransaction(selp)
+			if err != nil {
+				logUnassembledAction(selp, err)
+				continue
+			}
+			txs[strconv.FormatUint(selp.Nonce(), 10)] = tx
+		}
+		if len(txs) > 0 {
+			ret[ethAddr] = txs
+		}
+	}
+	return ret, nil
+}
+
+// inspectPendingTransactions summarizes actions in actpool into a sender -> nonce -> summary map,
+// the summary is in the same format as geth's txpool_inspect
+func inspectPendingTransactions(acts map[string][]*action.SealedEnvelope) (map[string]map[string]string, error) {
+	ret := make(map[string]map[string]string, len(acts))
+	for sender, selps := range acts {
+		ethAddr, err := ioAddrToEthAddr(sender)
+		if err != nil {
+			return nil, err
+		}
+		txs := make(map[string]string, len(selps))
+		for _, selp := range selps {
+			tx, err := selp.ToEthTx()
+			if err != nil {
+				logUnassembledAction(selp, err)
+				continue
+			}
+			if to := tx.To(); to != nil {
+				txs[strconv.FormatUint(selp.Nonce(), 10)] = fmt.Sprintf("%s: %v wei + %v gas × %v wei", to.Hex(), tx.Value(), tx.Gas(), tx.GasPrice())
+			} else {
+				txs[strconv.FormatUint(selp.Nonce(), 10)] = fmt.Sprintf("contract creation: %v wei + %v gas × %v wei", tx.Value(), tx.Gas(), tx.GasPrice())
+			}
+		}
+		if len(txs) > 0 {
+			ret[ethAddr] = txs
+		}
+	}
+	return ret, nil
+}
+
+func logUnassembledAction(selp *action.SealedEnvelope, err error) {
+	if errors.Cause(err) == errUnsupportedAction {
+		return
+	}
+	h, _ := selp.Hash()
+	log.Logger("api").Error("failed to get info from action", zap.Error(err), zap.String("actHash", hex.EncodeToString(h[:])))
+}
+
 func getRecipientAndContractAddrFromAction(selp *action.SealedEnvelope, receipt *action.Receipt) (*string, *string, error) {
 	// recipient is empty when contract is created
 	if exec, ok := selp.Action().(*action.Execution); ok && len(exec.Contract()) == 0 {
@@ -166,6 +229,39 @@ func (svr *web3Handler) parseBlockRange(fromStr string, toStr string) (from uint
 	return
 }
 
+// checkRateLimit takes the cost of the request from the quota of the client, the cost of a logs query grows with
+// its block range, and the cost of a proof is the weight of the method for the account and each storage key
+func (svr *web3Handler) checkRateLimit(ctx context.Context, method string, in *gjson.Result) error {
+	if svr.limiter == nil {
+		return nil
+	}
+	var logsRange uint64
+	if method == "eth_getLogs" {
+		if filter, err := parseLogRequest(in.Get("params")); err == nil {
+			if from, to, err := svr.parseBlockRange(filter.FromBlock, filter.ToBlock); err == nil && to >= from {
+				logsRange = to - from + 1
+			}
+		}
+	}
+	cost := svr.limiter.Cost(method, logsRange)
+	if method == "eth_getProof" {
+		cost *= 1 + len(in.Get("params.1").Array())
+	}
+	return svr.limiter.Allow(svr.limiter.clientFromContext(ctx), method, cost)
+}
+
+func web3ReqID(in *gjson.Result) any {
+	reqID := in.Get("id")
+	switch reqID.Type {
+	case gjson.String:
+		return reqID.String()
+	case gjson.Number:
+		return reqID.Int()
+	default:
+		return 0
+	}
+}
+
 func (svr *web3Handler) ethTxToEnvelope(tx *types.Transaction) (action.Envelope, error) {
 	to := ""
 	if tx.To() != nil {
@@ -189,6 +285,25 @@ func (svr *web3Handler) ethTxToEnvelope(tx *types.Transaction) (action.Envelope,
 	return elpBuilder.BuildTransfer(tx)
 }
 
+// simulateTxToEnvelope converts the tx into an envelope to simulate, which is an execution unless the tx is
+// sent to the staking or rewarding protocol, since the contracts created in the simulation are unknown to the chain
+func (svr *web3Handler) simulateTxToEnvelope(tx *types.Transaction) (action.Envelope, error) {
+	elpBuilder := (&action.EnvelopeBuilder{}).SetChainID(svr.coreService.ChainID())
+	if tx.To() != nil {
+		ioAddr, err := address.FromBytes(tx.To().Bytes())
+		if err != nil {
+			return nil, err
+		}
+		switch ioAddr.String() {
+		case address.StakingProtocolAddr:
+			return elpBuilder.BuildStakingAction(tx)
+		case address.RewardingProtocol:
+			return elpBuilder.BuildRewardingAction(tx)
+		}
+	}
+	return elpBuilder.BuildExecution(tx)
+}
+
 func (svr *web3Handler) checkContractAddr(to string) (bool, error) {
 	if to == "" {
 		return true, nil
@@ -294,9 +409,135 @@ type callMsg struct {
 	Data        []byte           // input data, usually an ABI-encoded contract method invocation
 	AccessList  types.AccessList // EIP-2930 access list.
 	BlockNumber rpc.BlockNumber
+	BlockHash   *common.Hash // the block hash if the block is specified by hash (EIP-1898)
+	// StateOverride and BlockOverride are applied to the simulation only, see eth_call of geth
+	StateOverride evm.StateOverride
+	BlockOverride *blockOverride
+}
+
+// overrideAccount is the json format of an account in the state override set
+type overrideAccount struct {
+	Nonce     *hexutil.Uint64             `json:"nonce"`
+	Code      *hexutil.Bytes              `json:"code"`
+	Balance   *hexutil.Big                `json:"balance"`
+	State     map[common.Hash]common.Hash `json:"state"`
+	StateDiff map[common.Hash]common.Hash `json:"stateDiff"`
+}
+
+// blockOverride is the json format of the block override set
+type blockOverride struct {
+	Number  *hexutil.Big    `json:"number"`
+	Time    *hexutil.Uint64 `json:"time"`
+	BaseFee *hexutil.Big    `json:"baseFee"`
 }
 
+func parseStateOverride(in *gjson.Result) (evm.StateOverride, error) {
+	if !in.Exists() || in.Type == gjson.Null {
+		return nil, nil
+	}
+	accounts := map[common.Address]overrideAccount{}
+	if err := json.Unmarshal([]byte(in.Raw), &accounts); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal state override %s", in.Raw)
+	}
+	so := make(evm.StateOverride, len(accounts))
+	for addr, acct := range accounts {
+		if acct.State != nil {
+			// contract storage is not kept in a trie that can be replaced as a whole
+			return nil, errors.Wrapf(errNotImplemented, "state override of the whole storage of %s, use stateDiff instead", addr.Hex())
+		}
+		override := evm.OverrideAccount{
+			StateDiff: acct.StateDiff,
+		}
+		if acct.Nonce != nil {
+			nonce := uint64(*acct.Nonce)
+			override.Nonce = &nonce
+		}
+		if acct.Code != nil {
+			override.Code = []byte(*acct.Code)
+		}
+		if acct.Balance != nil {
+			override.Balance = acct.Balance.ToInt()
+		}
+		so[addr] = override
+	}
+	return so, nil
+}
+
+func parseBlockOverride(in *gjson.Result) (*blockOverride, error) {
+	if !in.Exists() || in.Type == gjson.Null {
+		return nil, nil
+	}
+	bo := &blockOverride{}
+	if err := json.Unmarshal([]byte(in.Raw), bo); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal block override %s", in.Raw)
+	}
+	if bo.Number != nil && !bo.Number.ToInt().IsUint64() {
+		return nil, errors.Wrapf(errInvalidBlock, "block number override %s", bo.Number.String())
+	}
+	return bo, nil
+}
+
+// simulateOptions returns the options to apply the state and block overrides to the simulation
+func (call *callMsg) simulateOptions() []protocol.SimulateOption {
+	return simulateOptions(call.StateOverride, call.BlockOverride)
+}
+
+func simulateOptions(so evm.StateOverride, bo *blockOverride) []protocol.SimulateOption {
+	var opts []protocol.SimulateOption
+	if len(so) > 0 {
+		opts = append(opts, protocol.WithSimulateStateOverride(so.Apply))
+	}
+	if bo != nil {
+		opts = append(opts, protocol.WithSimulateBlockOverride(func(blkCtx *protocol.BlockCtx) {
+			if bo.Number != nil {
+				blkCtx.BlockHeight = bo.Number.ToInt().Uint64()
+			}
+			if bo.Time != nil {
+				blkCtx.BlockTimeStamp = time.Unix(int64(*bo.Time), 0)
+			}
+			if bo.BaseFee != nil {
+				blkCtx.BaseFee = new(big.Int).Set(bo.BaseFee.ToInt())
+			}
+		}))
+	}
+	return opts
+}
+
+// parseCallObject parses the call object and the block number or hash in the params
 func parseCallObject(in *gjson.Result) (*callMsg, error) {
+	tx := in.Get("params.0")
+	call, err := parseCallMsg(&tx)
+	if err != nil {
+		return nil, err
+	}
+	bnParam := in.Get("params.1")
+	if call.BlockNumber, call.BlockHash, err = parseBlockNumberOrHash(&bnParam); err != nil {
+		return nil, err
+	}
+	return call, nil
+}
+
+// parseCallObjectWithOverrides parses the state and block overrides following the call object and the block number
+// or hash in the params, which are taken by eth_call, eth_estimateGas and eth_createAccessList only, as the other
+// methods, e.g. debug_traceCall, take different params in those positions
+func parseCallObjectWithOverrides(in *gjson.Result) (*callMsg, error) {
+	call, err := parseCallObject(in)
+	if err != nil {
+		return nil, err
+	}
+	soParam := in.Get("params.2")
+	if call.StateOverride, err = parseStateOverride(&soParam); err != nil {
+		return nil, err
+	}
+	boParam := in.Get("params.3")
+	if call.BlockOverride, err = parseBlockOverride(&boParam); err != nil {
+		return nil, err
+	}
+	return call, nil
+}
+
+// parseCallMsg parses the fields of a call object, the block number is set to latest
+func parseCallMsg(tx *gjson.Result) (*callMsg, error) {
 	var (
 		from      address.Address
 		to        string
@@ -307,10 +548,9 @@ func parseCallObject(in *gjson.Result) (*callMsg, error) {
 		value     *big.Int = big.NewInt(0)
 		data      []byte
 		acl       types.AccessList
-		bn        = rpc.LatestBlockNumber
 		err       error
 	)
-	fromStr := in.Get("params.0.from").String()
+	fromStr := tx.Get("from").String()
 	if fromStr == "" {
 		fromStr = "0x0000000000000000000000000000000000000000"
 	}
@@ -318,7 +558,7 @@ func parseCallObject(in *gjson.Result) (*callMsg, error) {
 		return nil, err
 	}
 
-	toStr := in.Get("params.0.to").String()
+	toStr := tx.Get("to").String()
 	if toStr != "" {
 		ioAddr, err := ethAddrToIoAddr(toStr)
 		if err != nil {
@@ -327,14 +567,14 @@ func parseCallObject(in *gjson.Result) (*callMsg, error) {
 		to = ioAddr.String()
 	}
 
-	gasStr := in.Get("params.0.gas").String()
+	gasStr := tx.Get("gas").String()
 	if gasStr != "" {
 		if gasLimit, err = hexStringToNumber(gasStr); err != nil {
 			return nil, err
 		}
 	}
 
-	gasPriceStr := in.Get("params.0.gasPrice").String()
+	gasPriceStr := tx.Get("gasPrice").String()
 	if gasPriceStr != "" {
 		var ok bool
 		if gasPrice, ok = new(big.Int).SetString(util.Remove0xPrefix(gasPriceStr), 16); !ok {
@@ -342,21 +582,21 @@ func parseCallObject(in *gjson.Result) (*callMsg, error) {
 		}
 	}
 
-	if gasTipCapStr := in.Get("params.0.maxPriorityFeePerGas").String(); gasTipCapStr != "" {
+	if gasTipCapStr := tx.Get("maxPriorityFeePerGas").String(); gasTipCapStr != "" {
 		var ok bool
 		if gasTipCap, ok = new(big.Int).SetString(util.Remove0xPrefix(gasTipCapStr), 16); !ok {
 			return nil, errors.Wrapf(errUnkownType, "gasTipCap: %s", gasTipCapStr)
 		}
 	}
 
-	if gasFeeCapStr := in.Get("params.0.maxFeePerGas").String(); gasFeeCapStr != "" {
+	if gasFeeCapStr := tx.Get("maxFeePerGas").String(); gasFeeCapStr != "" {
 		var ok bool
 		if gasFeeCap, ok = new(big.Int).SetString(util.Remove0xPrefix(gasFeeCapStr), 16); !ok {
 			return nil, errors.Wrapf(errUnkownType, "gasFeeCap: %s", gasFeeCapStr)
 		}
 	}
 
-	valStr := in.Get("params.0.value").String()
+	valStr := tx.Get("value").String()
 	if valStr != "" {
 		var ok bool
 		if value, ok = new(big.Int).SetString(util.Remove0xPrefix(valStr), 16); !ok {
@@ -364,27 +604,19 @@ func parseCallObject(in *gjson.Result) (*callMsg, error) {
 		}
 	}
 
-	if input := in.Get("params.0.input"); input.Exists() {
+	if input := tx.Get("input"); input.Exists() {
 		data = common.FromHex(input.String())
 	} else {
-		data = common.FromHex(in.Get("params.0.data").String())
+		data = common.FromHex(tx.Get("data").String())
 	}
 
-	if accessList := in.Get("params.0.accessList"); accessList.Exists() {
+	if accessList := tx.Get("accessList"); accessList.Exists() {
 		acl = types.AccessList{}
 		log.L().Info("raw acl", zap.String("accessList", accessList.Raw))
 		if err := json.Unmarshal([]byte(accessList.Raw), &acl); err != nil {
 			return nil, errors.Wrapf(err, "failed to unmarshal access list %s", accessList.Raw)
 		}
 	}
-	if bnParam := in.Get("params.1"); bnParam.Exists() {
-		if err = bn.UnmarshalJSON([]byte(bnParam.String())); err != nil {
-			return nil, errors.Wrapf(err, "failed to unmarshal height %s", bnParam.String())
-		}
-		if bn == rpc.PendingBlockNumber {
-			return nil, errors.Wrap(errNotImplemented, "pending block number is not supported")
-		}
-	}
 	return &callMsg{
 		From:        from,
 		To:          to,
@@ -395,10 +627,48 @@ func parseCallObject(in *gjson.Result) (*callMsg, error) {
 		Value:       value,
 		Data:        data,
 		AccessList:  acl,
-		BlockNumber: bn,
+		BlockNumber: rpc.LatestBlockNumber,
 	}, nil
 }
 
+// simulateBlockStateCall is the json format of a block of calls in eth_simulateV1
+type simulateBlockStateCall struct {
+	StateOverride evm.StateOverride
+	BlockOverride *blockOverride
+	Calls         []*callMsg
+}
+
+func parseSimulateBlockStateCalls(in *gjson.Result) ([]*simulateBlockStateCall, error) {
+	blocks := in.Get("params.0.blockStateCalls")
+	if !blocks.IsArray() {
+		return nil, errors.Wrap(errInvalidFormat, "blockStateCalls is not an array")
+	}
+	var ret []*simulateBlockStateCall
+	for _, blk := range blocks.Array() {
+		var (
+			soParam = blk.Get("stateOverrides")
+			boParam = blk.Get("blockOverrides")
+			bsc     = &simulateBlockStateCall{}
+			err     error
+		)
+		if bsc.StateOverride, err = parseStateOverride(&soParam); err != nil {
+			return nil, err
+		}
+		if bsc.BlockOverride, err = parseBlockOverride(&boParam); err != nil {
+			return nil, err
+		}
+		for _, tx := range blk.Get("calls").Array() {
+			call, err := parseCallMsg(&tx)
+			if err != nil {
+				return nil, err
+			}
+			bsc.Calls = append(bsc.Calls, call)
+		}
+		ret = append(ret, bsc)
+	}
+	return ret, nil
+}
+
 func parseBlockNumber(in *gjson.Result) (rpc.BlockNumber, error) {
 	if !in.Exists() {
 		return rpc.LatestBlockNumber, nil
@@ -413,6 +683,57 @@ func parseBlockNumber(in *gjson.Result) (rpc.BlockNumber, error) {
 	return height, nil
 }
 
+// parseBlockNumberOrHash parses a block number, or an EIP-1898 object of block number or block hash
+func parseBlockNumberOrHash(in *gjson.Result) (rpc.BlockNumber, *common.Hash, error) {
+	if !in.IsObject() {
+		bn, err := parseBlockNumber(in)
+		return bn, nil, err
+	}
+	var bnOrHash rpc.BlockNumberOrHash
+	if err := bnOrHash.UnmarshalJSON([]byte(in.Raw)); err != nil {
+		return 0, nil, errors.Wrapf(err, "failed to unmarshal block number or hash %s", in.Raw)
+	}
+	if h, ok := bnOrHash.Hash(); ok {
+		return rpc.LatestBlockNumber, &h, nil
+	}
+	bn, _ := bnOrHash.Number()
+	if bn == rpc.PendingBlockNumber {
+		return 0, nil, errors.Wrap(errNotImplemented, "pending block number is not supported")
+	}
+	return bn, nil, nil
+}
+
+// parseStateHeight parses the block parameter of a state query, and returns the height to read the state at.
+// The returned bool is false if the state should be read at the tip
+func (svr *web3Handler) parseStateHeight(in *gjson.Result) (uint64, bool, error) {
+	bn, blkHash, err := parseBlockNumberOrHash(in)
+	if err != nil {
+		return 0, false, err
+	}
+	return svr.blockNumberOrHashToHeight(bn, blkHash)
+}
+
+func (svr *web3Handler) blockNumberOrHashToHeight(bn rpc.BlockNumber, blkHash *common.Hash) (uint64, bool, error) {
+	var height uint64
+	if blkHash == nil {
+		var archive bool
+		if height, archive = blockNumberToHeight(bn); !archive {
+			return 0, false, nil
+		}
+	} else {
+		blk, err := svr.coreService.BlockByHash(util.Remove0xPrefix(blkHash.Hex()))
+		if err != nil {
+			return 0, false, err
+		}
+		height = blk.Block.Height()
+	}
+	// the states of the tip block are read from the latest state, which doesn't require archive
+	if height != svr.coreService.TipHeight() {
+		return height, true, nil
+	}
+	return 0, false, nil
+}
+
 func blockNumberToHeight(bn rpc.BlockNumber) (uint64, bool) {
 	switch bn {
 	case rpc.SafeBlockNumber, rpc.FinalizedBlockNumber, rpc.LatestBlockNumber:
@@ -591,6 +912,86 @@ func (c *remoteCache) Get(key string) ([]byte, bool) {
 	return ret, true
 }
 
+func parseTraceConfig(options *gjson.Result) *tracers.TraceConfig {
+	cfg := &tracers.TraceConfig{
+		Config: &logger.Config{},
+	}
+	if !options.Exists() {
+		return cfg
+	}
+	cfg.Config.EnableMemory = options.Get("enableMemory").Bool()
+	cfg.Config.DisableStack = options.Get("disableStack").Bool()
+	cfg.Config.DisableStorage = options.Get("disableStorage").Bool()
+	cfg.Config.EnableReturnData = options.Get("enableReturnData").Bool()
+	if tracer := options.Get("tracer"); tracer.Exists() {
+		cfg.Tracer = new(string)
+		*cfg.Tracer = tracer.String()
+		if tracerConfig := options.Get("tracerConfig"); tracerConfig.Exists() {
+			cfg.TracerConfig = json.RawMessage(tracerConfig.Raw)
+		}
+	}
+	if timeout := options.Get("timeout"); timeout.Exists() {
+		cfg.Timeout = new(string)
+		*cfg.Timeout = timeout.String()
+	}
+	return cfg
+}
+
+func traceResult(retval []byte, receipt *action.Receipt, tracer any) (interface{}, error) {
+	switch tracer := tracer.(type) {
+	case *logger.StructLogger:
+		return &debugTraceTransactionResult{
+			Failed:      receipt.Status != uint64(iotextypes.ReceiptStatus_Success),
+			Revert:      receipt.ExecutionRevertMsg(),
+			ReturnValue: byteToHex(retval),
+			StructLogs:  fromLoggerStructLogs(tracer.StructLogs()),
+			Gas:         receipt.GasConsumed,
+		}, nil
+	case tracers.Tracer:
+		return tracer.GetResult()
+	default:
+		return nil, fmt.Errorf("unknown tracer type: %T", tracer)
+	}
+}
+
+// callTraceResults flattens the call traces of the actions
+func callTraceResults(traces []*apitypes.ActionCallTraces) []*callTraceResult {
+	ret := make([]*callTraceResult, 0)
+	for _, t := range traces {
+		for _, trace := range t.Traces {
+			ret = append(ret, &callTraceResult{t, trace})
+		}
+	}
+	return ret
+}
+
+func newTokenTransferResult(tt *blockindex.TokenTransfer) (*tokenTransferResult, error) {
+	var (
+		addrs = make([]string, 3)
+		err   error
+	)
+	for i, addr := range []address.Address{tt.Token, tt.From, tt.To} {
+		if addrs[i], err = ioAddrToEthAddr(addr.String()); err != nil {
+			return nil, err
+		}
+	}
+	ret := &tokenTransferResult{
+		Standard:        tt.Standard,
+		Token:           addrs[0],
+		From:            addrs[1],
+		To:              addrs[2],
+		Amount:          bigIntToHex(tt.Amount),
+		BlockNumber:     uint64ToHex(tt.BlockHeight),
+		TransactionHash: "0x" + hex.EncodeToString(tt.ActionHash[:]),
+		LogIndex:        uint64ToHex(uint64(tt.LogIndex)),
+	}
+	if tt.TokenID != nil {
+		tokenID := bigIntToHex(tt.TokenID)
+		ret.TokenID = &tokenID
+	}
+	return ret, nil
+}
+
 // fromLoggerStructLogs converts logger.StructLog to apitypes.StructLog
 func fromLoggerStructLogs(logs []logger.StructLog) []apitypes.StructLog {
 	ret := make([]apitypes.StructLog, len(logs))
@@ -7,9 +7,14 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/golang/mock/gomock"
+	"github.com/iotexproject/go-pkgs/util"
 	"github.com/iotexproject/iotex-address/address"
 	"github.com/stretchr/testify/require"
 	"github.com/tidwall/gjson"
+
+	"github.com/iotexproject/iotex-core/v2/action/protocol"
+	apitypes "github.com/iotexproject/iotex-core/v2/api/types"
+	"github.com/iotexproject/iotex-core/v2/blockchain/block"
 )
 
 func TestParseCallObject(t *testing.T) {
@@ -145,6 +150,63 @@ func TestParseCallObject(t *testing.T) {
 		require.EqualError(err, "value: unknown: wrong type of params")
 	})
 
+	t.Run("parse overrides", func(t *testing.T) {
+		input := `{"params":[{
+				"from":     "",
+				"to":       "0x7c13866F9253DEf79e20034eDD011e1d69E67fe5",
+				"input":    "0x6d4ce63c"
+			   },
+			   "latest",
+			   {
+				"0x7c13866F9253DEf79e20034eDD011e1d69E67fe5": {
+					"balance": "0x10",
+					"nonce":   "0x2",
+					"code":    "0x6000",
+					"stateDiff": {
+						"0x0000000000000000000000000000000000000000000000000000000000000001": "0x00000000000000000000000000000000000000000000000000000000000000ff"
+					}
+				}
+			   },
+			   {"number": "0x64", "time": "0x5f5e100", "baseFee": "0x3b9aca00"}]}`
+		in := gjson.Parse(input)
+		callMsg, err := parseCallObjectWithOverrides(&in)
+		require.NoError(err)
+		override, ok := callMsg.StateOverride[common.HexToAddress("0x7c13866F9253DEf79e20034eDD011e1d69E67fe5")]
+		require.True(ok)
+		require.Equal(big.NewInt(16), override.Balance)
+		require.EqualValues(2, *override.Nonce)
+		require.Equal([]byte{0x60, 0x00}, override.Code)
+		require.Equal(common.HexToHash("0xff"), override.StateDiff[common.HexToHash("0x01")])
+		opts := callMsg.simulateOptions()
+		require.Len(opts, 2)
+		cfg := &protocol.SimulateOptionConfig{}
+		for _, opt := range opts {
+			opt(cfg)
+		}
+		blkCtx := protocol.BlockCtx{BlockHeight: 1}
+		cfg.BlockOverride(&blkCtx)
+		require.EqualValues(100, blkCtx.BlockHeight)
+		require.EqualValues(100000000, blkCtx.BlockTimeStamp.Unix())
+		require.Equal(big.NewInt(1000000000), blkCtx.BaseFee)
+
+		// the params following the block number are not overrides for the other methods
+		callMsg, err = parseCallObject(&in)
+		require.NoError(err)
+		require.Empty(callMsg.simulateOptions())
+
+		input = `{"params":[{"to": "0x7c13866F9253DEf79e20034eDD011e1d69E67fe5"}, "latest", {
+				"0x7c13866F9253DEf79e20034eDD011e1d69E67fe5": {"state": {}}
+			   }]}`
+		in = gjson.Parse(input)
+		_, err = parseCallObjectWithOverrides(&in)
+		require.ErrorIs(err, errNotImplemented)
+
+		input = `{"params":[{"to": "0x7c13866F9253DEf79e20034eDD011e1d69E67fe5"}, "latest"]}`
+		in = gjson.Parse(input)
+		callMsg, err = parseCallObjectWithOverrides(&in)
+		require.NoError(err)
+		require.Empty(callMsg.simulateOptions())
+	})
 }
 
 func TestParseBlockNumber(t *testing.T) {
@@ -152,7 +214,7 @@ func TestParseBlockNumber(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	core := NewMockCoreService(ctrl)
-	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit}
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, nil}
 
 	t.Run("earliest block number", func(t *testing.T) {
 		num, _ := web3svr.parseBlockNumber("earliest")
@@ -177,3 +239,61 @@ func TestParseBlockNumber(t *testing.T) {
 		require.Equal(num, uint64(0x1))
 	})
 }
+
+func TestParseStateHeight(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	core := NewMockCoreService(ctrl)
+	web3svr := &web3Handler{core, nil, _defaultBatchRequestLimit, nil}
+
+	core.EXPECT().TipHeight().Return(uint64(12)).Times(5)
+	for _, test := range []struct {
+		param   string
+		height  uint64
+		archive bool
+	}{
+		{`{"params":[]}`, 0, false},
+		{`{"params":["latest"]}`, 0, false},
+		{`{"params":["earliest"]}`, 1, true},
+		{`{"params":["0xa"]}`, 10, true},
+		{`{"params":[{"blockNumber":"0xa"}]}`, 10, true},
+		{`{"params":[{"blockNumber":"latest"}]}`, 0, false},
+		// the tip height is read without archive
+		{`{"params":["0xc"]}`, 0, false},
+		{`{"params":[{"blockNumber":"0xc"}]}`, 0, false},
+	} {
+		in := gjson.Parse(test.param).Get("params.0")
+		height, archive, err := web3svr.parseStateHeight(&in)
+		require.NoError(err)
+		require.Equal(test.height, height)
+		require.Equal(test.archive, archive)
+	}
+
+	t.Run("block hash", func(t *testing.T) {
+		blkHash := "0x4d2ee0b0ae4fa7c87b0cebcc9af8e4a3c1b1e5e2df3d1d4d1c0fb4e1dee8f2a0"
+		core.EXPECT().BlockByHash(util.Remove0xPrefix(blkHash)).Return(&apitypes.BlockWithReceipts{
+			Block: &block.Block{Header: block.Header{}},
+		}, nil).Times(2)
+		core.EXPECT().TipHeight().Return(uint64(10))
+		in := gjson.Parse(`{"blockHash":"` + blkHash + `"}`)
+		height, archive, err := web3svr.parseStateHeight(&in)
+		require.NoError(err)
+		require.Zero(height)
+		require.True(archive)
+		// the tip block is read without archive
+		core.EXPECT().TipHeight().Return(uint64(0))
+		height, archive, err = web3svr.parseStateHeight(&in)
+		require.NoError(err)
+		require.Zero(height)
+		require.False(archive)
+	})
+
+	t.Run("pending", func(t *testing.T) {
+		for _, param := range []string{`"pending"`, `{"blockNumber":"pending"}`} {
+			in := gjson.Parse(param)
+			_, _, err := web3svr.parseStateHeight(&in)
+			require.ErrorIs(err, errNotImplemented)
+		}
+	})
+}
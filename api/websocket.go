@@ -99,7 +99,7 @@ func (wsSvr *WebsocketHandler) ServeHTTP(w http.ResponseWriter, req *http.Reques
 		return
 	}
 
-	wsSvr.handleConnection(req.Context(), ws)
+	wsSvr.handleConnection(withHTTPRequest(req.Context(), req), ws)
 }
 
 func (wsSvr *WebsocketHandler) handleConnection(ctx context.Context, ws *websocket.Conn) {
@@ -124,6 +124,8 @@ func (wsSvr *WebsocketHandler) handleConnection(ctx context.Context, ws *websock
 		sc, _ := StreamFromContext(ctx)
 		for _, id := range sc.ListenerIDs() {
 			wsSvr.coreService.ChainListener().RemoveResponder(id)
+			wsSvr.coreService.ActionListener().RemoveResponder(id)
+			sc.RemoveListener(id)
 		}
 	}()
 
@@ -332,7 +332,7 @@ func (bc *blockchain) ValidateBlock(blk *block.Block, opts ...BlockValidationOpt
 	if producerAddr == nil {
 		return errors.New("failed to get address")
 	}
-	ctx, err := bc.context(context.Background(), tipHeight)
+	ctx, err := bc.processContext(tipHeight)
 	if err != nil {
 		return err
 	}
@@ -407,6 +407,19 @@ func (bc *blockchain) context(ctx context.Context, height uint64) (context.Conte
 	return protocol.WithFeatureWithHeightCtx(ctx), nil
 }
 
+// processContext returns the context to process a block upon the tip, the call traces of the block are recorded
+// for the trace indexer in minting or validating the block, and kept with the cached working set to commit
+func (bc *blockchain) processContext(tipHeight uint64) (context.Context, error) {
+	ctx, err := bc.context(context.Background(), tipHeight)
+	if err != nil {
+		return nil, err
+	}
+	if bc.config.EnableTraceIndexer {
+		ctx = protocol.WithCallTraceCtx(ctx)
+	}
+	return ctx, nil
+}
+
 func (bc *blockchain) MintNewBlock(timestamp time.Time) (*block.Block, error) {
 	bc.mu.RLock()
 	defer bc.mu.RUnlock()
@@ -417,7 +430,7 @@ func (bc *blockchain) MintNewBlock(timestamp time.Time) (*block.Block, error) {
 		return nil, err
 	}
 	newblockHeight := tipHeight + 1
-	ctx, err := bc.context(context.Background(), tipHeight)
+	ctx, err := bc.processContext(tipHeight)
 	if err != nil {
 		return nil, err
 	}
@@ -508,7 +521,7 @@ func (bc *blockchain) commitBlock(blk *block.Block) error {
 	if err != nil {
 		return err
 	}
-	ctx, err := bc.context(context.Background(), tipHeight)
+	ctx, err := bc.processContext(tipHeight)
 	if err != nil {
 		return err
 	}
@@ -24,14 +24,16 @@ import (
 type (
 	// Config is the config struct for blockchain package
 	Config struct {
-		ChainDBPath            string `yaml:"chainDBPath"`
-		TrieDBPatchFile        string `yaml:"trieDBPatchFile"`
-		TrieDBPath             string `yaml:"trieDBPath"`
-		StakingPatchDir        string `yaml:"stakingPatchDir"`
-		IndexDBPath            string `yaml:"indexDBPath"`
-		BloomfilterIndexDBPath string `yaml:"bloomfilterIndexDBPath"`
-		CandidateIndexDBPath   string `yaml:"candidateIndexDBPath"`
-		StakingIndexDBPath     string `yaml:"stakingIndexDBPath"`
+		ChainDBPath              string `yaml:"chainDBPath"`
+		TrieDBPatchFile          string `yaml:"trieDBPatchFile"`
+		TrieDBPath               string `yaml:"trieDBPath"`
+		StakingPatchDir          string `yaml:"stakingPatchDir"`
+		IndexDBPath              string `yaml:"indexDBPath"`
+		BloomfilterIndexDBPath   string `yaml:"bloomfilterIndexDBPath"`
+		CandidateIndexDBPath     string `yaml:"candidateIndexDBPath"`
+		StakingIndexDBPath       string `yaml:"stakingIndexDBPath"`
+		TraceIndexDBPath         string `yaml:"traceIndexDBPath"`
+		TokenTransferIndexDBPath string `yaml:"tokenTransferIndexDBPath"`
 		// deprecated
 		SGDIndexDBPath             string           `yaml:"sgdIndexDBPath"`
 		ContractStakingIndexDBPath string           `yaml:"contractStakingIndexDBPath"`
@@ -52,6 +54,19 @@ type (
 		EnableStateDBCaching bool `yaml:"enableStateDBCaching"`
 		// EnableArchiveMode is only meaningful when EnableTrielessStateDB is false
 		EnableArchiveMode bool `yaml:"enableArchiveMode"`
+		// HistoryWindow is the number of recent blocks whose state history is retained in archive mode, 0 means full history
+		HistoryWindow uint64 `yaml:"historyWindow"`
+		// HistoryPruneInterval is the interval to prune the state history out of the window
+		HistoryPruneInterval time.Duration `yaml:"historyPruneInterval"`
+		// ContractStakingHistoryDBPath is the path of the history of contract staking buckets kept in archive mode
+		ContractStakingHistoryDBPath string `yaml:"contractStakingHistoryDBPath"`
+		// StateSnapshotDir is the directory of the state snapshot to bootstrap the node from, if the state db is empty
+		StateSnapshotDir string `yaml:"stateSnapshotDir"`
+		// StateSnapshotTrustedDigest is the digest of the state snapshot in StateSnapshotDir (see
+		// factory.SnapshotManifest.Digest), which is obtained from a trusted source, as the snapshot can't vouch for
+		// itself. It is required to import the snapshot, unless the snapshot is downloaded by state sync, which
+		// verifies it against blockSync.stateSync.trustedDigest
+		StateSnapshotTrustedDigest string `yaml:"stateSnapshotTrustedDigest"`
 		// EnableAsyncIndexWrite enables writing the block actions' and receipts' index asynchronously
 		EnableAsyncIndexWrite bool `yaml:"enableAsyncIndexWrite"`
 		// deprecated
@@ -60,6 +75,17 @@ type (
 		EnableStakingProtocol bool `yaml:"enableStakingProtocol"`
 		// EnableStakingIndexer enables staking indexer
 		EnableStakingIndexer bool `yaml:"enableStakingIndexer"`
+		// EnableTraceIndexer records the call traces of the blocks executed, and indexes them for the trace_* api
+		EnableTraceIndexer bool `yaml:"enableTraceIndexer"`
+		// TraceIndexStartHeight is the height to start indexing the call traces from, which has to be higher than
+		// the tip height if the trace indexer is enabled on a running node
+		TraceIndexStartHeight uint64 `yaml:"traceIndexStartHeight"`
+		// EnableTokenTransferIndexer indexes the XRC20, XRC721 and XRC1155 token transfers by address
+		EnableTokenTransferIndexer bool `yaml:"enableTokenTransferIndexer"`
+		// EnableStakingHistoryIndexer indexes the events of the native staking buckets and candidates
+		EnableStakingHistoryIndexer bool `yaml:"enableStakingHistoryIndexer"`
+		// StakingHistoryIndexDBPath is the path of the staking history index db
+		StakingHistoryIndexDBPath string `yaml:"stakingHistoryIndexDBPath"`
 		// AllowedBlockGasResidue is the amount of gas remained when block producer could stop processing more actions
 		AllowedBlockGasResidue uint64 `yaml:"allowedBlockGasResidue"`
 		// MaxCacheSize is the max number of blocks that will be put into an LRU cache. 0 means disabled
@@ -94,6 +120,8 @@ var (
 		BloomfilterIndexDBPath:     "/var/data/bloomfilter.index.db",
 		CandidateIndexDBPath:       "/var/data/candidate.index.db",
 		StakingIndexDBPath:         "/var/data/staking.index.db",
+		TraceIndexDBPath:           "/var/data/trace.index.db",
+		TokenTransferIndexDBPath:   "/var/data/tokentransfer.index.db",
 		SGDIndexDBPath:             "/var/data/sgd.index.db",
 		ContractStakingIndexDBPath: "/var/data/contractstaking.index.db",
 		BlobStoreDBPath:            "/var/data/blob.db",
@@ -111,10 +139,17 @@ var (
 		EnableTrielessStateDB:         true,
 		EnableStateDBCaching:          false,
 		EnableArchiveMode:             false,
+		HistoryWindow:                 0,
+		HistoryPruneInterval:          10 * time.Minute,
+		ContractStakingHistoryDBPath:  "/var/data/contractstaking.history.db",
 		EnableAsyncIndexWrite:         true,
 		EnableSystemLogIndexer:        false,
 		EnableStakingProtocol:         true,
 		EnableStakingIndexer:          false,
+		EnableTraceIndexer:            false,
+		EnableTokenTransferIndexer:    false,
+		EnableStakingHistoryIndexer:   false,
+		StakingHistoryIndexDBPath:     "/var/data/stakinghistory.index.db",
 		AllowedBlockGasResidue:        10000,
 		MaxCacheSize:                  0,
 		PollInitialCandidatesInterval: 10 * time.Second,
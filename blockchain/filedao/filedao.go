@@ -8,6 +8,7 @@ package filedao
 import (
 	"context"
 	"fmt"
+	"os"
 	"sync"
 
 	"github.com/pkg/errors"
@@ -111,6 +112,36 @@ func NewFileDAO(cfg db.Config, deser *block.Deserializer) (FileDAO, error) {
 	}
 }
 
+// NewFileDAOWithStart creates a new chain db whose first block is at the start height instead of 1,
+// which is used by a node bootstrapped from a state snapshot. An existing chain db without any block
+// is replaced.
+func NewFileDAOWithStart(start uint64, cfg db.Config, deser *block.Deserializer) (FileDAO, error) {
+	header, err := readFileHeader(cfg.DbPath, FileAll)
+	switch err {
+	case ErrFileNotExist:
+	case nil:
+		if header.Version != FileV2 {
+			return nil, errors.Errorf("chain db %s already exists", cfg.DbPath)
+		}
+		empty, err := isEmptyV2File(cfg, deser)
+		if err != nil {
+			return nil, err
+		}
+		if !empty {
+			return nil, errors.Errorf("chain db %s already exists", cfg.DbPath)
+		}
+		if err := os.Remove(cfg.DbPath); err != nil {
+			return nil, errors.Wrapf(err, "failed to remove empty chain db %s", cfg.DbPath)
+		}
+	default:
+		return nil, err
+	}
+	if err := createNewV2File(start, cfg, deser); err != nil {
+		return nil, err
+	}
+	return CreateFileDAO(false, cfg, deser)
+}
+
 // NewFileDAOInMemForTest creates an in-memory FileDAO for testing
 func NewFileDAOInMemForTest() (FileDAO, error) {
 	return newTestInMemFd()
@@ -406,6 +437,21 @@ func CreateFileDAO(legacy bool, cfg db.Config, deser *block.Deserializer) (FileD
 	return &fd, nil
 }
 
+// isEmptyV2File checks whether the v2 chain db file has no block
+func isEmptyV2File(cfg db.Config, deser *block.Deserializer) (bool, error) {
+	fd := openFileDAOv2(cfg, deser)
+	ctx := context.Background()
+	if err := fd.Start(ctx); err != nil {
+		return false, err
+	}
+	defer fd.Stop(ctx)
+	height, err := fd.Height()
+	if err != nil {
+		return false, err
+	}
+	return height+1 == fd.header.Start, nil
+}
+
 // createNewV2File creates a new v2 chain db file
 func createNewV2File(start uint64, cfg db.Config, deser *block.Deserializer) error {
 	v2, err := newFileDAOv2(start, cfg, deser)
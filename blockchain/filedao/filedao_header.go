@@ -28,6 +28,7 @@ type (
 	FileHeader struct {
 		Version        string
 		Compressor     string
+		CompressorDict []byte
 		BlockStoreSize uint64
 		Start          uint64
 	}
@@ -82,6 +83,7 @@ func (h *FileHeader) toProto() *headerpb.FileHeader {
 	return &headerpb.FileHeader{
 		Version:        h.Version,
 		Compressor:     h.Compressor,
+		CompressorDict: h.CompressorDict,
 		BlockStoreSize: h.BlockStoreSize,
 		Start:          h.Start,
 	}
@@ -91,6 +93,7 @@ func fromProtoFileHeader(pb *headerpb.FileHeader) *FileHeader {
 	return &FileHeader{
 		Version:        pb.Version,
 		Compressor:     pb.Compressor,
+		CompressorDict: pb.CompressorDict,
 		BlockStoreSize: pb.BlockStoreSize,
 		Start:          pb.Start,
 	}
@@ -8,7 +8,9 @@ package filedao
 import (
 	"context"
 	"encoding/hex"
+	"fmt"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/iotexproject/go-pkgs/crypto"
@@ -18,6 +20,7 @@ import (
 	"github.com/iotexproject/iotex-core/v2/blockchain/block"
 	"github.com/iotexproject/iotex-core/v2/db"
 	"github.com/iotexproject/iotex-core/v2/pkg/compress"
+	"github.com/iotexproject/iotex-core/v2/pkg/util/fileutil"
 )
 
 func TestChecksumNamespaceAndKeys(t *testing.T) {
@@ -186,6 +189,101 @@ func TestNewFileDAOSplitV2(t *testing.T) {
 	os.RemoveAll(file2)
 }
 
+func TestNewFileDAOMixedCodecs(t *testing.T) {
+	r := require.New(t)
+
+	dir := t.TempDir()
+	cfg := db.DefaultConfig
+	cfg.V2BlocksToSplitDB = 10
+	cfg.DbPath = filepath.Join(dir, "chain.db")
+	deser := block.NewDeserializer(_defaultEVMNetworkID)
+	ctx := context.Background()
+
+	// invalid compressor is rejected before creating the file
+	cfg.Compressor = "invalid"
+	_, err := NewFileDAO(cfg, deser)
+	r.ErrorIs(err, compress.ErrUnsupportedCompressor)
+	r.False(fileutil.FileExists(cfg.DbPath))
+
+	samples := make([][]byte, 16)
+	for i := range samples {
+		samples[i] = []byte(fmt.Sprintf("receipt of block %d with repetitive content", i))
+	}
+	dict, err := compress.BuildZstdDict(1, samples, 1024)
+	r.NoError(err)
+	dictPath := filepath.Join(dir, "zstd.dict")
+	r.NoError(os.WriteFile(dictPath, dict, 0644))
+
+	// each file split after restarting uses a different codec
+	for i, codec := range []struct {
+		compressor, dict string
+	}{
+		{compress.Snappy, ""},
+		{compress.Zstd, dictPath},
+		{compress.Lz4, ""},
+		{compress.Zstd, ""},
+	} {
+		cfg.Compressor = codec.compressor
+		cfg.CompressorDict = codec.dict
+		fd, err := NewFileDAO(cfg, deser)
+		r.NoError(err)
+		r.NoError(fd.Start(ctx))
+		start := uint64(i*10 + 1)
+		r.NoError(testCommitBlocks(t, fd, start, start+9, hash.ZeroHash256))
+		testVerifyChainDB(t, fd, 1, start+9)
+		r.NoError(fd.Stop(ctx))
+	}
+	h, err := readFileHeader(kthAuxFileName(cfg.DbPath, 1), FileV2)
+	r.NoError(err)
+	r.Equal(compress.Zstd, h.Compressor)
+	r.Equal(dict, h.CompressorDict)
+
+	// the dictionary is only needed to create a new file
+	r.NoError(os.Remove(dictPath))
+	cfg.Compressor = compress.Zstd
+	cfg.CompressorDict = dictPath
+	fd, err := NewFileDAO(cfg, deser)
+	r.NoError(err)
+	r.NoError(fd.Start(ctx))
+	defer fd.Stop(ctx)
+	testVerifyChainDB(t, fd, 1, 40)
+	r.ErrorContains(testCommitBlocks(t, fd, 41, 41, hash.ZeroHash256), "failed to read compressor dictionary")
+}
+
+func TestNewFileDAOWithStart(t *testing.T) {
+	r := require.New(t)
+
+	cfg := db.DefaultConfig
+	cfg.DbPath = t.TempDir() + "/chain.db"
+	deser := block.NewDeserializer(_defaultEVMNetworkID)
+	// an empty chain db is replaced
+	fd, err := NewFileDAO(cfg, deser)
+	r.NoError(err)
+	fd, err = NewFileDAOWithStart(100, cfg, deser)
+	r.NoError(err)
+	ctx := context.Background()
+	r.NoError(fd.Start(ctx))
+	height, err := fd.Height()
+	r.NoError(err)
+	r.EqualValues(99, height)
+	r.NoError(testCommitBlocks(t, fd, 100, 110, hash.ZeroHash256))
+	testVerifyChainDB(t, fd, 100, 110)
+	_, err = fd.GetBlockByHeight(99)
+	r.Equal(ErrNotSupported, err)
+	r.NoError(fd.Stop(ctx))
+
+	// the chain db cannot be created again
+	_, err = NewFileDAOWithStart(100, cfg, deser)
+	r.ErrorContains(err, "already exists")
+
+	// reopen the chain db
+	fd, err = NewFileDAO(cfg, deser)
+	r.NoError(err)
+	r.NoError(fd.Start(ctx))
+	defer fd.Stop(ctx)
+	testVerifyChainDB(t, fd, 100, 110)
+}
+
 func TestNewFileDAOSplitLegacy(t *testing.T) {
 	r := require.New(t)
 
@@ -7,6 +7,7 @@ package filedao
 
 import (
 	"context"
+	"os"
 	"sync/atomic"
 	"unsafe"
 
@@ -20,6 +21,7 @@ import (
 	"github.com/iotexproject/iotex-core/v2/blockchain/block"
 	"github.com/iotexproject/iotex-core/v2/db"
 	"github.com/iotexproject/iotex-core/v2/db/batch"
+	"github.com/iotexproject/iotex-core/v2/pkg/compress"
 	"github.com/iotexproject/iotex-core/v2/pkg/util/byteutil"
 )
 
@@ -47,6 +49,7 @@ type (
 		hashStore       db.CountingIndex // store block hash
 		blkStore        db.CountingIndex // store raw blocks
 		sysStore        db.CountingIndex // store transaction log
+		codec           *compress.Codec
 		deser           *block.Deserializer
 	}
 )
@@ -56,12 +59,24 @@ func newFileDAOv2(bottom uint64, cfg db.Config, deser *block.Deserializer) (*fil
 	if bottom == 0 {
 		return nil, ErrNotSupported
 	}
+	var dict []byte
+	if len(cfg.CompressorDict) > 0 {
+		var err error
+		if dict, err = os.ReadFile(cfg.CompressorDict); err != nil {
+			return nil, errors.Wrap(err, "failed to read compressor dictionary")
+		}
+	}
+	// validate the compressor before creating the file
+	if _, err := compress.NewCodec(cfg.Compressor, dict); err != nil {
+		return nil, err
+	}
 
 	fd := fileDAOv2{
 		filename: cfg.DbPath,
 		header: &FileHeader{
 			Version:        FileV2,
 			Compressor:     cfg.Compressor,
+			CompressorDict: dict,
 			BlockStoreSize: uint64(cfg.BlockStoreBatchSize),
 			Start:          bottom,
 		},
@@ -112,6 +127,10 @@ func (fd *fileDAOv2) Start(ctx context.Context) error {
 			return err
 		}
 	}
+	// the codec is recorded in the header of each file, so files of different codecs can be read together
+	if fd.codec, err = compress.NewCodec(fd.header.Compressor, fd.header.CompressorDict); err != nil {
+		return errors.Wrapf(err, "invalid compressor of file %s", fd.filename)
+	}
 
 	// create counting index for hash, blk, and transaction log
 	if fd.hashStore, err = db.NewCountingIndexNX(fd.kvStore, []byte(_hashDataNS)); err != nil {
@@ -213,7 +232,7 @@ func (fd *fileDAOv2) TransactionLogs(height uint64) (*iotextypes.TransactionLogs
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to get transaction log at height %d", height)
 	}
-	value, err = decompBytes(value, fd.header.Compressor)
+	value, err = fd.codec.Decompress(value)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to get transaction log at height %d", height)
 	}
@@ -263,7 +263,7 @@ func TestNewFdInterface(t *testing.T) {
 	genesis.SetGenesisTimestamp(g.Timestamp)
 	block.LoadGenesisHash(&g)
 
-	for _, compress := range []string{"", compress.Snappy} {
+	for _, compress := range []string{"", compress.Snappy, compress.Zstd, compress.Lz4} {
 		for _, start := range []uint64{1, 5, _blockStoreBatchSize + 1, 4 * _blockStoreBatchSize} {
 			cfg.Compressor = compress
 			t.Run("test fileDAOv2 interface", func(t *testing.T) {
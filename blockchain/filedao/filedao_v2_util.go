@@ -14,7 +14,6 @@ import (
 	"github.com/iotexproject/iotex-core/v2/blockchain/block"
 	"github.com/iotexproject/iotex-core/v2/db"
 	"github.com/iotexproject/iotex-core/v2/db/batch"
-	"github.com/iotexproject/iotex-core/v2/pkg/compress"
 	"github.com/iotexproject/iotex-core/v2/pkg/util/byteutil"
 )
 
@@ -30,7 +29,7 @@ func (fd *fileDAOv2) populateStagingBuffer() (*stagingBuffer, error) {
 			return nil, err
 		}
 
-		v, err = decompBytes(v, fd.header.Compressor)
+		v, err = fd.codec.Decompress(v)
 		if err != nil {
 			return nil, err
 		}
@@ -81,7 +80,7 @@ func (fd *fileDAOv2) putBlock(blk *block.Block) error {
 	if err != nil {
 		return err
 	}
-	blkBytes, err := compBytes(ser, fd.header.Compressor)
+	blkBytes, err := fd.codec.Compress(ser)
 	if err != nil {
 		return err
 	}
@@ -101,7 +100,7 @@ func (fd *fileDAOv2) putBlock(blk *block.Block) error {
 	if ser, err = fd.blkBuffer.Serialize(); err != nil {
 		return err
 	}
-	if blkBytes, err = compBytes(ser, fd.header.Compressor); err != nil {
+	if blkBytes, err = fd.codec.Compress(ser); err != nil {
 		return err
 	}
 	return addOneEntryToBatch(fd.blkStore, blkBytes, fd.batch)
@@ -112,7 +111,7 @@ func (fd *fileDAOv2) putTransactionLog(blk *block.Block) error {
 	if sysLog == nil {
 		sysLog = &block.BlkTransactionLog{}
 	}
-	logBytes, err := compBytes(sysLog.Serialize(), fd.header.Compressor)
+	logBytes, err := fd.codec.Compress(sysLog.Serialize())
 	if err != nil {
 		return err
 	}
@@ -129,20 +128,6 @@ func addOneEntryToBatch(c db.CountingIndex, v []byte, b batch.KVStoreBatch) erro
 	return c.Finalize()
 }
 
-func compBytes(v []byte, comp string) ([]byte, error) {
-	if comp != "" {
-		return compress.Compress(v, comp)
-	}
-	return v, nil
-}
-
-func decompBytes(v []byte, comp string) ([]byte, error) {
-	if comp != "" {
-		return compress.Decompress(v, comp)
-	}
-	return v, nil
-}
-
 // blockStoreKey is the slot of block in block storage (each item containing blockStorageBatchSize of blocks)
 func blockStoreKey(height uint64, header *FileHeader) uint64 {
 	if height <= header.Start {
@@ -223,7 +208,7 @@ func (fd *fileDAOv2) getBlockStore(height uint64) (*iotextypes.BlockStore, error
 	if err != nil {
 		return nil, err
 	}
-	value, err = decompBytes(value, fd.header.Compressor)
+	value, err = fd.codec.Decompress(value)
 	if err != nil {
 		return nil, err
 	}
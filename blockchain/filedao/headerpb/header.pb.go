@@ -2,14 +2,14 @@
 // This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
 // or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
 // This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
-
+//
 // To compile the proto, run:
 //      protoc --go_out=plugins=grpc:. *.proto
 
 // Code generated by protoc-gen-go. DO NOT EDIT.
 // versions:
-// 	protoc-gen-go v1.25.0
-// 	protoc        v3.12.4
+// 	protoc-gen-go v1.36.4
+// 	protoc        v5.29.3
 // source: header.proto
 
 package headerpb
@@ -19,6 +19,7 @@ import (
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	reflect "reflect"
 	sync "sync"
+	unsafe "unsafe"
 )
 
 const (
@@ -29,23 +30,22 @@ const (
 )
 
 type FileHeader struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
-	Version        string `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
-	Compressor     string `protobuf:"bytes,2,opt,name=compressor,proto3" json:"compressor,omitempty"`
-	BlockStoreSize uint64 `protobuf:"varint,3,opt,name=blockStoreSize,proto3" json:"blockStoreSize,omitempty"`
-	Start          uint64 `protobuf:"varint,4,opt,name=start,proto3" json:"start,omitempty"`
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Version        string                 `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	Compressor     string                 `protobuf:"bytes,2,opt,name=compressor,proto3" json:"compressor,omitempty"`
+	BlockStoreSize uint64                 `protobuf:"varint,3,opt,name=blockStoreSize,proto3" json:"blockStoreSize,omitempty"`
+	Start          uint64                 `protobuf:"varint,4,opt,name=start,proto3" json:"start,omitempty"`
+	// zstd dictionary used by the compressor, empty if no dictionary
+	CompressorDict []byte `protobuf:"bytes,5,opt,name=compressorDict,proto3" json:"compressorDict,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *FileHeader) Reset() {
 	*x = FileHeader{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_header_proto_msgTypes[0]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+	mi := &file_header_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *FileHeader) String() string {
@@ -56,7 +56,7 @@ func (*FileHeader) ProtoMessage() {}
 
 func (x *FileHeader) ProtoReflect() protoreflect.Message {
 	mi := &file_header_proto_msgTypes[0]
-	if protoimpl.UnsafeEnabled && x != nil {
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -99,22 +99,26 @@ func (x *FileHeader) GetStart() uint64 {
 	return 0
 }
 
+func (x *FileHeader) GetCompressorDict() []byte {
+	if x != nil {
+		return x.CompressorDict
+	}
+	return nil
+}
+
 type FileTip struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Height        uint64                 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	Hash          []byte                 `protobuf:"bytes,2,opt,name=hash,proto3" json:"hash,omitempty"`
 	unknownFields protoimpl.UnknownFields
-
-	Height uint64 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
-	Hash   []byte `protobuf:"bytes,2,opt,name=hash,proto3" json:"hash,omitempty"`
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *FileTip) Reset() {
 	*x = FileTip{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_header_proto_msgTypes[1]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+	mi := &file_header_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *FileTip) String() string {
@@ -125,7 +129,7 @@ func (*FileTip) ProtoMessage() {}
 
 func (x *FileTip) ProtoReflect() protoreflect.Message {
 	mi := &file_header_proto_msgTypes[1]
-	if protoimpl.UnsafeEnabled && x != nil {
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -156,9 +160,9 @@ func (x *FileTip) GetHash() []byte {
 
 var File_header_proto protoreflect.FileDescriptor
 
-var file_header_proto_rawDesc = []byte{
+var file_header_proto_rawDesc = string([]byte{
 	0x0a, 0x0c, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x08,
-	0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x70, 0x62, 0x22, 0x84, 0x01, 0x0a, 0x0a, 0x46, 0x69, 0x6c,
+	0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x70, 0x62, 0x22, 0xac, 0x01, 0x0a, 0x0a, 0x46, 0x69, 0x6c,
 	0x65, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69,
 	0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f,
 	0x6e, 0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x6f, 0x72, 0x18,
@@ -166,31 +170,34 @@ var file_header_proto_rawDesc = []byte{
 	0x72, 0x12, 0x26, 0x0a, 0x0e, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x53,
 	0x69, 0x7a, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0e, 0x62, 0x6c, 0x6f, 0x63, 0x6b,
 	0x53, 0x74, 0x6f, 0x72, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61,
-	0x72, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x22,
-	0x35, 0x0a, 0x07, 0x46, 0x69, 0x6c, 0x65, 0x54, 0x69, 0x70, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x65,
-	0x69, 0x67, 0x68, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x68, 0x65, 0x69, 0x67,
-	0x68, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x61, 0x73, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c,
-	0x52, 0x04, 0x68, 0x61, 0x73, 0x68, 0x42, 0x40, 0x5a, 0x3e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62,
-	0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x69, 0x6f, 0x74, 0x65, 0x78, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63,
-	0x74, 0x2f, 0x69, 0x6f, 0x74, 0x65, 0x78, 0x2d, 0x63, 0x6f, 0x72, 0x65, 0x2f, 0x62, 0x6c, 0x6f,
-	0x63, 0x6b, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x2f, 0x66, 0x69, 0x6c, 0x65, 0x64, 0x61, 0x6f, 0x2f,
-	0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
-}
+	0x72, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x12,
+	0x26, 0x0a, 0x0e, 0x63, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x6f, 0x72, 0x44, 0x69, 0x63,
+	0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0e, 0x63, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73,
+	0x73, 0x6f, 0x72, 0x44, 0x69, 0x63, 0x74, 0x22, 0x35, 0x0a, 0x07, 0x46, 0x69, 0x6c, 0x65, 0x54,
+	0x69, 0x70, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x61,
+	0x73, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x68, 0x61, 0x73, 0x68, 0x42, 0x43,
+	0x5a, 0x41, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x69, 0x6f, 0x74,
+	0x65, 0x78, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x2f, 0x69, 0x6f, 0x74, 0x65, 0x78, 0x2d,
+	0x63, 0x6f, 0x72, 0x65, 0x2f, 0x76, 0x32, 0x2f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x63, 0x68, 0x61,
+	0x69, 0x6e, 0x2f, 0x66, 0x69, 0x6c, 0x65, 0x64, 0x61, 0x6f, 0x2f, 0x68, 0x65, 0x61, 0x64, 0x65,
+	0x72, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+})
 
 var (
 	file_header_proto_rawDescOnce sync.Once
-	file_header_proto_rawDescData = file_header_proto_rawDesc
+	file_header_proto_rawDescData []byte
 )
 
 func file_header_proto_rawDescGZIP() []byte {
 	file_header_proto_rawDescOnce.Do(func() {
-		file_header_proto_rawDescData = protoimpl.X.CompressGZIP(file_header_proto_rawDescData)
+		file_header_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_header_proto_rawDesc), len(file_header_proto_rawDesc)))
 	})
 	return file_header_proto_rawDescData
 }
 
 var file_header_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
-var file_header_proto_goTypes = []interface{}{
+var file_header_proto_goTypes = []any{
 	(*FileHeader)(nil), // 0: headerpb.FileHeader
 	(*FileTip)(nil),    // 1: headerpb.FileTip
 }
@@ -207,37 +214,11 @@ func file_header_proto_init() {
 	if File_header_proto != nil {
 		return
 	}
-	if !protoimpl.UnsafeEnabled {
-		file_header_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*FileHeader); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_header_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*FileTip); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
-			RawDescriptor: file_header_proto_rawDesc,
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_header_proto_rawDesc), len(file_header_proto_rawDesc)),
 			NumEnums:      0,
 			NumMessages:   2,
 			NumExtensions: 0,
@@ -248,7 +229,6 @@ func file_header_proto_init() {
 		MessageInfos:      file_header_proto_msgTypes,
 	}.Build()
 	File_header_proto = out.File
-	file_header_proto_rawDesc = nil
 	file_header_proto_goTypes = nil
 	file_header_proto_depIdxs = nil
 }
@@ -75,6 +75,7 @@ func defaultConfig() Genesis {
 			TsunamiBlockHeight:        29275561,
 			UpernavikBlockHeight:      31174201,
 			VanuatuBlockHeight:        33730921,
+			WakeBlockHeight:           math.MaxUint64,
 			ToBeEnabledBlockHeight:    math.MaxUint64,
 		},
 		Account: Account{
@@ -351,6 +352,9 @@ type (
 		// 1. enable Cancun EVM
 		// 2. enable dynamic fee tx
 		VanuatuBlockHeight uint64 `yaml:"vanuatuHeight"`
+		// WakeBlockHeight is the start height to
+		// 1. enable the native staking reader for the executions sent to its address
+		WakeBlockHeight uint64 `yaml:"wakeHeight"`
 		// ToBeEnabledBlockHeight is a fake height that acts as a gating factor for WIP features
 		// upon next release, change IsToBeEnabled() to IsNextHeight() for features to be released
 		ToBeEnabledBlockHeight uint64 `yaml:"toBeEnabledHeight"`
@@ -716,6 +720,11 @@ func (g *Blockchain) IsVanuatu(height uint64) bool {
 	return g.isPost(g.VanuatuBlockHeight, height)
 }
 
+// IsWake checks whether height is equal to or larger than wake height
+func (g *Blockchain) IsWake(height uint64) bool {
+	return g.isPost(g.WakeBlockHeight, height)
+}
+
 // IsToBeEnabled checks whether height is equal to or larger than toBeEnabled height
 func (g *Blockchain) IsToBeEnabled(height uint64) bool {
 	return g.isPost(g.ToBeEnabledBlockHeight, height)
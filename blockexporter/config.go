@@ -0,0 +1,51 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package blockexporter
+
+// encoding formats of the exported blocks
+const (
+	FormatJSON     = "json"
+	FormatProtobuf = "protobuf"
+)
+
+type (
+	// Config is the config of the block exporter
+	Config struct {
+		// Enabled enables exporting the committed blocks to the file sink
+		Enabled bool `yaml:"enabled"`
+		// Format is the encoding format of the exported blocks, json or protobuf
+		Format string `yaml:"format"`
+		// CheckpointDBPath is the path of the db persisting the height of the last block exported
+		CheckpointDBPath string `yaml:"checkpointDBPath"`
+		// StartHeight is the height to start exporting from if there is no checkpoint
+		StartHeight uint64 `yaml:"startHeight"`
+		// FileSink is the config of the rotating NDJSON file sink
+		FileSink FileSinkConfig `yaml:"fileSink"`
+	}
+
+	// FileSinkConfig is the config of the rotating NDJSON file sink
+	FileSinkConfig struct {
+		// Dir is the directory of the exported files
+		Dir string `yaml:"dir"`
+		// MaxFileSize is the size in bytes of a file to rotate at
+		MaxFileSize uint64 `yaml:"maxFileSize"`
+		// MaxFiles is the max number of files to keep, 0 means keeping all files
+		MaxFiles int `yaml:"maxFiles"`
+	}
+)
+
+// DefaultConfig is the default config of the block exporter
+var DefaultConfig = Config{
+	Enabled:          false,
+	Format:           FormatJSON,
+	CheckpointDBPath: "/var/data/exporter.db",
+	StartHeight:      1,
+	FileSink: FileSinkConfig{
+		Dir:         "/var/data/export",
+		MaxFileSize: 1 << 30,
+		MaxFiles:    0,
+	},
+}
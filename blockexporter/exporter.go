@@ -0,0 +1,225 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package blockexporter
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/iotexproject/iotex-proto/golang/iotexapi"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/iotexproject/iotex-core/v2/action"
+	"github.com/iotexproject/iotex-core/v2/blockchain/block"
+	"github.com/iotexproject/iotex-core/v2/blockchain/blockdao"
+	"github.com/iotexproject/iotex-core/v2/db"
+	"github.com/iotexproject/iotex-core/v2/pkg/log"
+	"github.com/iotexproject/iotex-core/v2/pkg/util/byteutil"
+)
+
+const (
+	_checkpointNS = "exporter"
+	// _retryInterval is the interval to retry exporting after a failure
+	_retryInterval = 10 * time.Second
+)
+
+var (
+	_checkpointKey = []byte("height")
+)
+
+// Exporter exports the committed blocks, along with the receipts and the transaction logs, to a sink. The staking
+// events are the logs of the staking protocol in the receipts. The height of the last block exported is persisted
+// as the checkpoint, and the blocks committed since then are exported from the block dao once restarted, so
+// every block is exported at least once. The blocks are exported from the block dao in the background, a block
+// received only notifies the exporter, so that a slow sink doesn't block the delivery of the blocks.
+type Exporter struct {
+	mutex       sync.Mutex
+	dao         blockdao.BlockDAO
+	kvStore     db.KVStore
+	sink        Sink
+	format      string
+	startHeight uint64
+	height      uint64
+	notify      chan struct{}
+	cancel      context.CancelFunc
+	wg          sync.WaitGroup
+}
+
+// NewExporter creates a new block exporter
+func NewExporter(cfg Config, dao blockdao.BlockDAO, kv db.KVStore, sink Sink) (*Exporter, error) {
+	if dao == nil || kv == nil || sink == nil {
+		return nil, errors.New("empty block dao, kvStore or sink")
+	}
+	switch cfg.Format {
+	case FormatJSON, FormatProtobuf:
+	default:
+		return nil, errors.Errorf("unsupported format %s", cfg.Format)
+	}
+	startHeight := cfg.StartHeight
+	if startHeight == 0 {
+		startHeight = 1
+	}
+	return &Exporter{
+		dao:         dao,
+		kvStore:     kv,
+		sink:        sink,
+		format:      cfg.Format,
+		startHeight: startHeight,
+		notify:      make(chan struct{}, 1),
+	}, nil
+}
+
+// Start starts the exporter, which exports the blocks committed since the checkpoint in the background
+func (e *Exporter) Start(ctx context.Context) error {
+	if err := e.kvStore.Start(ctx); err != nil {
+		return err
+	}
+	h, err := e.kvStore.Get(_checkpointNS, _checkpointKey)
+	e.mutex.Lock()
+	switch errors.Cause(err) {
+	case nil:
+		e.height = byteutil.BytesToUint64BigEndian(h)
+	case db.ErrNotExist, db.ErrBucketNotExist:
+		e.height = e.startHeight - 1
+	default:
+		e.mutex.Unlock()
+		return err
+	}
+	e.mutex.Unlock()
+	runCtx, cancel := context.WithCancel(context.Background())
+	e.cancel = cancel
+	e.wg.Add(1)
+	go e.run(runCtx)
+	e.trigger()
+	return nil
+}
+
+// Stop stops the exporter
+func (e *Exporter) Stop(ctx context.Context) error {
+	if e.cancel != nil {
+		e.cancel()
+		e.wg.Wait()
+	}
+	if err := e.sink.Close(); err != nil {
+		return err
+	}
+	return e.kvStore.Stop(ctx)
+}
+
+// Height returns the height of the last block exported
+func (e *Exporter) Height() uint64 {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	return e.height
+}
+
+// ReceiveBlock notifies the exporter of the block committed, which is exported from the block dao
+func (e *Exporter) ReceiveBlock(*block.Block) error {
+	e.trigger()
+	return nil
+}
+
+func (e *Exporter) trigger() {
+	select {
+	case e.notify <- struct{}{}:
+	default:
+		// the exporter has been notified
+	}
+}
+
+// run exports the blocks up to the tip of the block dao once notified, and retries after a failure
+func (e *Exporter) run(ctx context.Context) {
+	defer e.wg.Done()
+	for {
+		var retry <-chan time.Time
+		if err := e.exportToTip(ctx); err != nil && ctx.Err() == nil {
+			log.L().Error("Error when exporting the blocks", zap.Error(err))
+			retry = time.After(_retryInterval)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-e.notify:
+		case <-retry:
+		}
+	}
+}
+
+func (e *Exporter) exportToTip(ctx context.Context) error {
+	end, err := e.dao.Height()
+	if err != nil {
+		return err
+	}
+	for height := e.Height() + 1; height <= end; height++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		blk, err := e.dao.GetBlockByHeight(height)
+		if err != nil {
+			return err
+		}
+		receipts, err := e.dao.GetReceipts(height)
+		if err != nil {
+			return err
+		}
+		if err := e.export(ctx, blk, receipts); err != nil {
+			return err
+		}
+		if height%1000 == 0 || height == end {
+			log.L().Info("Exported blocks up to", zap.Uint64("height", height))
+		}
+	}
+	return nil
+}
+
+func (e *Exporter) export(ctx context.Context, blk *block.Block, receipts []*action.Receipt) error {
+	height := blk.Height()
+	info := &iotexapi.BlockInfo{
+		Block:    blk.ConvertToBlockPb(),
+		Receipts: make([]*iotextypes.Receipt, 0, len(receipts)),
+	}
+	for _, receipt := range receipts {
+		info.Receipts = append(info.Receipts, receipt.ConvertToReceiptPb())
+	}
+	if e.dao.ContainsTransactionLog() {
+		txLogs, err := e.dao.TransactionLogs(height)
+		switch errors.Cause(err) {
+		case nil:
+			info.TransactionLogs = txLogs
+		case db.ErrNotExist:
+			// no transaction logs in the block
+		default:
+			return err
+		}
+	}
+	var (
+		data []byte
+		err  error
+	)
+	if e.format == FormatJSON {
+		data, err = protojson.Marshal(info)
+	} else {
+		data, err = proto.Marshal(info)
+	}
+	if err != nil {
+		return errors.Wrapf(err, "failed to encode block %d", height)
+	}
+	if err := e.sink.Write(ctx, height, data); err != nil {
+		return err
+	}
+	if err := e.kvStore.Put(_checkpointNS, _checkpointKey, byteutil.Uint64ToBytesBigEndian(height)); err != nil {
+		return errors.Wrapf(err, "failed to checkpoint block %d", height)
+	}
+	e.mutex.Lock()
+	e.height = height
+	e.mutex.Unlock()
+	return nil
+}
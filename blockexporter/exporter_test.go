@@ -0,0 +1,150 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package blockexporter
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/iotexproject/iotex-proto/golang/iotexapi"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/iotexproject/iotex-core/v2/action"
+	"github.com/iotexproject/iotex-core/v2/blockchain/block"
+	"github.com/iotexproject/iotex-core/v2/db"
+	"github.com/iotexproject/iotex-core/v2/pkg/util/byteutil"
+	"github.com/iotexproject/iotex-core/v2/test/identityset"
+	"github.com/iotexproject/iotex-core/v2/test/mock/mock_blockdao"
+	"github.com/iotexproject/iotex-core/v2/testutil"
+)
+
+type testProducer struct {
+	mutex  sync.Mutex
+	topics []string
+	keys   [][]byte
+	values [][]byte
+	closed bool
+}
+
+func (p *testProducer) Produce(_ context.Context, topic string, key, value []byte) error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.topics = append(p.topics, topic)
+	p.keys = append(p.keys, key)
+	p.values = append(p.values, value)
+	return nil
+}
+
+func (p *testProducer) Close() error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.closed = true
+	return nil
+}
+
+func (p *testProducer) count() int {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return len(p.values)
+}
+
+func TestExporter(t *testing.T) {
+	r := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	blks := make([]*block.Block, 6)
+	for i := range blks {
+		receipt := (&action.Receipt{
+			Status:      uint64(iotextypes.ReceiptStatus_Success),
+			BlockHeight: uint64(i + 1),
+		}).AddLogs(&action.Log{Address: identityset.Address(31).String()})
+		blk, err := block.NewTestingBuilder().
+			SetHeight(uint64(i + 1)).
+			SetTimeStamp(testutil.TimestampNow()).
+			SetReceipts([]*action.Receipt{receipt}).
+			SignAndBuild(identityset.PrivateKey(27))
+		r.NoError(err)
+		blks[i] = &blk
+	}
+	var tipHeight atomic.Uint64
+	tipHeight.Store(2)
+	dao := mock_blockdao.NewMockBlockDAO(ctrl)
+	dao.EXPECT().Height().DoAndReturn(func() (uint64, error) { return tipHeight.Load(), nil }).AnyTimes()
+	dao.EXPECT().GetBlockByHeight(gomock.Any()).DoAndReturn(func(h uint64) (*block.Block, error) {
+		return blks[h-1], nil
+	}).AnyTimes()
+	dao.EXPECT().GetReceipts(gomock.Any()).DoAndReturn(func(h uint64) ([]*action.Receipt, error) {
+		return blks[h-1].Receipts, nil
+	}).AnyTimes()
+	dao.EXPECT().ContainsTransactionLog().Return(true).AnyTimes()
+	dao.EXPECT().TransactionLogs(gomock.Any()).Return(&iotextypes.TransactionLogs{}, nil).AnyTimes()
+
+	var (
+		ctx      = context.Background()
+		kv       = db.NewMemKVStore()
+		producer = &testProducer{}
+		cfg      = DefaultConfig
+	)
+	_, err := NewExporter(Config{Format: "xml"}, dao, kv, NewQueueSink(producer, "blocks"))
+	r.ErrorContains(err, "unsupported format")
+	cfg.Format = FormatProtobuf
+	exporter, err := NewExporter(cfg, dao, kv, NewQueueSink(producer, "blocks"))
+	r.NoError(err)
+
+	exported := func(height uint64, count int) func() bool {
+		return func() bool {
+			return exporter.Height() == height && producer.count() == count
+		}
+	}
+	// blocks received before started are exported once started
+	r.NoError(exporter.ReceiveBlock(blks[0]))
+	r.Zero(producer.count())
+	r.NoError(exporter.Start(ctx))
+	r.Eventually(exported(2, 2), 5*time.Second, 10*time.Millisecond)
+	// block 3 is missed, and exported from the block dao
+	tipHeight.Store(4)
+	r.NoError(exporter.ReceiveBlock(blks[3]))
+	r.NoError(exporter.ReceiveBlock(blks[3]))
+	r.Eventually(exported(4, 4), 5*time.Second, 10*time.Millisecond)
+	for i, value := range producer.values {
+		r.Equal("blocks", producer.topics[i])
+		r.Equal(byteutil.Uint64ToBytesBigEndian(uint64(i+1)), producer.keys[i])
+		info := &iotexapi.BlockInfo{}
+		r.NoError(proto.Unmarshal(value, info))
+		r.EqualValues(i+1, info.Block.Header.Core.Height)
+		r.Len(info.Receipts, 1)
+		r.Len(info.Receipts[0].Logs, 1)
+		r.NotNil(info.TransactionLogs)
+	}
+	r.NoError(exporter.Stop(ctx))
+	r.True(producer.closed)
+
+	// restart from the checkpoint
+	tipHeight.Store(5)
+	producer = &testProducer{}
+	cfg.Format = FormatJSON
+	exporter, err = NewExporter(cfg, dao, kv, NewQueueSink(producer, "blocks"))
+	r.NoError(err)
+	r.NoError(exporter.Start(ctx))
+	r.Eventually(exported(5, 1), 5*time.Second, 10*time.Millisecond)
+	tipHeight.Store(6)
+	r.NoError(exporter.ReceiveBlock(blks[5]))
+	r.Eventually(exported(6, 2), 5*time.Second, 10*time.Millisecond)
+	for i, value := range producer.values {
+		info := &iotexapi.BlockInfo{}
+		r.NoError(protojson.Unmarshal(value, info))
+		r.EqualValues(i+5, info.Block.Header.Core.Height)
+	}
+	r.NoError(exporter.Stop(ctx))
+}
@@ -0,0 +1,155 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package blockexporter
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/v2/pkg/util/byteutil"
+)
+
+const (
+	_filePrefix = "blocks-"
+	_fileSuffix = ".ndjson"
+)
+
+type (
+	// Sink is the destination of the exported blocks
+	Sink interface {
+		// Write writes the encoded block at the height
+		Write(ctx context.Context, height uint64, data []byte) error
+		// Close closes the sink
+		Close() error
+	}
+
+	// Producer is the producer of a message queue, e.g., Kafka, to publish the exported blocks to
+	Producer interface {
+		// Produce publishes a message with the key to the topic
+		Produce(ctx context.Context, topic string, key, value []byte) error
+		// Close closes the producer
+		Close() error
+	}
+
+	queueSink struct {
+		producer Producer
+		topic    string
+	}
+
+	// fileSink writes the blocks into the files of NDJSON, one block per line, a new file named after the height
+	// of its first block is created once the current file exceeds the max size
+	fileSink struct {
+		cfg  FileSinkConfig
+		file *os.File
+		size uint64
+	}
+)
+
+// NewQueueSink creates a sink publishing the blocks to the topic of a message queue, keyed by the height
+func NewQueueSink(producer Producer, topic string) Sink {
+	return &queueSink{
+		producer: producer,
+		topic:    topic,
+	}
+}
+
+func (s *queueSink) Write(ctx context.Context, height uint64, data []byte) error {
+	return s.producer.Produce(ctx, s.topic, byteutil.Uint64ToBytesBigEndian(height), data)
+}
+
+func (s *queueSink) Close() error {
+	return s.producer.Close()
+}
+
+// NewFileSink creates a sink writing the blocks into rotating NDJSON files
+func NewFileSink(cfg FileSinkConfig) (Sink, error) {
+	if cfg.Dir == "" {
+		return nil, errors.New("empty directory of file sink")
+	}
+	if err := os.MkdirAll(cfg.Dir, 0755); err != nil {
+		return nil, errors.Wrapf(err, "failed to create directory %s", cfg.Dir)
+	}
+	return &fileSink{cfg: cfg}, nil
+}
+
+func (s *fileSink) Write(_ context.Context, height uint64, data []byte) error {
+	if s.file == nil || (s.cfg.MaxFileSize > 0 && s.size >= s.cfg.MaxFileSize) {
+		if err := s.rotate(height); err != nil {
+			return err
+		}
+	}
+	line := make([]byte, 0, len(data)+1)
+	n, err := s.file.Write(append(append(line, data...), '\n'))
+	s.size += uint64(n)
+	if err != nil {
+		return errors.Wrapf(err, "failed to write block %d", height)
+	}
+	// the block has to be durable before it is checkpointed
+	if err := s.file.Sync(); err != nil {
+		return errors.Wrapf(err, "failed to sync block %d", height)
+	}
+	return nil
+}
+
+func (s *fileSink) Close() error {
+	if s.file == nil {
+		return nil
+	}
+	err := s.file.Close()
+	s.file = nil
+	return err
+}
+
+func (s *fileSink) rotate(height uint64) error {
+	if err := s.Close(); err != nil {
+		return err
+	}
+	// the file of the same name only holds the blocks exported but not checkpointed before restart
+	file, err := os.OpenFile(
+		filepath.Join(s.cfg.Dir, fmt.Sprintf("%s%020d%s", _filePrefix, height, _fileSuffix)),
+		os.O_CREATE|os.O_WRONLY|os.O_TRUNC,
+		0644,
+	)
+	if err != nil {
+		return errors.Wrapf(err, "failed to create file of block %d", height)
+	}
+	s.file = file
+	s.size = 0
+	return s.prune()
+}
+
+// prune removes the oldest files beyond the max number of files
+func (s *fileSink) prune() error {
+	if s.cfg.MaxFiles <= 0 {
+		return nil
+	}
+	entries, err := os.ReadDir(s.cfg.Dir)
+	if err != nil {
+		return err
+	}
+	var files []string
+	for _, entry := range entries {
+		if name := entry.Name(); !entry.IsDir() && strings.HasPrefix(name, _filePrefix) && strings.HasSuffix(name, _fileSuffix) {
+			files = append(files, name)
+		}
+	}
+	if len(files) <= s.cfg.MaxFiles {
+		return nil
+	}
+	sort.Strings(files)
+	for _, name := range files[:len(files)-s.cfg.MaxFiles] {
+		if err := os.Remove(filepath.Join(s.cfg.Dir, name)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package blockexporter
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileSink(t *testing.T) {
+	r := require.New(t)
+	dir := t.TempDir()
+	_, err := NewFileSink(FileSinkConfig{})
+	r.Error(err)
+	sink, err := NewFileSink(FileSinkConfig{
+		Dir:         dir,
+		MaxFileSize: 8,
+		MaxFiles:    2,
+	})
+	r.NoError(err)
+
+	ctx := context.Background()
+	r.NoError(sink.Write(ctx, 1, []byte(`{"a":1}`)))
+	// rotated at block 2 and 3, and the file of block 1 is removed
+	r.NoError(sink.Write(ctx, 2, []byte(`{"b":2}`)))
+	r.NoError(sink.Write(ctx, 3, []byte(`{}`)))
+	r.NoError(sink.Write(ctx, 4, []byte(`{"d":4}`)))
+	r.NoError(sink.Close())
+
+	entries, err := os.ReadDir(dir)
+	r.NoError(err)
+	r.Len(entries, 2)
+	for i, expected := range []struct {
+		name, content string
+	}{
+		{"blocks-00000000000000000002.ndjson", "{\"b\":2}\n"},
+		{"blocks-00000000000000000003.ndjson", "{}\n{\"d\":4}\n"},
+	} {
+		r.Equal(expected.name, entries[i].Name())
+		data, err := os.ReadFile(filepath.Join(dir, expected.name))
+		r.NoError(err)
+		r.Equal(expected.content, string(data))
+	}
+
+	// restart at block 3 overwrites the file of block 3
+	r.NoError(sink.Write(ctx, 3, []byte(`{"c":3}`)))
+	r.NoError(sink.Close())
+	data, err := os.ReadFile(filepath.Join(dir, "blocks-00000000000000000003.ndjson"))
+	r.NoError(err)
+	r.Equal("{\"c\":3}\n", string(data))
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/iotexproject/iotex-core/v2/action/protocol"
 	"github.com/iotexproject/iotex-core/v2/db"
 	"github.com/iotexproject/iotex-core/v2/pkg/util/byteutil"
+	"github.com/iotexproject/iotex-core/v2/systemcontractindex"
 )
 
 type (
@@ -314,6 +315,47 @@ func (s *contractStakingCache) LoadFromDB(kvstore db.KVStore) error {
 	return nil
 }
 
+func (s *contractStakingCache) LoadFromHistory(history *systemcontractindex.History, height uint64) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.putHeight(height)
+
+	// load total bucket count
+	totalBucketCount, err := history.Total(height)
+	if err != nil {
+		return err
+	}
+	s.putTotalBucketCount(totalBucketCount)
+
+	// load bucket info
+	ids, vs, err := history.States(height, _StakingBucketInfoNS)
+	if err != nil {
+		return err
+	}
+	for i := range vs {
+		var b bucketInfo
+		if err := b.Deserialize(vs[i]); err != nil {
+			return err
+		}
+		s.putBucketInfo(ids[i], &b)
+	}
+
+	// load bucket type
+	ids, vs, err = history.States(height, _StakingBucketTypeNS)
+	if err != nil {
+		return err
+	}
+	for i := range vs {
+		var b BucketType
+		if err := b.Deserialize(vs[i]); err != nil {
+			return err
+		}
+		s.putBucketType(ids[i], &b)
+	}
+	return nil
+}
+
 func (s *contractStakingCache) getBucketTypeIndex(amount *big.Int, duration uint64) (uint64, bool) {
 	m, ok := s.propertyBucketTypeMap[amount.Int64()]
 	if !ok {
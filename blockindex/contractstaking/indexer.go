@@ -15,9 +15,12 @@ import (
 	"github.com/iotexproject/iotex-proto/golang/iotextypes"
 	"github.com/pkg/errors"
 
+	"github.com/iotexproject/iotex-core/v2/action/protocol/staking"
 	"github.com/iotexproject/iotex-core/v2/blockchain/block"
 	"github.com/iotexproject/iotex-core/v2/db"
+	"github.com/iotexproject/iotex-core/v2/db/batch"
 	"github.com/iotexproject/iotex-core/v2/pkg/util/byteutil"
+	"github.com/iotexproject/iotex-core/v2/systemcontractindex"
 )
 
 const (
@@ -30,11 +33,15 @@ type (
 	// 		1. handle contract staking contract events when new block comes to generate index data
 	// 		2. provide query interface for contract staking index data
 	Indexer struct {
-		kvstore db.KVStore            // persistent storage, used to initialize index cache at startup
-		cache   *contractStakingCache // in-memory index for clean data, used to query index data
-		config  Config                // indexer config
+		kvstore db.KVStore                   // persistent storage, used to initialize index cache at startup
+		cache   *contractStakingCache        // in-memory index for clean data, used to query index data
+		config  Config                       // indexer config
+		history *systemcontractindex.History // history of index data, used to query index data at a historical height
 	}
 
+	// IndexerOption is the option of contract staking indexer
+	IndexerOption func(*Indexer)
+
 	// Config is the config for contract staking indexer
 	Config struct {
 		ContractAddress      string // stake contract ContractAddress
@@ -47,8 +54,21 @@ type (
 	calculateVoteWeightFunc func(v *Bucket) *big.Int
 )
 
+// WithHistory keeps the history of index data in the versioned db, to query the buckets at a historical height.
+// The versioned db is shared with other indexers, so it has to be started before and stopped after the indexer.
+func WithHistory(vdb db.VersionedDB) IndexerOption {
+	return func(s *Indexer) {
+		s.history = systemcontractindex.NewHistory(vdb, _StakingNS, _StakingBucketInfoNS, _StakingBucketTypeNS)
+	}
+}
+
+// HistoryNamespaces returns the versioned namespaces of the history of index data
+func HistoryNamespaces() []db.Namespace {
+	return systemcontractindex.HistoryNamespaces(_StakingBucketInfoNS, _StakingBucketTypeNS)
+}
+
 // NewContractStakingIndexer creates a new contract staking indexer
-func NewContractStakingIndexer(kvStore db.KVStore, config Config) (*Indexer, error) {
+func NewContractStakingIndexer(kvStore db.KVStore, config Config, opts ...IndexerOption) (*Indexer, error) {
 	if kvStore == nil {
 		return nil, errors.New("kv store is nil")
 	}
@@ -58,11 +78,15 @@ func NewContractStakingIndexer(kvStore db.KVStore, config Config) (*Indexer, err
 	if config.CalculateVoteWeight == nil {
 		return nil, errors.New("calculate vote weight function is nil")
 	}
-	return &Indexer{
+	s := &Indexer{
 		kvstore: kvStore,
 		cache:   newContractStakingCache(config),
 		config:  config,
-	}, nil
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s, nil
 }
 
 // Start starts the indexer
@@ -70,7 +94,13 @@ func (s *Indexer) Start(ctx context.Context) error {
 	if err := s.kvstore.Start(ctx); err != nil {
 		return err
 	}
-	return s.loadFromDB()
+	if err := s.loadFromDB(); err != nil {
+		return err
+	}
+	if s.history == nil {
+		return nil
+	}
+	return s.initHistory()
 }
 
 // Stop stops the indexer
@@ -82,6 +112,23 @@ func (s *Indexer) Stop(ctx context.Context) error {
 	return nil
 }
 
+// IndexerAt returns the indexer of the buckets at the height, it reads the history if the height is lower than the
+// tip height, and returns the indexer itself if the height is 0 or the history is not kept
+func (s *Indexer) IndexerAt(height uint64) (staking.ContractStakingIndexer, error) {
+	if s.history == nil || height == 0 || height >= s.cache.Height() || s.isIgnored(height) {
+		return s, nil
+	}
+	cache := newContractStakingCache(s.config)
+	if err := cache.LoadFromHistory(s.history, height); err != nil {
+		return nil, err
+	}
+	return &Indexer{
+		kvstore: s.kvstore,
+		cache:   cache,
+		config:  s.config,
+	}, nil
+}
+
 // Height returns the tip block height
 func (s *Indexer) Height() (uint64, error) {
 	return s.cache.Height(), nil
@@ -202,6 +249,18 @@ func (s *Indexer) commit(handler *contractStakingEventHandler, height uint64) er
 		s.reloadCache()
 		return err
 	}
+	// update history
+	if s.history != nil {
+		total, err := s.cache.TotalBucketCount(0)
+		if err != nil {
+			s.reloadCache()
+			return err
+		}
+		if err := s.history.Commit(height, batch, total); err != nil {
+			s.reloadCache()
+			return err
+		}
+	}
 	// update db
 	batch.Put(_StakingNS, _stakingHeightKey, byteutil.Uint64ToBytesBigEndian(height), "failed to put height")
 	if err := s.kvstore.WriteBatch(batch); err != nil {
@@ -220,6 +279,33 @@ func (s *Indexer) loadFromDB() error {
 	return s.cache.LoadFromDB(s.kvstore)
 }
 
+// initHistory writes all the index data at the current height as the start of the history, if the history is empty
+func (s *Indexer) initHistory() error {
+	start, err := s.history.StartHeight()
+	if err != nil {
+		return err
+	}
+	height := s.cache.Height()
+	if start != 0 || height == 0 {
+		return nil
+	}
+	b := batch.NewBatch()
+	for _, ns := range []string{_StakingBucketInfoNS, _StakingBucketTypeNS} {
+		ks, vs, err := s.kvstore.Filter(ns, func(k, v []byte) bool { return true }, nil, nil)
+		if err != nil && !errors.Is(err, db.ErrBucketNotExist) {
+			return err
+		}
+		for i := range ks {
+			b.Put(ns, ks[i], vs[i], "failed to put index data")
+		}
+	}
+	total, err := s.cache.TotalBucketCount(0)
+	if err != nil {
+		return err
+	}
+	return s.history.Commit(height, b, total)
+}
+
 // isIgnored returns true if before cotractDeployHeight.
 // it aims to be compatible with blocks between feature hard-fork and contract deployed
 // read interface should return empty result instead of invalid height error if it returns true
@@ -26,6 +26,7 @@ import (
 	"github.com/iotexproject/iotex-core/v2/config"
 	"github.com/iotexproject/iotex-core/v2/consensus/consensusfsm"
 	"github.com/iotexproject/iotex-core/v2/db"
+	"github.com/iotexproject/iotex-core/v2/systemcontractindex"
 	"github.com/iotexproject/iotex-core/v2/test/identityset"
 	"github.com/iotexproject/iotex-core/v2/testutil"
 )
@@ -651,6 +652,126 @@ func TestContractStakingIndexerReadBuckets(t *testing.T) {
 	})
 }
 
+func TestContractStakingIndexerHistory(t *testing.T) {
+	r := require.New(t)
+	testDBPath, err := testutil.PathOfTempFile("staking.db")
+	r.NoError(err)
+	defer testutil.CleanupPath(testDBPath)
+	testHistoryPath, err := testutil.PathOfTempFile("staking.history.db")
+	r.NoError(err)
+	defer testutil.CleanupPath(testHistoryPath)
+	cfg := db.DefaultConfig
+	cfg.DbPath = testDBPath
+	kvStore := db.NewBoltDB(cfg)
+	vdb, err := db.CreateVersionedDB(cfg, testHistoryPath, HistoryNamespaces()...)
+	r.NoError(err)
+	r.NoError(vdb.Start(context.Background()))
+	defer func() {
+		r.NoError(vdb.Stop(context.Background()))
+	}()
+	config := Config{
+		ContractAddress:      _testStakingContractAddress,
+		ContractDeployHeight: 0,
+		CalculateVoteWeight:  calculateVoteWeightGen(genesis.TestDefault().VoteWeightCalConsts),
+		BlockInterval:        _blockInterval,
+	}
+	indexer, err := NewContractStakingIndexer(kvStore, config, WithHistory(vdb))
+	r.NoError(err)
+	r.NoError(indexer.Start(context.Background()))
+
+	// height 1: activate bucket types
+	handler := newContractStakingEventHandler(indexer.cache)
+	activateBucketType(r, handler, 10, 10, 1)
+	activateBucketType(r, handler, 20, 10, 1)
+	r.NoError(indexer.commit(handler, 1))
+	// height 2: stake 2 buckets
+	handler = newContractStakingEventHandler(indexer.cache)
+	stake(r, handler, identityset.Address(1), identityset.Address(2), 1, 10, 10, 2)
+	stake(r, handler, identityset.Address(1), identityset.Address(2), 2, 20, 10, 2)
+	r.NoError(indexer.commit(handler, 2))
+	// height 3: stake another bucket and change the delegate of bucket 1
+	handler = newContractStakingEventHandler(indexer.cache)
+	stake(r, handler, identityset.Address(1), identityset.Address(3), 3, 10, 10, 3)
+	changeDelegate(r, handler, identityset.Address(3), 1)
+	r.NoError(indexer.commit(handler, 3))
+	// height 4: withdraw bucket 2 and deactivate a bucket type
+	handler = newContractStakingEventHandler(indexer.cache)
+	unlock(r, handler, 2, 4)
+	unstake(r, handler, 2, 4)
+	withdraw(r, handler, 2)
+	deactivateBucketType(r, handler, 20, 10, 4)
+	r.NoError(indexer.commit(handler, 4))
+
+	checkBuckets := func(indexer staking.ContractStakingIndexer, height uint64, delegates map[uint64]address.Address, total uint64) {
+		buckets, err := indexer.Buckets(height)
+		r.NoError(err)
+		r.Len(buckets, len(delegates))
+		for _, b := range buckets {
+			r.Equal(delegates[b.Index].String(), b.Candidate.String())
+		}
+		tbc, err := indexer.TotalBucketCount(height)
+		r.NoError(err)
+		r.Equal(total, tbc)
+	}
+	t.Run("IndexerAt", func(t *testing.T) {
+		idx, err := indexer.IndexerAt(1)
+		r.NoError(err)
+		checkBuckets(idx, 1, map[uint64]address.Address{}, 0)
+		bts, err := idx.(*Indexer).BucketTypes(1)
+		r.NoError(err)
+		r.Len(bts, 2)
+		idx, err = indexer.IndexerAt(2)
+		r.NoError(err)
+		checkBuckets(idx, 2, map[uint64]address.Address{1: identityset.Address(2), 2: identityset.Address(2)}, 2)
+		buckets, err := idx.BucketsByCandidate(identityset.Address(3), 2)
+		r.NoError(err)
+		r.Len(buckets, 0)
+		idx, err = indexer.IndexerAt(3)
+		r.NoError(err)
+		checkBuckets(idx, 3, map[uint64]address.Address{1: identityset.Address(3), 2: identityset.Address(2), 3: identityset.Address(3)}, 3)
+		buckets, err = idx.BucketsByCandidate(identityset.Address(3), 3)
+		r.NoError(err)
+		r.Len(buckets, 2)
+		// the tip height is read from the indexer itself
+		idx, err = indexer.IndexerAt(4)
+		r.NoError(err)
+		r.Equal(indexer, idx)
+		checkBuckets(idx, 4, map[uint64]address.Address{1: identityset.Address(3), 3: identityset.Address(3)}, 3)
+		bts, err = indexer.BucketTypes(4)
+		r.NoError(err)
+		r.Len(bts, 1)
+	})
+	r.NoError(indexer.Stop(context.Background()))
+
+	t.Run("start history from the tip height", func(t *testing.T) {
+		testHistoryPath2, err := testutil.PathOfTempFile("staking.history2.db")
+		r.NoError(err)
+		defer testutil.CleanupPath(testHistoryPath2)
+		vdb2, err := db.CreateVersionedDB(cfg, testHistoryPath2, HistoryNamespaces()...)
+		r.NoError(err)
+		r.NoError(vdb2.Start(context.Background()))
+		defer func() {
+			r.NoError(vdb2.Stop(context.Background()))
+		}()
+		indexer, err := NewContractStakingIndexer(db.NewBoltDB(cfg), config, WithHistory(vdb2))
+		r.NoError(err)
+		r.NoError(indexer.Start(context.Background()))
+		defer func() {
+			r.NoError(indexer.Stop(context.Background()))
+		}()
+		_, err = indexer.IndexerAt(3)
+		r.ErrorIs(err, systemcontractindex.ErrHistoryNotAvailable)
+		// height 5: stake another bucket
+		handler := newContractStakingEventHandler(indexer.cache)
+		stake(r, handler, identityset.Address(1), identityset.Address(2), 4, 10, 10, 5)
+		r.NoError(indexer.commit(handler, 5))
+		idx, err := indexer.IndexerAt(4)
+		r.NoError(err)
+		checkBuckets(idx, 4, map[uint64]address.Address{1: identityset.Address(3), 3: identityset.Address(3)}, 3)
+		checkBuckets(indexer, 5, map[uint64]address.Address{1: identityset.Address(3), 3: identityset.Address(3), 4: identityset.Address(2)}, 4)
+	})
+}
+
 func TestContractStakingIndexerCacheClean(t *testing.T) {
 	r := require.New(t)
 	testDBPath, err := testutil.PathOfTempFile("staking.db")
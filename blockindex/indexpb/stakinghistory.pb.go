@@ -0,0 +1,249 @@
+// Copyright (c) 2025 IoTeX
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+//
+// To compile the proto, run:
+//      protoc --go_out=. *.proto
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.4
+// 	protoc        v5.29.3
+// source: blockindex/indexpb/stakinghistory.proto
+
+package indexpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// StakingEvent is an event of a native staking bucket or candidate
+type StakingEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	BucketIndex   uint64                 `protobuf:"varint,2,opt,name=bucketIndex,proto3" json:"bucketIndex,omitempty"`
+	Candidates    [][]byte               `protobuf:"bytes,3,rep,name=candidates,proto3" json:"candidates,omitempty"`
+	Owner         []byte                 `protobuf:"bytes,4,opt,name=owner,proto3" json:"owner,omitempty"`
+	BlockHeight   uint64                 `protobuf:"varint,5,opt,name=blockHeight,proto3" json:"blockHeight,omitempty"`
+	ActionHash    []byte                 `protobuf:"bytes,6,opt,name=actionHash,proto3" json:"actionHash,omitempty"`
+	LogIndex      uint32                 `protobuf:"varint,7,opt,name=logIndex,proto3" json:"logIndex,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StakingEvent) Reset() {
+	*x = StakingEvent{}
+	mi := &file_blockindex_indexpb_stakinghistory_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StakingEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StakingEvent) ProtoMessage() {}
+
+func (x *StakingEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_blockindex_indexpb_stakinghistory_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StakingEvent.ProtoReflect.Descriptor instead.
+func (*StakingEvent) Descriptor() ([]byte, []int) {
+	return file_blockindex_indexpb_stakinghistory_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *StakingEvent) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *StakingEvent) GetBucketIndex() uint64 {
+	if x != nil {
+		return x.BucketIndex
+	}
+	return 0
+}
+
+func (x *StakingEvent) GetCandidates() [][]byte {
+	if x != nil {
+		return x.Candidates
+	}
+	return nil
+}
+
+func (x *StakingEvent) GetOwner() []byte {
+	if x != nil {
+		return x.Owner
+	}
+	return nil
+}
+
+func (x *StakingEvent) GetBlockHeight() uint64 {
+	if x != nil {
+		return x.BlockHeight
+	}
+	return 0
+}
+
+func (x *StakingEvent) GetActionHash() []byte {
+	if x != nil {
+		return x.ActionHash
+	}
+	return nil
+}
+
+func (x *StakingEvent) GetLogIndex() uint32 {
+	if x != nil {
+		return x.LogIndex
+	}
+	return 0
+}
+
+// StakingEvents is the staking events in a block
+type StakingEvents struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Events        []*StakingEvent        `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StakingEvents) Reset() {
+	*x = StakingEvents{}
+	mi := &file_blockindex_indexpb_stakinghistory_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StakingEvents) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StakingEvents) ProtoMessage() {}
+
+func (x *StakingEvents) ProtoReflect() protoreflect.Message {
+	mi := &file_blockindex_indexpb_stakinghistory_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StakingEvents.ProtoReflect.Descriptor instead.
+func (*StakingEvents) Descriptor() ([]byte, []int) {
+	return file_blockindex_indexpb_stakinghistory_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *StakingEvents) GetEvents() []*StakingEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+var File_blockindex_indexpb_stakinghistory_proto protoreflect.FileDescriptor
+
+var file_blockindex_indexpb_stakinghistory_proto_rawDesc = string([]byte{
+	0x0a, 0x27, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x2f, 0x69, 0x6e, 0x64,
+	0x65, 0x78, 0x70, 0x62, 0x2f, 0x73, 0x74, 0x61, 0x6b, 0x69, 0x6e, 0x67, 0x68, 0x69, 0x73, 0x74,
+	0x6f, 0x72, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x07, 0x69, 0x6e, 0x64, 0x65, 0x78,
+	0x70, 0x62, 0x22, 0xd8, 0x01, 0x0a, 0x0c, 0x53, 0x74, 0x61, 0x6b, 0x69, 0x6e, 0x67, 0x45, 0x76,
+	0x65, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x62, 0x75, 0x63, 0x6b, 0x65,
+	0x74, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x62, 0x75,
+	0x63, 0x6b, 0x65, 0x74, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x61, 0x6e,
+	0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x0a, 0x63,
+	0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x6f, 0x77, 0x6e,
+	0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x12,
+	0x20, 0x0a, 0x0b, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x65, 0x69, 0x67, 0x68,
+	0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x61, 0x73, 0x68, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0a, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x61, 0x73,
+	0x68, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x6f, 0x67, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x08, 0x6c, 0x6f, 0x67, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x22, 0x3e, 0x0a,
+	0x0d, 0x53, 0x74, 0x61, 0x6b, 0x69, 0x6e, 0x67, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x2d,
+	0x0a, 0x06, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15,
+	0x2e, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x70, 0x62, 0x2e, 0x53, 0x74, 0x61, 0x6b, 0x69, 0x6e, 0x67,
+	0x45, 0x76, 0x65, 0x6e, 0x74, 0x52, 0x06, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x42, 0x3a, 0x5a,
+	0x38, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x69, 0x6f, 0x74, 0x65,
+	0x78, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x2f, 0x69, 0x6f, 0x74, 0x65, 0x78, 0x2d, 0x63,
+	0x6f, 0x72, 0x65, 0x2f, 0x76, 0x32, 0x2f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x69, 0x6e, 0x64, 0x65,
+	0x78, 0x2f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x33,
+})
+
+var (
+	file_blockindex_indexpb_stakinghistory_proto_rawDescOnce sync.Once
+	file_blockindex_indexpb_stakinghistory_proto_rawDescData []byte
+)
+
+func file_blockindex_indexpb_stakinghistory_proto_rawDescGZIP() []byte {
+	file_blockindex_indexpb_stakinghistory_proto_rawDescOnce.Do(func() {
+		file_blockindex_indexpb_stakinghistory_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_blockindex_indexpb_stakinghistory_proto_rawDesc), len(file_blockindex_indexpb_stakinghistory_proto_rawDesc)))
+	})
+	return file_blockindex_indexpb_stakinghistory_proto_rawDescData
+}
+
+var file_blockindex_indexpb_stakinghistory_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_blockindex_indexpb_stakinghistory_proto_goTypes = []any{
+	(*StakingEvent)(nil),  // 0: indexpb.StakingEvent
+	(*StakingEvents)(nil), // 1: indexpb.StakingEvents
+}
+var file_blockindex_indexpb_stakinghistory_proto_depIdxs = []int32{
+	0, // 0: indexpb.StakingEvents.events:type_name -> indexpb.StakingEvent
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_blockindex_indexpb_stakinghistory_proto_init() }
+func file_blockindex_indexpb_stakinghistory_proto_init() {
+	if File_blockindex_indexpb_stakinghistory_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_blockindex_indexpb_stakinghistory_proto_rawDesc), len(file_blockindex_indexpb_stakinghistory_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_blockindex_indexpb_stakinghistory_proto_goTypes,
+		DependencyIndexes: file_blockindex_indexpb_stakinghistory_proto_depIdxs,
+		MessageInfos:      file_blockindex_indexpb_stakinghistory_proto_msgTypes,
+	}.Build()
+	File_blockindex_indexpb_stakinghistory_proto = out.File
+	file_blockindex_indexpb_stakinghistory_proto_goTypes = nil
+	file_blockindex_indexpb_stakinghistory_proto_depIdxs = nil
+}
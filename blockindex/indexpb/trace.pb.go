@@ -0,0 +1,354 @@
+// Copyright (c) 2025 IoTeX
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+//
+// To compile the proto, run:
+//      protoc --go_out=. *.proto
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.4
+// 	protoc        v5.29.3
+// source: blockindex/indexpb/trace.proto
+
+package indexpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// CallTrace is a call frame of an action
+type CallTrace struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	CallType      string                 `protobuf:"bytes,2,opt,name=callType,proto3" json:"callType,omitempty"`
+	From          string                 `protobuf:"bytes,3,opt,name=from,proto3" json:"from,omitempty"`
+	To            string                 `protobuf:"bytes,4,opt,name=to,proto3" json:"to,omitempty"`
+	Value         string                 `protobuf:"bytes,5,opt,name=value,proto3" json:"value,omitempty"`
+	Gas           uint64                 `protobuf:"varint,6,opt,name=gas,proto3" json:"gas,omitempty"`
+	GasUsed       uint64                 `protobuf:"varint,7,opt,name=gasUsed,proto3" json:"gasUsed,omitempty"`
+	Input         []byte                 `protobuf:"bytes,8,opt,name=input,proto3" json:"input,omitempty"`
+	Output        []byte                 `protobuf:"bytes,9,opt,name=output,proto3" json:"output,omitempty"`
+	Error         string                 `protobuf:"bytes,10,opt,name=error,proto3" json:"error,omitempty"`
+	TraceAddress  []uint64               `protobuf:"varint,11,rep,packed,name=traceAddress,proto3" json:"traceAddress,omitempty"`
+	Subtraces     uint64                 `protobuf:"varint,12,opt,name=subtraces,proto3" json:"subtraces,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CallTrace) Reset() {
+	*x = CallTrace{}
+	mi := &file_blockindex_indexpb_trace_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CallTrace) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CallTrace) ProtoMessage() {}
+
+func (x *CallTrace) ProtoReflect() protoreflect.Message {
+	mi := &file_blockindex_indexpb_trace_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CallTrace.ProtoReflect.Descriptor instead.
+func (*CallTrace) Descriptor() ([]byte, []int) {
+	return file_blockindex_indexpb_trace_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *CallTrace) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *CallTrace) GetCallType() string {
+	if x != nil {
+		return x.CallType
+	}
+	return ""
+}
+
+func (x *CallTrace) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *CallTrace) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *CallTrace) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *CallTrace) GetGas() uint64 {
+	if x != nil {
+		return x.Gas
+	}
+	return 0
+}
+
+func (x *CallTrace) GetGasUsed() uint64 {
+	if x != nil {
+		return x.GasUsed
+	}
+	return 0
+}
+
+func (x *CallTrace) GetInput() []byte {
+	if x != nil {
+		return x.Input
+	}
+	return nil
+}
+
+func (x *CallTrace) GetOutput() []byte {
+	if x != nil {
+		return x.Output
+	}
+	return nil
+}
+
+func (x *CallTrace) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *CallTrace) GetTraceAddress() []uint64 {
+	if x != nil {
+		return x.TraceAddress
+	}
+	return nil
+}
+
+func (x *CallTrace) GetSubtraces() uint64 {
+	if x != nil {
+		return x.Subtraces
+	}
+	return 0
+}
+
+// ActionTraces is the call traces of an action
+type ActionTraces struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ActionHash    []byte                 `protobuf:"bytes,1,opt,name=actionHash,proto3" json:"actionHash,omitempty"`
+	Traces        []*CallTrace           `protobuf:"bytes,2,rep,name=traces,proto3" json:"traces,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ActionTraces) Reset() {
+	*x = ActionTraces{}
+	mi := &file_blockindex_indexpb_trace_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ActionTraces) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ActionTraces) ProtoMessage() {}
+
+func (x *ActionTraces) ProtoReflect() protoreflect.Message {
+	mi := &file_blockindex_indexpb_trace_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ActionTraces.ProtoReflect.Descriptor instead.
+func (*ActionTraces) Descriptor() ([]byte, []int) {
+	return file_blockindex_indexpb_trace_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ActionTraces) GetActionHash() []byte {
+	if x != nil {
+		return x.ActionHash
+	}
+	return nil
+}
+
+func (x *ActionTraces) GetTraces() []*CallTrace {
+	if x != nil {
+		return x.Traces
+	}
+	return nil
+}
+
+// BlockTraces is the call traces of the actions in a block
+type BlockTraces struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Actions       []*ActionTraces        `protobuf:"bytes,1,rep,name=actions,proto3" json:"actions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BlockTraces) Reset() {
+	*x = BlockTraces{}
+	mi := &file_blockindex_indexpb_trace_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BlockTraces) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlockTraces) ProtoMessage() {}
+
+func (x *BlockTraces) ProtoReflect() protoreflect.Message {
+	mi := &file_blockindex_indexpb_trace_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlockTraces.ProtoReflect.Descriptor instead.
+func (*BlockTraces) Descriptor() ([]byte, []int) {
+	return file_blockindex_indexpb_trace_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *BlockTraces) GetActions() []*ActionTraces {
+	if x != nil {
+		return x.Actions
+	}
+	return nil
+}
+
+var File_blockindex_indexpb_trace_proto protoreflect.FileDescriptor
+
+var file_blockindex_indexpb_trace_proto_rawDesc = string([]byte{
+	0x0a, 0x1e, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x2f, 0x69, 0x6e, 0x64,
+	0x65, 0x78, 0x70, 0x62, 0x2f, 0x74, 0x72, 0x61, 0x63, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x12, 0x07, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x70, 0x62, 0x22, 0xa7, 0x02, 0x0a, 0x09, 0x43, 0x61,
+	0x6c, 0x6c, 0x54, 0x72, 0x61, 0x63, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x63,
+	0x61, 0x6c, 0x6c, 0x54, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63,
+	0x61, 0x6c, 0x6c, 0x54, 0x79, 0x70, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x12, 0x0e, 0x0a, 0x02, 0x74,
+	0x6f, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x74, 0x6f, 0x12, 0x14, 0x0a, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x12, 0x10, 0x0a, 0x03, 0x67, 0x61, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x03,
+	0x67, 0x61, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x67, 0x61, 0x73, 0x55, 0x73, 0x65, 0x64, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x67, 0x61, 0x73, 0x55, 0x73, 0x65, 0x64, 0x12, 0x14, 0x0a,
+	0x05, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x69, 0x6e,
+	0x70, 0x75, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x18, 0x09, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x06, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x65,
+	0x72, 0x72, 0x6f, 0x72, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f,
+	0x72, 0x12, 0x22, 0x0a, 0x0c, 0x74, 0x72, 0x61, 0x63, 0x65, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73,
+	0x73, 0x18, 0x0b, 0x20, 0x03, 0x28, 0x04, 0x52, 0x0c, 0x74, 0x72, 0x61, 0x63, 0x65, 0x41, 0x64,
+	0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x75, 0x62, 0x74, 0x72, 0x61, 0x63,
+	0x65, 0x73, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x73, 0x75, 0x62, 0x74, 0x72, 0x61,
+	0x63, 0x65, 0x73, 0x22, 0x5a, 0x0a, 0x0c, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x72, 0x61,
+	0x63, 0x65, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x61, 0x73,
+	0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0a, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x48,
+	0x61, 0x73, 0x68, 0x12, 0x2a, 0x0a, 0x06, 0x74, 0x72, 0x61, 0x63, 0x65, 0x73, 0x18, 0x02, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x70, 0x62, 0x2e, 0x43, 0x61,
+	0x6c, 0x6c, 0x54, 0x72, 0x61, 0x63, 0x65, 0x52, 0x06, 0x74, 0x72, 0x61, 0x63, 0x65, 0x73, 0x22,
+	0x3e, 0x0a, 0x0b, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x54, 0x72, 0x61, 0x63, 0x65, 0x73, 0x12, 0x2f,
+	0x0a, 0x07, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x15, 0x2e, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x70, 0x62, 0x2e, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x54, 0x72, 0x61, 0x63, 0x65, 0x73, 0x52, 0x07, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x42,
+	0x3a, 0x5a, 0x38, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x69, 0x6f,
+	0x74, 0x65, 0x78, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x2f, 0x69, 0x6f, 0x74, 0x65, 0x78,
+	0x2d, 0x63, 0x6f, 0x72, 0x65, 0x2f, 0x76, 0x32, 0x2f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x69, 0x6e,
+	0x64, 0x65, 0x78, 0x2f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x33,
+})
+
+var (
+	file_blockindex_indexpb_trace_proto_rawDescOnce sync.Once
+	file_blockindex_indexpb_trace_proto_rawDescData []byte
+)
+
+func file_blockindex_indexpb_trace_proto_rawDescGZIP() []byte {
+	file_blockindex_indexpb_trace_proto_rawDescOnce.Do(func() {
+		file_blockindex_indexpb_trace_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_blockindex_indexpb_trace_proto_rawDesc), len(file_blockindex_indexpb_trace_proto_rawDesc)))
+	})
+	return file_blockindex_indexpb_trace_proto_rawDescData
+}
+
+var file_blockindex_indexpb_trace_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_blockindex_indexpb_trace_proto_goTypes = []any{
+	(*CallTrace)(nil),    // 0: indexpb.CallTrace
+	(*ActionTraces)(nil), // 1: indexpb.ActionTraces
+	(*BlockTraces)(nil),  // 2: indexpb.BlockTraces
+}
+var file_blockindex_indexpb_trace_proto_depIdxs = []int32{
+	0, // 0: indexpb.ActionTraces.traces:type_name -> indexpb.CallTrace
+	1, // 1: indexpb.BlockTraces.actions:type_name -> indexpb.ActionTraces
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_blockindex_indexpb_trace_proto_init() }
+func file_blockindex_indexpb_trace_proto_init() {
+	if File_blockindex_indexpb_trace_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_blockindex_indexpb_trace_proto_rawDesc), len(file_blockindex_indexpb_trace_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_blockindex_indexpb_trace_proto_goTypes,
+		DependencyIndexes: file_blockindex_indexpb_trace_proto_depIdxs,
+		MessageInfos:      file_blockindex_indexpb_trace_proto_msgTypes,
+	}.Build()
+	File_blockindex_indexpb_trace_proto = out.File
+	file_blockindex_indexpb_trace_proto_goTypes = nil
+	file_blockindex_indexpb_trace_proto_depIdxs = nil
+}
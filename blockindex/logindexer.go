@@ -0,0 +1,210 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package blockindex
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/v2/db"
+	"github.com/iotexproject/iotex-core/v2/db/batch"
+	"github.com/iotexproject/iotex-core/v2/pkg/util/byteutil"
+)
+
+type (
+	// logIndexer is the common part of the indexers of the items decoded from the receipt logs, which adds the
+	// items of a block to the counting index buckets, and keeps the height of the indexer in the meta namespace
+	logIndexer struct {
+		mutex       sync.RWMutex
+		kvStore     db.KVStoreWithRange
+		metaNS      string
+		blockNS     string
+		startHeight uint64
+		height      uint64
+	}
+
+	// logIndexItem is an item decoded from the receipt logs, and the counting index buckets to add it to
+	logIndexItem struct {
+		data    []byte
+		buckets [][]byte
+	}
+)
+
+func newLogIndexer(kv db.KVStore, name, metaNS, blockNS string, startHeight uint64) (*logIndexer, error) {
+	if kv == nil {
+		return nil, errors.New("empty kvStore")
+	}
+	kvRange, ok := kv.(db.KVStoreWithRange)
+	if !ok {
+		return nil, errors.Errorf("%s indexer can only be created from KVStoreWithRange", name)
+	}
+	return &logIndexer{
+		kvStore:     kvRange,
+		metaNS:      metaNS,
+		blockNS:     blockNS,
+		startHeight: startHeight,
+	}, nil
+}
+
+// Start starts the indexer
+func (x *logIndexer) Start(ctx context.Context) error {
+	if err := x.kvStore.Start(ctx); err != nil {
+		return err
+	}
+	x.mutex.Lock()
+	defer x.mutex.Unlock()
+	h, err := x.kvStore.Get(x.metaNS, []byte(CurrentHeightKey))
+	switch errors.Cause(err) {
+	case nil:
+		x.height = byteutil.BytesToUint64BigEndian(h)
+	case db.ErrNotExist, db.ErrBucketNotExist:
+		x.height = 0
+	default:
+		return err
+	}
+	return nil
+}
+
+// Stop stops the indexer
+func (x *logIndexer) Stop(ctx context.Context) error {
+	return x.kvStore.Stop(ctx)
+}
+
+// Height returns the height of the indexer
+func (x *logIndexer) Height() (uint64, error) {
+	x.mutex.RLock()
+	defer x.mutex.RUnlock()
+	return x.height, nil
+}
+
+// putBlock adds the items of the block at height to the counting index buckets, and puts the data of the block
+// if not empty, the blocks lower than the start height are skipped
+func (x *logIndexer) putBlock(height uint64, items []*logIndexItem, blockData []byte) error {
+	x.mutex.Lock()
+	defer x.mutex.Unlock()
+	if height < x.startHeight || height <= x.height {
+		return nil
+	}
+	if next := max(x.height+1, x.startHeight); height != next {
+		return errors.Wrapf(db.ErrInvalid, "wrong block height %d, expecting %d", height, next)
+	}
+	var (
+		b       = batch.NewBatch()
+		hKey    = byteutil.Uint64ToBytesBigEndian(height)
+		indices = make(map[string]db.CountingIndex)
+	)
+	for _, item := range items {
+		for _, bucket := range item.buckets {
+			index, ok := indices[string(bucket)]
+			if !ok {
+				var err error
+				if index, err = db.NewCountingIndexNX(x.kvStore, bucket); err != nil {
+					return err
+				}
+				if err := index.UseBatch(b); err != nil {
+					return err
+				}
+				indices[string(bucket)] = index
+			}
+			if err := index.Add(item.data, true); err != nil {
+				return err
+			}
+		}
+	}
+	for _, index := range indices {
+		if err := index.Finalize(); err != nil {
+			return err
+		}
+	}
+	if len(blockData) > 0 {
+		b.Put(x.blockNS, hKey, blockData, "failed to put block data")
+	}
+	b.Put(x.metaNS, []byte(CurrentHeightKey), hKey, "failed to put indexer height")
+	if err := x.kvStore.WriteBatch(b); err != nil {
+		return err
+	}
+	x.height = height
+	return nil
+}
+
+// deleteTipBlock removes the items of the tip block at height from the counting index buckets, which are returned
+// by bucketsOf from the data of the block
+func (x *logIndexer) deleteTipBlock(height uint64, bucketsOf func([]byte) ([][]byte, error)) error {
+	x.mutex.Lock()
+	defer x.mutex.Unlock()
+	if height != x.height || height == 0 {
+		return errors.Wrapf(db.ErrInvalid, "wrong block height %d, expecting tip height %d", height, x.height)
+	}
+	var (
+		b      = batch.NewBatch()
+		hKey   = byteutil.Uint64ToBytesBigEndian(height)
+		counts = make(map[string]uint64)
+	)
+	data, err := x.kvStore.Get(x.blockNS, hKey)
+	switch errors.Cause(err) {
+	case nil:
+		buckets, err := bucketsOf(data)
+		if err != nil {
+			return err
+		}
+		for _, bucket := range buckets {
+			counts[string(bucket)]++
+		}
+		b.Delete(x.blockNS, hKey, "failed to delete block data")
+	case db.ErrNotExist, db.ErrBucketNotExist:
+	default:
+		return err
+	}
+	// revert the counting indices in the same batch
+	for bucket, count := range counts {
+		index, err := db.GetCountingIndex(x.kvStore, []byte(bucket))
+		if err != nil {
+			return err
+		}
+		size := index.Size()
+		if count > size {
+			return errors.Wrapf(db.ErrInvalid, "cannot revert %d items of %d in bucket %x", count, size, bucket)
+		}
+		for i := size - count; i < size; i++ {
+			b.Delete(bucket, byteutil.Uint64ToBytesBigEndian(i), fmt.Sprintf("failed to delete %d-th item", i))
+		}
+		b.Put(bucket, db.CountKey, byteutil.Uint64ToBytesBigEndian(size-count), fmt.Sprintf("failed to update size = %d", size-count))
+	}
+	b.Put(x.metaNS, []byte(CurrentHeightKey), byteutil.Uint64ToBytesBigEndian(height-1), "failed to put indexer height")
+	if err := x.kvStore.WriteBatch(b); err != nil {
+		return err
+	}
+	x.height = height - 1
+	return nil
+}
+
+// items returns the items [start, start+count) in the bucket, and the total number of them
+func (x *logIndexer) items(bucket []byte, start, count uint64) ([][]byte, uint64, error) {
+	x.mutex.RLock()
+	defer x.mutex.RUnlock()
+	index, err := db.GetCountingIndex(x.kvStore, bucket)
+	if err != nil {
+		if errors.Cause(err) == db.ErrBucketNotExist || errors.Cause(err) == db.ErrNotExist {
+			return nil, 0, nil
+		}
+		return nil, 0, err
+	}
+	total := index.Size()
+	if start >= total || count == 0 {
+		return nil, total, nil
+	}
+	if start+count > total {
+		count = total - start
+	}
+	values, err := index.Range(start, count)
+	if err != nil {
+		return nil, 0, err
+	}
+	return values, total, nil
+}
@@ -0,0 +1,338 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package blockindex
+
+import (
+	"context"
+	"math"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/iotexproject/iotex-core/v2/action"
+	"github.com/iotexproject/iotex-core/v2/action/protocol/staking"
+	"github.com/iotexproject/iotex-core/v2/blockchain/block"
+	"github.com/iotexproject/iotex-core/v2/blockchain/blockdao"
+	"github.com/iotexproject/iotex-core/v2/blockindex/indexpb"
+	"github.com/iotexproject/iotex-core/v2/db"
+	"github.com/iotexproject/iotex-core/v2/pkg/util/byteutil"
+)
+
+// types of the staking events, which are the topics of the staking receipt logs, except for the migrate event
+// which is a withdrawStake log emitted by the MigrateStake action
+const (
+	StakingEventCreateStake            = staking.HandleCreateStake
+	StakingEventUnstake                = staking.HandleUnstake
+	StakingEventWithdrawStake          = staking.HandleWithdrawStake
+	StakingEventChangeCandidate        = staking.HandleChangeCandidate
+	StakingEventTransferStake          = staking.HandleTransferStake
+	StakingEventDepositToStake         = staking.HandleDepositToStake
+	StakingEventRestake                = staking.HandleRestake
+	StakingEventCandidateRegister      = staking.HandleCandidateRegister
+	StakingEventCandidateUpdate        = staking.HandleCandidateUpdate
+	StakingEventCandidateActivate      = "candidateActivate"
+	StakingEventCandidateEndorsement   = "candidateEndorsement"
+	StakingEventCandidateEndorsementOp = "candidateEndorsementWithOp"
+	StakingEventMigrateStake           = "migrateStake"
+)
+
+const (
+	// _blockStakingEventsNS is the namespace of the staking events of the blocks, keyed by height
+	_blockStakingEventsNS = "bse"
+	// _stakingHistoryMetaNS is the namespace of the meta data of the staking history indexer
+	_stakingHistoryMetaNS = "shm"
+
+	// NoBucketIndex is the bucket index of the staking events not related to a bucket, such as the update of a
+	// candidate, or the register of a candidate without self-stake
+	NoBucketIndex = uint64(math.MaxUint64)
+)
+
+var (
+	// _stakingBucketPrefix is the prefix of the counting index buckets of the events of a staking bucket, the
+	// bucket is prefix || bucket index
+	_stakingBucketPrefix = []byte("sb")
+	// _stakingCandidatePrefix is the prefix of the counting index buckets of the events of a candidate, the bucket
+	// is prefix || candidate identifier
+	_stakingCandidatePrefix = []byte("sc")
+
+	_stakingEventTopics = func() map[hash.Hash256]string {
+		topics := make(map[hash.Hash256]string)
+		for _, name := range []string{
+			StakingEventCreateStake, StakingEventUnstake, StakingEventWithdrawStake, StakingEventChangeCandidate,
+			StakingEventTransferStake, StakingEventDepositToStake, StakingEventRestake, StakingEventCandidateRegister,
+			StakingEventCandidateUpdate, StakingEventCandidateActivate, StakingEventCandidateEndorsement,
+			StakingEventCandidateEndorsementOp,
+		} {
+			topics[hash.BytesToHash256([]byte(name))] = name
+		}
+		return topics
+	}()
+
+	// ErrStakingHistoryIndexNA indicates the staking history index is not enabled
+	ErrStakingHistoryIndexNA = errors.New("staking history index not supported")
+)
+
+type (
+	// StakingEvent is an event of a native staking bucket or candidate, decoded from the staking receipt logs
+	StakingEvent struct {
+		Type string
+		// BucketIndex is NoBucketIndex if the event is not related to a bucket
+		BucketIndex uint64
+		// Candidates is the candidate identifiers of the event, which are the previous and the new candidates
+		// of a changeCandidate event
+		Candidates []address.Address
+		// Owner is the new owner of a transferStake event, or the owner of a depositToStake event, nil otherwise
+		Owner       address.Address
+		BlockHeight uint64
+		ActionHash  hash.Hash256
+		LogIndex    uint32
+	}
+
+	// StakingHistoryIndexer is the interface of the indexer of the native staking events, the events of a bucket
+	// or a candidate are in the order of being executed. The staking receipt logs before the Fairbank migration
+	// carry the hashes of the candidate and the caller instead of the bucket index and the candidate, which can't
+	// be decoded, so the events are indexed from the start height of the new receipt format
+	StakingHistoryIndexer interface {
+		blockdao.BlockIndexerWithStart
+		// BucketHistory returns the events [start, start+count) of the bucket, and the total number of them
+		BucketHistory(index uint64, start, count uint64) ([]*StakingEvent, uint64, error)
+		// CandidateHistory returns the events [start, start+count) of the candidate, and the total number of them
+		CandidateHistory(candidate address.Address, start, count uint64) ([]*StakingEvent, uint64, error)
+	}
+
+	stakingHistoryIndexer struct {
+		*logIndexer
+	}
+)
+
+// NewStakingHistoryIndexer creates a new staking history indexer, which indexes the blocks from the start height
+func NewStakingHistoryIndexer(kv db.KVStore, startHeight uint64) (StakingHistoryIndexer, error) {
+	x, err := newLogIndexer(kv, "staking history", _stakingHistoryMetaNS, _blockStakingEventsNS, startHeight)
+	if err != nil {
+		return nil, err
+	}
+	return &stakingHistoryIndexer{x}, nil
+}
+
+// StartHeight returns the height to start indexing from
+func (x *stakingHistoryIndexer) StartHeight() uint64 {
+	return x.startHeight
+}
+
+// PutBlock indexes the staking events in the receipt logs of the block
+func (x *stakingHistoryIndexer) PutBlock(_ context.Context, blk *block.Block) error {
+	var (
+		events  = &indexpb.StakingEvents{}
+		items   []*logIndexItem
+		migrate map[hash.Hash256]struct{}
+	)
+	for _, receipt := range blk.Receipts {
+		if receipt.Status != uint64(iotextypes.ReceiptStatus_Success) {
+			continue
+		}
+		for _, log := range receipt.Logs() {
+			se := decodeStakingEvent(log)
+			if se == nil {
+				continue
+			}
+			if se.Type == StakingEventWithdrawStake {
+				if migrate == nil {
+					migrate = migrateStakeHashes(blk)
+				}
+				if _, ok := migrate[se.ActionHash]; ok {
+					se.Type = StakingEventMigrateStake
+				}
+			}
+			se.BlockHeight = blk.Height()
+			sePb := se.toProto()
+			data, err := proto.Marshal(sePb)
+			if err != nil {
+				return err
+			}
+			items = append(items, &logIndexItem{data: data, buckets: se.buckets()})
+			events.Events = append(events.Events, sePb)
+		}
+	}
+	var blockData []byte
+	if len(events.Events) > 0 {
+		var err error
+		if blockData, err = proto.Marshal(events); err != nil {
+			return err
+		}
+	}
+	return x.putBlock(blk.Height(), items, blockData)
+}
+
+// BucketHistory returns the events [start, start+count) of the bucket, and the total number of them
+func (x *stakingHistoryIndexer) BucketHistory(index uint64, start, count uint64) ([]*StakingEvent, uint64, error) {
+	return x.history(stakingBucketKey(index), start, count)
+}
+
+// CandidateHistory returns the events [start, start+count) of the candidate, and the total number of them
+func (x *stakingHistoryIndexer) CandidateHistory(candidate address.Address, start, count uint64) ([]*StakingEvent, uint64, error) {
+	return x.history(stakingCandidateKey(candidate), start, count)
+}
+
+func (x *stakingHistoryIndexer) history(bucket []byte, start, count uint64) ([]*StakingEvent, uint64, error) {
+	values, total, err := x.items(bucket, start, count)
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(values) == 0 {
+		return nil, total, nil
+	}
+	ret := make([]*StakingEvent, 0, len(values))
+	for _, v := range values {
+		sePb := &indexpb.StakingEvent{}
+		if err := proto.Unmarshal(v, sePb); err != nil {
+			return nil, 0, err
+		}
+		se, err := stakingEventFromProto(sePb)
+		if err != nil {
+			return nil, 0, err
+		}
+		ret = append(ret, se)
+	}
+	return ret, total, nil
+}
+
+// decodeStakingEvent decodes the staking receipt log in the format since the Fairbank height, a log of other
+// contracts or a malformed log is ignored, as well as the candidateTransferOwnership log which carries neither a
+// bucket nor a candidate identifier
+func decodeStakingEvent(log *action.Log) *StakingEvent {
+	if len(log.Topics) < 2 || log.Address != staking.ProtocolAddr().String() {
+		return nil
+	}
+	name, ok := _stakingEventTopics[log.Topics[0]]
+	if !ok {
+		return nil
+	}
+	var (
+		se = &StakingEvent{
+			Type:        name,
+			BucketIndex: NoBucketIndex,
+			ActionHash:  log.ActionHash,
+			LogIndex:    log.Index,
+		}
+		addrAt = func(i int) address.Address {
+			if i >= len(log.Topics) {
+				return nil
+			}
+			addr, err := topicToAddress(log.Topics[i])
+			if err != nil {
+				return nil
+			}
+			return addr
+		}
+	)
+	switch name {
+	case StakingEventCandidateUpdate:
+		se.Candidates = []address.Address{addrAt(1)}
+	case StakingEventChangeCandidate:
+		se.Candidates = []address.Address{addrAt(2), addrAt(3)}
+	case StakingEventTransferStake, StakingEventDepositToStake:
+		se.Owner = addrAt(2)
+		if se.Owner == nil {
+			return nil
+		}
+		se.Candidates = []address.Address{addrAt(3)}
+	default:
+		se.Candidates = []address.Address{addrAt(2)}
+	}
+	for _, cand := range se.Candidates {
+		if cand == nil {
+			return nil
+		}
+	}
+	if name != StakingEventCandidateUpdate {
+		se.BucketIndex = byteutil.BytesToUint64BigEndian(log.Topics[1][24:])
+	}
+	return se
+}
+
+// migrateStakeHashes returns the hashes of the MigrateStake actions in the block
+func migrateStakeHashes(blk *block.Block) map[hash.Hash256]struct{} {
+	hashes := make(map[hash.Hash256]struct{})
+	for _, selp := range blk.Actions {
+		if _, ok := selp.Action().(*action.MigrateStake); !ok {
+			continue
+		}
+		h, err := selp.Hash()
+		if err != nil {
+			continue
+		}
+		hashes[h] = struct{}{}
+	}
+	return hashes
+}
+
+// buckets returns the buckets of the counting indices to put the staking event into
+func (se *StakingEvent) buckets() [][]byte {
+	var ret [][]byte
+	if se.BucketIndex != NoBucketIndex {
+		ret = append(ret, stakingBucketKey(se.BucketIndex))
+	}
+	for i, cand := range se.Candidates {
+		if i > 0 && cand.String() == se.Candidates[0].String() {
+			continue
+		}
+		ret = append(ret, stakingCandidateKey(cand))
+	}
+	return ret
+}
+
+func stakingBucketKey(index uint64) []byte {
+	return append(append([]byte{}, _stakingBucketPrefix...), byteutil.Uint64ToBytesBigEndian(index)...)
+}
+
+func stakingCandidateKey(candidate address.Address) []byte {
+	return append(append([]byte{}, _stakingCandidatePrefix...), candidate.Bytes()...)
+}
+
+func (se *StakingEvent) toProto() *indexpb.StakingEvent {
+	pb := &indexpb.StakingEvent{
+		Type:        se.Type,
+		BucketIndex: se.BucketIndex,
+		BlockHeight: se.BlockHeight,
+		ActionHash:  se.ActionHash[:],
+		LogIndex:    se.LogIndex,
+	}
+	for _, cand := range se.Candidates {
+		pb.Candidates = append(pb.Candidates, cand.Bytes())
+	}
+	if se.Owner != nil {
+		pb.Owner = se.Owner.Bytes()
+	}
+	return pb
+}
+
+func stakingEventFromProto(pb *indexpb.StakingEvent) (*StakingEvent, error) {
+	se := &StakingEvent{
+		Type:        pb.Type,
+		BucketIndex: pb.BucketIndex,
+		BlockHeight: pb.BlockHeight,
+		ActionHash:  hash.BytesToHash256(pb.ActionHash),
+		LogIndex:    pb.LogIndex,
+	}
+	for _, b := range pb.Candidates {
+		cand, err := address.FromBytes(b)
+		if err != nil {
+			return nil, err
+		}
+		se.Candidates = append(se.Candidates, cand)
+	}
+	if len(pb.Owner) > 0 {
+		owner, err := address.FromBytes(pb.Owner)
+		if err != nil {
+			return nil, err
+		}
+		se.Owner = owner
+	}
+	return se, nil
+}
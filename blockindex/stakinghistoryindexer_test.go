@@ -0,0 +1,204 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package blockindex
+
+import (
+	"context"
+	"testing"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/v2/action"
+	"github.com/iotexproject/iotex-core/v2/action/protocol/staking"
+	"github.com/iotexproject/iotex-core/v2/blockchain/block"
+	"github.com/iotexproject/iotex-core/v2/db"
+	"github.com/iotexproject/iotex-core/v2/pkg/util/byteutil"
+	"github.com/iotexproject/iotex-core/v2/test/identityset"
+	"github.com/iotexproject/iotex-core/v2/testutil"
+)
+
+func TestStakingHistoryIndexer(t *testing.T) {
+	r := require.New(t)
+	testPath, err := testutil.PathOfTempFile("test-staking-history-indexer")
+	r.NoError(err)
+	defer testutil.CleanupPath(testPath)
+	cfg := db.DefaultConfig
+	cfg.DbPath = testPath
+
+	var (
+		ctx      = context.Background()
+		owner    = identityset.Address(28)
+		voter    = identityset.Address(29)
+		cand1    = identityset.Address(30)
+		cand2    = identityset.Address(31)
+		contract = identityset.Address(32)
+	)
+	stakingLog := func(name string, topics ...[]byte) *action.Log {
+		log := &action.Log{
+			Address: staking.ProtocolAddr().String(),
+			Topics:  action.Topics{hash.BytesToHash256([]byte(name))},
+		}
+		for _, topic := range topics {
+			log.Topics = append(log.Topics, hash.BytesToHash256(topic))
+		}
+		return log
+	}
+	index := func(i uint64) []byte {
+		return byteutil.Uint64ToBytesBigEndian(i)
+	}
+	newBlock := func(height uint64, acts []*action.SealedEnvelope, receipts ...*action.Receipt) *block.Block {
+		var logIndex uint32
+		for i, receipt := range receipts {
+			if i < len(acts) {
+				receipt.ActionHash, err = acts[i].Hash()
+				r.NoError(err)
+			} else {
+				receipt.ActionHash = hash.Hash256b([]byte{byte(height), byte(i)})
+			}
+			for _, log := range receipt.Logs() {
+				log.ActionHash = receipt.ActionHash
+				log.Index = logIndex
+				logIndex++
+			}
+		}
+		blk, err := block.NewTestingBuilder().
+			SetHeight(height).
+			SetTimeStamp(testutil.TimestampNow()).
+			AddActions(acts...).
+			SetReceipts(receipts).
+			SignAndBuild(identityset.PrivateKey(27))
+		r.NoError(err)
+		return &blk
+	}
+	success := uint64(iotextypes.ReceiptStatus_Success)
+	failure := uint64(iotextypes.ReceiptStatus_Failure)
+	migrate, err := action.Sign((&action.EnvelopeBuilder{}).SetNonce(1).SetGasLimit(100000).
+		SetAction(action.NewMigrateStake(2)).Build(), identityset.PrivateKey(28))
+	r.NoError(err)
+
+	blk1 := newBlock(1, nil,
+		(&action.Receipt{Status: success}).AddLogs(
+			stakingLog(StakingEventCandidateRegister, index(NoBucketIndex), cand1.Bytes()),
+			stakingLog(StakingEventCreateStake, index(1), cand1.Bytes()),
+			stakingLog(StakingEventCreateStake, index(2), cand2.Bytes()),
+		),
+		// logs of a failed action are not indexed
+		(&action.Receipt{Status: failure}).AddLogs(stakingLog(StakingEventUnstake, index(1), cand1.Bytes())),
+		// logs of other contracts are not indexed
+		(&action.Receipt{Status: success}).AddLogs(&action.Log{
+			Address: contract.String(),
+			Topics:  action.Topics{hash.BytesToHash256([]byte(StakingEventUnstake)), hash.BytesToHash256(index(1))},
+		}),
+	)
+	blk2 := newBlock(2, []*action.SealedEnvelope{migrate},
+		(&action.Receipt{Status: success}).AddLogs(stakingLog(StakingEventWithdrawStake, index(2), cand2.Bytes())),
+		(&action.Receipt{Status: success}).AddLogs(
+			stakingLog(StakingEventChangeCandidate, index(1), cand1.Bytes(), cand2.Bytes()),
+			stakingLog(StakingEventTransferStake, index(1), voter.Bytes(), cand2.Bytes()),
+			stakingLog(StakingEventDepositToStake, index(1), owner.Bytes(), cand2.Bytes()),
+			stakingLog(StakingEventCandidateUpdate, cand1.Bytes()),
+			// malformed log is ignored
+			stakingLog(StakingEventTransferStake, index(1)),
+		),
+	)
+
+	indexer, err := NewStakingHistoryIndexer(db.NewBoltDB(cfg), 0)
+	r.NoError(err)
+	r.NoError(indexer.Start(ctx))
+	r.NoError(indexer.PutBlock(ctx, blk1))
+	r.Error(indexer.PutBlock(ctx, newBlock(3, nil)))
+	r.NoError(indexer.PutBlock(ctx, blk2))
+	r.NoError(indexer.Stop(ctx))
+
+	// reopen the indexer
+	r.NoError(indexer.Start(ctx))
+	defer func() {
+		r.NoError(indexer.Stop(ctx))
+	}()
+	h, err := indexer.Height()
+	r.NoError(err)
+	r.EqualValues(2, h)
+
+	types := func(events []*StakingEvent) []string {
+		var ret []string
+		for _, se := range events {
+			ret = append(ret, se.Type)
+		}
+		return ret
+	}
+	events, total, err := indexer.BucketHistory(1, 0, 10)
+	r.NoError(err)
+	r.EqualValues(4, total)
+	r.Equal([]string{StakingEventCreateStake, StakingEventChangeCandidate, StakingEventTransferStake, StakingEventDepositToStake}, types(events))
+	r.EqualValues(1, events[0].BlockHeight)
+	r.Equal(blk1.Receipts[0].ActionHash, events[0].ActionHash)
+	r.EqualValues(1, events[0].LogIndex)
+	r.Nil(events[0].Owner)
+	r.Len(events[1].Candidates, 2)
+	r.Equal(cand1.String(), events[1].Candidates[0].String())
+	r.Equal(cand2.String(), events[1].Candidates[1].String())
+	r.Equal(voter.String(), events[2].Owner.String())
+	r.Equal(cand2.String(), events[2].Candidates[0].String())
+
+	events, total, err = indexer.BucketHistory(1, 1, 2)
+	r.NoError(err)
+	r.EqualValues(4, total)
+	r.Equal([]string{StakingEventChangeCandidate, StakingEventTransferStake}, types(events))
+
+	// the withdrawStake log of a MigrateStake action is a migrate event
+	events, total, err = indexer.BucketHistory(2, 0, 10)
+	r.NoError(err)
+	r.EqualValues(2, total)
+	r.Equal([]string{StakingEventCreateStake, StakingEventMigrateStake}, types(events))
+
+	events, total, err = indexer.CandidateHistory(cand1, 0, 10)
+	r.NoError(err)
+	r.EqualValues(4, total)
+	r.Equal([]string{StakingEventCandidateRegister, StakingEventCreateStake, StakingEventChangeCandidate, StakingEventCandidateUpdate}, types(events))
+	r.Equal(NoBucketIndex, events[0].BucketIndex)
+	r.Equal(NoBucketIndex, events[3].BucketIndex)
+
+	events, total, err = indexer.CandidateHistory(cand2, 0, 10)
+	r.NoError(err)
+	r.EqualValues(5, total)
+	r.Equal([]string{StakingEventCreateStake, StakingEventMigrateStake, StakingEventChangeCandidate, StakingEventTransferStake, StakingEventDepositToStake}, types(events))
+
+	events, total, err = indexer.CandidateHistory(cand2, 5, 10)
+	r.NoError(err)
+	r.EqualValues(5, total)
+	r.Empty(events)
+	events, total, err = indexer.BucketHistory(3, 0, 10)
+	r.NoError(err)
+	r.Zero(total)
+	r.Empty(events)
+
+	// the blocks lower than the start height are skipped
+	testPath2, err := testutil.PathOfTempFile("test-staking-history-indexer-start")
+	r.NoError(err)
+	defer testutil.CleanupPath(testPath2)
+	cfg.DbPath = testPath2
+	indexer2, err := NewStakingHistoryIndexer(db.NewBoltDB(cfg), 2)
+	r.NoError(err)
+	r.EqualValues(2, indexer2.StartHeight())
+	r.NoError(indexer2.Start(ctx))
+	defer func() {
+		r.NoError(indexer2.Stop(ctx))
+	}()
+	r.NoError(indexer2.PutBlock(ctx, blk1))
+	h, err = indexer2.Height()
+	r.NoError(err)
+	r.Zero(h)
+	r.NoError(indexer2.PutBlock(ctx, blk2))
+	h, err = indexer2.Height()
+	r.NoError(err)
+	r.EqualValues(2, h)
+	events, total, err = indexer2.BucketHistory(1, 0, 10)
+	r.NoError(err)
+	r.EqualValues(3, total)
+	r.Equal([]string{StakingEventChangeCandidate, StakingEventTransferStake, StakingEventDepositToStake}, types(events))
+}
@@ -69,6 +69,29 @@ func (ig *SyncIndexers) PutBlock(ctx context.Context, blk *block.Block) error {
 	return nil
 }
 
+// DeleteTipBlock deletes the tip block from the indexers in the group supporting it, in the reverse order
+func (ig *SyncIndexers) DeleteTipBlock(ctx context.Context, blk *block.Block) error {
+	for i := len(ig.indexers) - 1; i >= 0; i-- {
+		indexer, ok := ig.indexers[i].(interface {
+			DeleteTipBlock(context.Context, *block.Block) error
+		})
+		if !ok {
+			continue
+		}
+		height, err := ig.indexers[i].Height()
+		if err != nil {
+			return err
+		}
+		if blk.Height() != height {
+			continue
+		}
+		if err := indexer.DeleteTipBlock(ctx, blk); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // StartHeight returns the minimum start height of the indexers in the group
 func (ig *SyncIndexers) StartHeight() uint64 {
 	return ig.minStartHeight
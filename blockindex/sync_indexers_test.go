@@ -15,8 +15,10 @@ import (
 
 	"github.com/iotexproject/iotex-core/v2/blockchain/block"
 	"github.com/iotexproject/iotex-core/v2/blockchain/blockdao"
+	"github.com/iotexproject/iotex-core/v2/db"
 	"github.com/iotexproject/iotex-core/v2/test/identityset"
 	"github.com/iotexproject/iotex-core/v2/test/mock/mock_blockdao"
+	"github.com/iotexproject/iotex-core/v2/testutil"
 )
 
 func TestSyncIndexers_StartHeight(t *testing.T) {
@@ -216,3 +218,48 @@ func TestSyncIndexers_PutBlock(t *testing.T) {
 		})
 	}
 }
+
+func TestSyncIndexers_DeleteTipBlock(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testPath, err := testutil.PathOfTempFile("test-sync-indexers-delete")
+	require.NoError(err)
+	defer testutil.CleanupPath(testPath)
+	cfg := db.DefaultConfig
+	cfg.DbPath = testPath
+	ttIndexer, err := NewTokenTransferIndexer(db.NewBoltDB(cfg))
+	require.NoError(err)
+	// the indexer not supporting DeleteTipBlock is skipped
+	mockIndexer := mock_blockdao.NewMockBlockIndexer(ctrl)
+	mockIndexer.EXPECT().Start(gomock.Any()).Return(nil).Times(1)
+	mockIndexer.EXPECT().Height().Return(uint64(0), nil).AnyTimes()
+	mockIndexer.EXPECT().PutBlock(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+
+	ctx := context.Background()
+	ig := NewSyncIndexers(mockIndexer, ttIndexer)
+	require.NoError(ig.Start(ctx))
+	defer func() {
+		require.NoError(ttIndexer.Stop(ctx))
+	}()
+	var blks []*block.Block
+	for h := uint64(1); h <= 2; h++ {
+		blk, err := block.NewTestingBuilder().
+			SetHeight(h).
+			SetTimeStamp(testutil.TimestampNow()).
+			SignAndBuild(identityset.PrivateKey(27))
+		require.NoError(err)
+		require.NoError(ig.PutBlock(ctx, &blk))
+		blks = append(blks, &blk)
+	}
+	height, err := ttIndexer.Height()
+	require.NoError(err)
+	require.EqualValues(2, height)
+	// the block not at the tip of the indexer is skipped
+	require.NoError(ig.DeleteTipBlock(ctx, blks[0]))
+	require.NoError(ig.DeleteTipBlock(ctx, blks[1]))
+	height, err = ttIndexer.Height()
+	require.NoError(err)
+	require.EqualValues(1, height)
+}
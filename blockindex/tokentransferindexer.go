@@ -0,0 +1,334 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package blockindex
+
+import (
+	"bytes"
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/iotexproject/iotex-core/v2/action"
+	"github.com/iotexproject/iotex-core/v2/blockchain/block"
+	"github.com/iotexproject/iotex-core/v2/blockchain/blockdao"
+	"github.com/iotexproject/iotex-core/v2/blockindex/indexpb"
+	"github.com/iotexproject/iotex-core/v2/db"
+)
+
+// token standards of the token transfers
+const (
+	TokenStandardXRC20   = "xrc20"
+	TokenStandardXRC721  = "xrc721"
+	TokenStandardXRC1155 = "xrc1155"
+)
+
+const (
+	// _blockTokenTransfersNS is the namespace of the token transfers of the blocks, keyed by height
+	_blockTokenTransfersNS = "btt"
+	// _tokenTransferMetaNS is the namespace of the meta data of the token transfer indexer
+	_tokenTransferMetaNS = "ttm"
+)
+
+var (
+	// _tokenTransferPrefix is the prefix of the counting index buckets of the token transfers of an address,
+	// the bucket of all tokens is prefix || address, and the bucket of a token is prefix || address || token
+	_tokenTransferPrefix = []byte("tt")
+
+	_transferEvent       = hash.BytesToHash256(crypto.Keccak256([]byte("Transfer(address,address,uint256)")))
+	_transferSingleEvent = hash.BytesToHash256(crypto.Keccak256([]byte("TransferSingle(address,address,address,uint256,uint256)")))
+	_transferBatchEvent  = hash.BytesToHash256(crypto.Keccak256([]byte("TransferBatch(address,address,address,uint256[],uint256[])")))
+	_transferBatchArgs   abi.Arguments
+
+	// ErrTokenTransferIndexNA indicates the token transfer index is not enabled
+	ErrTokenTransferIndexNA = errors.New("token transfer index not supported")
+)
+
+func init() {
+	uint256Array, err := abi.NewType("uint256[]", "", nil)
+	if err != nil {
+		panic(err)
+	}
+	_transferBatchArgs = abi.Arguments{{Type: uint256Array}, {Type: uint256Array}}
+}
+
+type (
+	// TokenTransfer is a transfer of an XRC20, XRC721 or XRC1155 token
+	TokenTransfer struct {
+		Standard string
+		Token    address.Address
+		From     address.Address
+		To       address.Address
+		// TokenID is the id of the XRC721 or XRC1155 token transferred, nil for XRC20
+		TokenID *big.Int
+		// Amount is 1 for XRC721
+		Amount      *big.Int
+		BlockHeight uint64
+		ActionHash  hash.Hash256
+		LogIndex    uint32
+	}
+
+	// TokenTransferIndexer is the interface of the indexer of the token transfers decoded from the receipt logs,
+	// the transfers of an address are in the order of being executed
+	TokenTransferIndexer interface {
+		blockdao.BlockIndexer
+		// DeleteTipBlock removes the token transfers of the tip block
+		DeleteTipBlock(context.Context, *block.Block) error
+		// TokenTransferCount returns the number of token transfers from or to the address, of the token if not nil
+		TokenTransferCount(address.Address, address.Address) (uint64, error)
+		// TokenTransfers returns the token transfers [start, start+count) from or to the address, of the token if not nil
+		TokenTransfers(address.Address, address.Address, uint64, uint64) ([]*TokenTransfer, error)
+	}
+
+	tokenTransferIndexer struct {
+		*logIndexer
+	}
+)
+
+// NewTokenTransferIndexer creates a new token transfer indexer
+func NewTokenTransferIndexer(kv db.KVStore) (TokenTransferIndexer, error) {
+	x, err := newLogIndexer(kv, "token transfer", _tokenTransferMetaNS, _blockTokenTransfersNS, 0)
+	if err != nil {
+		return nil, err
+	}
+	return &tokenTransferIndexer{x}, nil
+}
+
+// PutBlock indexes the token transfers in the receipt logs of the block
+func (x *tokenTransferIndexer) PutBlock(_ context.Context, blk *block.Block) error {
+	var (
+		transfers = &indexpb.TokenTransfers{}
+		items     []*logIndexItem
+	)
+	for _, receipt := range blk.Receipts {
+		if receipt.Status != uint64(iotextypes.ReceiptStatus_Success) {
+			continue
+		}
+		for _, log := range receipt.Logs() {
+			for _, tt := range decodeTokenTransfers(log) {
+				tt.BlockHeight = blk.Height()
+				ttPb := tt.toProto()
+				data, err := proto.Marshal(ttPb)
+				if err != nil {
+					return err
+				}
+				items = append(items, &logIndexItem{data: data, buckets: tt.buckets()})
+				transfers.Transfers = append(transfers.Transfers, ttPb)
+			}
+		}
+	}
+	var blockData []byte
+	if len(transfers.Transfers) > 0 {
+		var err error
+		if blockData, err = proto.Marshal(transfers); err != nil {
+			return err
+		}
+	}
+	return x.putBlock(blk.Height(), items, blockData)
+}
+
+// DeleteTipBlock removes the token transfers of the tip block
+func (x *tokenTransferIndexer) DeleteTipBlock(_ context.Context, blk *block.Block) error {
+	return x.deleteTipBlock(blk.Height(), func(data []byte) ([][]byte, error) {
+		transfers := &indexpb.TokenTransfers{}
+		if err := proto.Unmarshal(data, transfers); err != nil {
+			return nil, err
+		}
+		var buckets [][]byte
+		for _, ttPb := range transfers.Transfers {
+			tt, err := tokenTransferFromProto(ttPb)
+			if err != nil {
+				return nil, err
+			}
+			buckets = append(buckets, tt.buckets()...)
+		}
+		return buckets, nil
+	})
+}
+
+// TokenTransferCount returns the number of token transfers from or to the address, of the token if not nil
+func (x *tokenTransferIndexer) TokenTransferCount(addr address.Address, token address.Address) (uint64, error) {
+	_, total, err := x.items(tokenTransferBucket(addr, token), 0, 0)
+	return total, err
+}
+
+// TokenTransfers returns the token transfers [start, start+count) from or to the address, of the token if not nil
+func (x *tokenTransferIndexer) TokenTransfers(addr address.Address, token address.Address, start, count uint64) ([]*TokenTransfer, error) {
+	values, _, err := x.items(tokenTransferBucket(addr, token), start, count)
+	if err != nil {
+		return nil, err
+	}
+	if len(values) == 0 {
+		return nil, nil
+	}
+	ret := make([]*TokenTransfer, 0, len(values))
+	for _, v := range values {
+		ttPb := &indexpb.TokenTransfer{}
+		if err := proto.Unmarshal(v, ttPb); err != nil {
+			return nil, err
+		}
+		tt, err := tokenTransferFromProto(ttPb)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, tt)
+	}
+	return ret, nil
+}
+
+// decodeTokenTransfers decodes the Transfer event of XRC20 and XRC721, and the TransferSingle and TransferBatch
+// events of XRC1155, a malformed log is ignored
+func decodeTokenTransfers(log *action.Log) []*TokenTransfer {
+	if len(log.Topics) == 0 {
+		return nil
+	}
+	token, err := address.FromString(log.Address)
+	if err != nil {
+		return nil
+	}
+	newTransfer := func(standard string, from, to hash.Hash256, tokenID, amount *big.Int) *TokenTransfer {
+		fromAddr, err := topicToAddress(from)
+		if err != nil {
+			return nil
+		}
+		toAddr, err := topicToAddress(to)
+		if err != nil {
+			return nil
+		}
+		return &TokenTransfer{
+			Standard:   standard,
+			Token:      token,
+			From:       fromAddr,
+			To:         toAddr,
+			TokenID:    tokenID,
+			Amount:     amount,
+			ActionHash: log.ActionHash,
+			LogIndex:   log.Index,
+		}
+	}
+	var ret []*TokenTransfer
+	switch log.Topics[0] {
+	case _transferEvent:
+		switch {
+		case len(log.Topics) == 3 && len(log.Data) == 32:
+			ret = append(ret, newTransfer(TokenStandardXRC20, log.Topics[1], log.Topics[2], nil, new(big.Int).SetBytes(log.Data)))
+		case len(log.Topics) == 4 && len(log.Data) == 0:
+			ret = append(ret, newTransfer(TokenStandardXRC721, log.Topics[1], log.Topics[2], new(big.Int).SetBytes(log.Topics[3][:]), big.NewInt(1)))
+		}
+	case _transferSingleEvent:
+		if len(log.Topics) == 4 && len(log.Data) == 64 {
+			ret = append(ret, newTransfer(TokenStandardXRC1155, log.Topics[2], log.Topics[3], new(big.Int).SetBytes(log.Data[:32]), new(big.Int).SetBytes(log.Data[32:])))
+		}
+	case _transferBatchEvent:
+		if len(log.Topics) != 4 {
+			return nil
+		}
+		values, err := _transferBatchArgs.Unpack(log.Data)
+		if err != nil || len(values) != 2 {
+			return nil
+		}
+		ids, ok := values[0].([]*big.Int)
+		if !ok {
+			return nil
+		}
+		amounts, ok := values[1].([]*big.Int)
+		if !ok || len(ids) != len(amounts) {
+			return nil
+		}
+		for i := range ids {
+			ret = append(ret, newTransfer(TokenStandardXRC1155, log.Topics[2], log.Topics[3], ids[i], amounts[i]))
+		}
+	}
+	for _, tt := range ret {
+		if tt == nil {
+			return nil
+		}
+	}
+	return ret
+}
+
+func topicToAddress(topic hash.Hash256) (address.Address, error) {
+	for _, b := range topic[:12] {
+		if b != 0 {
+			return nil, errors.Errorf("invalid address topic %x", topic)
+		}
+	}
+	return address.FromBytes(topic[12:])
+}
+
+// buckets returns the buckets of the counting indices to put the token transfer into, the zero address of
+// minting and burning is not indexed
+func (tt *TokenTransfer) buckets() [][]byte {
+	var ret [][]byte
+	for i, addr := range []address.Address{tt.From, tt.To} {
+		if addr.String() == address.ZeroAddress || (i > 0 && bytes.Equal(addr.Bytes(), tt.From.Bytes())) {
+			continue
+		}
+		ret = append(ret, tokenTransferBucket(addr, nil), tokenTransferBucket(addr, tt.Token))
+	}
+	return ret
+}
+
+func tokenTransferBucket(addr address.Address, token address.Address) []byte {
+	bucket := make([]byte, 0, len(_tokenTransferPrefix)+40)
+	bucket = append(append(bucket, _tokenTransferPrefix...), addr.Bytes()...)
+	if token != nil {
+		bucket = append(bucket, token.Bytes()...)
+	}
+	return bucket
+}
+
+func (tt *TokenTransfer) toProto() *indexpb.TokenTransfer {
+	pb := &indexpb.TokenTransfer{
+		Standard:    tt.Standard,
+		Token:       tt.Token.Bytes(),
+		From:        tt.From.Bytes(),
+		To:          tt.To.Bytes(),
+		Amount:      tt.Amount.String(),
+		BlockHeight: tt.BlockHeight,
+		ActionHash:  tt.ActionHash[:],
+		LogIndex:    tt.LogIndex,
+	}
+	if tt.TokenID != nil {
+		pb.TokenID = tt.TokenID.String()
+	}
+	return pb
+}
+
+func tokenTransferFromProto(pb *indexpb.TokenTransfer) (*TokenTransfer, error) {
+	tt := &TokenTransfer{
+		Standard:    pb.Standard,
+		BlockHeight: pb.BlockHeight,
+		ActionHash:  hash.BytesToHash256(pb.ActionHash),
+		LogIndex:    pb.LogIndex,
+	}
+	var err error
+	if tt.Token, err = address.FromBytes(pb.Token); err != nil {
+		return nil, err
+	}
+	if tt.From, err = address.FromBytes(pb.From); err != nil {
+		return nil, err
+	}
+	if tt.To, err = address.FromBytes(pb.To); err != nil {
+		return nil, err
+	}
+	var ok bool
+	if tt.Amount, ok = new(big.Int).SetString(pb.Amount, 10); !ok {
+		return nil, errors.Errorf("invalid token transfer amount %s", pb.Amount)
+	}
+	if pb.TokenID != "" {
+		if tt.TokenID, ok = new(big.Int).SetString(pb.TokenID, 10); !ok {
+			return nil, errors.Errorf("invalid token id %s", pb.TokenID)
+		}
+	}
+	return tt, nil
+}
@@ -0,0 +1,216 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package blockindex
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/v2/action"
+	"github.com/iotexproject/iotex-core/v2/blockchain/block"
+	"github.com/iotexproject/iotex-core/v2/db"
+	"github.com/iotexproject/iotex-core/v2/test/identityset"
+	"github.com/iotexproject/iotex-core/v2/testutil"
+)
+
+func TestTokenTransferIndexer(t *testing.T) {
+	r := require.New(t)
+	testPath, err := testutil.PathOfTempFile("test-token-transfer-indexer")
+	r.NoError(err)
+	defer testutil.CleanupPath(testPath)
+	cfg := db.DefaultConfig
+	cfg.DbPath = testPath
+
+	var (
+		ctx     = context.Background()
+		a       = identityset.Address(28)
+		b       = identityset.Address(29)
+		c       = identityset.Address(30)
+		xrc20   = identityset.Address(31)
+		xrc721  = identityset.Address(32)
+		xrc1155 = identityset.Address(33)
+		zero, _ = address.FromString(address.ZeroAddress)
+	)
+	topic := func(addr address.Address) hash.Hash256 {
+		return hash.BytesToHash256(addr.Bytes())
+	}
+	word := func(v int64) []byte {
+		h := hash.BytesToHash256(big.NewInt(v).Bytes())
+		return h[:]
+	}
+	transfer := func(from, to address.Address, amount int64) *action.Log {
+		return &action.Log{
+			Address: xrc20.String(),
+			Topics:  action.Topics{_transferEvent, topic(from), topic(to)},
+			Data:    word(amount),
+		}
+	}
+	nftTransfer := func(from, to address.Address, id int64) *action.Log {
+		return &action.Log{
+			Address: xrc721.String(),
+			Topics:  action.Topics{_transferEvent, topic(from), topic(to), hash.BytesToHash256(word(id))},
+		}
+	}
+	newBlock := func(height uint64, receipts ...*action.Receipt) *block.Block {
+		var logIndex uint32
+		for _, receipt := range receipts {
+			receipt.ActionHash = hash.Hash256b([]byte{byte(height), byte(logIndex)})
+			for _, log := range receipt.Logs() {
+				log.ActionHash = receipt.ActionHash
+				log.Index = logIndex
+				logIndex++
+			}
+		}
+		blk, err := block.NewTestingBuilder().
+			SetHeight(height).
+			SetTimeStamp(testutil.TimestampNow()).
+			SetReceipts(receipts).
+			SignAndBuild(identityset.PrivateKey(27))
+		r.NoError(err)
+		return &blk
+	}
+	success := uint64(iotextypes.ReceiptStatus_Success)
+	failure := uint64(iotextypes.ReceiptStatus_Failure)
+	batchData, err := _transferBatchArgs.Pack([]*big.Int{big.NewInt(2), big.NewInt(3)}, []*big.Int{big.NewInt(6), big.NewInt(7)})
+	r.NoError(err)
+
+	blk1 := newBlock(1,
+		(&action.Receipt{Status: success}).AddLogs(
+			transfer(zero, a, 100),
+			transfer(a, b, 40),
+			nftTransfer(a, c, 7),
+		),
+		// logs of a failed action are not indexed
+		(&action.Receipt{Status: failure}).AddLogs(transfer(a, c, 10)),
+	)
+	blk2 := newBlock(2,
+		(&action.Receipt{Status: success}).AddLogs(
+			&action.Log{
+				Address: xrc1155.String(),
+				Topics:  action.Topics{_transferSingleEvent, topic(a), topic(b), topic(c)},
+				Data:    append(word(1), word(5)...),
+			},
+			&action.Log{
+				Address: xrc1155.String(),
+				Topics:  action.Topics{_transferBatchEvent, topic(b), topic(a), topic(b)},
+				Data:    batchData,
+			},
+			// malformed transfer event is ignored
+			&action.Log{
+				Address: xrc20.String(),
+				Topics:  action.Topics{_transferEvent, topic(a), topic(b)},
+			},
+			// self transfer is indexed once
+			transfer(c, c, 1),
+		),
+	)
+
+	indexer, err := NewTokenTransferIndexer(db.NewBoltDB(cfg))
+	r.NoError(err)
+	r.NoError(indexer.Start(ctx))
+	r.NoError(indexer.PutBlock(ctx, blk1))
+	r.Error(indexer.PutBlock(ctx, newBlock(3)))
+	r.NoError(indexer.PutBlock(ctx, blk2))
+	r.NoError(indexer.Stop(ctx))
+
+	// reopen the indexer
+	r.NoError(indexer.Start(ctx))
+	defer func() {
+		r.NoError(indexer.Stop(ctx))
+	}()
+	h, err := indexer.Height()
+	r.NoError(err)
+	r.EqualValues(2, h)
+
+	checkCounts := func(expected map[[2]address.Address]uint64) {
+		for k, v := range expected {
+			count, err := indexer.TokenTransferCount(k[0], k[1])
+			r.NoError(err)
+			r.Equal(v, count, "address %s token %v", k[0], k[1])
+		}
+	}
+	checkCounts(map[[2]address.Address]uint64{
+		{a, nil}:     5,
+		{a, xrc20}:   2,
+		{a, xrc721}:  1,
+		{a, xrc1155}: 2,
+		{b, nil}:     4,
+		{b, xrc1155}: 3,
+		{c, nil}:     3,
+		{c, xrc20}:   1,
+		{zero, nil}:  0,
+	})
+
+	transfers, err := indexer.TokenTransfers(a, nil, 1, 2)
+	r.NoError(err)
+	r.Len(transfers, 2)
+	r.Equal(TokenStandardXRC20, transfers[0].Standard)
+	r.Equal(a.String(), transfers[0].From.String())
+	r.Equal(b.String(), transfers[0].To.String())
+	r.Nil(transfers[0].TokenID)
+	r.EqualValues(40, transfers[0].Amount.Int64())
+	r.EqualValues(1, transfers[0].BlockHeight)
+	r.Equal(blk1.Receipts[0].ActionHash, transfers[0].ActionHash)
+	r.EqualValues(1, transfers[0].LogIndex)
+	r.Equal(TokenStandardXRC721, transfers[1].Standard)
+	r.Equal(xrc721.String(), transfers[1].Token.String())
+	r.EqualValues(7, transfers[1].TokenID.Int64())
+	r.EqualValues(1, transfers[1].Amount.Int64())
+
+	transfers, err = indexer.TokenTransfers(b, xrc1155, 0, 10)
+	r.NoError(err)
+	r.Len(transfers, 3)
+	for i, expected := range []struct {
+		from, to   address.Address
+		id, amount int64
+	}{
+		{b, c, 1, 5},
+		{a, b, 2, 6},
+		{a, b, 3, 7},
+	} {
+		r.Equal(TokenStandardXRC1155, transfers[i].Standard)
+		r.Equal(expected.from.String(), transfers[i].From.String())
+		r.Equal(expected.to.String(), transfers[i].To.String())
+		r.EqualValues(expected.id, transfers[i].TokenID.Int64())
+		r.EqualValues(expected.amount, transfers[i].Amount.Int64())
+		r.EqualValues(2, transfers[i].BlockHeight)
+	}
+	transfers, err = indexer.TokenTransfers(b, xrc1155, 3, 10)
+	r.NoError(err)
+	r.Empty(transfers)
+	transfers, err = indexer.TokenTransfers(identityset.Address(34), nil, 0, 10)
+	r.NoError(err)
+	r.Empty(transfers)
+
+	// revert the tip block
+	r.Error(indexer.DeleteTipBlock(ctx, blk1))
+	r.NoError(indexer.DeleteTipBlock(ctx, blk2))
+	h, err = indexer.Height()
+	r.NoError(err)
+	r.EqualValues(1, h)
+	checkCounts(map[[2]address.Address]uint64{
+		{a, nil}:     3,
+		{a, xrc1155}: 0,
+		{b, nil}:     1,
+		{b, xrc1155}: 0,
+		{c, nil}:     1,
+		{c, xrc20}:   0,
+	})
+	transfers, err = indexer.TokenTransfers(a, nil, 0, 10)
+	r.NoError(err)
+	r.Len(transfers, 3)
+	r.NoError(indexer.PutBlock(ctx, blk2))
+	checkCounts(map[[2]address.Address]uint64{
+		{a, nil}: 5,
+		{b, nil}: 4,
+	})
+}
@@ -0,0 +1,348 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package blockindex
+
+import (
+	"context"
+	"math/big"
+	"sort"
+	"sync"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/iotexproject/iotex-core/v2/action"
+	"github.com/iotexproject/iotex-core/v2/action/protocol"
+	"github.com/iotexproject/iotex-core/v2/blockchain/block"
+	"github.com/iotexproject/iotex-core/v2/blockchain/blockdao"
+	"github.com/iotexproject/iotex-core/v2/blockindex/indexpb"
+	"github.com/iotexproject/iotex-core/v2/db"
+	"github.com/iotexproject/iotex-core/v2/db/batch"
+	"github.com/iotexproject/iotex-core/v2/pkg/log"
+	"github.com/iotexproject/iotex-core/v2/pkg/util/byteutil"
+)
+
+const (
+	// _blockTracesNS is the namespace of the call traces of the blocks, keyed by height
+	_blockTracesNS = "bt"
+	// _addrTracesNS is the namespace of the heights of the blocks having call traces from or to an address,
+	// keyed by address || height
+	_addrTracesNS = "at"
+	// _traceMetaNS is the namespace of the meta data of the trace indexer
+	_traceMetaNS = "tm"
+	// _traceGapNS is the namespace of the heights of the blocks indexed without call traces, keyed by height
+	_traceGapNS = "tg"
+)
+
+var (
+	_traceHeightKey = []byte(CurrentHeightKey)
+
+	// ErrCallTraceNotRecorded indicates the block was not executed with call traces recorded
+	ErrCallTraceNotRecorded = errors.New("call traces are not recorded")
+)
+
+type (
+	// ActionTraces is the call traces of an action
+	ActionTraces struct {
+		ActionHash hash.Hash256
+		Traces     []*action.CallTrace
+	}
+
+	// TraceIndexer is the interface of the indexer of the call traces of the actions
+	TraceIndexer interface {
+		blockdao.BlockIndexerWithStart
+		// BlockTraces returns the call traces of the actions in the block at height
+		BlockTraces(uint64) ([]*ActionTraces, error)
+		// HeightsByAddress returns the heights of the blocks in range [start, end] having call traces from or to
+		// any of the addresses, in ascending order
+		HeightsByAddress([]address.Address, uint64, uint64) ([]uint64, error)
+		// GapHeights returns the heights of the blocks in range [start, end] indexed without call traces, in
+		// ascending order
+		GapHeights(uint64, uint64) ([]uint64, error)
+	}
+
+	traceIndexer struct {
+		mutex       sync.RWMutex
+		kvStore     db.KVStore
+		startHeight uint64
+		height      uint64
+	}
+)
+
+// NewTraceIndexer creates a new trace indexer, which indexes the blocks from the start height
+func NewTraceIndexer(kv db.KVStore, startHeight uint64) (TraceIndexer, error) {
+	if kv == nil {
+		return nil, errors.New("empty kvStore")
+	}
+	return &traceIndexer{
+		kvStore:     kv,
+		startHeight: startHeight,
+	}, nil
+}
+
+// Start starts the trace indexer
+func (x *traceIndexer) Start(ctx context.Context) error {
+	if err := x.kvStore.Start(ctx); err != nil {
+		return err
+	}
+	x.mutex.Lock()
+	defer x.mutex.Unlock()
+	h, err := x.kvStore.Get(_traceMetaNS, _traceHeightKey)
+	switch errors.Cause(err) {
+	case nil:
+		x.height = byteutil.BytesToUint64BigEndian(h)
+	case db.ErrNotExist, db.ErrBucketNotExist:
+		x.height = 0
+	default:
+		return err
+	}
+	return nil
+}
+
+// Stop stops the trace indexer
+func (x *traceIndexer) Stop(ctx context.Context) error {
+	return x.kvStore.Stop(ctx)
+}
+
+// Height returns the height of the trace indexer
+func (x *traceIndexer) Height() (uint64, error) {
+	x.mutex.RLock()
+	defer x.mutex.RUnlock()
+	return x.height, nil
+}
+
+// StartHeight returns the height to start indexing from
+func (x *traceIndexer) StartHeight() uint64 {
+	return x.startHeight
+}
+
+// PutBlock indexes the call traces in the receipts of the block, which are recorded when the block is committed.
+// The block loaded from the chain db to catch up with the chain has no call traces, which is recorded as a gap,
+// whose call traces are replayed by the api upon the archive-mode state.
+func (x *traceIndexer) PutBlock(ctx context.Context, blk *block.Block) error {
+	height := blk.Height()
+	x.mutex.Lock()
+	defer x.mutex.Unlock()
+	if height < x.startHeight || height <= x.height {
+		return nil
+	}
+	var (
+		b    = batch.NewBatch()
+		hKey = byteutil.Uint64ToBytesBigEndian(height)
+	)
+	if !protocol.CallTraceEnabled(ctx) {
+		if x.height < x.startHeight {
+			// the blocks up to the chain tip would be indexed without call traces
+			return errors.Wrapf(ErrCallTraceNotRecorded, "start height %d of the trace indexer has to be higher than the tip of the chain db, which has block %d", x.startHeight, height)
+		}
+		log.L().Warn("Block is indexed without call traces.", zap.Uint64("height", height))
+		b.Put(_traceGapNS, hKey, []byte{}, "failed to put trace gap")
+		b.Put(_traceMetaNS, _traceHeightKey, hKey, "failed to put trace indexer height")
+		if err := x.kvStore.WriteBatch(b); err != nil {
+			return err
+		}
+		x.height = height
+		return nil
+	}
+	if len(blk.Receipts) != len(blk.Actions) {
+		return errors.Errorf("block %d has %d actions but %d receipts", height, len(blk.Actions), len(blk.Receipts))
+	}
+	var (
+		blkPb = &indexpb.BlockTraces{}
+		addrs = make(map[string]struct{})
+	)
+	for i, receipt := range blk.Receipts {
+		traces := receipt.CallTraces()
+		if len(traces) == 0 {
+			continue
+		}
+		actPb := &indexpb.ActionTraces{ActionHash: receipt.ActionHash[:]}
+		for _, trace := range traces {
+			actPb.Traces = append(actPb.Traces, callTraceToProto(trace))
+			for _, s := range []string{trace.From, trace.To} {
+				if s == "" {
+					continue
+				}
+				addr, err := address.FromString(s)
+				if err != nil {
+					return errors.Wrapf(err, "invalid address %s in call traces of action %d in block %d", s, i, height)
+				}
+				addrs[string(addr.Bytes())] = struct{}{}
+			}
+		}
+		blkPb.Actions = append(blkPb.Actions, actPb)
+	}
+	if len(blkPb.Actions) > 0 {
+		data, err := proto.Marshal(blkPb)
+		if err != nil {
+			return err
+		}
+		b.Put(_blockTracesNS, hKey, data, "failed to put block traces")
+	}
+	for addr := range addrs {
+		b.Put(_addrTracesNS, addrTraceKey([]byte(addr), height), []byte{}, "failed to put address traces")
+	}
+	b.Put(_traceMetaNS, _traceHeightKey, hKey, "failed to put trace indexer height")
+	if err := x.kvStore.WriteBatch(b); err != nil {
+		return err
+	}
+	x.height = height
+	return nil
+}
+
+// BlockTraces returns the call traces of the actions in the block at height
+func (x *traceIndexer) BlockTraces(height uint64) ([]*ActionTraces, error) {
+	x.mutex.RLock()
+	defer x.mutex.RUnlock()
+	if height < x.startHeight || height > x.height {
+		return nil, errors.Wrapf(db.ErrNotExist, "block %d is not indexed in range [%d, %d]", height, x.startHeight, x.height)
+	}
+	hKey := byteutil.Uint64ToBytesBigEndian(height)
+	switch _, err := x.kvStore.Get(_traceGapNS, hKey); errors.Cause(err) {
+	case nil:
+		return nil, errors.Wrapf(ErrCallTraceNotRecorded, "block %d", height)
+	case db.ErrNotExist, db.ErrBucketNotExist:
+	default:
+		return nil, err
+	}
+	data, err := x.kvStore.Get(_blockTracesNS, hKey)
+	switch errors.Cause(err) {
+	case nil:
+	case db.ErrNotExist, db.ErrBucketNotExist:
+		// no call traces in the block
+		return nil, nil
+	default:
+		return nil, err
+	}
+	blkPb := &indexpb.BlockTraces{}
+	if err := proto.Unmarshal(data, blkPb); err != nil {
+		return nil, err
+	}
+	ret := make([]*ActionTraces, 0, len(blkPb.Actions))
+	for _, actPb := range blkPb.Actions {
+		traces := make([]*action.CallTrace, 0, len(actPb.Traces))
+		for _, tracePb := range actPb.Traces {
+			trace, err := callTraceFromProto(tracePb)
+			if err != nil {
+				return nil, err
+			}
+			traces = append(traces, trace)
+		}
+		ret = append(ret, &ActionTraces{
+			ActionHash: hash.BytesToHash256(actPb.ActionHash),
+			Traces:     traces,
+		})
+	}
+	return ret, nil
+}
+
+// HeightsByAddress returns the heights of the blocks in range [start, end] having call traces from or to any
+// of the addresses, in ascending order
+func (x *traceIndexer) HeightsByAddress(addrs []address.Address, start, end uint64) ([]uint64, error) {
+	if start > end {
+		return nil, errors.Errorf("invalid block range [%d, %d]", start, end)
+	}
+	var (
+		heights = make(map[uint64]struct{})
+		ret     []uint64
+	)
+	for _, addr := range addrs {
+		hs, err := x.heightsInRange(_addrTracesNS, addr.Bytes(), start, end)
+		if err != nil {
+			return nil, err
+		}
+		for _, h := range hs {
+			if _, ok := heights[h]; !ok {
+				heights[h] = struct{}{}
+				ret = append(ret, h)
+			}
+		}
+	}
+	sort.Slice(ret, func(i, j int) bool { return ret[i] < ret[j] })
+	return ret, nil
+}
+
+// GapHeights returns the heights of the blocks in range [start, end] indexed without call traces, in ascending
+// order
+func (x *traceIndexer) GapHeights(start, end uint64) ([]uint64, error) {
+	if start > end {
+		return nil, errors.Errorf("invalid block range [%d, %d]", start, end)
+	}
+	return x.heightsInRange(_traceGapNS, nil, start, end)
+}
+
+// heightsInRange returns the heights in range [start, end] of the keys prefix || height in the namespace
+func (x *traceIndexer) heightsInRange(ns string, prefix []byte, start, end uint64) ([]uint64, error) {
+	keys, _, err := x.kvStore.Filter(ns, func(k, v []byte) bool {
+		return len(k) == len(prefix)+8
+	}, addrTraceKey(prefix, start), addrTraceKey(prefix, end))
+	switch errors.Cause(err) {
+	case nil:
+	case db.ErrNotExist, db.ErrBucketNotExist:
+		return nil, nil
+	default:
+		return nil, err
+	}
+	ret := make([]uint64, 0, len(keys))
+	for _, k := range keys {
+		ret = append(ret, byteutil.BytesToUint64BigEndian(k[len(prefix):]))
+	}
+	return ret, nil
+}
+
+func addrTraceKey(addr []byte, height uint64) []byte {
+	key := make([]byte, 0, len(addr)+8)
+	return append(append(key, addr...), byteutil.Uint64ToBytesBigEndian(height)...)
+}
+
+func callTraceToProto(trace *action.CallTrace) *indexpb.CallTrace {
+	value := "0"
+	if trace.Value != nil {
+		value = trace.Value.String()
+	}
+	return &indexpb.CallTrace{
+		Type:         trace.Type,
+		CallType:     trace.CallType,
+		From:         trace.From,
+		To:           trace.To,
+		Value:        value,
+		Gas:          trace.Gas,
+		GasUsed:      trace.GasUsed,
+		Input:        trace.Input,
+		Output:       trace.Output,
+		Error:        trace.Error,
+		TraceAddress: trace.TraceAddress,
+		Subtraces:    trace.Subtraces,
+	}
+}
+
+func callTraceFromProto(pb *indexpb.CallTrace) (*action.CallTrace, error) {
+	value, ok := new(big.Int).SetString(pb.Value, 10)
+	if !ok {
+		return nil, errors.Errorf("invalid call trace value %s", pb.Value)
+	}
+	traceAddress := pb.TraceAddress
+	if traceAddress == nil {
+		traceAddress = []uint64{}
+	}
+	return &action.CallTrace{
+		Type:         pb.Type,
+		CallType:     pb.CallType,
+		From:         pb.From,
+		To:           pb.To,
+		Value:        value,
+		Gas:          pb.Gas,
+		GasUsed:      pb.GasUsed,
+		Input:        pb.Input,
+		Output:       pb.Output,
+		Error:        pb.Error,
+		TraceAddress: traceAddress,
+		Subtraces:    pb.Subtraces,
+	}, nil
+}
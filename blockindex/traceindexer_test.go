@@ -0,0 +1,163 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package blockindex
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/v2/action"
+	"github.com/iotexproject/iotex-core/v2/action/protocol"
+	"github.com/iotexproject/iotex-core/v2/blockchain/block"
+	"github.com/iotexproject/iotex-core/v2/db"
+	"github.com/iotexproject/iotex-core/v2/test/identityset"
+	"github.com/iotexproject/iotex-core/v2/testutil"
+)
+
+func TestTraceIndexer(t *testing.T) {
+	r := require.New(t)
+	testPath, err := testutil.PathOfTempFile("test-trace-indexer")
+	r.NoError(err)
+	defer testutil.CleanupPath(testPath)
+	cfg := db.DefaultConfig
+	cfg.DbPath = testPath
+
+	var (
+		ctx      = protocol.WithCallTraceCtx(context.Background())
+		a        = identityset.Address(28)
+		b        = identityset.Address(29)
+		contract = identityset.Address(31)
+	)
+	newBlock := func(height uint64, receipts ...*action.Receipt) *block.Block {
+		acts := make([]*action.SealedEnvelope, 0, len(receipts))
+		for i, receipt := range receipts {
+			tsf, err := action.SignedTransfer(b.String(), identityset.PrivateKey(28), uint64(i+1), big.NewInt(1), nil, testutil.TestGasLimit, big.NewInt(0))
+			r.NoError(err)
+			receipt.ActionHash, err = tsf.Hash()
+			r.NoError(err)
+			acts = append(acts, tsf)
+		}
+		blk, err := block.NewTestingBuilder().
+			SetHeight(height).
+			SetTimeStamp(testutil.TimestampNow()).
+			AddActions(acts...).
+			SetReceipts(receipts).
+			SignAndBuild(identityset.PrivateKey(27))
+		r.NoError(err)
+		return &blk
+	}
+	transfer := (&action.Receipt{}).AddCallTraces(&action.CallTrace{
+		Type:         action.CallTraceTypeCall,
+		CallType:     action.CallTraceTypeCall,
+		From:         a.String(),
+		To:           b.String(),
+		Value:        big.NewInt(1),
+		TraceAddress: []uint64{},
+	})
+	execution := (&action.Receipt{}).AddCallTraces(&action.CallTrace{
+		Type:         action.CallTraceTypeCall,
+		CallType:     action.CallTraceTypeCall,
+		From:         a.String(),
+		To:           contract.String(),
+		Value:        big.NewInt(0),
+		Gas:          100000,
+		GasUsed:      30000,
+		Input:        []byte{1, 2, 3, 4},
+		Output:       []byte{5},
+		TraceAddress: []uint64{},
+		Subtraces:    1,
+	}, &action.CallTrace{
+		Type:         action.CallTraceTypeCall,
+		CallType:     "delegatecall",
+		From:         contract.String(),
+		To:           b.String(),
+		Value:        big.NewInt(0),
+		Gas:          50000,
+		GasUsed:      21000,
+		Error:        "execution reverted",
+		TraceAddress: []uint64{0},
+	})
+
+	indexer, err := NewTraceIndexer(db.NewBoltDB(cfg), 2)
+	r.NoError(err)
+	r.NoError(indexer.Start(ctx))
+	r.EqualValues(2, indexer.StartHeight())
+
+	// blocks below the start height are skipped
+	r.NoError(indexer.PutBlock(ctx, newBlock(1, transfer)))
+	h, err := indexer.Height()
+	r.NoError(err)
+	r.Zero(h)
+	// blocks loaded from the chain db are rejected before any block is indexed, as the start height is not higher
+	// than the tip of the chain db
+	r.ErrorIs(indexer.PutBlock(context.Background(), newBlock(2, transfer)), ErrCallTraceNotRecorded)
+	blk2 := newBlock(2, transfer, execution)
+	r.NoError(indexer.PutBlock(ctx, blk2))
+	r.NoError(indexer.PutBlock(ctx, newBlock(3, &action.Receipt{})))
+	r.NoError(indexer.PutBlock(ctx, newBlock(4, transfer)))
+	// afterwards the blocks loaded from the chain db are recorded as gaps
+	r.NoError(indexer.PutBlock(context.Background(), newBlock(5, transfer)))
+	r.NoError(indexer.PutBlock(ctx, newBlock(6, (&action.Receipt{}).AddCallTraces(execution.CallTraces()...))))
+	r.NoError(indexer.Stop(ctx))
+
+	// reopen the indexer
+	r.NoError(indexer.Start(ctx))
+	defer func() {
+		r.NoError(indexer.Stop(ctx))
+	}()
+	h, err = indexer.Height()
+	r.NoError(err)
+	r.EqualValues(6, h)
+
+	traces, err := indexer.BlockTraces(2)
+	r.NoError(err)
+	r.Len(traces, 2)
+	for i, receipt := range blk2.Receipts {
+		r.Equal(receipt.ActionHash, traces[i].ActionHash)
+		r.Equal(receipt.CallTraces(), traces[i].Traces)
+	}
+	traces, err = indexer.BlockTraces(3)
+	r.NoError(err)
+	r.Empty(traces)
+	_, err = indexer.BlockTraces(5)
+	r.ErrorIs(err, ErrCallTraceNotRecorded)
+	for _, height := range []uint64{1, 7} {
+		_, err = indexer.BlockTraces(height)
+		r.ErrorIs(err, db.ErrNotExist)
+	}
+
+	for _, c := range []struct {
+		addrs      []address.Address
+		start, end uint64
+		expected   []uint64
+	}{
+		{[]address.Address{a}, 1, 10, []uint64{2, 4, 6}},
+		{[]address.Address{b}, 3, 4, []uint64{4}},
+		{[]address.Address{contract}, 1, 4, []uint64{2}},
+		{[]address.Address{contract, b}, 1, 10, []uint64{2, 4, 6}},
+		{[]address.Address{identityset.Address(30)}, 1, 10, nil},
+	} {
+		heights, err := indexer.HeightsByAddress(c.addrs, c.start, c.end)
+		r.NoError(err)
+		r.Equal(c.expected, heights)
+	}
+	_, err = indexer.HeightsByAddress([]address.Address{a}, 3, 2)
+	r.Error(err)
+
+	// the gaps are returned apart from the heights of the addresses
+	gaps, err := indexer.GapHeights(1, 10)
+	r.NoError(err)
+	r.Equal([]uint64{5}, gaps)
+	gaps, err = indexer.GapHeights(1, 4)
+	r.NoError(err)
+	r.Empty(gaps)
+	_, err = indexer.GapHeights(3, 2)
+	r.Error(err)
+}
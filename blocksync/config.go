@@ -17,6 +17,31 @@ type Config struct {
 	MaxRepeat int `yaml:"maxRepeat"`
 	// RepeatDecayStep is the step for repeat number decreasing by 1
 	RepeatDecayStep int `yaml:"repeatDecayStep"`
+	// StateSync is the config of syncing the state snapshot from peers
+	StateSync StateSyncConfig `yaml:"stateSync"`
+}
+
+// StateSyncConfig is the config struct for the state sync
+type StateSyncConfig struct {
+	// Enabled downloads the state snapshot from peers into chain.stateSnapshotDir when the state db is empty
+	Enabled bool `yaml:"enabled"`
+	// ServeDir is the directory of the state snapshots served to peers, each in a sub-directory named by its height
+	ServeDir string `yaml:"serveDir"`
+	// MinPeers is the minimal number of peers agreeing on the same snapshot manifest
+	MinPeers int `yaml:"minPeers"`
+	// RequestTimeout is the timeout of a request to a peer
+	RequestTimeout time.Duration `yaml:"requestTimeout"`
+	// RetryInterval is the interval to retry when there are not enough peers
+	RetryInterval time.Duration `yaml:"retryInterval"`
+	// MaxFileSize is the max size of a file of the state snapshot to download
+	MaxFileSize uint64 `yaml:"maxFileSize"`
+	// ServeRateLimit is the max number of requests per second served to a peer, 0 means no limit
+	ServeRateLimit int `yaml:"serveRateLimit"`
+	// TrustedHeight is the height of the state snapshot to download
+	TrustedHeight uint64 `yaml:"trustedHeight"`
+	// TrustedDigest is the digest of the state snapshot to download (see factory.SnapshotManifest.Digest), which is
+	// obtained from a trusted source, as the state other than the account trie is not verified by the next block
+	TrustedDigest string `yaml:"trustedDigest"`
 }
 
 // DefaultConfig is the default config
@@ -27,4 +52,11 @@ var DefaultConfig = Config{
 	IntervalSize:          20,
 	MaxRepeat:             3,
 	RepeatDecayStep:       1,
+	StateSync: StateSyncConfig{
+		MinPeers:       3,
+		RequestTimeout: 30 * time.Second,
+		RetryInterval:  10 * time.Second,
+		MaxFileSize:    256 << 20,
+		ServeRateLimit: 10,
+	},
 }
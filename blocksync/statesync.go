@@ -0,0 +1,491 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package blocksync
+
+import (
+	"context"
+	"encoding/hex"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/iotexproject/go-pkgs/cache"
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-proto/golang/iotexrpc"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"golang.org/x/time/rate"
+
+	"github.com/iotexproject/iotex-core/v2/blockchain/block"
+	"github.com/iotexproject/iotex-core/v2/blocksync/statesyncpb"
+	"github.com/iotexproject/iotex-core/v2/pkg/log"
+	"github.com/iotexproject/iotex-core/v2/state/factory"
+)
+
+// _stateSyncPieceSize is the max size of the data in a state sync response
+var _stateSyncPieceSize = 4 << 20
+
+type (
+	// StateSyncer downloads the files of a state snapshot (see factory.ExportSnapshot) from peers, and serves
+	// the state snapshots in its serve dir to peers.
+	//
+	// It is not a snap-style sync of trie ranges with boundary proofs: the block header commits to the delta
+	// state digest of the block rather than a state root, so there is no root in the chain to verify a range
+	// against. The trust anchor is the manifest digest configured by the operator instead.
+	//
+	// The downloaded snapshot is trusted as follows:
+	//   1. the manifest is accepted only if its digest matches TrustedDigest, and at least MinPeers peers
+	//      serve the identical manifest
+	//   2. every file is verified against its hash in the manifest, so it can be downloaded from any peer
+	//   3. the block following the snapshot is obtained by block sync, on which at least MinPeers peers
+	//      agree, and it has to be linked to the block of the snapshot
+	//   4. every imported namespace is verified against its digest in the manifest, and the imported
+	//      account trie is verified against the state root in the manifest
+	//   5. the block following the snapshot is committed upon the imported state, whose delta state
+	//      digest has to match the one in its block header
+	StateSyncer struct {
+		cfg             StateSyncConfig
+		p2pNeighbor     Neighbors
+		unicastOutbound UniCastOutbound
+		limiters        cache.LRUCache
+
+		mu            sync.Mutex
+		waiting       map[stateSyncKey]chan *statesyncpb.StateSyncResponse
+		waitingBlocks map[uint64]chan *peerBlock
+	}
+
+	stateSyncKey struct {
+		peer   string
+		file   string
+		offset uint64
+	}
+)
+
+// NewStateSyncer returns a new state syncer
+func NewStateSyncer(cfg StateSyncConfig, p2pNeighbor Neighbors, unicastHandler UniCastOutbound) *StateSyncer {
+	return &StateSyncer{
+		cfg:             cfg,
+		p2pNeighbor:     p2pNeighbor,
+		unicastOutbound: unicastHandler,
+		limiters:        cache.NewThreadSafeLruCache(1000),
+		waiting:         make(map[stateSyncKey]chan *statesyncpb.StateSyncResponse),
+		waitingBlocks:   make(map[uint64]chan *peerBlock),
+	}
+}
+
+// ProcessRequest responds a piece of a file of the state snapshot in the serve dir
+func (s *StateSyncer) ProcessRequest(ctx context.Context, peer peer.AddrInfo, req *statesyncpb.StateSyncRequest) error {
+	if s.cfg.ServeRateLimit > 0 && !s.limiter(peer.ID.String()).Allow() {
+		return errors.Errorf("state sync requests of peer %s exceed the rate limit", peer.ID)
+	}
+	resp, err := s.readPiece(req)
+	if err != nil {
+		resp = &statesyncpb.StateSyncResponse{
+			Height: req.Height,
+			File:   req.File,
+			Offset: req.Offset,
+			Error:  err.Error(),
+		}
+	}
+	if err := s.unicastOutbound(ctx, peer, resp); err != nil {
+		return err
+	}
+	return err
+}
+
+// VerifyTrustedManifest verifies the manifest of the state snapshot matches the trusted height and digest
+func (cfg StateSyncConfig) VerifyTrustedManifest(manifest *factory.SnapshotManifest) error {
+	if err := cfg.checkTrusted(); err != nil {
+		return err
+	}
+	if manifest.Height != cfg.TrustedHeight {
+		return errors.Wrapf(factory.ErrSnapshotInvalid, "height %d doesn't match the trusted height %d", manifest.Height, cfg.TrustedHeight)
+	}
+	return manifest.VerifyDigest(cfg.TrustedDigest)
+}
+
+func (cfg StateSyncConfig) checkTrusted() error {
+	if cfg.TrustedHeight == 0 || len(cfg.TrustedDigest) == 0 {
+		return errors.New("trusted height and digest of the state snapshot are required by state sync")
+	}
+	return nil
+}
+
+func (s *StateSyncer) limiter(peer string) *rate.Limiter {
+	limiter, ok := s.limiters.Get(peer)
+	if !ok {
+		limiter = rate.NewLimiter(rate.Limit(s.cfg.ServeRateLimit), s.cfg.ServeRateLimit)
+		s.limiters.Add(peer, limiter)
+	}
+	return limiter.(*rate.Limiter)
+}
+
+func (s *StateSyncer) readPiece(req *statesyncpb.StateSyncRequest) (*statesyncpb.StateSyncResponse, error) {
+	if len(s.cfg.ServeDir) == 0 {
+		return nil, errors.New("state snapshot is not served")
+	}
+	height := req.Height
+	if height == 0 {
+		var err error
+		if height, err = s.latestServedHeight(); err != nil {
+			return nil, err
+		}
+	}
+	dir := filepath.Join(s.cfg.ServeDir, strconv.FormatUint(height, 10))
+	if err := checkServedFile(dir, req.File); err != nil {
+		return nil, err
+	}
+	f, err := os.Open(filepath.Join(dir, req.File))
+	if err != nil {
+		return nil, errors.Errorf("state snapshot at height %d doesn't have file %s", height, req.File)
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	size := uint64(info.Size())
+	if req.Offset > size {
+		return nil, errors.Errorf("offset %d exceeds size %d of file %s", req.Offset, size, req.File)
+	}
+	data := make([]byte, min(uint64(_stateSyncPieceSize), size-req.Offset))
+	if _, err := f.ReadAt(data, int64(req.Offset)); err != nil && err != io.EOF {
+		return nil, err
+	}
+	return &statesyncpb.StateSyncResponse{
+		Height: height,
+		File:   req.File,
+		Offset: req.Offset,
+		Size:   size,
+		Data:   data,
+	}, nil
+}
+
+// checkServedFile checks the file is the manifest or listed in the manifest of the state snapshot in dir,
+// so no other file can be read by a request
+func checkServedFile(dir, file string) error {
+	manifest, err := factory.ReadSnapshotManifest(dir)
+	if err != nil {
+		return errors.Errorf("state snapshot at %s is not served", filepath.Base(dir))
+	}
+	if file == factory.SnapshotManifestFile {
+		return nil
+	}
+	for _, f := range manifest.Files() {
+		if f.Name == file {
+			return nil
+		}
+	}
+	return errors.Errorf("invalid file name %s", file)
+}
+
+// latestServedHeight returns the highest state snapshot in the serve dir
+func (s *StateSyncer) latestServedHeight() (uint64, error) {
+	entries, err := os.ReadDir(s.cfg.ServeDir)
+	if err != nil {
+		return 0, errors.New("no state snapshot is served")
+	}
+	var latest uint64
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+		height, err := strconv.ParseUint(e.Name(), 10, 64)
+		if err != nil || height <= latest {
+			continue
+		}
+		if _, err := os.Stat(filepath.Join(s.cfg.ServeDir, e.Name(), factory.SnapshotManifestFile)); err == nil {
+			latest = height
+		}
+	}
+	if latest == 0 {
+		return 0, errors.New("no state snapshot is served")
+	}
+	return latest, nil
+}
+
+// ProcessResponse processes a response of a state sync request
+func (s *StateSyncer) ProcessResponse(_ context.Context, peer string, resp *statesyncpb.StateSyncResponse) error {
+	s.mu.Lock()
+	ch, ok := s.waiting[stateSyncKey{peer: peer, file: resp.File, offset: resp.Offset}]
+	s.mu.Unlock()
+	if !ok {
+		return nil
+	}
+	select {
+	case ch <- resp:
+	default:
+	}
+	return nil
+}
+
+// ProcessBlock processes a block received by block sync while syncing the state snapshot
+func (s *StateSyncer) ProcessBlock(_ context.Context, peer string, blk *block.Block) error {
+	s.mu.Lock()
+	ch, ok := s.waitingBlocks[blk.Height()]
+	s.mu.Unlock()
+	if !ok {
+		return nil
+	}
+	select {
+	case ch <- newPeerBlock(peer, blk):
+	default:
+	}
+	return nil
+}
+
+// Sync downloads the state snapshot of the trusted height and digest from peers into dir, and retries until
+// it succeeds or the context is done. The files already downloaded in dir are skipped. It returns the manifest, and the
+// block following the snapshot obtained by block sync.
+func (s *StateSyncer) Sync(ctx context.Context, dir string) (*factory.SnapshotManifest, *block.Block, error) {
+	if err := s.cfg.checkTrusted(); err != nil {
+		return nil, nil, err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to create dir %s", dir)
+	}
+	for {
+		manifest, next, err := s.sync(ctx, dir)
+		if err == nil {
+			return manifest, next, nil
+		}
+		log.L().Warn("Failed to sync state snapshot, retrying.", zap.Error(err))
+		select {
+		case <-ctx.Done():
+			return nil, nil, ctx.Err()
+		case <-time.After(s.cfg.RetryInterval):
+		}
+	}
+}
+
+func (s *StateSyncer) sync(ctx context.Context, dir string) (*factory.SnapshotManifest, *block.Block, error) {
+	manifest, ser, peers, err := s.syncManifest(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	next, err := s.syncBlock(ctx, manifest.Height+1)
+	if err != nil {
+		return nil, nil, err
+	}
+	if prev := next.PrevHash(); hex.EncodeToString(prev[:]) != manifest.BlockHash {
+		return nil, nil, errors.Wrapf(factory.ErrSnapshotInvalid, "block %d obtained by block sync is not linked to the snapshot block %s", next.Height(), manifest.BlockHash)
+	}
+	log.L().Info("Syncing state snapshot.", zap.Uint64("height", manifest.Height), zap.Int("peers", len(peers)))
+	for i, f := range manifest.Files() {
+		path := filepath.Join(dir, f.Name)
+		if data, err := os.ReadFile(path); err == nil && f.Verify(data) == nil {
+			continue
+		}
+		// each file is tried from a different peer first to spread the load
+		var data []byte
+		for j := range peers {
+			p := peers[(i+j)%len(peers)]
+			if _, data, err = s.fetchFile(ctx, p, manifest.Height, f.Name, f.Size); err == nil {
+				if err = f.Verify(data); err == nil {
+					break
+				}
+			}
+			log.L().Debug("Failed to fetch state snapshot file.", zap.String("file", f.Name), zap.String("peer", p.ID.String()), zap.Error(err))
+		}
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "failed to fetch %s", f.Name)
+		}
+		if err := os.WriteFile(path, data, 0644); err != nil {
+			return nil, nil, errors.Wrapf(err, "failed to write %s", f.Name)
+		}
+	}
+	// the manifest is written at last, so a snapshot with a manifest is complete
+	if err := os.WriteFile(filepath.Join(dir, factory.SnapshotManifestFile), ser, 0644); err != nil {
+		return nil, nil, errors.Wrap(err, "failed to write manifest")
+	}
+	return manifest, next, nil
+}
+
+// syncBlock requests the block at height by block sync from the neighbors, and returns the block on which
+// at least MinPeers peers agree
+func (s *StateSyncer) syncBlock(ctx context.Context, height uint64) (*block.Block, error) {
+	neighbors, err := s.p2pNeighbor()
+	if err != nil {
+		return nil, err
+	}
+	ch := make(chan *peerBlock, len(neighbors))
+	s.mu.Lock()
+	s.waitingBlocks[height] = ch
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		delete(s.waitingBlocks, height)
+		s.mu.Unlock()
+	}()
+	for _, p := range neighbors {
+		if err := s.unicastOutbound(ctx, p, &iotexrpc.BlockSync{Start: height, End: height}); err != nil {
+			log.L().Debug("Failed to request block.", zap.String("peer", p.ID.String()), zap.Error(err))
+		}
+	}
+	var (
+		peers = map[hash.Hash256]map[string]struct{}{}
+		timer = time.NewTimer(s.cfg.RequestTimeout)
+	)
+	defer timer.Stop()
+	for {
+		select {
+		case pb := <-ch:
+			h := pb.block.HashBlock()
+			if _, ok := peers[h]; !ok {
+				peers[h] = map[string]struct{}{}
+			}
+			peers[h][pb.pid] = struct{}{}
+			if len(peers[h]) >= s.cfg.MinPeers {
+				return pb.block, nil
+			}
+		case <-timer.C:
+			return nil, errors.Errorf("less than %d peers agree on block %d", s.cfg.MinPeers, height)
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// syncManifest fetches the manifest at the trusted height from the neighbors, and returns the manifest matching
+// the trusted digest served by the most peers, along with these peers
+func (s *StateSyncer) syncManifest(ctx context.Context) (*factory.SnapshotManifest, []byte, []peer.AddrInfo, error) {
+	neighbors, err := s.p2pNeighbor()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if len(neighbors) < s.cfg.MinPeers {
+		return nil, nil, nil, errors.Errorf("%d peers are less than %d", len(neighbors), s.cfg.MinPeers)
+	}
+	type group struct {
+		height uint64
+		ser    []byte
+		peers  []peer.AddrInfo
+	}
+	var (
+		mu     sync.Mutex
+		wg     sync.WaitGroup
+		groups = map[hash.Hash256]*group{}
+	)
+	for _, p := range neighbors {
+		wg.Add(1)
+		go func(p peer.AddrInfo) {
+			defer wg.Done()
+			height, ser, err := s.fetchFile(ctx, p, s.cfg.TrustedHeight, factory.SnapshotManifestFile, 0)
+			if err == nil {
+				var manifest *factory.SnapshotManifest
+				if manifest, err = factory.ParseSnapshotManifest(ser); err == nil {
+					err = s.cfg.VerifyTrustedManifest(manifest)
+				}
+			}
+			if err != nil {
+				log.L().Debug("Failed to fetch state snapshot manifest.", zap.String("peer", p.ID.String()), zap.Error(err))
+				return
+			}
+			h := hash.Hash256b(ser)
+			mu.Lock()
+			defer mu.Unlock()
+			if g, ok := groups[h]; ok {
+				g.peers = append(g.peers, p)
+			} else {
+				groups[h] = &group{height: height, ser: ser, peers: []peer.AddrInfo{p}}
+			}
+		}(p)
+	}
+	wg.Wait()
+	candidates := make([]*group, 0, len(groups))
+	for _, g := range groups {
+		candidates = append(candidates, g)
+	}
+	if len(candidates) == 0 {
+		return nil, nil, nil, errors.Errorf("no peer serves the trusted state snapshot at height %d", s.cfg.TrustedHeight)
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		if len(candidates[i].peers) != len(candidates[j].peers) {
+			return len(candidates[i].peers) > len(candidates[j].peers)
+		}
+		return candidates[i].height > candidates[j].height
+	})
+	best := candidates[0]
+	if len(best.peers) < s.cfg.MinPeers {
+		return nil, nil, nil, errors.Errorf("only %d peers agree on the state snapshot at height %d, less than %d", len(best.peers), best.height, s.cfg.MinPeers)
+	}
+	manifest, err := factory.ParseSnapshotManifest(best.ser)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if manifest.Height != best.height {
+		return nil, nil, nil, errors.Wrapf(factory.ErrSnapshotInvalid, "manifest height %d doesn't match snapshot height %d", manifest.Height, best.height)
+	}
+	return manifest, best.ser, best.peers, nil
+}
+
+// fetchFile fetches a whole file of the state snapshot at height from the peer piece by piece. The size of
+// the file has to match the expected size if it is not 0, and is capped by MaxFileSize.
+func (s *StateSyncer) fetchFile(ctx context.Context, p peer.AddrInfo, height uint64, file string, size uint64) (uint64, []byte, error) {
+	var data []byte
+	for {
+		resp, err := s.request(ctx, p, &statesyncpb.StateSyncRequest{
+			Height: height,
+			File:   file,
+			Offset: uint64(len(data)),
+		})
+		if err != nil {
+			return 0, nil, err
+		}
+		switch {
+		case height != 0 && resp.Height != height:
+			return 0, nil, errors.Errorf("unexpected height %d", resp.Height)
+		case size != 0 && resp.Size != size:
+			return 0, nil, errors.Errorf("size %d doesn't match manifest %d", resp.Size, size)
+		case resp.Size > s.cfg.MaxFileSize:
+			return 0, nil, errors.Errorf("size %d exceeds the max file size %d", resp.Size, s.cfg.MaxFileSize)
+		case uint64(len(data)+len(resp.Data)) > resp.Size:
+			return 0, nil, errors.Errorf("data exceeds size %d", resp.Size)
+		case len(resp.Data) == 0 && uint64(len(data)) < resp.Size:
+			return 0, nil, errors.New("empty data")
+		}
+		// later pieces have to come from the same snapshot
+		height = resp.Height
+		data = append(data, resp.Data...)
+		if uint64(len(data)) == resp.Size {
+			return height, data, nil
+		}
+	}
+}
+
+func (s *StateSyncer) request(ctx context.Context, p peer.AddrInfo, req *statesyncpb.StateSyncRequest) (*statesyncpb.StateSyncResponse, error) {
+	key := stateSyncKey{peer: p.ID.String(), file: req.File, offset: req.Offset}
+	ch := make(chan *statesyncpb.StateSyncResponse, 1)
+	s.mu.Lock()
+	s.waiting[key] = ch
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		delete(s.waiting, key)
+		s.mu.Unlock()
+	}()
+	if err := s.unicastOutbound(ctx, p, req); err != nil {
+		return nil, err
+	}
+	timer := time.NewTimer(s.cfg.RequestTimeout)
+	defer timer.Stop()
+	select {
+	case resp := <-ch:
+		if len(resp.Error) > 0 {
+			return nil, errors.New(resp.Error)
+		}
+		return resp, nil
+	case <-timer.C:
+		return nil, errors.Errorf("request of %s at offset %d timed out", req.File, req.Offset)
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
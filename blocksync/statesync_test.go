@@ -0,0 +1,240 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package blocksync
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-proto/golang/iotexrpc"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/iotexproject/iotex-core/v2/blockchain/block"
+	"github.com/iotexproject/iotex-core/v2/blocksync/statesyncpb"
+	"github.com/iotexproject/iotex-core/v2/state/factory"
+	"github.com/iotexproject/iotex-core/v2/test/identityset"
+	"github.com/iotexproject/iotex-core/v2/testutil"
+)
+
+func testSnapshotBlockHash(height uint64) hash.Hash256 {
+	return hash.Hash256b([]byte("block at height " + strconv.FormatUint(height, 10)))
+}
+
+func writeTestSnapshot(r *require.Assertions, dir string, height uint64, files map[string][]byte) string {
+	dir = filepath.Join(dir, strconv.FormatUint(height, 10))
+	r.NoError(os.MkdirAll(dir, 0755))
+	blkHash := testSnapshotBlockHash(height)
+	manifest := &factory.SnapshotManifest{
+		Version:   factory.SnapshotVersion,
+		Height:    height,
+		BlockHash: hex.EncodeToString(blkHash[:]),
+		Stores:    []*factory.SnapshotStore{{Name: factory.SnapshotStateStore}},
+	}
+	for _, name := range []string{"block-" + strconv.FormatUint(height, 10), "state-000000", "state-000001"} {
+		data := files[name]
+		h := hash.Hash256b(data)
+		f := &factory.SnapshotFile{Name: name, Hash: hex.EncodeToString(h[:]), Size: uint64(len(data))}
+		if name[:5] == "block" {
+			manifest.Blocks = append(manifest.Blocks, f)
+		} else {
+			manifest.Stores[0].Chunks = append(manifest.Stores[0].Chunks, f)
+		}
+		r.NoError(os.WriteFile(filepath.Join(dir, name), data, 0644))
+	}
+	ser, err := json.Marshal(manifest)
+	r.NoError(err)
+	r.NoError(os.WriteFile(filepath.Join(dir, factory.SnapshotManifestFile), ser, 0644))
+	digest := manifest.Digest()
+	return hex.EncodeToString(digest[:])
+}
+
+func TestStateSyncer(t *testing.T) {
+	r := require.New(t)
+	var wg sync.WaitGroup
+	defer func(size int) {
+		// wait for the in-flight requests before restoring the piece size
+		wg.Wait()
+		_stateSyncPieceSize = size
+	}(_stateSyncPieceSize)
+	_stateSyncPieceSize = 7
+
+	files := map[string][]byte{
+		"block-5":      []byte("block at height 5"),
+		"state-000000": bytes.Repeat([]byte{1}, 30),
+		"state-000001": []byte("last chunk"),
+	}
+	cfg := DefaultConfig.StateSync
+	cfg.MinPeers = 2
+	cfg.RequestTimeout = time.Second
+	cfg.RetryInterval = 10 * time.Millisecond
+	cfg.ServeRateLimit = 0
+	cfg.TrustedHeight = 5
+	cfg.TrustedDigest = writeTestSnapshot(r, t.TempDir(), 5, files)
+	nextBlk, err := block.NewTestingBuilder().
+		SetHeight(6).
+		SetPrevBlockHash(testSnapshotBlockHash(5)).
+		SetTimeStamp(testutil.TimestampNow()).
+		SignAndBuild(identityset.PrivateKey(27))
+	r.NoError(err)
+	// the peers serving block 6 by block sync
+	blockPeers := map[peer.ID]bool{"peer0": true, "peer1": true, "peer2": true}
+
+	var (
+		client  *StateSyncer
+		servers = map[peer.ID]*StateSyncer{}
+		peers   []peer.AddrInfo
+		self    = peer.AddrInfo{ID: peer.ID("client")}
+	)
+	for i, id := range []peer.ID{"peer0", "peer1", "peer2", "peer3"} {
+		serveCfg := cfg
+		serveCfg.ServeDir = t.TempDir()
+		switch i {
+		case 1:
+			// serves a tampered chunk
+			writeTestSnapshot(r, serveCfg.ServeDir, 5, files)
+			r.NoError(os.WriteFile(filepath.Join(serveCfg.ServeDir, "5", "state-000001"), []byte("tampered"), 0644))
+		case 2:
+			// serves an older snapshot as well
+			writeTestSnapshot(r, serveCfg.ServeDir, 3, map[string][]byte{})
+			writeTestSnapshot(r, serveCfg.ServeDir, 5, files)
+		case 3:
+			// serves a different snapshot alone
+			writeTestSnapshot(r, serveCfg.ServeDir, 6, map[string][]byte{})
+		default:
+			writeTestSnapshot(r, serveCfg.ServeDir, 5, files)
+		}
+		id := id
+		servers[id] = NewStateSyncer(serveCfg, nil, func(ctx context.Context, _ peer.AddrInfo, msg proto.Message) error {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				client.ProcessResponse(ctx, id.String(), msg.(*statesyncpb.StateSyncResponse))
+			}()
+			return nil
+		})
+		peers = append(peers, peer.AddrInfo{ID: id})
+	}
+	client = NewStateSyncer(cfg, func() ([]peer.AddrInfo, error) {
+		return peers, nil
+	}, func(ctx context.Context, p peer.AddrInfo, msg proto.Message) error {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			switch req := msg.(type) {
+			case *statesyncpb.StateSyncRequest:
+				servers[p.ID].ProcessRequest(ctx, self, req)
+			case *iotexrpc.BlockSync:
+				if blockPeers[p.ID] && req.Start == nextBlk.Height() {
+					client.ProcessBlock(ctx, p.ID.String(), &nextBlk)
+				}
+			}
+		}()
+		return nil
+	})
+
+	dir := t.TempDir()
+	manifest, next, err := client.Sync(context.Background(), dir)
+	r.NoError(err)
+	r.EqualValues(5, manifest.Height)
+	r.Equal(nextBlk.HashBlock(), next.HashBlock())
+	for name, data := range files {
+		ser, err := os.ReadFile(filepath.Join(dir, name))
+		r.NoError(err)
+		r.Equal(data, ser)
+	}
+	synced, err := factory.ReadSnapshotManifest(dir)
+	r.NoError(err)
+	r.Equal(manifest, synced)
+
+	t.Run("untrusted snapshot", func(t *testing.T) {
+		r := require.New(t)
+		trusted := client.cfg
+		defer func() {
+			client.cfg = trusted
+		}()
+		client.cfg.TrustedDigest = ""
+		_, _, err := client.Sync(context.Background(), t.TempDir())
+		r.ErrorContains(err, "trusted height and digest of the state snapshot are required")
+		client.cfg.TrustedDigest = hex.EncodeToString(hash.ZeroHash256[:])
+		_, _, err = client.sync(context.Background(), t.TempDir())
+		r.ErrorContains(err, "no peer serves the trusted state snapshot at height 5")
+		manifest, err := factory.ReadSnapshotManifest(filepath.Join(servers["peer0"].cfg.ServeDir, "5"))
+		r.NoError(err)
+		r.ErrorContains(client.cfg.VerifyTrustedManifest(manifest), "doesn't match the trusted digest")
+		client.cfg.TrustedHeight = 3
+		r.ErrorContains(client.cfg.VerifyTrustedManifest(manifest), "doesn't match the trusted height 3")
+	})
+
+	t.Run("not enough peers agree on the block", func(t *testing.T) {
+		r := require.New(t)
+		_, err := client.syncBlock(context.Background(), 7)
+		r.ErrorContains(err, "less than 2 peers agree on block 7")
+		blockPeers = map[peer.ID]bool{"peer0": true}
+		_, _, err = client.sync(context.Background(), t.TempDir())
+		r.ErrorContains(err, "less than 2 peers agree on block 6")
+	})
+
+	t.Run("file size", func(t *testing.T) {
+		r := require.New(t)
+		_, _, err := client.fetchFile(context.Background(), peers[0], 5, "block-5", 10)
+		r.ErrorContains(err, "doesn't match manifest")
+		client.cfg.MaxFileSize = 10
+		_, _, err = client.fetchFile(context.Background(), peers[0], 5, "block-5", 0)
+		r.ErrorContains(err, "exceeds the max file size")
+		client.cfg.MaxFileSize = DefaultConfig.StateSync.MaxFileSize
+	})
+
+	t.Run("rate limit", func(t *testing.T) {
+		r := require.New(t)
+		server := NewStateSyncer(StateSyncConfig{ServeRateLimit: 1}, nil, func(context.Context, peer.AddrInfo, proto.Message) error {
+			return nil
+		})
+		req := &statesyncpb.StateSyncRequest{File: factory.SnapshotManifestFile}
+		r.ErrorContains(server.ProcessRequest(context.Background(), self, req), "state snapshot is not served")
+		r.ErrorContains(server.ProcessRequest(context.Background(), self, req), "exceed the rate limit")
+		r.ErrorContains(server.ProcessRequest(context.Background(), peer.AddrInfo{ID: "other"}, req), "state snapshot is not served")
+	})
+
+	t.Run("not enough peers agree", func(t *testing.T) {
+		r := require.New(t)
+		peers = peers[2:]
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		_, _, err := client.Sync(ctx, t.TempDir())
+		r.ErrorIs(err, context.DeadlineExceeded)
+	})
+
+	t.Run("invalid request", func(t *testing.T) {
+		r := require.New(t)
+		_, err := servers["peer0"].readPiece(&statesyncpb.StateSyncRequest{File: "../5/" + factory.SnapshotManifestFile})
+		r.ErrorContains(err, "invalid file name")
+		for _, name := range []string{"", ".", "..", "block-6", "state-000002"} {
+			_, err = servers["peer0"].readPiece(&statesyncpb.StateSyncRequest{File: name})
+			r.ErrorContains(err, "invalid file name")
+		}
+		r.NoError(os.WriteFile(filepath.Join(servers["peer0"].cfg.ServeDir, "5", "other"), []byte("not in manifest"), 0644))
+		_, err = servers["peer0"].readPiece(&statesyncpb.StateSyncRequest{File: "other"})
+		r.ErrorContains(err, "invalid file name")
+		_, err = servers["peer0"].readPiece(&statesyncpb.StateSyncRequest{Height: 4, File: factory.SnapshotManifestFile})
+		r.ErrorContains(err, "is not served")
+		r.NoError(os.Remove(filepath.Join(servers["peer0"].cfg.ServeDir, "5", "state-000001")))
+		_, err = servers["peer0"].readPiece(&statesyncpb.StateSyncRequest{Height: 5, File: "state-000001"})
+		r.ErrorContains(err, "doesn't have file")
+		_, err = servers["peer0"].readPiece(&statesyncpb.StateSyncRequest{File: "block-5", Offset: 100})
+		r.ErrorContains(err, "exceeds size")
+	})
+}
@@ -37,6 +37,7 @@ import (
 	"github.com/iotexproject/iotex-core/v2/blockchain/blockdao"
 	"github.com/iotexproject/iotex-core/v2/blockchain/filedao"
 	"github.com/iotexproject/iotex-core/v2/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/v2/blockexporter"
 	"github.com/iotexproject/iotex-core/v2/blockindex"
 	"github.com/iotexproject/iotex-core/v2/blockindex/contractstaking"
 	"github.com/iotexproject/iotex-core/v2/blocksync"
@@ -56,8 +57,11 @@ import (
 
 // Builder is a builder to build chainservice
 type Builder struct {
-	cfg config.Config
-	cs  *ChainService
+	cfg              config.Config
+	cs               *ChainService
+	snapshotVerifier *snapshotVerifier
+	exporterSink     blockexporter.Sink
+	historyDB        db.VersionedDB // history of contract staking indexers, pruned along with the state history
 }
 
 // NewBuilder creates a new chainservice builder
@@ -131,6 +135,14 @@ func (builder *Builder) SetBlockSync(bs blocksync.BlockSync) *Builder {
 	return builder
 }
 
+// SetBlockExporterSink sets the sink to export the committed blocks to, e.g., a message queue, instead of the
+// file sink in config
+func (builder *Builder) SetBlockExporterSink(sink blockexporter.Sink) *Builder {
+	builder.createInstance()
+	builder.exporterSink = sink
+	return builder
+}
+
 // BuildForTest builds a chainservice for test purpose
 func (builder *Builder) BuildForTest() (*ChainService, error) {
 	builder.createInstance()
@@ -198,12 +210,14 @@ func (builder *Builder) createFactory(forTest bool) (factory.Factory, error) {
 	if err != nil {
 		return nil, err
 	}
-	return factory.NewFactory(
-		factoryCfg,
-		dao,
+	opts := []factory.Option{
 		factory.RegistryOption(builder.cs.registry),
 		factory.DefaultTriePatchOption(),
-	)
+	}
+	if builder.historyDB != nil {
+		opts = append(opts, factory.PruneHistoryDBOption(builder.historyDB))
+	}
+	return factory.NewFactory(factoryCfg, dao, opts...)
 }
 
 func (builder *Builder) buildElectionCommittee() error {
@@ -305,6 +319,12 @@ func (builder *Builder) buildBlockDAO(forTest bool) error {
 	if builder.cs.contractStakingIndexerV2 != nil {
 		synchronizedIndexers = append(synchronizedIndexers, builder.cs.contractStakingIndexerV2)
 	}
+	if builder.cs.tokenTransferIndexer != nil {
+		synchronizedIndexers = append(synchronizedIndexers, builder.cs.tokenTransferIndexer)
+	}
+	if builder.cs.stakingHistoryIndexer != nil {
+		synchronizedIndexers = append(synchronizedIndexers, builder.cs.stakingHistoryIndexer)
+	}
 	if len(synchronizedIndexers) > 1 {
 		indexers = append(indexers, blockindex.NewSyncIndexers(synchronizedIndexers...))
 	} else {
@@ -316,6 +336,9 @@ func (builder *Builder) buildBlockDAO(forTest bool) error {
 	if builder.cs.bfIndexer != nil {
 		indexers = append(indexers, builder.cs.bfIndexer)
 	}
+	if builder.cs.traceIndexer != nil {
+		indexers = append(indexers, builder.cs.traceIndexer)
+	}
 	var (
 		cfg       = builder.cfg
 		err       error
@@ -361,6 +384,31 @@ func (builder *Builder) buildBlockDAO(forTest bool) error {
 	return nil
 }
 
+// buildContractStakingHistoryDB creates the db to keep the history of buckets in archive mode, to read the buckets
+// at a historical height
+func (builder *Builder) buildContractStakingHistoryDB(forTest bool) error {
+	if !builder.cfg.Chain.EnableStakingProtocol || !builder.cfg.Chain.EnableArchiveMode || forTest {
+		return nil
+	}
+	historyDB, err := db.CreateVersionedDB(builder.cfg.DB, builder.cfg.Chain.ContractStakingHistoryDBPath, ContractStakingHistoryNamespaces(builder.cfg.Genesis)...)
+	if err != nil {
+		return errors.Wrap(err, "failed to create contract staking history db")
+	}
+	builder.historyDB = historyDB
+	// the history db is shared by the contract staking indexers, it starts before and stops after the chain
+	builder.cs.lifecycle.Add(historyDB)
+	return nil
+}
+
+// ContractStakingHistoryNamespaces returns the versioned namespaces of the contract staking history db
+func ContractStakingHistoryNamespaces(g genesis.Genesis) []db.Namespace {
+	nss := contractstaking.HistoryNamespaces()
+	if len(g.SystemStakingContractV2Address) > 0 {
+		nss = append(nss, stakingindex.HistoryNamespaces(g.SystemStakingContractV2Address)...)
+	}
+	return nss
+}
+
 func (builder *Builder) buildContractStakingIndexer(forTest bool) error {
 	if !builder.cfg.Chain.EnableStakingProtocol {
 		return nil
@@ -373,9 +421,14 @@ func (builder *Builder) buildContractStakingIndexer(forTest bool) error {
 	dbConfig := builder.cfg.DB
 	dbConfig.DbPath = builder.cfg.Chain.ContractStakingIndexDBPath
 	kvstore := db.NewBoltDB(dbConfig)
+	historyDB := builder.historyDB
 	// build contract staking indexer
 	if builder.cs.contractStakingIndexer == nil && len(builder.cfg.Genesis.SystemStakingContractAddress) > 0 {
 		voteCalcConsts := builder.cfg.Genesis.VoteWeightCalConsts
+		var opts []contractstaking.IndexerOption
+		if historyDB != nil {
+			opts = append(opts, contractstaking.WithHistory(historyDB))
+		}
 		indexer, err := contractstaking.NewContractStakingIndexer(
 			kvstore,
 			contractstaking.Config{
@@ -385,7 +438,7 @@ func (builder *Builder) buildContractStakingIndexer(forTest bool) error {
 					return staking.CalculateVoteWeight(voteCalcConsts, v, false)
 				},
 				BlockInterval: builder.cfg.DardanellesUpgrade.BlockInterval,
-			})
+			}, opts...)
 		if err != nil {
 			return err
 		}
@@ -393,10 +446,15 @@ func (builder *Builder) buildContractStakingIndexer(forTest bool) error {
 	}
 	// build contract staking indexer v2
 	if builder.cs.contractStakingIndexerV2 == nil && len(builder.cfg.Genesis.SystemStakingContractV2Address) > 0 {
+		var opts []stakingindex.IndexerOption
+		if historyDB != nil {
+			opts = append(opts, stakingindex.WithHistory(historyDB))
+		}
 		indexer := stakingindex.NewIndexer(
 			kvstore,
 			builder.cfg.Genesis.SystemStakingContractV2Address,
 			builder.cfg.Genesis.SystemStakingContractV2Height, builder.cfg.DardanellesUpgrade.BlockInterval,
+			opts...,
 		)
 		builder.cs.contractStakingIndexerV2 = indexer
 	}
@@ -404,6 +462,49 @@ func (builder *Builder) buildContractStakingIndexer(forTest bool) error {
 	return nil
 }
 
+func (builder *Builder) buildTraceIndexer(forTest bool) error {
+	if !builder.cfg.Chain.EnableTraceIndexer || forTest {
+		return nil
+	}
+	dbConfig := builder.cfg.DB
+	dbConfig.DbPath = builder.cfg.Chain.TraceIndexDBPath
+	indexer, err := blockindex.NewTraceIndexer(db.NewBoltDB(dbConfig), builder.cfg.Chain.TraceIndexStartHeight)
+	if err != nil {
+		return errors.Wrap(err, "failed to create trace indexer")
+	}
+	builder.cs.traceIndexer = indexer
+	return nil
+}
+
+func (builder *Builder) buildTokenTransferIndexer(forTest bool) error {
+	if !builder.cfg.Chain.EnableTokenTransferIndexer || forTest {
+		return nil
+	}
+	dbConfig := builder.cfg.DB
+	dbConfig.DbPath = builder.cfg.Chain.TokenTransferIndexDBPath
+	indexer, err := blockindex.NewTokenTransferIndexer(db.NewBoltDB(dbConfig))
+	if err != nil {
+		return errors.Wrap(err, "failed to create token transfer indexer")
+	}
+	builder.cs.tokenTransferIndexer = indexer
+	return nil
+}
+
+func (builder *Builder) buildStakingHistoryIndexer(forTest bool) error {
+	if !builder.cfg.Chain.EnableStakingHistoryIndexer || forTest {
+		return nil
+	}
+	dbConfig := builder.cfg.DB
+	dbConfig.DbPath = builder.cfg.Chain.StakingHistoryIndexDBPath
+	// the staking receipt logs before the Fairbank migration can't be decoded
+	indexer, err := blockindex.NewStakingHistoryIndexer(db.NewBoltDB(dbConfig), builder.cfg.Genesis.FbkMigrationBlockHeight)
+	if err != nil {
+		return errors.Wrap(err, "failed to create staking history indexer")
+	}
+	builder.cs.stakingHistoryIndexer = indexer
+	return nil
+}
+
 func (builder *Builder) buildGatewayComponents(forTest bool) error {
 	indexer, bfIndexer, candidateIndexer, candBucketsIndexer, err := builder.createGateWayComponents(forTest)
 	if err != nil {
@@ -485,6 +586,11 @@ func (builder *Builder) createGateWayComponents(forTest bool) (
 func (builder *Builder) buildBlockchain(forSubChain, forTest bool) error {
 	builder.cs.chain = builder.createBlockchain(forSubChain, forTest)
 	builder.cs.lifecycle.Add(builder.cs.chain)
+	if builder.snapshotVerifier != nil {
+		// verify the imported state snapshot right after the chain starts
+		builder.snapshotVerifier.chain = builder.cs.chain
+		builder.cs.lifecycle.Add(builder.snapshotVerifier)
+	}
 	builder.cs.lifecycle.Add(builder.cs.actpool)
 	if err := builder.cs.chain.AddSubscriber(builder.cs.actpool); err != nil {
 		return errors.Wrap(err, "failed to add actpool as subscriber")
@@ -503,6 +609,31 @@ func (builder *Builder) buildBlockchain(forSubChain, forTest bool) error {
 	return nil
 }
 
+func (builder *Builder) buildBlockExporter(forTest bool) error {
+	cfg := builder.cfg.BlockExporter
+	if forTest || (!cfg.Enabled && builder.exporterSink == nil) {
+		return nil
+	}
+	sink := builder.exporterSink
+	if sink == nil {
+		var err error
+		if sink, err = blockexporter.NewFileSink(cfg.FileSink); err != nil {
+			return errors.Wrap(err, "failed to create file sink of block exporter")
+		}
+	}
+	dbConfig := builder.cfg.DB
+	dbConfig.DbPath = cfg.CheckpointDBPath
+	exporter, err := blockexporter.NewExporter(cfg, builder.cs.blockdao, db.NewBoltDB(dbConfig), sink)
+	if err != nil {
+		return errors.Wrap(err, "failed to create block exporter")
+	}
+	builder.cs.lifecycle.Add(exporter)
+	if err := builder.cs.chain.AddSubscriber(exporter); err != nil {
+		return errors.Wrap(err, "failed to add block exporter as subscriber")
+	}
+	return nil
+}
+
 func (builder *Builder) createBlockchain(forSubChain, forTest bool) blockchain.Blockchain {
 	if builder.cs.chain != nil {
 		return builder.cs.chain
@@ -662,6 +793,19 @@ func (builder *Builder) buildActionSyncer() error {
 	return nil
 }
 
+func (builder *Builder) buildStateSyncer() error {
+	cfg := builder.cfg.BlockSync.StateSync
+	if !cfg.Enabled && len(cfg.ServeDir) == 0 {
+		return nil
+	}
+	p2pAgent := builder.cs.p2pAgent
+	builder.cs.stateSyncer = blocksync.NewStateSyncer(cfg, p2pAgent.ConnectedPeers, p2pAgent.UnicastOutbound)
+	if builder.cs.pendingStateSync != nil {
+		builder.cs.pendingStateSync.syncer = builder.cs.stateSyncer
+	}
+	return nil
+}
+
 func (builder *Builder) registerStakingProtocol() error {
 	if !builder.cfg.Chain.EnableStakingProtocol {
 		return nil
@@ -834,6 +978,12 @@ func (builder *Builder) build(forSubChain, forTest bool) (*ChainService, error)
 	if builder.cs.p2pAgent == nil {
 		builder.cs.p2pAgent = p2p.NewDummyAgent()
 	}
+	if err := builder.buildStateSnapshot(forTest); err != nil {
+		return nil, err
+	}
+	if err := builder.buildContractStakingHistoryDB(forTest); err != nil {
+		return nil, err
+	}
 	if err := builder.buildFactory(forTest); err != nil {
 		return nil, err
 	}
@@ -849,12 +999,24 @@ func (builder *Builder) build(forSubChain, forTest bool) (*ChainService, error)
 	if err := builder.buildContractStakingIndexer(forTest); err != nil {
 		return nil, err
 	}
+	if err := builder.buildTraceIndexer(forTest); err != nil {
+		return nil, err
+	}
+	if err := builder.buildTokenTransferIndexer(forTest); err != nil {
+		return nil, err
+	}
+	if err := builder.buildStakingHistoryIndexer(forTest); err != nil {
+		return nil, err
+	}
 	if err := builder.buildBlockDAO(forTest); err != nil {
 		return nil, err
 	}
 	if err := builder.buildBlockchain(forSubChain, forTest); err != nil {
 		return nil, err
 	}
+	if err := builder.buildBlockExporter(forTest); err != nil {
+		return nil, err
+	}
 	if err := builder.buildBlockTimeCalculator(); err != nil {
 		return nil, err
 	}
@@ -886,6 +1048,9 @@ func (builder *Builder) build(forSubChain, forTest bool) (*ChainService, error)
 	if err := builder.buildActionSyncer(); err != nil {
 		return nil, err
 	}
+	if err := builder.buildStateSyncer(); err != nil {
+		return nil, err
+	}
 	cs := builder.cs
 	builder.cs = nil
 
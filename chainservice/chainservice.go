@@ -34,6 +34,7 @@ import (
 	"github.com/iotexproject/iotex-core/v2/blockindex"
 	"github.com/iotexproject/iotex-core/v2/blockindex/contractstaking"
 	"github.com/iotexproject/iotex-core/v2/blocksync"
+	"github.com/iotexproject/iotex-core/v2/blocksync/statesyncpb"
 	"github.com/iotexproject/iotex-core/v2/consensus"
 	"github.com/iotexproject/iotex-core/v2/nodeinfo"
 	"github.com/iotexproject/iotex-core/v2/p2p"
@@ -73,6 +74,9 @@ type ChainService struct {
 	// TODO: explorer dependency deleted at #1085, need to api related params
 	indexer                  blockindex.Indexer
 	bfIndexer                blockindex.BloomFilterIndexer
+	traceIndexer             blockindex.TraceIndexer
+	tokenTransferIndexer     blockindex.TokenTransferIndexer
+	stakingHistoryIndexer    blockindex.StakingHistoryIndexer
 	candidateIndexer         *poll.CandidateIndexer
 	candBucketsIndexer       *staking.CandidatesBucketsIndexer
 	contractStakingIndexer   *contractstaking.Indexer
@@ -82,6 +86,8 @@ type ChainService struct {
 	apiStats                 *nodestats.APILocalStats
 	blockTimeCalculator      *blockutil.BlockTimeCalculator
 	actionsync               *actsync.ActionSync
+	stateSyncer              *blocksync.StateSyncer
+	pendingStateSync         *pendingStateSync
 	rateLimiters             cache.LRUCache
 	accRateLimitCfg          int
 }
@@ -174,6 +180,51 @@ func (cs *ChainService) HandleSyncRequest(ctx context.Context, peer peer.AddrInf
 	return cs.blocksync.ProcessSyncRequest(ctx, peer, sync.Start, sync.End)
 }
 
+// HandleStateSyncRequest handles incoming state sync request.
+func (cs *ChainService) HandleStateSyncRequest(ctx context.Context, peer peer.AddrInfo, req *statesyncpb.StateSyncRequest) error {
+	if cs.stateSyncer == nil {
+		return nil
+	}
+	return cs.stateSyncer.ProcessRequest(ctx, peer, req)
+}
+
+// HandleStateSyncResponse handles incoming state sync response.
+func (cs *ChainService) HandleStateSyncResponse(ctx context.Context, peer string, resp *statesyncpb.StateSyncResponse) error {
+	if cs.stateSyncer == nil {
+		return nil
+	}
+	return cs.stateSyncer.ProcessResponse(ctx, peer, resp)
+}
+
+// HandleStateSyncBlock handles the block received by block sync while syncing the state snapshot.
+func (cs *ChainService) HandleStateSyncBlock(ctx context.Context, peer string, pbBlock *iotextypes.Block) error {
+	if cs.stateSyncer == nil {
+		return nil
+	}
+	blk, err := block.NewDeserializer(cs.chain.EvmNetworkID()).FromBlockProto(pbBlock)
+	if err != nil {
+		return err
+	}
+	return cs.stateSyncer.ProcessBlock(ctx, peer, blk)
+}
+
+// StateSyncPending returns whether the state snapshot has to be synced from peers before the chain service starts
+func (cs *ChainService) StateSyncPending() bool {
+	return cs.pendingStateSync != nil
+}
+
+// SyncState syncs the state snapshot from peers and imports it, which has to be called before Start
+func (cs *ChainService) SyncState(ctx context.Context) error {
+	if cs.pendingStateSync == nil {
+		return nil
+	}
+	if err := cs.pendingStateSync.sync(ctx); err != nil {
+		return err
+	}
+	cs.pendingStateSync = nil
+	return nil
+}
+
 // HandleConsensusMsg handles incoming consensus message.
 func (cs *ChainService) HandleConsensusMsg(msg *iotextypes.ConsensusMessage) error {
 	return cs.consensus.HandleConsensusMsg(msg)
@@ -247,6 +298,15 @@ func (cs *ChainService) NewAPIServer(cfg api.Config, archive bool) (*api.ServerV
 	if archive {
 		apiServerOptions = append(apiServerOptions, api.WithArchiveSupport())
 	}
+	if cs.traceIndexer != nil {
+		apiServerOptions = append(apiServerOptions, api.WithTraceIndexer(cs.traceIndexer))
+	}
+	if cs.tokenTransferIndexer != nil {
+		apiServerOptions = append(apiServerOptions, api.WithTokenTransferIndexer(cs.tokenTransferIndexer))
+	}
+	if cs.stakingHistoryIndexer != nil {
+		apiServerOptions = append(apiServerOptions, api.WithStakingHistoryIndexer(cs.stakingHistoryIndexer))
+	}
 
 	svr, err := api.NewServerV2(
 		cfg,
@@ -0,0 +1,339 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package chainservice
+
+import (
+	"context"
+	"net/url"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/v2/blockchain"
+	"github.com/iotexproject/iotex-core/v2/blockchain/block"
+	"github.com/iotexproject/iotex-core/v2/blockchain/filedao"
+	"github.com/iotexproject/iotex-core/v2/blocksync"
+	"github.com/iotexproject/iotex-core/v2/config"
+	"github.com/iotexproject/iotex-core/v2/db"
+	"github.com/iotexproject/iotex-core/v2/pkg/log"
+	"github.com/iotexproject/iotex-core/v2/pkg/util/byteutil"
+	"github.com/iotexproject/iotex-core/v2/pkg/util/fileutil"
+	"github.com/iotexproject/iotex-core/v2/state/factory"
+)
+
+const (
+	// ContractStakingSnapshotStore is the name of the contract staking indexer's store in a state snapshot
+	ContractStakingSnapshotStore = "contractStaking"
+	// StakingIndexSnapshotStore is the name of the staking candidates and buckets indexer's store in a state snapshot
+	StakingIndexSnapshotStore = "stakingIndex"
+)
+
+type (
+	// snapshotVerifier verifies the state imported from a snapshot by committing the block following the
+	// snapshot, whose delta state digest has to match the one computed upon the imported state. The node
+	// refuses to start until the imported state is verified. The block comes along with the snapshot, so it
+	// only proves the state is consistent with the snapshot, which is trusted by its digest matching the
+	// configured trusted digest.
+	snapshotVerifier struct {
+		chain blockchain.Blockchain
+		blk   *block.Block
+	}
+
+	// pendingStateSync syncs the state snapshot from peers into an empty node before it starts
+	pendingStateSync struct {
+		cfg      config.Config
+		syncer   *blocksync.StateSyncer
+		verifier *snapshotVerifier
+	}
+)
+
+func (v *snapshotVerifier) Start(context.Context) error {
+	if v.blk == nil {
+		return errors.New("no block to verify the state snapshot")
+	}
+	if tip := v.chain.TipHeight(); tip >= v.blk.Height() {
+		// the next block has been committed, which verified the state
+		return nil
+	} else if tip+1 != v.blk.Height() {
+		return errors.Errorf("chain is at height %d, cannot verify state snapshot with block %d", tip, v.blk.Height())
+	}
+	if err := v.chain.ValidateBlock(v.blk); err != nil {
+		return errors.Wrapf(err, "failed to verify state snapshot with block %d", v.blk.Height())
+	}
+	if err := v.chain.CommitBlock(v.blk); err != nil {
+		return errors.Wrapf(err, "failed to commit block %d", v.blk.Height())
+	}
+	log.L().Info("Verified state snapshot.", zap.Uint64("height", v.blk.Height()-1))
+	return nil
+}
+
+func (s *pendingStateSync) sync(ctx context.Context) error {
+	dir := s.cfg.Chain.StateSnapshotDir
+	_, next, err := s.syncer.Sync(ctx, dir)
+	if err != nil {
+		return errors.Wrap(err, "failed to sync state snapshot")
+	}
+	blk, err := loadStateSnapshot(s.cfg, dir)
+	if err != nil {
+		return err
+	}
+	// the snapshot is verified by the block obtained by block sync
+	if blk.HashBlock() != next.HashBlock() {
+		return errors.Wrapf(factory.ErrSnapshotInvalid, "block %d of the snapshot doesn't match the one obtained by block sync", blk.Height())
+	}
+	s.verifier.blk = next
+	return nil
+}
+
+// buildStateSnapshot imports the state snapshot into an empty state db, and stores the block at the snapshot
+// height into the chain db, so that the node resumes blocksync from the snapshot height. If state sync is
+// enabled and there is no snapshot yet, the snapshot is synced from peers before the chain service starts.
+func (builder *Builder) buildStateSnapshot(forTest bool) error {
+	dir := builder.cfg.Chain.StateSnapshotDir
+	stateSync := builder.cfg.BlockSync.StateSync.Enabled
+	if forTest {
+		return nil
+	}
+	unverified, err := isSnapshotUnverified(builder.cfg)
+	if err != nil {
+		return err
+	}
+	if stateSync {
+		if stateSyncCfg := builder.cfg.BlockSync.StateSync; stateSyncCfg.TrustedHeight == 0 || len(stateSyncCfg.TrustedDigest) == 0 {
+			return errors.New("blockSync.stateSync.trustedHeight and trustedDigest are required to verify the state snapshot downloaded by state sync")
+		}
+	}
+	if len(dir) == 0 {
+		if stateSync {
+			return errors.New("chain.stateSnapshotDir is required to download the state snapshot by state sync")
+		}
+		if unverified {
+			return errors.New("the state imported from a snapshot is not verified yet, chain.stateSnapshotDir is required to verify it")
+		}
+		return nil
+	}
+	if !stateSync && len(builder.cfg.Chain.StateSnapshotTrustedDigest) == 0 {
+		return errors.New("chain.stateSnapshotTrustedDigest is required to verify the state snapshot in chain.stateSnapshotDir")
+	}
+	if _, gateway := builder.cfg.Plugins[config.GatewayPlugin]; gateway {
+		return errors.New("cannot bootstrap from state snapshot with gateway plugin, whose indexers require all the blocks")
+	}
+	builder.snapshotVerifier = &snapshotVerifier{}
+	if !stateSync || fileutil.FileExists(filepath.Join(dir, factory.SnapshotManifestFile)) {
+		blk, err := loadStateSnapshot(builder.cfg, dir)
+		if err != nil {
+			return err
+		}
+		builder.snapshotVerifier.blk = blk
+		return nil
+	}
+	stores, err := openSnapshotStores(builder.cfg)
+	if err != nil {
+		return err
+	}
+	empty, err := isStateStoreEmpty(stores[factory.SnapshotStateStore])
+	closeSnapshotStores(stores)
+	if err != nil || !empty {
+		return err
+	}
+	builder.cs.pendingStateSync = &pendingStateSync{
+		cfg:      builder.cfg,
+		verifier: builder.snapshotVerifier,
+	}
+	return nil
+}
+
+// loadStateSnapshot imports the state snapshot in dir, and returns the block following the snapshot. The
+// snapshot has to match the trusted digest of state sync if it is downloaded by state sync, or the trusted
+// digest of the chain config otherwise.
+func loadStateSnapshot(cfg config.Config, dir string) (*block.Block, error) {
+	manifest, err := factory.ReadSnapshotManifest(dir)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.BlockSync.StateSync.Enabled {
+		err = cfg.BlockSync.StateSync.VerifyTrustedManifest(manifest)
+	} else {
+		err = manifest.VerifyDigest(cfg.Chain.StateSnapshotTrustedDigest)
+	}
+	if err != nil {
+		return nil, err
+	}
+	blks, err := factory.ReadSnapshotBlocks(dir, manifest, block.NewDeserializer(cfg.Chain.EVMNetworkID))
+	if err != nil {
+		return nil, err
+	}
+	if err := importStateSnapshot(cfg, dir, manifest); err != nil {
+		return nil, errors.Wrap(err, "failed to import state snapshot")
+	}
+	if err := putSnapshotBlock(cfg, blks[0]); err != nil {
+		return nil, err
+	}
+	return blks[1], nil
+}
+
+func openSnapshotStores(cfg config.Config) (map[string]db.KVStore, error) {
+	factoryDBCfg := cfg.DB
+	factoryDBCfg.DBType = cfg.Chain.FactoryDBType
+	stateStore, err := db.CreateKVStore(factoryDBCfg, cfg.Chain.TrieDBPath)
+	if err != nil {
+		return nil, err
+	}
+	dbConfig := cfg.DB
+	dbConfig.DbPath = cfg.Chain.ContractStakingIndexDBPath
+	stakingIndexDBConfig := cfg.DB
+	stakingIndexDBConfig.DbPath = cfg.Chain.StakingIndexDBPath
+	stores := map[string]db.KVStore{
+		factory.SnapshotStateStore:   stateStore,
+		ContractStakingSnapshotStore: db.NewBoltDB(dbConfig),
+		StakingIndexSnapshotStore:    db.NewBoltDB(stakingIndexDBConfig),
+	}
+	ctx := context.Background()
+	for name, kv := range stores {
+		if err := kv.Start(ctx); err != nil {
+			delete(stores, name)
+			closeSnapshotStores(stores)
+			return nil, err
+		}
+	}
+	return stores, nil
+}
+
+func closeSnapshotStores(stores map[string]db.KVStore) {
+	for _, kv := range stores {
+		if err := kv.Stop(context.Background()); err != nil {
+			log.L().Warn("Failed to stop store.", zap.Error(err))
+		}
+	}
+}
+
+// isSnapshotUnverified returns whether the state db is imported from a snapshot and not verified yet. The mark of
+// the unverified snapshot is removed once the state db is beyond the snapshot height, as the next block has been
+// committed by the snapshot verifier.
+func isSnapshotUnverified(cfg config.Config) (bool, error) {
+	if len(cfg.Chain.TrieDBPath) == 0 {
+		return false, nil
+	}
+	factoryDBCfg := cfg.DB
+	factoryDBCfg.DBType = cfg.Chain.FactoryDBType
+	stateStore, err := db.CreateKVStore(factoryDBCfg, cfg.Chain.TrieDBPath)
+	if err != nil {
+		return false, err
+	}
+	ctx := context.Background()
+	if err := stateStore.Start(ctx); err != nil {
+		return false, err
+	}
+	defer stateStore.Stop(ctx)
+	v, err := stateStore.Get(factory.AccountKVNamespace, []byte(factory.SnapshotUnverifiedKey))
+	switch errors.Cause(err) {
+	case nil:
+	case db.ErrNotExist, db.ErrBucketNotExist:
+		return false, nil
+	default:
+		return false, err
+	}
+	h, err := stateStore.Get(factory.AccountKVNamespace, []byte(factory.CurrentHeightKey))
+	if err != nil {
+		return false, err
+	}
+	if byteutil.BytesToUint64(h) <= byteutil.BytesToUint64(v) {
+		return true, nil
+	}
+	return false, stateStore.Delete(factory.AccountKVNamespace, []byte(factory.SnapshotUnverifiedKey))
+}
+
+func isStateStoreEmpty(stateStore db.KVStore) (bool, error) {
+	switch _, err := stateStore.Get(factory.AccountKVNamespace, []byte(factory.CurrentHeightKey)); errors.Cause(err) {
+	case nil:
+		return false, nil
+	case db.ErrNotExist, db.ErrBucketNotExist:
+		return true, nil
+	default:
+		return false, err
+	}
+}
+
+func importStateSnapshot(cfg config.Config, dir string, manifest *factory.SnapshotManifest) error {
+	stores, err := openSnapshotStores(cfg)
+	if err != nil {
+		return err
+	}
+	defer closeSnapshotStores(stores)
+	empty, err := isStateStoreEmpty(stores[factory.SnapshotStateStore])
+	if err != nil {
+		return err
+	}
+	if !empty {
+		log.L().Info("State db exists, skip importing state snapshot.")
+		return nil
+	}
+	log.L().Info("Importing state snapshot.", zap.String("dir", dir), zap.Uint64("height", manifest.Height))
+	return factory.ImportSnapshot(dir, manifest, stores)
+}
+
+// putSnapshotBlock creates the chain db starting at the snapshot height, or checks the existing chain db
+// contains the block at the snapshot height
+func putSnapshotBlock(cfg config.Config, blk *block.Block) error {
+	uri, err := url.Parse(cfg.Chain.ChainDBPath)
+	if err != nil {
+		return errors.Wrapf(err, "failed to parse chain db path %s", cfg.Chain.ChainDBPath)
+	}
+	if uri.Scheme != "file" && uri.Scheme != "" {
+		return errors.Errorf("cannot bootstrap from state snapshot with blockdao scheme %s", uri.Scheme)
+	}
+	var (
+		dbConfig = cfg.DB
+		deser    = block.NewDeserializer(cfg.Chain.EVMNetworkID)
+		ctx      = context.Background()
+	)
+	dbConfig.DbPath = uri.Path
+	if fileutil.FileExists(uri.Path) {
+		empty, err := checkSnapshotBlock(dbConfig, deser, blk)
+		if err != nil || !empty {
+			return err
+		}
+		// the empty chain db created at build time is replaced
+	}
+	fd, err := filedao.NewFileDAOWithStart(blk.Height(), dbConfig, deser)
+	if err != nil {
+		return err
+	}
+	if err := fd.Start(ctx); err != nil {
+		return err
+	}
+	defer fd.Stop(ctx)
+	return fd.PutBlock(ctx, blk)
+}
+
+// checkSnapshotBlock checks the existing chain db contains the block at the snapshot height, unless it is empty
+func checkSnapshotBlock(dbConfig db.Config, deser *block.Deserializer, blk *block.Block) (bool, error) {
+	ctx := context.Background()
+	fd, err := filedao.NewFileDAO(dbConfig, deser)
+	if err != nil {
+		return false, err
+	}
+	if err := fd.Start(ctx); err != nil {
+		return false, err
+	}
+	defer fd.Stop(ctx)
+	height, err := fd.Height()
+	if err != nil {
+		return false, err
+	}
+	if height == 0 {
+		return true, nil
+	}
+	h, err := fd.GetBlockHash(blk.Height())
+	if err != nil {
+		return false, errors.Wrapf(err, "chain db doesn't have block %d of the state snapshot", blk.Height())
+	}
+	if h != blk.HashBlock() {
+		return false, errors.Errorf("block %d in chain db doesn't match the state snapshot", blk.Height())
+	}
+	return false, nil
+}
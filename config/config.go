@@ -18,6 +18,7 @@ import (
 	"github.com/iotexproject/iotex-core/v2/api"
 	"github.com/iotexproject/iotex-core/v2/blockchain"
 	"github.com/iotexproject/iotex-core/v2/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/v2/blockexporter"
 	"github.com/iotexproject/iotex-core/v2/blockindex"
 	"github.com/iotexproject/iotex-core/v2/blocksync"
 	"github.com/iotexproject/iotex-core/v2/consensus"
@@ -79,11 +80,12 @@ var (
 			StartSubChainInterval: 10 * time.Second,
 			SystemLogDBPath:       "/var/log",
 		},
-		DB:         db.DefaultConfig,
-		Indexer:    blockindex.DefaultConfig,
-		Genesis:    genesis.Default,
-		NodeInfo:   nodeinfo.DefaultConfig,
-		ActionSync: actsync.DefaultConfig,
+		DB:            db.DefaultConfig,
+		Indexer:       blockindex.DefaultConfig,
+		Genesis:       genesis.Default,
+		NodeInfo:      nodeinfo.DefaultConfig,
+		ActionSync:    actsync.DefaultConfig,
+		BlockExporter: blockexporter.DefaultConfig,
 	}
 
 	// ErrInvalidCfg indicates the invalid config value
@@ -97,6 +99,7 @@ var (
 		ValidateAPI,
 		ValidateActPool,
 		ValidateForkHeights,
+		ValidateBlockExporter,
 	}
 )
 
@@ -136,6 +139,7 @@ type (
 		Genesis            genesis.Genesis                 `yaml:"genesis"`
 		NodeInfo           nodeinfo.Config                 `yaml:"nodeinfo"`
 		ActionSync         actsync.Config                  `yaml:"actionSync"`
+		BlockExporter      blockexporter.Config            `yaml:"blockExporter"`
 	}
 
 	// Validate is the interface of validating the config
@@ -256,11 +260,16 @@ func ValidateRollDPoS(cfg Config) error {
 
 // ValidateArchiveMode validates the state factory setting
 func ValidateArchiveMode(cfg Config) error {
-	if !cfg.Chain.EnableArchiveMode || !cfg.Chain.EnableTrielessStateDB {
+	if !cfg.Chain.EnableArchiveMode {
 		return nil
 	}
-
-	return errors.Wrap(ErrInvalidCfg, "Archive mode is incompatible with trieless state DB")
+	if cfg.Chain.EnableTrielessStateDB {
+		return errors.Wrap(ErrInvalidCfg, "Archive mode is incompatible with trieless state DB")
+	}
+	if cfg.Chain.HistoryWindow > 0 && cfg.Chain.HistoryPruneInterval <= 0 {
+		return errors.Wrap(ErrInvalidCfg, "history prune interval should be greater than 0 with a history window")
+	}
+	return nil
 }
 
 // ValidateAPI validates the api configs
@@ -290,6 +299,17 @@ func ValidateActPool(cfg Config) error {
 	return nil
 }
 
+// ValidateBlockExporter validates the block exporter config
+func ValidateBlockExporter(cfg Config) error {
+	if !cfg.BlockExporter.Enabled {
+		return nil
+	}
+	if cfg.BlockExporter.Format != blockexporter.FormatJSON {
+		return errors.Wrap(ErrInvalidCfg, "the file sink of the block exporter only supports the json format")
+	}
+	return nil
+}
+
 // ValidateForkHeights validates the forked heights
 func ValidateForkHeights(cfg Config) error {
 	hu := cfg.Genesis
@@ -340,6 +360,8 @@ func ValidateForkHeights(cfg Config) error {
 		return errors.Wrap(ErrInvalidCfg, "Tsunami is heigher than Upernavik")
 	case hu.UpernavikBlockHeight > hu.VanuatuBlockHeight:
 		return errors.Wrap(ErrInvalidCfg, "Upernavik is heigher than Vanuatu")
+	case hu.VanuatuBlockHeight > hu.WakeBlockHeight:
+		return errors.Wrap(ErrInvalidCfg, "Vanuatu is heigher than Wake")
 	}
 	return nil
 }
@@ -18,6 +18,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/iotexproject/iotex-core/v2/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/v2/blockexporter"
 )
 
 const (
@@ -253,6 +254,16 @@ func TestValidateRollDPoS(t *testing.T) {
 	)
 }
 
+func TestValidateBlockExporter(t *testing.T) {
+	cfg := Default
+	cfg.BlockExporter.Format = blockexporter.FormatProtobuf
+	require.NoError(t, ValidateBlockExporter(cfg))
+	cfg.BlockExporter.Enabled = true
+	require.EqualError(t, ValidateBlockExporter(cfg), "the file sink of the block exporter only supports the json format: invalid config value")
+	cfg.BlockExporter.Format = blockexporter.FormatJSON
+	require.NoError(t, ValidateBlockExporter(cfg))
+}
+
 func TestValidateArchiveMode(t *testing.T) {
 	cfg := Default
 	cfg.Chain.EnableArchiveMode = true
@@ -268,6 +279,11 @@ func TestValidateArchiveMode(t *testing.T) {
 	cfg.Chain.EnableArchiveMode = false
 	cfg.Chain.EnableTrielessStateDB = false
 	require.NoError(t, errors.Cause(ValidateArchiveMode(cfg)))
+	cfg.Chain.EnableArchiveMode = true
+	cfg.Chain.HistoryWindow = 100
+	require.NoError(t, ValidateArchiveMode(cfg))
+	cfg.Chain.HistoryPruneInterval = 0
+	require.EqualError(t, ValidateArchiveMode(cfg), "history prune interval should be greater than 0 with a history window: invalid config value")
 }
 
 func TestValidateActPool(t *testing.T) {
@@ -395,6 +411,9 @@ func TestValidateForkHeights(t *testing.T) {
 		{
 			"Upernavik", ErrInvalidCfg, "Upernavik is heigher than Vanuatu",
 		},
+		{
+			"Vanuatu", ErrInvalidCfg, "Vanuatu is heigher than Wake",
+		},
 		{
 			"", nil, "",
 		},
@@ -459,6 +478,8 @@ func newTestCfg(fork string) Config {
 		cfg.Genesis.TsunamiBlockHeight = cfg.Genesis.UpernavikBlockHeight + 1
 	case "Upernavik":
 		cfg.Genesis.UpernavikBlockHeight = cfg.Genesis.VanuatuBlockHeight + 1
+	case "Vanuatu":
+		cfg.Genesis.WakeBlockHeight = cfg.Genesis.VanuatuBlockHeight - 1
 	}
 	return cfg
 }
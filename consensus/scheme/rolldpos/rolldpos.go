@@ -43,6 +43,9 @@ type (
 		ToleratedOvertime time.Duration                `yaml:"toleratedOvertime"`
 		Delay             time.Duration                `yaml:"delay"`
 		ConsensusDBPath   string                       `yaml:"consensusDBPath"`
+		// SlashingProtectionDBPath is the path of the db of the messages signed by the block producer, which refuses
+		// to sign a conflicting message in the same round, the protection is disabled if it is empty
+		SlashingProtectionDBPath string `yaml:"slashingProtectionDBPath"`
 	}
 
 	// ChainManager defines the blockchain interface
@@ -424,6 +427,12 @@ func (b *Builder) Build() (*RollDPoS, error) {
 	if b.clock == nil {
 		b.clock = clock.New()
 	}
+	var sp *SlashingProtector
+	if path := b.cfg.Consensus.SlashingProtectionDBPath; len(path) > 0 {
+		spDBConfig := b.cfg.DB
+		spDBConfig.DbPath = path
+		sp = NewSlashingProtector(db.NewBoltDB(spDBConfig))
+	}
 	b.cfg.DB.DbPath = b.cfg.Consensus.ConsensusDBPath
 	ctx, err := NewRollDPoSCtx(
 		consensusfsm.NewConsensusConfig(b.cfg.Consensus.FSM, b.cfg.DardanellesUpgrade, b.cfg.Genesis, b.cfg.Consensus.Delay),
@@ -441,6 +450,7 @@ func (b *Builder) Build() (*RollDPoS, error) {
 		b.priKey,
 		b.clock,
 		b.cfg.Genesis.BeringBlockHeight,
+		sp,
 	)
 	if err != nil {
 		return nil, errors.Wrap(err, "error when constructing consensus context")
@@ -92,6 +92,7 @@ type (
 		broadcastHandler  scheme.Broadcast
 		roundCalc         *roundCalculator
 		eManagerDB        db.KVStore
+		slashingProtector *SlashingProtector
 		toleratedOvertime time.Duration
 
 		encodedAddr string
@@ -120,6 +121,7 @@ func NewRollDPoSCtx(
 	priKey crypto.PrivateKey,
 	clock clock.Clock,
 	beringHeight uint64,
+	slashingProtector *SlashingProtector,
 ) (RDPoSCtx, error) {
 	if chain == nil {
 		return nil, errors.New("chain cannot be nil")
@@ -169,6 +171,7 @@ func NewRollDPoSCtx(
 		clock:             clock,
 		roundCalc:         roundCalc,
 		eManagerDB:        eManagerDB,
+		slashingProtector: slashingProtector,
 		toleratedOvertime: toleratedOvertime,
 	}, nil
 }
@@ -181,12 +184,22 @@ func (ctx *rollDPoSCtx) Start(c context.Context) (err error) {
 		}
 		eManager, err = newEndorsementManager(ctx.eManagerDB, ctx.blockDeserializer)
 	}
+	if ctx.slashingProtector != nil {
+		if err := ctx.slashingProtector.Start(c); err != nil {
+			return errors.Wrap(err, "failed to start the slashing protector")
+		}
+	}
 	ctx.round, err = ctx.roundCalc.NewRoundWithToleration(0, ctx.BlockInterval(0), ctx.clock.Now(), eManager, ctx.toleratedOvertime)
 
 	return err
 }
 
 func (ctx *rollDPoSCtx) Stop(c context.Context) error {
+	if ctx.slashingProtector != nil {
+		if err := ctx.slashingProtector.Stop(c); err != nil {
+			return errors.Wrap(err, "failed to stop the slashing protector")
+		}
+	}
 	if ctx.eManagerDB != nil {
 		return ctx.eManagerDB.Stop(c)
 	}
@@ -544,6 +557,11 @@ func (ctx *rollDPoSCtx) Commit(msg interface{}) (bool, error) {
 		)
 	}
 
+	if ctx.slashingProtector != nil {
+		if err := ctx.slashingProtector.Prune(pendingBlock.Height()); err != nil {
+			ctx.logger().Warn("failed to prune the slashing protection db", zap.Error(err))
+		}
+	}
 	_consensusDurationMtc.WithLabelValues().Set(float64(time.Since(ctx.round.roundStartTime)))
 	if pendingBlock.Height() > 1 {
 		prevBlkProposeTime, err := ctx.chain.BlockProposeTime(pendingBlock.Height() - 1)
@@ -652,6 +670,10 @@ func (ctx *rollDPoSCtx) isDelegate() bool {
 }
 
 func (ctx *rollDPoSCtx) endorseBlockProposal(proposal *blockProposal) (*EndorsedConsensusMessage, error) {
+	blkHash := proposal.block.HashBlock()
+	if err := ctx.checkSlashingProtection(proposal.block.Height(), _blockProposalTopic, blkHash[:]); err != nil {
+		return nil, err
+	}
 	en, err := endorsement.Endorse(ctx.priKey, proposal, ctx.round.StartTime())
 	if err != nil {
 		return nil, err
@@ -659,6 +681,18 @@ func (ctx *rollDPoSCtx) endorseBlockProposal(proposal *blockProposal) (*Endorsed
 	return NewEndorsedConsensusMessage(proposal.block.Height(), proposal, en), nil
 }
 
+// checkSlashingProtection refuses to sign a message conflicting with the one signed in the same round
+func (ctx *rollDPoSCtx) checkSlashingProtection(height uint64, topic uint8, blkHash []byte) error {
+	if ctx.slashingProtector == nil {
+		return nil
+	}
+	err := ctx.slashingProtector.CheckAndRecord(ctx.priKey.PublicKey().Address(), height, ctx.round.Number(), topic, blkHash)
+	if err != nil {
+		ctx.logger().Error("refused to sign the message", zap.Error(err))
+	}
+	return err
+}
+
 func (ctx *rollDPoSCtx) logger() *zap.Logger {
 	return ctx.round.Log(log.Logger("consensus"))
 }
@@ -738,6 +772,9 @@ func (ctx *rollDPoSCtx) newEndorsement(
 	topic ConsensusVoteTopic,
 	timestamp time.Time,
 ) (*EndorsedConsensusMessage, error) {
+	if err := ctx.checkSlashingProtection(ctx.round.Height(), uint8(topic), blkHash); err != nil {
+		return nil, err
+	}
 	vote := NewConsensusVote(
 		blkHash,
 		topic,
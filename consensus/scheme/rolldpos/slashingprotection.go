@@ -0,0 +1,260 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package rolldpos
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"sync"
+
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/v2/db"
+	"github.com/iotexproject/iotex-core/v2/db/batch"
+	"github.com/iotexproject/iotex-core/v2/pkg/util/byteutil"
+)
+
+const (
+	// _signedMessageNS is the namespace of the signed messages, keyed by height, round, topic and signer
+	_signedMessageNS = "sm"
+	// _signedMessageKeyLen is the length of the keys of the signed messages
+	_signedMessageKeyLen = 8 + 4 + 1 + 20
+	// _slashingProtectionRetention is the number of heights below the tip, of which the signed messages are kept
+	_slashingProtectionRetention = 720
+	// _slashingProtectionVersion is the version of the exported slashing protection data
+	_slashingProtectionVersion = 1
+
+	// _blockProposalTopic is the signing topic of block proposals, which follows the ones of consensus votes
+	_blockProposalTopic = 0xff
+)
+
+var (
+	// ErrConflictingMessage is the error that a distinct message of the same height, round and topic was signed
+	ErrConflictingMessage = errors.New("a conflicting message has been signed")
+
+	_signingTopicNames = map[uint8]string{
+		uint8(PROPOSAL):     "PROPOSAL",
+		uint8(LOCK):         "LOCK",
+		uint8(COMMIT):       "COMMIT",
+		_blockProposalTopic: "BLOCK",
+	}
+)
+
+type (
+	// SignedMessage is a message signed by a block producer in consensus
+	SignedMessage struct {
+		Signer string `json:"signer"`
+		Height uint64 `json:"height"`
+		Round  uint32 `json:"round"`
+		Topic  string `json:"topic"`
+		// Hash is the hash of the block in the message
+		Hash string `json:"hash"`
+	}
+
+	// SlashingProtectionData is the exported slashing protection data
+	SlashingProtectionData struct {
+		Version  int              `json:"version"`
+		Messages []*SignedMessage `json:"messages"`
+	}
+
+	// SlashingProtector keeps the messages signed by the block producers in a local db, and refuses to sign a
+	// second distinct message of the same height, round and topic, in case that two nodes with the same key are
+	// active at the same time
+	SlashingProtector struct {
+		kv    db.KVStore
+		mutex sync.Mutex
+	}
+)
+
+// NewSlashingProtector creates a slashing protector on the kv store
+func NewSlashingProtector(kv db.KVStore) *SlashingProtector {
+	return &SlashingProtector{kv: kv}
+}
+
+// Start starts the slashing protector
+func (sp *SlashingProtector) Start(ctx context.Context) error {
+	return sp.kv.Start(ctx)
+}
+
+// Stop stops the slashing protector
+func (sp *SlashingProtector) Stop(ctx context.Context) error {
+	return sp.kv.Stop(ctx)
+}
+
+// CheckAndRecord checks the message to sign against the signed ones, and records it if it doesn't conflict with
+// them. Signing the same message again is allowed
+func (sp *SlashingProtector) CheckAndRecord(signer address.Address, height uint64, round uint32, topic uint8, blkHash []byte) error {
+	sp.mutex.Lock()
+	defer sp.mutex.Unlock()
+	key := signedMessageKey(signer, height, round, topic)
+	value, err := sp.kv.Get(_signedMessageNS, key)
+	switch errors.Cause(err) {
+	case nil:
+		if !bytes.Equal(value, blkHash) {
+			return errors.Wrapf(
+				ErrConflictingMessage,
+				"height %d, round %d, topic %s, signed %x, to sign %x",
+				height, round, _signingTopicNames[topic], value, blkHash,
+			)
+		}
+		return nil
+	case db.ErrNotExist, db.ErrBucketNotExist:
+		return sp.kv.Put(_signedMessageNS, key, blkHash)
+	default:
+		return err
+	}
+}
+
+// Prune deletes the signed messages lower than the retention below the height
+func (sp *SlashingProtector) Prune(height uint64) error {
+	if height <= _slashingProtectionRetention {
+		return nil
+	}
+	sp.mutex.Lock()
+	defer sp.mutex.Unlock()
+	maxKey := byteutil.Uint64ToBytesBigEndian(height - _slashingProtectionRetention - 1)
+	maxKey = append(maxKey, bytes.Repeat([]byte{0xff}, _signedMessageKeyLen-len(maxKey))...)
+	keys, _, err := sp.kv.Filter(_signedMessageNS, func(k, v []byte) bool { return true }, nil, maxKey)
+	switch errors.Cause(err) {
+	case nil:
+	case db.ErrNotExist, db.ErrBucketNotExist:
+		return nil
+	default:
+		return err
+	}
+	b := batch.NewBatch()
+	for _, k := range keys {
+		b.Delete(_signedMessageNS, k, "failed to delete signed message")
+	}
+	return sp.kv.WriteBatch(b)
+}
+
+// Export writes the signed messages to the writer in json
+func (sp *SlashingProtector) Export(w io.Writer) error {
+	sp.mutex.Lock()
+	defer sp.mutex.Unlock()
+	data := SlashingProtectionData{
+		Version:  _slashingProtectionVersion,
+		Messages: []*SignedMessage{},
+	}
+	keys, values, err := sp.kv.Filter(_signedMessageNS, func(k, v []byte) bool { return true }, nil, nil)
+	switch errors.Cause(err) {
+	case nil, db.ErrNotExist, db.ErrBucketNotExist:
+	default:
+		return err
+	}
+	for i, k := range keys {
+		msg, err := decodeSignedMessage(k, values[i])
+		if err != nil {
+			return err
+		}
+		data.Messages = append(data.Messages, msg)
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(&data)
+}
+
+// Import reads the signed messages in json from the reader and records them. Nothing is imported if any of the
+// messages conflicts with a recorded one
+func (sp *SlashingProtector) Import(r io.Reader) error {
+	var data SlashingProtectionData
+	if err := json.NewDecoder(r).Decode(&data); err != nil {
+		return errors.Wrap(err, "failed to decode slashing protection data")
+	}
+	if data.Version != _slashingProtectionVersion {
+		return errors.Errorf("unsupported slashing protection data version %d", data.Version)
+	}
+	sp.mutex.Lock()
+	defer sp.mutex.Unlock()
+	var (
+		b        = batch.NewBatch()
+		imported = make(map[string][]byte)
+	)
+	for _, msg := range data.Messages {
+		key, blkHash, err := encodeSignedMessage(msg)
+		if err != nil {
+			return err
+		}
+		value, ok := imported[string(key)]
+		if !ok {
+			value, err = sp.kv.Get(_signedMessageNS, key)
+			switch errors.Cause(err) {
+			case nil:
+				ok = true
+			case db.ErrNotExist, db.ErrBucketNotExist:
+			default:
+				return err
+			}
+		}
+		if ok {
+			if !bytes.Equal(value, blkHash) {
+				return errors.Wrapf(
+					ErrConflictingMessage,
+					"signer %s, height %d, round %d, topic %s, recorded %x, imported %x",
+					msg.Signer, msg.Height, msg.Round, msg.Topic, value, blkHash,
+				)
+			}
+			continue
+		}
+		imported[string(key)] = blkHash
+		b.Put(_signedMessageNS, key, blkHash, "failed to put signed message")
+	}
+	return sp.kv.WriteBatch(b)
+}
+
+func signedMessageKey(signer address.Address, height uint64, round uint32, topic uint8) []byte {
+	key := make([]byte, 0, _signedMessageKeyLen)
+	key = append(key, byteutil.Uint64ToBytesBigEndian(height)...)
+	key = append(key, byteutil.Uint32ToBytesBigEndian(round)...)
+	key = append(key, topic)
+	return append(key, signer.Bytes()...)
+}
+
+func encodeSignedMessage(msg *SignedMessage) ([]byte, []byte, error) {
+	signer, err := address.FromString(msg.Signer)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "invalid signer %s", msg.Signer)
+	}
+	var topic uint8
+	found := false
+	for t, name := range _signingTopicNames {
+		if name == msg.Topic {
+			topic, found = t, true
+			break
+		}
+	}
+	if !found {
+		return nil, nil, errors.Errorf("invalid topic %s", msg.Topic)
+	}
+	blkHash, err := hex.DecodeString(msg.Hash)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "invalid hash %s", msg.Hash)
+	}
+	return signedMessageKey(signer, msg.Height, msg.Round, topic), blkHash, nil
+}
+
+func decodeSignedMessage(key, value []byte) (*SignedMessage, error) {
+	if len(key) != _signedMessageKeyLen {
+		return nil, errors.Errorf("invalid signed message key %x", key)
+	}
+	signer, err := address.FromBytes(key[13:])
+	if err != nil {
+		return nil, err
+	}
+	return &SignedMessage{
+		Signer: signer.String(),
+		Height: byteutil.BytesToUint64BigEndian(key[:8]),
+		Round:  binary.BigEndian.Uint32(key[8:12]),
+		Topic:  _signingTopicNames[key[12]],
+		Hash:   hex.EncodeToString(value),
+	}, nil
+}
@@ -0,0 +1,116 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package rolldpos
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/v2/db"
+	"github.com/iotexproject/iotex-core/v2/test/identityset"
+	"github.com/iotexproject/iotex-core/v2/testutil"
+)
+
+func newTestSlashingProtector(t *testing.T) *SlashingProtector {
+	require := require.New(t)
+	testPath, err := testutil.PathOfTempFile("test-slashing-protection")
+	require.NoError(err)
+	t.Cleanup(func() { testutil.CleanupPath(testPath) })
+	cfg := db.DefaultConfig
+	cfg.DbPath = testPath
+	sp := NewSlashingProtector(db.NewBoltDB(cfg))
+	require.NoError(sp.Start(context.Background()))
+	t.Cleanup(func() { require.NoError(sp.Stop(context.Background())) })
+	return sp
+}
+
+func TestSlashingProtector(t *testing.T) {
+	require := require.New(t)
+	sp := newTestSlashingProtector(t)
+	signer := identityset.Address(1)
+	hash1 := hash.Hash256b([]byte("block 1"))
+	hash2 := hash.Hash256b([]byte("block 2"))
+
+	t.Run("check and record", func(t *testing.T) {
+		require.NoError(sp.CheckAndRecord(signer, 10, 0, uint8(PROPOSAL), hash1[:]))
+		// signing the same message again is allowed
+		require.NoError(sp.CheckAndRecord(signer, 10, 0, uint8(PROPOSAL), hash1[:]))
+		require.ErrorIs(sp.CheckAndRecord(signer, 10, 0, uint8(PROPOSAL), hash2[:]), ErrConflictingMessage)
+		// a distinct round, topic, height or signer doesn't conflict
+		require.NoError(sp.CheckAndRecord(signer, 10, 1, uint8(PROPOSAL), hash2[:]))
+		require.NoError(sp.CheckAndRecord(signer, 10, 0, uint8(LOCK), hash2[:]))
+		require.NoError(sp.CheckAndRecord(signer, 11, 0, uint8(PROPOSAL), hash2[:]))
+		require.NoError(sp.CheckAndRecord(identityset.Address(2), 10, 0, uint8(PROPOSAL), hash2[:]))
+	})
+
+	t.Run("prune", func(t *testing.T) {
+		require.NoError(sp.CheckAndRecord(signer, 1000, 0, _blockProposalTopic, hash1[:]))
+		require.NoError(sp.Prune(_slashingProtectionRetention + 11))
+		// the messages at height 10 are deleted
+		require.NoError(sp.CheckAndRecord(signer, 10, 0, uint8(PROPOSAL), hash2[:]))
+		require.ErrorIs(sp.CheckAndRecord(signer, 11, 0, uint8(PROPOSAL), hash1[:]), ErrConflictingMessage)
+		require.ErrorIs(sp.CheckAndRecord(signer, 1000, 0, _blockProposalTopic, hash2[:]), ErrConflictingMessage)
+	})
+
+	t.Run("export and import", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(sp.Export(&buf))
+		exported := buf.Bytes()
+		data := SlashingProtectionData{}
+		require.NoError(json.Unmarshal(exported, &data))
+		require.Equal(_slashingProtectionVersion, data.Version)
+		require.Len(data.Messages, 3)
+		require.Equal(&SignedMessage{
+			Signer: signer.String(),
+			Height: 10,
+			Round:  0,
+			Topic:  "PROPOSAL",
+			Hash:   hex.EncodeToString(hash2[:]),
+		}, data.Messages[0])
+
+		sp2 := newTestSlashingProtector(t)
+		require.NoError(sp2.Import(bytes.NewReader(exported)))
+		require.ErrorIs(sp2.CheckAndRecord(signer, 1000, 0, _blockProposalTopic, hash2[:]), ErrConflictingMessage)
+		require.NoError(sp2.CheckAndRecord(signer, 1000, 0, _blockProposalTopic, hash1[:]))
+		// importing the same data again is allowed
+		require.NoError(sp2.Import(bytes.NewReader(exported)))
+
+		// nothing is imported if any of the messages conflicts
+		sp3 := newTestSlashingProtector(t)
+		require.NoError(sp3.CheckAndRecord(signer, 11, 0, uint8(PROPOSAL), hash1[:]))
+		require.ErrorIs(sp3.Import(bytes.NewReader(exported)), ErrConflictingMessage)
+		require.NoError(sp3.CheckAndRecord(signer, 1000, 0, _blockProposalTopic, hash2[:]))
+
+		// conflicting messages in the data
+		data.Messages = append(data.Messages, &SignedMessage{
+			Signer: signer.String(),
+			Height: 10,
+			Round:  0,
+			Topic:  "PROPOSAL",
+			Hash:   "00",
+		})
+		conflicting, err := json.Marshal(&data)
+		require.NoError(err)
+		require.ErrorIs(newTestSlashingProtector(t).Import(bytes.NewReader(conflicting)), ErrConflictingMessage)
+
+		// invalid data
+		data.Version = 2
+		invalid, err := json.Marshal(&data)
+		require.NoError(err)
+		require.ErrorContains(newTestSlashingProtector(t).Import(bytes.NewReader(invalid)), "unsupported slashing protection data version")
+		data.Version = _slashingProtectionVersion
+		data.Messages[0].Topic = "UNKNOWN"
+		invalid, err = json.Marshal(&data)
+		require.NoError(err)
+		require.ErrorContains(newTestSlashingProtector(t).Import(bytes.NewReader(invalid)), "invalid topic")
+	})
+}
@@ -32,3 +32,19 @@ func CreateKVStoreWithCache(cfg Config, dbPath string, cacheSize int) (KVStore,
 
 	return NewKvStoreWithCache(dao, cacheSize), nil
 }
+
+// CreateVersionedDB creates versioned db from config and db path, with the versioned namespaces
+func CreateVersionedDB(cfg Config, dbPath string, vns ...Namespace) (VersionedDB, error) {
+	if len(dbPath) == 0 {
+		return nil, ErrEmptyDBPath
+	}
+	cfg.DbPath = dbPath
+	switch cfg.DBType {
+	case DBPebble:
+		return NewPebbleDBVersioned(cfg, PebbleVnsOption(vns...)), nil
+	case DBBolt:
+		return NewBoltDBVersioned(cfg, VnsOption(vns...)), nil
+	default:
+		return nil, errors.Errorf("unsupported db type %s", cfg.DBType)
+	}
+}
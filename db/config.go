@@ -18,6 +18,8 @@ type Config struct {
 	V2BlocksToSplitDB uint64 `yaml:"v2BlocksToSplitDB"`
 	// Compressor is the compression used on block data, used by new DB file after v1.1.2
 	Compressor string `yaml:"compressor"`
+	// CompressorDict is the path of the zstd dictionary used by Zstd compressor, which is recorded in new DB file
+	CompressorDict string `yaml:"compressorDict"`
 	// CompressLegacy enables gzip compression on block data, used by legacy DB file before v1.1.2
 	CompressLegacy bool `yaml:"compressLegacy"`
 	// SplitDBSize is the config for DB's split file size
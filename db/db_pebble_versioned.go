@@ -0,0 +1,561 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package db
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"maps"
+	"math"
+	"sync"
+	"syscall"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/pkg/errors"
+	bolt "go.etcd.io/bbolt"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/v2/db/batch"
+	"github.com/iotexproject/iotex-core/v2/pkg/log"
+)
+
+// _migrateBatchSize is the number of records written in one batch during migration
+const _migrateBatchSize = 10000
+
+type (
+	// PebbleDBVersioned is VersionedDB implementation based on pebble DB
+	//
+	// It uses the same key encoding as BoltDBVersioned, each version of a key is stored
+	// at (namespace prefix + key + 8-byte version + 1-byte write type), so all versions of
+	// a key are adjacent and sorted by version in the underlying DB
+	PebbleDBVersioned struct {
+		db  *PebbleDB
+		vns map[string]int // map of versioned namespace
+		mu  sync.Mutex     // a versioned write reads the key's last version first
+	}
+
+	// PebbleDBVersionedOption sets option for PebbleDBVersioned
+	PebbleDBVersionedOption func(*PebbleDBVersioned)
+)
+
+// PebbleVnsOption sets the versioned namespaces
+func PebbleVnsOption(ns ...Namespace) PebbleDBVersionedOption {
+	return func(k *PebbleDBVersioned) {
+		for _, v := range ns {
+			k.vns[v.ns] = int(v.keyLen)
+		}
+	}
+}
+
+// NewPebbleDBVersioned instantiates an PebbleDB which implements VersionedDB
+func NewPebbleDBVersioned(cfg Config, opts ...PebbleDBVersionedOption) *PebbleDBVersioned {
+	b := PebbleDBVersioned{
+		db:  NewPebbleDB(cfg),
+		vns: make(map[string]int),
+	}
+	for _, opt := range opts {
+		opt(&b)
+	}
+	return &b
+}
+
+// Start starts the DB
+func (b *PebbleDBVersioned) Start(ctx context.Context) error {
+	if err := b.db.Start(ctx); err != nil {
+		return err
+	}
+	return b.addVersionedNamespace()
+}
+
+// Stop stops the DB
+func (b *PebbleDBVersioned) Stop(ctx context.Context) error {
+	return b.db.Stop(ctx)
+}
+
+func (b *PebbleDBVersioned) addVersionedNamespace() error {
+	for ns, keyLen := range b.vns {
+		vn, err := b.checkNamespace(ns)
+		if errors.Cause(err) == ErrNotExist {
+			// create metadata for namespace
+			if err = b.db.Put(ns, _minKey, (&versionedNamespace{
+				keyLen: uint32(keyLen),
+			}).serialize()); err != nil {
+				return err
+			}
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if vn.keyLen != uint32(keyLen) {
+			return errors.Wrapf(ErrInvalid, "namespace %s already exists with key length = %d, got %d", ns, vn.keyLen, keyLen)
+		}
+	}
+	return nil
+}
+
+// Put writes a <key, value> record
+func (b *PebbleDBVersioned) Put(version uint64, ns string, key, value []byte) error {
+	if !b.db.IsReady() {
+		return ErrDBNotStarted
+	}
+	keyLen, ok := b.vns[ns]
+	if !ok {
+		return b.db.Put(ns, key, value)
+	}
+	// check key length
+	if len(key) != keyLen {
+		return errors.Wrapf(ErrInvalid, "invalid key length, expecting %d, got %d", keyLen, len(key))
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	last, _, err := b.get(b.db.db, math.MaxUint64, ns, key)
+	switch errors.Cause(err) {
+	case nil, ErrDeleted:
+		if version < last {
+			// not allowed to perform write on an earlier version
+			return errors.Wrapf(ErrInvalid, "cannot write at earlier version %d", version)
+		}
+	case ErrNotExist:
+	default:
+		return err
+	}
+	wb := b.db.db.NewBatch()
+	if version == last {
+		if err := wb.Delete(nsKey(ns, keyForDelete(key, version)), nil); err != nil {
+			return errors.Wrapf(err, "failed to delete key %x", key)
+		}
+	}
+	if err := wb.Set(nsKey(ns, keyForWrite(key, version)), value, nil); err != nil {
+		return errors.Wrapf(err, "failed to put key %x", key)
+	}
+	return b.commit(wb)
+}
+
+// Get retrieves the most recent version
+func (b *PebbleDBVersioned) Get(version uint64, ns string, key []byte) ([]byte, error) {
+	if !b.db.IsReady() {
+		return nil, ErrDBNotStarted
+	}
+	keyLen, ok := b.vns[ns]
+	if !ok {
+		return b.db.Get(ns, key)
+	}
+	// check key length
+	if len(key) != keyLen {
+		return nil, errors.Wrapf(ErrInvalid, "invalid key length, expecting %d, got %d", keyLen, len(key))
+	}
+	_, v, err := b.get(b.db.db, version, ns, key)
+	if errors.Cause(err) == ErrDeleted {
+		err = errors.Wrapf(ErrNotExist, "key %x deleted", key)
+	}
+	return v, err
+}
+
+// get returns the last entry of the key at or below the version, reader is either the DB or an indexed batch
+func (b *PebbleDBVersioned) get(reader pebble.Reader, version uint64, ns string, key []byte) (uint64, []byte, error) {
+	iter, err := reader.NewIter(&pebble.IterOptions{
+		// the delete marker at version 0 is the minimum key, same as BoltDBVersioned
+		LowerBound: nsKey(ns, keyForWrite(key, 0)),
+		UpperBound: append(nsKey(ns, keyForWrite(key, version)), 0),
+	})
+	if err != nil {
+		return 0, nil, errors.Wrap(err, "failed to create iterator")
+	}
+	defer func() {
+		if e := iter.Close(); e != nil {
+			log.L().Error("Failed to close iterator", zap.Error(e))
+		}
+	}()
+	if !iter.Last() {
+		return 0, nil, ErrNotExist
+	}
+	isDelete, last := parseKey(iter.Key())
+	if isDelete {
+		return last, nil, ErrDeleted
+	}
+	value := make([]byte, len(iter.Value()))
+	copy(value, iter.Value())
+	return last, value, nil
+}
+
+// Delete deletes a record, if key does not exist, it returns nil
+func (b *PebbleDBVersioned) Delete(version uint64, ns string, key []byte) error {
+	if !b.db.IsReady() {
+		return ErrDBNotStarted
+	}
+	keyLen, ok := b.vns[ns]
+	if !ok {
+		return b.db.Delete(ns, key)
+	}
+	// check key length
+	if len(key) != keyLen {
+		return errors.Wrapf(ErrInvalid, "invalid key length, expecting %d, got %d", keyLen, len(key))
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	last, _, err := b.get(b.db.db, math.MaxUint64, ns, key)
+	switch errors.Cause(err) {
+	case nil, ErrDeleted:
+	case ErrNotExist:
+		return nil
+	default:
+		return err
+	}
+	if version < last {
+		// not allowed to perform delete on an earlier version
+		return errors.Wrapf(ErrInvalid, "cannot delete at earlier version %d", version)
+	}
+	wb := b.db.db.NewBatch()
+	if err := wb.Set(nsKey(ns, keyForDelete(key, version)), nil, nil); err != nil {
+		return errors.Wrapf(err, "failed to delete key %x", key)
+	}
+	if version == last {
+		if err := wb.Delete(nsKey(ns, keyForWrite(key, version)), nil); err != nil {
+			return errors.Wrapf(err, "failed to delete key %x", key)
+		}
+	}
+	return b.commit(wb)
+}
+
+// Version returns the key's most recent version
+func (b *PebbleDBVersioned) Version(ns string, key []byte) (uint64, error) {
+	if !b.db.IsReady() {
+		return 0, ErrDBNotStarted
+	}
+	keyLen, ok := b.vns[ns]
+	if !ok {
+		return 0, errors.Errorf("namespace %s is non-versioned", ns)
+	}
+	// check key length
+	if len(key) != keyLen {
+		return 0, errors.Wrapf(ErrInvalid, "invalid key length, expecting %d, got %d", keyLen, len(key))
+	}
+	last, _, err := b.get(b.db.db, math.MaxUint64, ns, key)
+	if isNotExist(err) {
+		// key not yet written
+		err = errors.Wrapf(ErrNotExist, "key = %x doesn't exist", key)
+	}
+	return last, err
+}
+
+// Filter returns <k, v> pair in a bucket that meet the condition, for a versioned namespace
+// the value of each key at the version is checked
+func (b *PebbleDBVersioned) Filter(version uint64, ns string, cond Condition, minKey, maxKey []byte) ([][]byte, [][]byte, error) {
+	if !b.db.IsReady() {
+		return nil, nil, ErrDBNotStarted
+	}
+	keyLen, ok := b.vns[ns]
+	if !ok {
+		return b.db.Filter(ns, cond, minKey, maxKey)
+	}
+	var (
+		keys, vals [][]byte
+		curr       []byte // the key being processed
+		value      []byte // the value of the key at the version, nil if deleted or not exist yet
+	)
+	emit := func() {
+		if value != nil && cond(curr, value) {
+			keys = append(keys, curr)
+			vals = append(vals, value)
+		}
+	}
+	if err := b.forEach(ns, keyLen, minKey, func(k, v []byte) (bool, error) {
+		if !bytes.Equal(curr, k[:keyLen]) {
+			if curr != nil {
+				emit()
+			}
+			if len(maxKey) > 0 && bytes.Compare(k[:keyLen], maxKey) > 0 {
+				curr = nil
+				return false, nil
+			}
+			curr = append([]byte{}, k[:keyLen]...)
+			value = nil
+		}
+		if isDelete, last := parseKey(k); last <= version {
+			if isDelete {
+				value = nil
+			} else {
+				value = append([]byte{}, v...)
+			}
+		}
+		return true, nil
+	}); err != nil {
+		return nil, nil, err
+	}
+	if curr != nil {
+		emit()
+	}
+	if len(keys) == 0 {
+		return nil, nil, errors.Wrap(ErrNotExist, "filter returns no match")
+	}
+	return keys, vals, nil
+}
+
+// forEach iterates over the entries of versioned keys no less than minKey in the namespace,
+// the iteration stops if fn returns false
+func (b *PebbleDBVersioned) forEach(ns string, keyLen int, minKey []byte, fn func(k, v []byte) (bool, error)) error {
+	iter, err := b.db.db.NewIter(&pebble.IterOptions{
+		LowerBound: nsKey(ns, minKey),
+		UpperBound: prefixUpperBound(nsToPrefix(ns)),
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to create iterator")
+	}
+	defer func() {
+		if e := iter.Close(); e != nil {
+			log.L().Error("Failed to close iterator", zap.Error(e))
+		}
+	}()
+	for iter.First(); iter.Valid(); iter.Next() {
+		k, err := decodeKey(iter.Key())
+		if err != nil {
+			return err
+		}
+		if len(k) != keyLen+9 {
+			// namespace metadata
+			continue
+		}
+		next, err := fn(k, iter.Value())
+		if err != nil {
+			return err
+		}
+		if !next {
+			break
+		}
+	}
+	return iter.Error()
+}
+
+// CommitBatch write a batch to DB, where the batch can contain keys for
+// both versioned and non-versioned namespace
+func (b *PebbleDBVersioned) CommitBatch(version uint64, kvsb batch.KVStoreBatch) error {
+	if !b.db.IsReady() {
+		return ErrDBNotStarted
+	}
+	ve, nve, err := dedup(b.vns, kvsb)
+	if err != nil {
+		return errors.Wrapf(err, "PebbleDBVersioned failed to write batch")
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	// the indexed batch can read its own writes
+	wb := b.db.db.NewIndexedBatch()
+	// keep order of the writes same as the original batch
+	for i := len(ve) - 1; i >= 0; i-- {
+		write := ve[i]
+		// wrong-size key should be caught in dedup(), but check anyway
+		if b.vns[write.Namespace()] != len(write.Key()) {
+			panic(fmt.Sprintf("PebbleDBVersioned.CommitBatch(), expect vnsize[%s] = %d, got %d", write.Namespace(), b.vns[write.Namespace()], len(write.Key())))
+		}
+		if err := b.writeVersionedEntry(wb, version, write); err != nil {
+			return err
+		}
+	}
+	// write non-versioned keys
+	for i := len(nve) - 1; i >= 0; i-- {
+		write := nve[i]
+		switch write.WriteType() {
+		case batch.Put:
+			err = wb.Set(nsKey(write.Namespace(), write.Key()), write.Value(), nil)
+		case batch.Delete:
+			err = wb.Delete(nsKey(write.Namespace(), write.Key()), nil)
+		}
+		if err != nil {
+			return errors.Wrap(err, write.Error())
+		}
+	}
+	return b.commit(wb)
+}
+
+func (b *PebbleDBVersioned) writeVersionedEntry(wb *pebble.Batch, version uint64, ve *batch.WriteInfo) error {
+	var (
+		ns       = ve.Namespace()
+		key      = ve.Key()
+		notexist bool
+	)
+	last, _, err := b.get(wb, math.MaxUint64, ns, key)
+	switch errors.Cause(err) {
+	case nil, ErrDeleted:
+	case ErrNotExist:
+		notexist = true
+	default:
+		return err
+	}
+	switch ve.WriteType() {
+	case batch.Put:
+		if !notexist && version <= last {
+			// not allowed to perform write on an earlier version
+			return errors.Wrapf(ErrInvalid, "cannot write at earlier version %d", version)
+		}
+		if err := wb.Set(nsKey(ns, keyForWrite(key, version)), ve.Value(), nil); err != nil {
+			return errors.Wrap(err, ve.Error())
+		}
+	case batch.Delete:
+		if notexist {
+			return nil
+		}
+		if version < last {
+			// not allowed to perform delete on an earlier version
+			return errors.Wrapf(ErrInvalid, "cannot delete at earlier version %d", version)
+		}
+		if err := wb.Set(nsKey(ns, keyForDelete(key, version)), nil, nil); err != nil {
+			return errors.Wrap(err, ve.Error())
+		}
+		if version == last {
+			if err := wb.Delete(nsKey(ns, keyForWrite(key, version)), nil); err != nil {
+				return errors.Wrap(err, ve.Error())
+			}
+		}
+	}
+	return nil
+}
+
+// Prune removes the history of versioned keys which is not needed to read at the given
+// version or any later version. After pruning, reading at an earlier version is not supported.
+// The entries are pruned batch by batch, each committed along with the progress, so an
+// interrupted pruning at the same version resumes from where it stopped
+func (b *PebbleDBVersioned) Prune(version uint64) error {
+	if !b.db.IsReady() {
+		return ErrDBNotStarted
+	}
+	progress, err := b.pruneProgress()
+	if err != nil {
+		return err
+	}
+	nss := sortedNamespaces(b.vns)
+	i, next := progress.resumeFrom(version, nss)
+	for ; i < len(nss); i++ {
+		for {
+			if next, err = b.pruneBatch(version, nss[i], next); err != nil {
+				return err
+			}
+			if next == nil {
+				break
+			}
+		}
+	}
+	return b.db.Delete(_pruneProgressNS, _pruneProgressKey)
+}
+
+func (b *PebbleDBVersioned) pruneProgress() (*pruneProgress, error) {
+	data, err := b.db.Get(_pruneProgressNS, _pruneProgressKey)
+	if err != nil {
+		if isNotExist(errors.Cause(err)) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return deserializePruneProgress(data)
+}
+
+// pruneBatch prunes the entries of the namespace starting from the entry, up to _pruneBatchSize entries, and
+// commits the progress in the same batch. It returns the entry to continue from, or nil if the namespace is done
+func (b *PebbleDBVersioned) pruneBatch(version uint64, ns string, from []byte) ([]byte, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	var (
+		wb      = b.db.db.NewBatch()
+		p       = historyPruner{version: version, keyLen: b.vns[ns]}
+		next    []byte
+		scanned int
+	)
+	if err := b.forEach(ns, p.keyLen, from, func(k, _ []byte) (bool, error) {
+		if scanned >= _pruneBatchSize && p.isNewKey(k) {
+			// the entries of a key are pruned in the same batch
+			next = append([]byte{}, k...)
+			return false, nil
+		}
+		scanned++
+		if e := p.visit(k); e != nil {
+			return true, wb.Delete(nsKey(ns, e), nil)
+		}
+		return true, nil
+	}); err != nil {
+		return nil, err
+	}
+	progress := &pruneProgress{version: version, ns: ns, next: next}
+	if err := wb.Set(nsKey(_pruneProgressNS, _pruneProgressKey), progress.serialize(), nil); err != nil {
+		return nil, err
+	}
+	return next, b.commit(wb)
+}
+
+func (b *PebbleDBVersioned) commit(wb *pebble.Batch) error {
+	if err := wb.Commit(nil); err != nil {
+		if errors.Is(err, syscall.ENOSPC) {
+			log.L().Fatal("PebbleDBVersioned failed to write batch", zap.Error(err))
+		}
+		return errors.Wrap(ErrIO, err.Error())
+	}
+	return nil
+}
+
+func (b *PebbleDBVersioned) checkNamespace(ns string) (*versionedNamespace, error) {
+	data, err := b.db.Get(ns, _minKey)
+	if err != nil {
+		return nil, err
+	}
+	return deserializeVersionedNamespace(data)
+}
+
+// MigrateBoltDBVersioned copies all records of a BoltDBVersioned into a PebbleDBVersioned, both
+// DBs should have been started with the same versioned namespaces. The records are copied as is,
+// since both DBs encode the versioned keys in the same way
+func MigrateBoltDBVersioned(src *BoltDBVersioned, dst *PebbleDBVersioned) error {
+	if !src.db.IsReady() || !dst.db.IsReady() {
+		return ErrDBNotStarted
+	}
+	if !maps.Equal(src.vns, dst.vns) {
+		return errors.Wrap(ErrInvalid, "versioned namespaces of the source and the destination don't match")
+	}
+	dst.mu.Lock()
+	defer dst.mu.Unlock()
+	if err := src.db.db.View(func(tx *bolt.Tx) error {
+		return tx.ForEach(func(name []byte, bucket *bolt.Bucket) error {
+			var (
+				ns    = string(name)
+				wb    = dst.db.db.NewBatch()
+				count int
+			)
+			if err := bucket.ForEach(func(k, v []byte) error {
+				if err := wb.Set(nsKey(ns, k), v, nil); err != nil {
+					return err
+				}
+				if count++; count%_migrateBatchSize == 0 {
+					if err := dst.commit(wb); err != nil {
+						return err
+					}
+					wb = dst.db.db.NewBatch()
+				}
+				return nil
+			}); err != nil {
+				return errors.Wrapf(err, "failed to migrate namespace %s", ns)
+			}
+			log.L().Info("Migrated namespace.", zap.String("namespace", ns), zap.Int("records", count))
+			return dst.commit(wb)
+		})
+	}); err != nil {
+		return err
+	}
+	// verify the metadata of versioned namespaces
+	return dst.addVersionedNamespace()
+}
+
+// prefixUpperBound returns the smallest key larger than all keys with the prefix
+func prefixUpperBound(prefix []byte) []byte {
+	end := make([]byte, len(prefix))
+	copy(end, prefix)
+	for i := len(end) - 1; i >= 0; i-- {
+		end[i]++
+		if end[i] != 0 {
+			return end[:i+1]
+		}
+	}
+	// the prefix is all 0xff, no upper bound
+	return nil
+}
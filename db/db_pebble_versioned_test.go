@@ -0,0 +1,251 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package db
+
+import (
+	"context"
+	"math/rand"
+	"path/filepath"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/v2/db/batch"
+)
+
+func TestPebbleDBVersioned(t *testing.T) {
+	r := require.New(t)
+	cfg := DefaultConfig
+	cfg.DBType = DBPebble
+	vdb, err := CreateVersionedDB(cfg, t.TempDir(), NewNamespace(_bucket1, uint32(len(_k2))))
+	r.NoError(err)
+	db := vdb.(*PebbleDBVersioned)
+	ctx := context.Background()
+	r.NoError(db.Start(ctx))
+	defer func() {
+		r.NoError(db.Stop(ctx))
+	}()
+
+	// namespace created
+	vn, err := db.checkNamespace(_bucket1)
+	r.NoError(err)
+	r.EqualValues(len(_k2), vn.keyLen)
+	r.ErrorContains(db.Put(1, _bucket1, _k10, _v1), "invalid key length, expecting 5, got 6: invalid input")
+	r.NoError(db.Put(0, _bucket1, _k2, _v2))
+	r.NoError(db.Put(1, _bucket1, _k2, _v1))
+	r.NoError(db.Put(3, _bucket1, _k2, _v3))
+	r.NoError(db.Put(6, _bucket1, _k2, _v2))
+	r.NoError(db.Put(2, _bucket1, _k4, _v2))
+	r.NoError(db.Put(4, _bucket1, _k4, _v1))
+	r.NoError(db.Put(7, _bucket1, _k4, _v3))
+	r.ErrorContains(db.Put(3, _bucket1, _k2, _v4), "cannot write at earlier version 3: invalid input")
+	r.NoError(db.Delete(8, _bucket1, _k2))
+	r.ErrorContains(db.Delete(7, _bucket1, _k2), "cannot delete at earlier version 7: invalid input")
+	r.NoError(db.Delete(8, _bucket1, _k3))
+	r.NoError(db.Put(1, _bucket2, _k1, _v1))
+	for _, e := range []versionTest{
+		{_bucket2, _k1, _v1, 0, ""}, // non-versioned namespace
+		{_bucket1, _k1, nil, 0, _errNotExist},
+		{_bucket1, _k2, _v2, 0, ""},
+		{_bucket1, _k2, _v1, 1, ""},
+		{_bucket1, _k2, _v1, 2, ""},
+		{_bucket1, _k2, _v3, 3, ""},
+		{_bucket1, _k2, _v2, 7, ""},
+		{_bucket1, _k2, nil, 8, _errDeleted},
+		{_bucket1, _k3, nil, 8, _errNotExist},
+		{_bucket1, _k4, nil, 1, _errNotExist}, // before first write version
+		{_bucket1, _k4, _v2, 3, ""},
+		{_bucket1, _k4, _v1, 6, ""},
+		{_bucket1, _k4, _v3, 7, ""},
+		{_bucket1, _k4, _v3, 9, ""},
+		{_bucket1, _k10, nil, 0, ErrInvalid.Error()},
+	} {
+		value, err := db.Get(e.height, e.ns, e.k)
+		if len(e.err) == 0 {
+			r.NoError(err)
+		} else {
+			r.ErrorContains(err, e.err)
+		}
+		r.Equal(e.v, value)
+	}
+	v, err := db.Version(_bucket1, _k2)
+	r.Equal(ErrDeleted, errors.Cause(err))
+	r.EqualValues(8, v)
+	v, err = db.Version(_bucket1, _k4)
+	r.NoError(err)
+	r.EqualValues(7, v)
+	_, err = db.Version(_bucket1, _k1)
+	r.Equal(ErrNotExist, errors.Cause(err))
+	_, err = db.Version(_bucket2, _k1)
+	r.ErrorContains(err, "namespace test_ns2 is non-versioned")
+
+	// filter at version
+	all := func([]byte, []byte) bool { return true }
+	for _, e := range []struct {
+		version    uint64
+		keys, vals [][]byte
+	}{
+		{1, [][]byte{_k2}, [][]byte{_v1}},
+		{2, [][]byte{_k2, _k4}, [][]byte{_v1, _v2}},
+		{7, [][]byte{_k2, _k4}, [][]byte{_v2, _v3}},
+		{8, [][]byte{_k4}, [][]byte{_v3}},
+	} {
+		keys, vals, err := db.Filter(e.version, _bucket1, all, nil, nil)
+		r.NoError(err)
+		r.Equal(e.keys, keys)
+		r.Equal(e.vals, vals)
+	}
+	keys, _, err := db.Filter(7, _bucket1, all, _k3, nil)
+	r.NoError(err)
+	r.Equal([][]byte{_k4}, keys)
+	keys, _, err = db.Filter(7, _bucket1, all, nil, _k3)
+	r.NoError(err)
+	r.Equal([][]byte{_k2}, keys)
+	_, _, err = db.Filter(0, _bucket1, func(_, v []byte) bool { return false }, nil, nil)
+	r.Equal(ErrNotExist, errors.Cause(err))
+}
+
+func TestPebbleDBVersionedSameAsBolt(t *testing.T) {
+	r := require.New(t)
+	var (
+		ctx  = context.Background()
+		path = t.TempDir()
+		ns   = NewNamespace(_bucket1, uint32(len(_k2)))
+		keys = [][]byte{_k1, _k2, _k3, _k4, _k5}
+		vals = [][]byte{_v1, _v2, _v3, _v4}
+		cfg  = DefaultConfig
+		dbs  = make([]VersionedDB, 2)
+		err  error
+	)
+	for i, dbType := range []string{DBBolt, DBPebble} {
+		cfg.DBType = dbType
+		dbs[i], err = CreateVersionedDB(cfg, filepath.Join(path, dbType), ns)
+		r.NoError(err)
+		r.NoError(dbs[i].Start(ctx))
+		defer dbs[i].Stop(ctx)
+	}
+
+	// apply the same random writes to both DBs
+	rnd := rand.New(rand.NewSource(0))
+	const maxVersion = 50
+	for version := uint64(1); version <= maxVersion; version++ {
+		b := batch.NewBatch()
+		for i := 0; i < 4; i++ {
+			k := keys[rnd.Intn(len(keys))]
+			if rnd.Intn(3) == 0 {
+				b.Delete(_bucket1, k, "")
+			} else {
+				b.Put(_bucket1, k, vals[rnd.Intn(len(vals))], "")
+			}
+		}
+		for _, db := range dbs {
+			r.NoError(db.CommitBatch(version, b))
+		}
+		if version%5 == 0 {
+			k := keys[rnd.Intn(len(keys))]
+			for _, db := range dbs {
+				r.NoError(db.Delete(version, _bucket1, k))
+			}
+		}
+	}
+	check := func(from uint64) {
+		for _, k := range keys {
+			for version := from; version <= maxVersion+1; version++ {
+				v0, err0 := dbs[0].Get(version, _bucket1, k)
+				v1, err1 := dbs[1].Get(version, _bucket1, k)
+				r.Equal(errors.Cause(err0), errors.Cause(err1))
+				r.Equal(v0, v1)
+			}
+			v0, err0 := dbs[0].Version(_bucket1, k)
+			v1, err1 := dbs[1].Version(_bucket1, k)
+			r.Equal(errors.Cause(err0), errors.Cause(err1))
+			r.Equal(v0, v1)
+		}
+	}
+	check(0)
+	// prune in small batches
+	batchSize := _pruneBatchSize
+	_pruneBatchSize = 3
+	defer func() {
+		_pruneBatchSize = batchSize
+	}()
+	for _, db := range dbs {
+		r.NoError(db.Prune(maxVersion / 2))
+	}
+	check(maxVersion / 2)
+}
+
+func TestMigrateBoltDBVersioned(t *testing.T) {
+	r := require.New(t)
+	var (
+		ctx  = context.Background()
+		path = t.TempDir()
+		ns   = NewNamespace(_bucket1, uint32(len(_k2)))
+		cfg  = DefaultConfig
+	)
+	cfg.DbPath = filepath.Join(path, DBBolt)
+	src := NewBoltDBVersioned(cfg, VnsOption(ns))
+	r.NoError(src.Start(ctx))
+	r.NoError(src.Put(1, _bucket1, _k2, _v1))
+	r.NoError(src.Put(3, _bucket1, _k2, _v3))
+	r.NoError(src.Delete(5, _bucket1, _k2))
+	r.NoError(src.Put(2, _bucket1, _k4, _v2))
+	r.NoError(src.Put(0, _bucket2, _k1, _v1))
+	cfg.DbPath = filepath.Join(path, DBPebble)
+	dst := NewPebbleDBVersioned(cfg, PebbleVnsOption(ns))
+	r.NoError(dst.Start(ctx))
+	defer func() {
+		r.NoError(dst.Stop(ctx))
+	}()
+	r.Equal(ErrDBNotStarted, MigrateBoltDBVersioned(NewBoltDBVersioned(cfg), dst))
+	r.NoError(MigrateBoltDBVersioned(src, dst))
+	r.NoError(src.Stop(ctx))
+
+	for _, e := range []versionTest{
+		{_bucket1, _k2, nil, 0, _errNotExist},
+		{_bucket1, _k2, _v1, 1, ""},
+		{_bucket1, _k2, _v3, 4, ""},
+		{_bucket1, _k2, nil, 5, _errDeleted},
+		{_bucket1, _k4, _v2, 2, ""},
+		{_bucket2, _k1, _v1, 0, ""},
+	} {
+		value, err := dst.Get(e.height, e.ns, e.k)
+		if len(e.err) == 0 {
+			r.NoError(err)
+		} else {
+			r.ErrorContains(err, e.err)
+		}
+		r.Equal(e.v, value)
+	}
+	// the migrated DB continues to work
+	r.NoError(dst.Put(6, _bucket1, _k2, _v4))
+	value, err := dst.Get(6, _bucket1, _k2)
+	r.NoError(err)
+	r.Equal(_v4, value)
+
+	// versioned namespaces are compared
+	cfg.DbPath = filepath.Join(path, "another")
+	another := NewPebbleDBVersioned(cfg, PebbleVnsOption(NewNamespace(_bucket1, uint32(len(_k10)))))
+	r.NoError(another.Start(ctx))
+	defer func() {
+		r.NoError(another.Stop(ctx))
+	}()
+	cfg.DbPath = filepath.Join(path, DBBolt)
+	src = NewBoltDBVersioned(cfg, VnsOption(ns))
+	r.NoError(src.Start(ctx))
+	defer func() {
+		r.NoError(src.Stop(ctx))
+	}()
+	r.ErrorContains(MigrateBoltDBVersioned(src, another), "versioned namespaces of the source and the destination don't match")
+	cfg.DbPath = filepath.Join(path, "unversioned")
+	unversioned := NewPebbleDBVersioned(cfg)
+	r.NoError(unversioned.Start(ctx))
+	defer func() {
+		r.NoError(unversioned.Stop(ctx))
+	}()
+	r.ErrorContains(MigrateBoltDBVersioned(src, unversioned), "versioned namespaces of the source and the destination don't match")
+}
@@ -26,6 +26,12 @@ import (
 var (
 	ErrDeleted = errors.New("deleted in DB")
 	_minKey    = []byte{0} // the minimum key, used to store namespace's metadata
+
+	// _pruneBatchSize is the max number of entries scanned in a batch of pruning
+	_pruneBatchSize = 10000
+	// _pruneProgressNS keeps the progress of an interrupted pruning
+	_pruneProgressNS  = "VersionedPruneProgress"
+	_pruneProgressKey = []byte("progress")
 )
 
 type (
@@ -46,6 +52,12 @@ type (
 
 		// Version returns the key's most recent version
 		Version(string, []byte) (uint64, error)
+
+		// CommitBatch writes a batch at the version
+		CommitBatch(uint64, batch.KVStoreBatch) error
+
+		// Prune removes the history which is not needed to read at the version or later
+		Prune(uint64) error
 	}
 
 	// BoltDBVersioned is KvVersioned implementation based on bolt DB
@@ -61,6 +73,11 @@ type (
 	}
 )
 
+// NewNamespace returns a versioned namespace with the name and key length
+func NewNamespace(ns string, keyLen uint32) Namespace {
+	return Namespace{ns: ns, keyLen: keyLen}
+}
+
 // BoltDBVersionedOption sets option for BoltDBVersioned
 type BoltDBVersionedOption func(*BoltDBVersioned)
 
@@ -255,6 +272,94 @@ func (b *BoltDBVersioned) Version(ns string, key []byte) (uint64, error) {
 	return last, err
 }
 
+// Prune removes the history of versioned keys which is not needed to read at the given
+// version or any later version. After pruning, reading at an earlier version is not supported.
+// The entries are pruned batch by batch, each in its own transaction along with the progress, so
+// an interrupted pruning at the same version resumes from where it stopped
+func (b *BoltDBVersioned) Prune(version uint64) error {
+	if !b.db.IsReady() {
+		return ErrDBNotStarted
+	}
+	progress, err := b.pruneProgress()
+	if err != nil {
+		return err
+	}
+	nss := sortedNamespaces(b.vns)
+	i, next := progress.resumeFrom(version, nss)
+	for ; i < len(nss); i++ {
+		for {
+			if next, err = b.pruneBatch(version, nss[i], next); err != nil {
+				return errors.Wrap(ErrIO, err.Error())
+			}
+			if next == nil {
+				break
+			}
+		}
+	}
+	return b.db.Delete(_pruneProgressNS, _pruneProgressKey)
+}
+
+func (b *BoltDBVersioned) pruneProgress() (*pruneProgress, error) {
+	data, err := b.db.Get(_pruneProgressNS, _pruneProgressKey)
+	if err != nil {
+		if isNotExist(errors.Cause(err)) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return deserializePruneProgress(data)
+}
+
+// pruneBatch prunes the entries of the namespace starting from the entry, up to _pruneBatchSize entries, and
+// writes the progress in the same transaction. It returns the entry to continue from, or nil if the namespace
+// is done
+func (b *BoltDBVersioned) pruneBatch(version uint64, ns string, from []byte) ([]byte, error) {
+	var next []byte
+	err := b.db.db.Update(func(tx *bolt.Tx) error {
+		next = nil
+		if bucket := tx.Bucket([]byte(ns)); bucket != nil {
+			var (
+				p        = historyPruner{version: version, keyLen: b.vns[ns]}
+				prunable [][]byte
+				scanned  int
+				c        = bucket.Cursor()
+				k        []byte
+			)
+			if from == nil {
+				k, _ = c.First()
+			} else {
+				k, _ = c.Seek(from)
+			}
+			for ; k != nil; k, _ = c.Next() {
+				if len(k) != p.keyLen+9 {
+					// namespace metadata
+					continue
+				}
+				if scanned >= _pruneBatchSize && p.isNewKey(k) {
+					// the entries of a key are pruned in the same batch
+					next = append([]byte{}, k...)
+					break
+				}
+				scanned++
+				if e := p.visit(k); e != nil {
+					prunable = append(prunable, e)
+				}
+			}
+			for _, k := range prunable {
+				if err := bucket.Delete(k); err != nil {
+					return err
+				}
+			}
+		}
+		bucket, err := tx.CreateBucketIfNotExists([]byte(_pruneProgressNS))
+		if err != nil {
+			return err
+		}
+		return bucket.Put(_pruneProgressKey, (&pruneProgress{version: version, ns: ns, next: next}).serialize())
+	})
+	return next, err
+}
+
 // Filter returns <k, v> pair in a bucket that meet the condition
 func (b *BoltDBVersioned) Filter(version uint64, ns string, cond Condition, minKey, maxKey []byte) ([][]byte, [][]byte, error) {
 	if _, ok := b.vns[ns]; ok {
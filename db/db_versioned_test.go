@@ -588,3 +588,122 @@ func TestCommitBatch(t *testing.T) {
 	b.Put(_bucket1, _k2, _v1, "test")
 	r.ErrorIs(db.CommitBatch(4, b), ErrInvalid)
 }
+
+func TestPrune(t *testing.T) {
+	r := require.New(t)
+	testPath, err := testutil.PathOfTempFile("test-version")
+	r.NoError(err)
+	defer func() {
+		testutil.CleanupPath(testPath)
+	}()
+
+	cfg := DefaultConfig
+	cfg.DbPath = testPath
+	db := NewBoltDBVersioned(cfg, VnsOption(Namespace{_bucket1, uint32(len(_k2))}))
+	ctx := context.Background()
+	r.NoError(db.Start(ctx))
+	defer func() {
+		db.Stop(ctx)
+	}()
+
+	r.NoError(db.Put(1, _bucket1, _k2, _v1))
+	r.NoError(db.Put(3, _bucket1, _k2, _v3))
+	r.NoError(db.Put(6, _bucket1, _k2, _v2))
+	r.NoError(db.Put(2, _bucket1, _k4, _v2))
+	r.NoError(db.Delete(4, _bucket1, _k4))
+	r.NoError(db.Put(7, _bucket1, _k4, _v3))
+	r.NoError(db.Put(1, _bucket1, _k1, _v1))
+	r.NoError(db.Delete(3, _bucket1, _k1))
+	r.NoError(db.Put(2, _bucket1, _k3, _v3))
+	// non-versioned namespace is not affected
+	r.NoError(db.Put(1, _bucket2, _k1, _v1))
+	r.NoError(db.Prune(5))
+
+	for _, e := range []versionTest{
+		{_bucket1, _k1, nil, 5, _errNotExist},
+		{_bucket1, _k1, nil, 8, _errNotExist},
+		{_bucket1, _k2, _v3, 5, ""},
+		{_bucket1, _k2, _v2, 6, ""},
+		{_bucket1, _k2, _v2, 8, ""},
+		{_bucket1, _k3, _v3, 5, ""},
+		{_bucket1, _k3, _v3, 8, ""},
+		{_bucket1, _k4, nil, 5, _errNotExist},
+		{_bucket1, _k4, _v3, 7, ""},
+		{_bucket1, _k4, _v3, 8, ""},
+		// history before the pruned version is removed
+		{_bucket1, _k2, nil, 2, _errNotExist},
+		{_bucket1, _k4, nil, 2, _errNotExist},
+		{_bucket2, _k1, _v1, 0, ""},
+	} {
+		value, err := db.Get(e.height, e.ns, e.k)
+		if len(e.err) == 0 {
+			r.NoError(err)
+		} else {
+			r.ErrorContains(err, e.err)
+		}
+		r.Equal(e.v, value)
+	}
+	// the latest version of each key is kept
+	for _, e := range []struct {
+		k   []byte
+		v   uint64
+		err error
+	}{
+		{_k1, 3, ErrDeleted},
+		{_k2, 6, nil},
+		{_k3, 2, nil},
+		{_k4, 7, nil},
+	} {
+		v, err := db.Version(_bucket1, e.k)
+		r.Equal(e.err, errors.Cause(err))
+		r.Equal(e.v, v)
+	}
+}
+
+func TestPruneResume(t *testing.T) {
+	r := require.New(t)
+	testPath, err := testutil.PathOfTempFile("test-version")
+	r.NoError(err)
+	defer func() {
+		testutil.CleanupPath(testPath)
+	}()
+
+	cfg := DefaultConfig
+	cfg.DbPath = testPath
+	db := NewBoltDBVersioned(cfg, VnsOption(Namespace{_bucket1, uint32(len(_k2))}))
+	ctx := context.Background()
+	r.NoError(db.Start(ctx))
+	defer func() {
+		db.Stop(ctx)
+	}()
+
+	for _, k := range [][]byte{_k1, _k2, _k3} {
+		r.NoError(db.Put(1, _bucket1, k, _v1))
+		r.NoError(db.Put(3, _bucket1, k, _v2))
+	}
+	// the pruning at version 5 was interrupted after the entries before _k2 were pruned
+	progress := &pruneProgress{version: 5, ns: _bucket1, next: keyForWrite(_k2, 1)}
+	r.NoError(db.db.Put(_pruneProgressNS, _pruneProgressKey, progress.serialize()))
+	r.NoError(db.Prune(5))
+	// the pruning resumes from _k2
+	v, err := db.Get(1, _bucket1, _k1)
+	r.NoError(err)
+	r.Equal(_v1, v)
+	for _, k := range [][]byte{_k2, _k3} {
+		_, err = db.Get(1, _bucket1, k)
+		r.ErrorContains(err, _errNotExist)
+	}
+	// the progress is removed once the pruning is done
+	_, err = db.db.Get(_pruneProgressNS, _pruneProgressKey)
+	r.Equal(ErrNotExist, errors.Cause(err))
+
+	// the pruning at another version restarts
+	progress = &pruneProgress{version: 4, ns: _bucket1}
+	r.NoError(db.db.Put(_pruneProgressNS, _pruneProgressKey, progress.serialize()))
+	r.NoError(db.Prune(5))
+	_, err = db.Get(1, _bucket1, _k1)
+	r.ErrorContains(err, _errNotExist)
+	v, err = db.Get(5, _bucket1, _k1)
+	r.NoError(err)
+	r.Equal(_v2, v)
+}
@@ -7,7 +7,12 @@
 package db
 
 import (
+	"bytes"
+	"encoding/binary"
+	"sort"
+
 	"github.com/iotexproject/go-pkgs/byteutil"
+	"github.com/pkg/errors"
 	"google.golang.org/protobuf/proto"
 
 	"github.com/iotexproject/iotex-core/v2/db/versionpb"
@@ -43,3 +48,101 @@ func deserializeVersionedNamespace(buf []byte) (*versionedNamespace, error) {
 	}
 	return fromProtoVN(&vn), nil
 }
+
+// pruneProgress is the progress of a pruning at the version, in which the namespaces before ns, and the entries
+// of ns before next, have been pruned. ns has been pruned if next is nil
+type pruneProgress struct {
+	version uint64
+	ns      string
+	next    []byte
+}
+
+// serialize to bytes, as version | length of ns | ns | next
+func (p *pruneProgress) serialize() []byte {
+	buf := make([]byte, 12, 12+len(p.ns)+len(p.next))
+	binary.BigEndian.PutUint64(buf, p.version)
+	binary.BigEndian.PutUint32(buf[8:], uint32(len(p.ns)))
+	buf = append(buf, p.ns...)
+	return append(buf, p.next...)
+}
+
+func deserializePruneProgress(buf []byte) (*pruneProgress, error) {
+	if len(buf) < 12 {
+		return nil, errors.Wrap(ErrInvalid, "invalid prune progress")
+	}
+	nsLen := int(binary.BigEndian.Uint32(buf[8:]))
+	if len(buf) < 12+nsLen {
+		return nil, errors.Wrap(ErrInvalid, "invalid prune progress")
+	}
+	p := &pruneProgress{
+		version: binary.BigEndian.Uint64(buf),
+		ns:      string(buf[12 : 12+nsLen]),
+	}
+	if next := buf[12+nsLen:]; len(next) > 0 {
+		p.next = next
+	}
+	return p, nil
+}
+
+// resumeFrom returns the index of the namespace and the entry to resume the pruning at the version from. The
+// pruning restarts if it was at another version
+func (p *pruneProgress) resumeFrom(version uint64, nss []string) (int, []byte) {
+	if p == nil || p.version != version {
+		return 0, nil
+	}
+	i := sort.SearchStrings(nss, p.ns)
+	if i == len(nss) || nss[i] != p.ns {
+		return 0, nil
+	}
+	if p.next == nil {
+		return i + 1, nil
+	}
+	return i, p.next
+}
+
+func sortedNamespaces(vns map[string]int) []string {
+	nss := make([]string, 0, len(vns))
+	for ns := range vns {
+		nss = append(nss, ns)
+	}
+	sort.Strings(nss)
+	return nss
+}
+
+// historyPruner finds the entries of the versioned keys, visited in order, which are not needed to read at the
+// version or any later version
+type historyPruner struct {
+	version uint64
+	keyLen  int
+	curr    []byte // the key being processed
+	prev    []byte // the latest entry of the key at or below the version
+}
+
+// isNewKey returns whether the entry is of a key other than the one being processed
+func (p *historyPruner) isNewKey(k []byte) bool {
+	return !bytes.Equal(p.curr, k[:p.keyLen])
+}
+
+// visit visits the entry, and returns the prunable entry visited before it, or nil
+func (p *historyPruner) visit(k []byte) []byte {
+	if p.isNewKey(k) {
+		// a new key, the last entry of previous key is always kept
+		p.curr = append([]byte{}, k[:p.keyLen]...)
+		p.prev = nil
+	}
+	var prunable []byte
+	if _, last := parseKey(k); last > p.version {
+		// a delete marker at or below the version is not needed, as there is a later write
+		if p.prev != nil {
+			if isDelete, _ := parseKey(p.prev); isDelete {
+				prunable = p.prev
+			}
+			p.prev = nil
+		}
+		return prunable
+	}
+	// the previous entry is overwritten at or below the version
+	prunable = p.prev
+	p.prev = append([]byte{}, k...)
+	return prunable
+}
@@ -0,0 +1,187 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package mptrie
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/iotexproject/iotex-core/v2/db/trie"
+	"github.com/iotexproject/iotex-core/v2/db/trie/triepb"
+)
+
+// ErrInvalidProof is an error when a merkle proof cannot be verified
+var ErrInvalidProof = errors.New("invalid merkle proof")
+
+// Proof returns the serialized nodes on the path from the root to the key.
+// If the key does not exist, the returned nodes prove its absence.
+func (mpt *merklePatriciaTrie) Proof(key []byte) ([][]byte, error) {
+	mpt.mutex.RLock()
+	defer mpt.mutex.RUnlock()
+
+	kt, err := mpt.checkKeyType(key)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		proof  [][]byte
+		n      node = mpt.root
+		offset uint8
+	)
+	for {
+		if hn, ok := n.(*hashNode); ok {
+			if n, err = hn.LoadNode(mpt); err != nil {
+				return nil, err
+			}
+		}
+		sn, ok := n.(serializable)
+		if !ok {
+			return nil, errors.Wrapf(trie.ErrInvalidTrie, "unexpected node type %T", n)
+		}
+		pb, err := sn.proto(mpt, false)
+		if err != nil {
+			return nil, err
+		}
+		ser, err := proto.Marshal(pb)
+		if err != nil {
+			return nil, err
+		}
+		proof = append(proof, ser)
+		switch nd := n.(type) {
+		case *branchNode:
+			child, err := nd.child(kt[offset])
+			if errors.Cause(err) == trie.ErrNotExist {
+				return proof, nil
+			}
+			if err != nil {
+				return nil, err
+			}
+			n = child
+			offset++
+		case *extensionNode:
+			if nd.commonPrefixLength(kt[offset:]) != uint8(len(nd.path)) {
+				return proof, nil
+			}
+			n = nd.child
+			offset += uint8(len(nd.path))
+		case *leafNode:
+			return proof, nil
+		default:
+			return nil, errors.Wrapf(trie.ErrInvalidTrie, "unexpected node type %T", n)
+		}
+	}
+}
+
+// Proof returns the proof of the layer one key followed by the proof of the layer two key.
+// If the layer one key does not exist, only the proof of its absence is returned.
+func (tlt *twoLayerTrie) Proof(layerOneKey []byte, layerTwoKey []byte) ([][]byte, error) {
+	if err := tlt.flush(context.Background()); err != nil {
+		return nil, err
+	}
+	proof, err := tlt.layerOne.Proof(layerOneKey)
+	if err != nil {
+		return nil, err
+	}
+	_, err = tlt.layerOne.Get(layerOneKey)
+	switch errors.Cause(err) {
+	case trie.ErrNotExist:
+		return proof, nil
+	case nil:
+	default:
+		return nil, err
+	}
+	lt, err := tlt.layerTwoTrie(layerOneKey, len(layerTwoKey))
+	if err != nil {
+		return nil, err
+	}
+	layerTwoProof, err := lt.tr.Proof(layerTwoKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(proof, layerTwoProof...), nil
+}
+
+// VerifyProof verifies the proof of the key against the root hash, and returns the value of the key.
+// trie.ErrNotExist is returned if the proof shows that the key does not exist in the trie.
+func VerifyProof(rootHash []byte, key []byte, proof [][]byte, hashFunc HashFunc) ([]byte, error) {
+	value, n, err := verifyProof(rootHash, key, proof, hashFunc)
+	if err != nil && errors.Cause(err) != trie.ErrNotExist {
+		return nil, err
+	}
+	if n != len(proof) {
+		return nil, errors.Wrapf(ErrInvalidProof, "%d redundant nodes in proof", len(proof)-n)
+	}
+
+	return value, err
+}
+
+// VerifyTwoLayerProof verifies the proof generated by a two layer trie, and returns the value of the
+// layer two key. trie.ErrNotExist is returned if the proof shows that either key does not exist.
+func VerifyTwoLayerProof(rootHash []byte, layerOneKey []byte, layerTwoKey []byte, proof [][]byte) ([]byte, error) {
+	layerTwoRoot, n, err := verifyProof(rootHash, layerOneKey, proof, DefaultHashFunc)
+	if err != nil {
+		if errors.Cause(err) == trie.ErrNotExist && n != len(proof) {
+			return nil, errors.Wrapf(ErrInvalidProof, "%d redundant nodes in proof", len(proof)-n)
+		}
+		return nil, err
+	}
+
+	return VerifyProof(layerTwoRoot, layerTwoKey, proof[n:], DefaultHashFunc)
+}
+
+// verifyProof walks through the proof from the root, and returns the value and the number of nodes consumed
+func verifyProof(rootHash []byte, key []byte, proof [][]byte, hashFunc HashFunc) ([]byte, int, error) {
+	expected := rootHash
+	offset := 0
+	for i, ser := range proof {
+		if !bytes.Equal(hashFunc(ser), expected) {
+			return nil, i, errors.Wrapf(ErrInvalidProof, "hash mismatch of node %d", i)
+		}
+		pb := triepb.NodePb{}
+		if err := proto.Unmarshal(ser, &pb); err != nil {
+			return nil, i, errors.Wrapf(ErrInvalidProof, "failed to unmarshal node %d: %v", i, err)
+		}
+		if pbBranch := pb.GetBranch(); pbBranch != nil {
+			if offset >= len(key) {
+				return nil, i, errors.Wrapf(ErrInvalidProof, "unexpected branch node %d", i)
+			}
+			expected = nil
+			for _, b := range pbBranch.Branches {
+				if b.Index == uint32(key[offset]) {
+					expected = b.Path
+					break
+				}
+			}
+			if expected == nil {
+				return nil, i + 1, errors.Wrapf(trie.ErrNotExist, "key %x does not exist", key)
+			}
+			offset++
+			continue
+		}
+		if pbExtend := pb.GetExtend(); pbExtend != nil {
+			path := pbExtend.Path
+			if len(key)-offset < len(path) || !bytes.Equal(key[offset:offset+len(path)], path) {
+				return nil, i + 1, errors.Wrapf(trie.ErrNotExist, "key %x does not exist", key)
+			}
+			expected = pbExtend.Value
+			offset += len(path)
+			continue
+		}
+		if pbLeaf := pb.GetLeaf(); pbLeaf != nil {
+			if !bytes.Equal(pbLeaf.Path, key) {
+				return nil, i + 1, errors.Wrapf(trie.ErrNotExist, "key %x does not exist", key)
+			}
+			return pbLeaf.Value, i + 1, nil
+		}
+		return nil, i, errors.Wrapf(ErrInvalidProof, "invalid type of node %d", i)
+	}
+
+	return nil, len(proof), errors.Wrap(ErrInvalidProof, "incomplete proof")
+}
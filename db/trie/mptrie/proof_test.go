@@ -0,0 +1,126 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package mptrie
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/v2/db/trie"
+)
+
+func TestProof(t *testing.T) {
+	for _, async := range []bool{false, true} {
+		require := require.New(t)
+		opts := []Option{KeyLengthOption(8)}
+		if async {
+			opts = append(opts, AsyncOption())
+		}
+		tr, err := New(opts...)
+		require.NoError(err)
+		require.NoError(tr.Start(context.Background()))
+
+		// proof of absence in an empty trie
+		rootHash, err := tr.RootHash()
+		require.NoError(err)
+		proof, err := tr.Proof(cat)
+		require.NoError(err)
+		require.Len(proof, 1)
+		_, err = VerifyProof(rootHash, cat, proof, DefaultHashFunc)
+		require.Equal(trie.ErrNotExist, errors.Cause(err))
+
+		keys := [][]byte{ham, car, cat, dog, egg, fox, cow, ant}
+		for i, k := range keys {
+			require.NoError(tr.Upsert(k, testV[i]))
+		}
+		rootHash, err = tr.RootHash()
+		require.NoError(err)
+		for i, k := range keys {
+			proof, err := tr.Proof(k)
+			require.NoError(err)
+			value, err := VerifyProof(rootHash, k, proof, DefaultHashFunc)
+			require.NoError(err)
+			require.Equal(testV[i], value)
+		}
+		// proof of absence
+		for _, k := range [][]byte{rat, br1, br2, cl1} {
+			proof, err := tr.Proof(k)
+			require.NoError(err)
+			_, err = VerifyProof(rootHash, k, proof, DefaultHashFunc)
+			require.Equal(trie.ErrNotExist, errors.Cause(err))
+		}
+
+		proof, err = tr.Proof(cat)
+		require.NoError(err)
+		// a proof cannot be used for another key
+		_, err = VerifyProof(rootHash, rat, proof, DefaultHashFunc)
+		require.Error(err)
+		// incomplete proof
+		_, err = VerifyProof(rootHash, cat, proof[:len(proof)-1], DefaultHashFunc)
+		require.Equal(ErrInvalidProof, errors.Cause(err))
+		// redundant nodes
+		_, err = VerifyProof(rootHash, cat, append(proof, proof[0]), DefaultHashFunc)
+		require.Equal(ErrInvalidProof, errors.Cause(err))
+		// tampered node
+		tampered := make([][]byte, len(proof))
+		copy(tampered, proof)
+		last := append([]byte{}, proof[len(proof)-1]...)
+		last[len(last)-1]++
+		tampered[len(tampered)-1] = last
+		_, err = VerifyProof(rootHash, cat, tampered, DefaultHashFunc)
+		require.Equal(ErrInvalidProof, errors.Cause(err))
+		// wrong root
+		_, err = VerifyProof(emptyTrieRootHash, cat, proof, DefaultHashFunc)
+		require.Equal(ErrInvalidProof, errors.Cause(err))
+		// invalid key length
+		_, err = tr.Proof([]byte("cat"))
+		require.Error(err)
+		require.NoError(tr.Stop(context.Background()))
+	}
+}
+
+func TestTwoLayerTrieProof(t *testing.T) {
+	require := require.New(t)
+	var (
+		ns1 = []byte("layerOneKey111111111")
+		ns2 = []byte("layerOneKey222222222")
+	)
+	ctx := context.Background()
+	tlt := NewTwoLayerTrie(trie.NewMemKVStore(), "rootKey")
+	require.NoError(tlt.Start(ctx))
+	defer func() {
+		require.NoError(tlt.Stop(ctx))
+	}()
+	require.NoError(tlt.Upsert(ns1, []byte("layerTwoKey1"), []byte("value1")))
+	require.NoError(tlt.Upsert(ns1, []byte("layerTwoKey2"), []byte("value2")))
+	rootHash, err := tlt.RootHash()
+	require.NoError(err)
+
+	proof, err := tlt.Proof(ns1, []byte("layerTwoKey2"))
+	require.NoError(err)
+	value, err := VerifyTwoLayerProof(rootHash, ns1, []byte("layerTwoKey2"), proof)
+	require.NoError(err)
+	require.Equal([]byte("value2"), value)
+	_, err = VerifyTwoLayerProof(rootHash, ns1, []byte("layerTwoKey1"), proof)
+	require.Error(err)
+
+	// absence in layer two
+	proof, err = tlt.Proof(ns1, []byte("layerTwoKey3"))
+	require.NoError(err)
+	_, err = VerifyTwoLayerProof(rootHash, ns1, []byte("layerTwoKey3"), proof)
+	require.Equal(trie.ErrNotExist, errors.Cause(err))
+
+	// absence in layer one
+	proof, err = tlt.Proof(ns2, []byte("layerTwoKey1"))
+	require.NoError(err)
+	_, err = VerifyTwoLayerProof(rootHash, ns2, []byte("layerTwoKey1"), proof)
+	require.Equal(trie.ErrNotExist, errors.Cause(err))
+	_, err = VerifyTwoLayerProof(rootHash, ns2, []byte("layerTwoKey1"), append(proof, proof[0]))
+	require.Equal(ErrInvalidProof, errors.Cause(err))
+}
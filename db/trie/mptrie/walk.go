@@ -0,0 +1,112 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package mptrie
+
+import (
+	"bytes"
+	"context"
+	"sync"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/iotexproject/iotex-core/v2/db/trie"
+	"github.com/iotexproject/iotex-core/v2/db/trie/triepb"
+)
+
+// WalkNodes visits the nodes stored in kvStore of the trie with the root hash in depth-first order.
+// The key of each node is passed to fn, and the children of a node are skipped if fn returns false.
+func WalkNodes(kvStore trie.KVStore, rootHash []byte, fn func([]byte) bool) error {
+	return walkNodes(kvStore, rootHash, fn, nil)
+}
+
+// WalkTwoLayerNodes visits the nodes stored in kvStore of the two layer trie with the root hash,
+// including the nodes of all the layer two tries. The key of each node is passed to fn, and the
+// children of a node are skipped if fn returns false.
+func WalkTwoLayerNodes(kvStore trie.KVStore, rootHash []byte, fn func([]byte) bool) error {
+	return walkNodes(kvStore, rootHash, fn, func(layerTwoRoot []byte) error {
+		return walkNodes(kvStore, layerTwoRoot, fn, nil)
+	})
+}
+
+func walkNodes(kvStore trie.KVStore, rootHash []byte, fn func([]byte) bool, onLeaf func([]byte) error) error {
+	if len(rootHash) == 0 {
+		return nil
+	}
+	ser, err := kvStore.Get(rootHash)
+	if errors.Cause(err) == trie.ErrNotExist && bytes.Equal(rootHash, emptyRootHash()) {
+		// an empty trie is not necessarily persisted
+		return nil
+	}
+	if err != nil {
+		return errors.Wrapf(err, "failed to get node %x", rootHash)
+	}
+	var (
+		stack = []keyAndNode{{key: rootHash, ser: ser}}
+		pb    triepb.NodePb
+	)
+	for len(stack) > 0 {
+		kn := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if kn.ser == nil {
+			if kn.ser, err = kvStore.Get(kn.key); err != nil {
+				return errors.Wrapf(err, "failed to get node %x", kn.key)
+			}
+		}
+		if !fn(kn.key) {
+			continue
+		}
+		if err := proto.Unmarshal(kn.ser, &pb); err != nil {
+			return errors.Wrapf(err, "failed to unmarshal node %x", kn.key)
+		}
+		if pbBranch := pb.GetBranch(); pbBranch != nil {
+			for i := len(pbBranch.Branches) - 1; i >= 0; i-- {
+				stack = append(stack, keyAndNode{key: pbBranch.Branches[i].Path})
+			}
+			continue
+		}
+		if pbExtend := pb.GetExtend(); pbExtend != nil {
+			stack = append(stack, keyAndNode{key: pbExtend.Value})
+			continue
+		}
+		if pbLeaf := pb.GetLeaf(); pbLeaf != nil {
+			if onLeaf != nil {
+				if err := onLeaf(pbLeaf.Value); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		return errors.Wrapf(trie.ErrInvalidTrie, "invalid type of node %x", kn.key)
+	}
+	return nil
+}
+
+type keyAndNode struct {
+	key []byte
+	ser []byte
+}
+
+var (
+	_emptyRootHash     []byte
+	_emptyRootHashOnce sync.Once
+)
+
+func emptyRootHash() []byte {
+	_emptyRootHashOnce.Do(func() {
+		mpt, err := New(KVStoreOption(trie.NewMemKVStore()))
+		if err != nil {
+			panic(err)
+		}
+		if err := mpt.Start(context.Background()); err != nil {
+			panic(err)
+		}
+		if _emptyRootHash, err = mpt.RootHash(); err != nil {
+			panic(err)
+		}
+	})
+	return _emptyRootHash
+}
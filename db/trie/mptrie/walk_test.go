@@ -0,0 +1,104 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package mptrie
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/v2/db/trie"
+)
+
+func TestWalkNodes(t *testing.T) {
+	require := require.New(t)
+	store := trie.NewMemKVStore()
+	tr, err := New(KVStoreOption(store), KeyLengthOption(8))
+	require.NoError(err)
+	require.NoError(tr.Start(context.Background()))
+	defer tr.Stop(context.Background())
+
+	// empty trie
+	rootHash, err := tr.RootHash()
+	require.NoError(err)
+	require.NoError(WalkNodes(store, rootHash, func([]byte) bool {
+		return true
+	}))
+
+	keys := [][]byte{ham, car, cat, dog, egg, fox, cow, ant}
+	for i, k := range keys {
+		require.NoError(tr.Upsert(k, testV[i]))
+	}
+	rootHash, err = tr.RootHash()
+	require.NoError(err)
+	visited := map[string]struct{}{}
+	require.NoError(WalkNodes(store, rootHash, func(h []byte) bool {
+		_, ok := visited[string(h)]
+		require.False(ok)
+		visited[string(h)] = struct{}{}
+		return true
+	}))
+	// every node on the path to a key is visited
+	for _, k := range keys {
+		proof, err := tr.Proof(k)
+		require.NoError(err)
+		for _, node := range proof {
+			_, ok := visited[string(DefaultHashFunc(node))]
+			require.True(ok)
+		}
+	}
+
+	// skip the children
+	var count int
+	require.NoError(WalkNodes(store, rootHash, func(h []byte) bool {
+		count++
+		return false
+	}))
+	require.Equal(1, count)
+
+	// missing node
+	require.Error(WalkNodes(store, []byte("nonexistentrootkey12"), func([]byte) bool {
+		return true
+	}))
+}
+
+func TestWalkTwoLayerNodes(t *testing.T) {
+	require := require.New(t)
+	var (
+		ns1   = []byte("layerOneKey111111111")
+		ns2   = []byte("layerOneKey222222222")
+		store = trie.NewMemKVStore()
+	)
+	tlt := NewTwoLayerTrie(store, "rootKey")
+	require.NoError(tlt.Start(context.Background()))
+	defer tlt.Stop(context.Background())
+	require.NoError(tlt.Upsert(ns1, []byte("layerTwoKey1"), []byte("value1")))
+	require.NoError(tlt.Upsert(ns1, []byte("layerTwoKey2"), []byte("value2")))
+	require.NoError(tlt.Upsert(ns2, []byte("layerTwoKey3"), []byte("value3")))
+	rootHash, err := tlt.RootHash()
+	require.NoError(err)
+
+	visited := map[string]struct{}{}
+	require.NoError(WalkTwoLayerNodes(store, rootHash, func(h []byte) bool {
+		visited[string(h)] = struct{}{}
+		return true
+	}))
+	for _, k := range [][]byte{ns1, ns2} {
+		layerTwoRoot, err := tlt.(*twoLayerTrie).layerOne.Get(k)
+		require.NoError(err)
+		_, ok := visited[string(layerTwoRoot)]
+		require.True(ok)
+	}
+	for _, k := range [][]byte{[]byte("layerTwoKey1"), []byte("layerTwoKey2")} {
+		proof, err := tlt.Proof(ns1, k)
+		require.NoError(err)
+		for _, node := range proof {
+			_, ok := visited[string(DefaultHashFunc(node))]
+			require.True(ok)
+		}
+	}
+}
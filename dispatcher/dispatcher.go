@@ -20,6 +20,7 @@ import (
 	"github.com/iotexproject/iotex-proto/golang/iotexrpc"
 	"github.com/iotexproject/iotex-proto/golang/iotextypes"
 
+	"github.com/iotexproject/iotex-core/v2/blocksync/statesyncpb"
 	"github.com/iotexproject/iotex-core/v2/pkg/lifecycle"
 	"github.com/iotexproject/iotex-core/v2/pkg/log"
 )
@@ -222,8 +223,9 @@ func (d *IotxDispatcher) HandleTell(ctx context.Context, chainID uint32, peer pe
 	if !d.IsReady() {
 		return
 	}
+	// the state sync messages have no message type
 	msgType, err := goproto.GetTypeFromRPCMsg(msgProto)
-	if err != nil {
+	if err != nil && !isStateSyncMsg(msgProto) {
 		log.L().Warn("Unexpected message handled by HandleTell.", zap.Error(err))
 	}
 	cp := peer
@@ -245,6 +247,17 @@ func (d *IotxDispatcher) HandleTell(ctx context.Context, chainID uint32, peer pe
 	d.updateMetrics(msg, queue)
 }
 
+// isStateSyncMsg returns whether the message is a state sync message, which is defined in this repo rather than in
+// the MessageType enum of iotex-proto
+func isStateSyncMsg(msg proto.Message) bool {
+	switch msg.(type) {
+	case *statesyncpb.StateSyncRequest, *statesyncpb.StateSyncResponse:
+		return true
+	default:
+		return false
+	}
+}
+
 func (d *IotxDispatcher) updateEventAudit(t iotexrpc.MessageType) {
 	d.eventAuditLock.Lock()
 	defer d.eventAuditLock.Unlock()
@@ -341,6 +354,18 @@ func (d *IotxDispatcher) dispatchMsg(message *message) {
 				log.L().Warn("Failed to handle action sync message.", zap.Error(err))
 			}
 		}
+	case *statesyncpb.StateSyncRequest:
+		if message.peerInfo == nil {
+			log.L().Warn("StateSyncRequest message must be unicast.")
+			return
+		}
+		if err := subscriber.HandleStateSyncRequest(message.ctx, *message.peerInfo, msg); err != nil {
+			log.L().Debug("Failed to handle state sync request.", zap.Error(err))
+		}
+	case *statesyncpb.StateSyncResponse:
+		if err := subscriber.HandleStateSyncResponse(message.ctx, message.peer, msg); err != nil {
+			log.L().Debug("Failed to handle state sync response.", zap.Error(err))
+		}
 	default:
 		msgType, _ := goproto.GetTypeFromRPCMsg(message.msg)
 		log.L().Warn("Unexpected msgType handled by HandleBroadcast.", zap.Any("msgType", msgType))
@@ -22,6 +22,7 @@ import (
 	"github.com/iotexproject/iotex-proto/golang/iotextypes"
 	"github.com/iotexproject/iotex-proto/golang/testingpb"
 
+	"github.com/iotexproject/iotex-core/v2/blocksync/statesyncpb"
 	"github.com/iotexproject/iotex-core/v2/testutil"
 )
 
@@ -208,6 +209,14 @@ func (ds *dummySubscriber) HandleActionHash(context.Context, hash.Hash256, strin
 	return nil
 }
 
+func (ds *dummySubscriber) HandleStateSyncRequest(context.Context, peer.AddrInfo, *statesyncpb.StateSyncRequest) error {
+	return nil
+}
+
+func (ds *dummySubscriber) HandleStateSyncResponse(context.Context, string, *statesyncpb.StateSyncResponse) error {
+	return nil
+}
+
 type counterSubscriber struct {
 	block       atomic.Int32
 	blockSync   atomic.Int32
@@ -260,3 +269,11 @@ func (cs *counterSubscriber) HandleActionHash(context.Context, hash.Hash256, str
 	cs.actionHash.Inc()
 	return nil
 }
+
+func (cs *counterSubscriber) HandleStateSyncRequest(context.Context, peer.AddrInfo, *statesyncpb.StateSyncRequest) error {
+	return nil
+}
+
+func (cs *counterSubscriber) HandleStateSyncResponse(context.Context, string, *statesyncpb.StateSyncResponse) error {
+	return nil
+}
@@ -88,6 +88,9 @@ func (m *msgQueueMgr) consume(q string) {
 }
 
 func (m *msgQueueMgr) Queue(msg *message) msgQueue {
+	if isStateSyncMsg(msg.msg) {
+		return m.queues[blockSyncQ]
+	}
 	switch msg.msgType {
 	case iotexrpc.MessageType_ACTION, iotexrpc.MessageType_ACTIONS, iotexrpc.MessageType_ACTION_HASH, iotexrpc.MessageType_ACTION_REQUEST:
 		return m.queues[actionQ]
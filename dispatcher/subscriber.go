@@ -7,6 +7,8 @@ import (
 	"github.com/iotexproject/iotex-proto/golang/iotexrpc"
 	"github.com/iotexproject/iotex-proto/golang/iotextypes"
 	"github.com/libp2p/go-libp2p/core/peer"
+
+	"github.com/iotexproject/iotex-core/v2/blocksync/statesyncpb"
 )
 
 // Subscriber is the dispatcher subscriber interface
@@ -20,4 +22,6 @@ type Subscriber interface {
 	HandleNodeInfo(context.Context, string, *iotextypes.NodeInfo) error
 	HandleActionRequest(ctx context.Context, peer peer.AddrInfo, actHash hash.Hash256) error
 	HandleActionHash(ctx context.Context, actHash hash.Hash256, from string) error
+	HandleStateSyncRequest(context.Context, peer.AddrInfo, *statesyncpb.StateSyncRequest) error
+	HandleStateSyncResponse(context.Context, string, *statesyncpb.StateSyncResponse) error
 }
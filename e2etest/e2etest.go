@@ -345,6 +345,8 @@ func initDBPaths(r *require.Assertions, cfg *config.Config) {
 	r.NoError(err)
 	testConsensusPath, err := testutil.PathOfTempFile("consensus")
 	r.NoError(err)
+	testSlashingProtectionPath, err := testutil.PathOfTempFile("slashingprotection")
+	r.NoError(err)
 	testBlobPath, err := testutil.PathOfTempFile("blob")
 	r.NoError(err)
 	testStakingIndexPath, err := testutil.PathOfTempFile("stakingindex")
@@ -361,6 +363,7 @@ func initDBPaths(r *require.Assertions, cfg *config.Config) {
 	cfg.Chain.CandidateIndexDBPath = testCandidateIndexPath
 	cfg.System.SystemLogDBPath = testSystemLogPath
 	cfg.Consensus.RollDPoS.ConsensusDBPath = testConsensusPath
+	cfg.Consensus.RollDPoS.SlashingProtectionDBPath = testSlashingProtectionPath
 	cfg.Chain.BlobStoreDBPath = testBlobPath
 
 	if cfg.ActPool.Store != nil {
@@ -381,6 +384,7 @@ func clearDBPaths(cfg *config.Config) {
 	testutil.CleanupPath(cfg.Chain.IndexDBPath)
 	testutil.CleanupPath(cfg.System.SystemLogDBPath)
 	testutil.CleanupPath(cfg.Consensus.RollDPoS.ConsensusDBPath)
+	testutil.CleanupPath(cfg.Consensus.RollDPoS.SlashingProtectionDBPath)
 	testutil.CleanupPath(cfg.Chain.BlobStoreDBPath)
 	if cfg.ActPool.Store != nil {
 		testutil.CleanupPath(cfg.ActPool.Store.Datadir)
@@ -3,6 +3,7 @@ package e2etest
 import (
 	"context"
 	"encoding/hex"
+	"fmt"
 	"math"
 	"math/big"
 	"testing"
@@ -16,12 +17,17 @@ import (
 	"github.com/mohae/deepcopy"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/iotexproject/iotex-core/v2/action"
 	"github.com/iotexproject/iotex-core/v2/action/protocol"
 	accountutil "github.com/iotexproject/iotex-core/v2/action/protocol/account/util"
 	"github.com/iotexproject/iotex-core/v2/action/protocol/staking"
+	"github.com/iotexproject/iotex-core/v2/api/extensionpb"
 	"github.com/iotexproject/iotex-core/v2/blockchain/genesis"
 	"github.com/iotexproject/iotex-core/v2/config"
 	"github.com/iotexproject/iotex-core/v2/pkg/unit"
@@ -1433,3 +1439,69 @@ func TestCandidateOwnerCollision(t *testing.T) {
 		},
 	})
 }
+
+func TestProjectDelegateReward(t *testing.T) {
+	require := require.New(t)
+	registerAmount, _ := big.NewInt(0).SetString("1200000000000000000000000", 10)
+	gasLimit = uint64(10000000)
+	gasPrice = big.NewInt(1)
+
+	cfg := initCfg(require)
+	cfg.Consensus.Scheme = config.RollDPoSScheme
+	cfg.Genesis.PollMode = "native"
+	cfg.Genesis.ScoreThreshold = "0"
+	cfg.Genesis.FbkMigrationBlockHeight = 1
+	test := newE2ETest(t, cfg)
+	defer test.teardown()
+	chainID := test.cfg.Chain.ID
+	conn, err := grpc.NewClient(fmt.Sprintf("localhost:%d", test.cfg.API.GRPCPort), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(err)
+	defer conn.Close()
+	cli := extensionpb.NewExtensionServiceClient(conn)
+	test.run([]*testcase{
+		{
+			name: "project the reward of a bucket",
+			act:  &actionWithTime{mustNoErr(action.SignedCandidateRegister(test.nonceMgr.pop(identityset.Address(1).String()), "cand1", identityset.Address(1).String(), identityset.Address(1).String(), identityset.Address(1).String(), registerAmount.String(), 91, true, nil, gasLimit, gasPrice, identityset.PrivateKey(1), action.WithChainID(chainID))), time.Now()},
+			expect: []actionExpect{successExpect, &functionExpect{func(test *e2etest, act *action.SealedEnvelope, receipt *action.Receipt, err error) {
+				req := &extensionpb.ProjectDelegateRewardRequest{
+					Candidate:         "cand1",
+					Amount:            "100000000000000000000",
+					Duration:          91,
+					AutoStake:         true,
+					Epochs:            3,
+					DistributionRatio: 90,
+				}
+				res, err := cli.ProjectDelegateReward(context.Background(), req)
+				require.NoError(err)
+				require.Len(res.Epochs, 3)
+				bucketVotes, ok := new(big.Int).SetString(res.BucketVotes, 10)
+				require.True(ok)
+				require.Positive(bucketVotes.Sign())
+				for _, e := range res.Epochs {
+					votes, ok := new(big.Int).SetString(e.CandidateVotes, 10)
+					require.True(ok)
+					require.True(votes.Cmp(bucketVotes) > 0)
+					reward, ok := new(big.Int).SetString(e.EpochReward, 10)
+					require.True(ok)
+					bonus, ok := new(big.Int).SetString(e.FoundationBonus, 10)
+					require.True(ok)
+					// the delegate distributes 90% of the rewards to the voters
+					expected := new(big.Int).Add(reward, bonus)
+					expected.Mul(expected, bucketVotes).Mul(expected, big.NewInt(90)).Div(expected, new(big.Int).Mul(votes, big.NewInt(100)))
+					require.Equal(expected.String(), e.BucketReward)
+				}
+				// the simulated bucket doesn't leak into the state
+				res2, err := cli.ProjectDelegateReward(context.Background(), req)
+				require.NoError(err)
+				require.Equal(res.Epochs[0].CandidateVotes, res2.Epochs[0].CandidateVotes)
+				// the bucket can't be created for a non-existing candidate
+				_, err = cli.ProjectDelegateReward(context.Background(), &extensionpb.ProjectDelegateRewardRequest{
+					Candidate: "cand2",
+					Amount:    "100000000000000000000",
+					Epochs:    3,
+				})
+				require.Equal(codes.InvalidArgument, status.Code(err))
+			}}},
+		},
+	})
+}
@@ -162,6 +162,8 @@ func TestBlockEpochReward(t *testing.T) {
 		dbFilePaths = append(dbFilePaths, contractIndexDBPath)
 		consensusDBPath := fmt.Sprintf("./consensus%d.db", i+1)
 		dbFilePaths = append(dbFilePaths, consensusDBPath)
+		slashingProtectionDBPath := fmt.Sprintf("./slashingprotection%d.db", i+1)
+		dbFilePaths = append(dbFilePaths, slashingProtectionDBPath)
 		networkPort := 4689 + i
 		apiPort := 14014 + i
 		HTTPStatsPort := 8080 + i
@@ -169,6 +171,7 @@ func TestBlockEpochReward(t *testing.T) {
 		cfg := newConfig(chainDBPath, trieDBPath, indexDBPath, contractIndexDBPath, identityset.PrivateKey(i),
 			networkPort, apiPort, uint64(numNodes))
 		cfg.Consensus.RollDPoS.ConsensusDBPath = consensusDBPath
+		cfg.Consensus.RollDPoS.SlashingProtectionDBPath = slashingProtectionDBPath
 		if i == 0 {
 			cfg.Network.BootstrapNodes = []string{}
 			cfg.Network.MasterKey = "bootnode"
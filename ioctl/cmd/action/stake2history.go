@@ -0,0 +1,184 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package action
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/util/metautils"
+	"github.com/iotexproject/iotex-proto/golang/iotexapi"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/iotexproject/iotex-core/v2/api/extensionpb"
+	"github.com/iotexproject/iotex-core/v2/ioctl/config"
+	"github.com/iotexproject/iotex-core/v2/ioctl/output"
+	"github.com/iotexproject/iotex-core/v2/ioctl/util"
+)
+
+// Multi-language support
+var (
+	_stake2HistoryCmdUses = map[config.Language]string{
+		config.English: "history [BUCKET_INDEX] [--candidate CANDIDATE] [--offset OFFSET] [--limit LIMIT]",
+		config.Chinese: "history [票索引] [--candidate 候选人] [--offset 偏移] [--limit 数量]",
+	}
+	_stake2HistoryCmdShorts = map[config.Language]string{
+		config.English: "Show the staking history of a bucket or a candidate on IoTeX blockchain",
+		config.Chinese: "在IoTeX区块链上查看票或候选人的质押历史",
+	}
+	_stake2HistoryFlagCandidateUsages = map[config.Language]string{
+		config.English: "show the history of the candidate, by the identifier address or alias",
+		config.Chinese: "查看候选人的历史，使用候选人标识地址或别名",
+	}
+	_stake2HistoryFlagOffsetUsages = map[config.Language]string{
+		config.English: "number of the earliest events to skip",
+		config.Chinese: "跳过最早的事件数量",
+	}
+	_stake2HistoryFlagLimitUsages = map[config.Language]string{
+		config.English: "max number of the events to show",
+		config.Chinese: "显示的最大事件数量",
+	}
+)
+
+var (
+	_stake2HistoryCandidate string
+	_stake2HistoryOffset    uint64
+	_stake2HistoryLimit     uint64
+)
+
+// _stake2HistoryCmd represents the stake2 history command
+var _stake2HistoryCmd = &cobra.Command{
+	Use:   config.TranslateInLang(_stake2HistoryCmdUses, config.UILanguage),
+	Short: config.TranslateInLang(_stake2HistoryCmdShorts, config.UILanguage),
+	Args:  cobra.MaximumNArgs(1),
+	Example: `ioctl stake2 history 1234 --limit 10
+ioctl stake2 history --candidate io1... --offset 10 --limit 10`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+		err := stake2History(args)
+		return output.PrintError(err)
+	},
+}
+
+func init() {
+	_stake2HistoryCmd.Flags().StringVar(&_stake2HistoryCandidate, "candidate", "",
+		config.TranslateInLang(_stake2HistoryFlagCandidateUsages, config.UILanguage))
+	_stake2HistoryCmd.Flags().Uint64Var(&_stake2HistoryOffset, "offset", 0,
+		config.TranslateInLang(_stake2HistoryFlagOffsetUsages, config.UILanguage))
+	_stake2HistoryCmd.Flags().Uint64Var(&_stake2HistoryLimit, "limit", 100,
+		config.TranslateInLang(_stake2HistoryFlagLimitUsages, config.UILanguage))
+}
+
+type stakingHistoryMessage struct {
+	Node        string                      `json:"node"`
+	Total       uint64                      `json:"total"`
+	StartHeight uint64                      `json:"startHeight"`
+	Events      []*extensionpb.StakingEvent `json:"events"`
+}
+
+func (m *stakingHistoryMessage) String() string {
+	if output.Format == "" {
+		lines := []string{fmt.Sprintf("Total: %d (indexed since height %d)", m.Total, m.StartHeight)}
+		for _, e := range m.Events {
+			line := fmt.Sprintf("height %d, action %s, log %d: %s", e.BlockHeight, e.ActionHash, e.LogIndex, e.Type)
+			if e.BucketIndex != _noBucketIndex {
+				line += fmt.Sprintf(", bucket %d", e.BucketIndex)
+			}
+			if len(e.Candidates) > 0 {
+				line += ", candidate " + strings.Join(e.Candidates, " -> ")
+			}
+			if e.Owner != "" {
+				line += ", owner " + e.Owner
+			}
+			lines = append(lines, line)
+		}
+		return strings.Join(lines, "\n")
+	}
+	return output.FormatString(output.Result, m)
+}
+
+// _noBucketIndex is the bucket index of the events not related to a bucket
+const _noBucketIndex = ^uint64(0)
+
+func stake2History(args []string) error {
+	req := &extensionpb.ReadStakingHistoryRequest{
+		Start: _stake2HistoryOffset,
+		Count: _stake2HistoryLimit,
+	}
+	switch {
+	case _stake2HistoryCandidate != "":
+		cand, err := util.GetAddress(_stake2HistoryCandidate)
+		if err != nil {
+			return output.NewError(output.AddressError, "", err)
+		}
+		req.Candidate = cand
+	case len(args) == 1:
+		bucketIndex, err := strconv.ParseUint(args[0], 10, 64)
+		if err != nil {
+			return output.NewError(output.ConvertError, "failed to convert bucket index", nil)
+		}
+		req.BucketIndex = bucketIndex
+	default:
+		return output.NewError(output.InputError, "either a bucket index or a candidate is required", nil)
+	}
+	res, err := readStakingHistory(req)
+	if err != nil {
+		return err
+	}
+	message := stakingHistoryMessage{
+		Node:        config.ReadConfig.Endpoint,
+		Total:       res.Total,
+		StartHeight: res.StartHeight,
+		Events:      res.Events,
+	}
+	fmt.Println(message.String())
+	return nil
+}
+
+func readStakingHistory(req *extensionpb.ReadStakingHistoryRequest) (*extensionpb.StakingEventList, error) {
+	conn, err := util.ConnectToEndpoint(config.ReadConfig.SecureConnect && !config.Insecure)
+	if err != nil {
+		return nil, output.NewError(output.NetworkError, "failed to connect to endpoint", err)
+	}
+	defer conn.Close()
+	cli := iotexapi.NewAPIServiceClient(conn)
+
+	ctx := context.Background()
+	jwtMD, err := util.JwtAuth()
+	if err == nil {
+		ctx = metautils.NiceMD(jwtMD).ToOutgoing(ctx)
+	}
+
+	methodData, err := proto.Marshal(&iotexapi.ReadStakingDataMethod{Method: extensionpb.ReadStakingDataMethodHistory})
+	if err != nil {
+		return nil, output.NewError(output.SerializationError, "failed to marshal read staking data method", err)
+	}
+	requestData, err := proto.Marshal(req)
+	if err != nil {
+		return nil, output.NewError(output.SerializationError, "failed to marshal read staking history request", err)
+	}
+	response, err := cli.ReadState(ctx, &iotexapi.ReadStateRequest{
+		ProtocolID: []byte("staking"),
+		MethodName: methodData,
+		Arguments:  [][]byte{requestData},
+	})
+	if err != nil {
+		sta, ok := status.FromError(err)
+		if ok {
+			return nil, output.NewError(output.APIError, sta.Message(), nil)
+		}
+		return nil, output.NewError(output.NetworkError, "failed to invoke ReadState api", err)
+	}
+	list := &extensionpb.StakingEventList{}
+	if err := proto.Unmarshal(response.Data, list); err != nil {
+		return nil, output.NewError(output.SerializationError, "failed to unmarshal response", err)
+	}
+	return list, nil
+}
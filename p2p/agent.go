@@ -292,7 +292,48 @@ func (p *agent) Start(ctx context.Context) error {
 		return errors.Wrap(err, "error when adding broadcast pubsub")
 	}
 
-	if err := host.AddUnicastPubSub(_unicastTopic+p.topicSuffix, func(ctx context.Context, peerInfo peer.AddrInfo, data []byte) (err error) {
+	for _, topic := range []string{_unicastTopic, _stateSyncRequestTopic, _stateSyncResponseTopic} {
+		if err := host.AddUnicastPubSub(topic+p.topicSuffix, p.unicastHandler(ready, topic)); err != nil {
+			return errors.Wrap(err, "error when adding unicast pubsub")
+		}
+	}
+
+	// create boot nodes list except itself
+	hostName := host.HostIdentity()
+	for _, bootstrapNode := range p.cfg.BootstrapNodes {
+		bootAddr := multiaddr.StringCast(bootstrapNode)
+		if !strings.Contains(bootAddr.String(), hostName) {
+			p.bootNodeAddr = append(p.bootNodeAddr, bootAddr)
+		}
+	}
+	if err := host.AddBootstrap(p.bootNodeAddr); err != nil {
+		return err
+	}
+	host.JoinOverlay()
+	p.host = host
+
+	// connect to bootstrap nodes
+	if err := p.connectBootNode(ctx); err != nil {
+		log.L().Error("fail to connect bootnode", zap.Error(err))
+		return err
+	}
+	if err := p.host.AdvertiseAsync(); err != nil {
+		return err
+	}
+	if err := p.host.FindPeersAsync(); err != nil {
+		return err
+	}
+
+	close(ready)
+
+	// check network connectivity every 60 blocks, and reconnect in case of disconnection
+	p.reconnectTask = routine.NewRecurringTask(p.reconnect, p.reconnectTimeout)
+	return p.reconnectTask.Start(ctx)
+}
+
+// unicastHandler returns the handler of the unicast messages received on the topic
+func (p *agent) unicastHandler(ready <-chan interface{}, topic string) p2p.HandleUnicast {
+	return func(ctx context.Context, peerInfo peer.AddrInfo, data []byte) (err error) {
 		// Blocking handling the unicast message until the agent is started
 		<-ready
 		var (
@@ -312,7 +353,12 @@ func (p *agent) Start(ctx context.Context) error {
 			err = errors.Wrap(err, "error when marshaling unicast message")
 			return
 		}
-		msg, err := goproto.TypifyRPCMsg(unicast.MsgType, unicast.MsgBody)
+		var msg proto.Message
+		if topic == _unicastTopic {
+			msg, err = goproto.TypifyRPCMsg(unicast.MsgType, unicast.MsgBody)
+		} else {
+			msg, err = typifyStateSyncMsg(topic, unicast.MsgBody)
+		}
 		if err != nil {
 			err = errors.Wrap(err, "error when typifying unicast message")
 			return
@@ -328,41 +374,7 @@ func (p *agent) Start(ctx context.Context) error {
 		p.unicastInboundAsyncHandler(ctx, unicast.ChainId, peerInfo, msg)
 		p.qosMetrics.updateRecvUnicast(peerID, time.Now())
 		return
-	}); err != nil {
-		return errors.Wrap(err, "error when adding unicast pubsub")
-	}
-
-	// create boot nodes list except itself
-	hostName := host.HostIdentity()
-	for _, bootstrapNode := range p.cfg.BootstrapNodes {
-		bootAddr := multiaddr.StringCast(bootstrapNode)
-		if !strings.Contains(bootAddr.String(), hostName) {
-			p.bootNodeAddr = append(p.bootNodeAddr, bootAddr)
-		}
-	}
-	if err := host.AddBootstrap(p.bootNodeAddr); err != nil {
-		return err
-	}
-	host.JoinOverlay()
-	p.host = host
-
-	// connect to bootstrap nodes
-	if err := p.connectBootNode(ctx); err != nil {
-		log.L().Error("fail to connect bootnode", zap.Error(err))
-		return err
-	}
-	if err := p.host.AdvertiseAsync(); err != nil {
-		return err
-	}
-	if err := p.host.FindPeersAsync(); err != nil {
-		return err
 	}
-
-	close(ready)
-
-	// check network connectivity every 60 blocks, and reconnect in case of disconnection
-	p.reconnectTask = routine.NewRecurringTask(p.reconnect, p.reconnectTimeout)
-	return p.reconnectTask.Start(ctx)
 }
 
 func (p *agent) Stop(ctx context.Context) error {
@@ -446,7 +458,14 @@ func (p *agent) UnicastOutbound(ctx context.Context, peer peer.AddrInfo, msg pro
 		_p2pMsgCounter.WithLabelValues("unicast", strconv.Itoa(int(msgType)), "out", peer.ID.String(), status).Inc()
 	}()
 
-	msgType, msgBody, err = convertAppMsg(msg)
+	topic := stateSyncTopic(msg)
+	if topic != "" {
+		// the state sync message is sent on its own topic without a message type
+		msgBody, err = proto.Marshal(msg)
+	} else {
+		topic = _unicastTopic
+		msgType, msgBody, err = convertAppMsg(msg)
+	}
 	if err != nil {
 		return
 	}
@@ -464,7 +483,7 @@ func (p *agent) UnicastOutbound(ctx context.Context, peer peer.AddrInfo, msg pro
 	}
 
 	t := time.Now()
-	if err = host.Unicast(ctx, peer, _unicastTopic+p.topicSuffix, data); err != nil {
+	if err = host.Unicast(ctx, peer, topic+p.topicSuffix, data); err != nil {
 		err = errors.Wrap(err, "error when sending unicast message")
 		p.qosMetrics.updateSendUnicast(peerName, t, false)
 		return
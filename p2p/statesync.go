@@ -0,0 +1,50 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package p2p
+
+import (
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/iotexproject/iotex-core/v2/blocksync/statesyncpb"
+)
+
+// the state sync messages are defined in this repo rather than in the MessageType enum of iotex-proto, so they
+// don't take a message type, which could collide with the ones added to iotex-proto. Instead, each of them is sent
+// on its own unicast topic, and the topic tells the message
+const (
+	_stateSyncRequestTopic  = "statesyncrequest"
+	_stateSyncResponseTopic = "statesyncresponse"
+)
+
+// stateSyncTopic returns the unicast topic of the state sync message, or empty if it is not a state sync message
+func stateSyncTopic(msg proto.Message) string {
+	switch msg.(type) {
+	case *statesyncpb.StateSyncRequest:
+		return _stateSyncRequestTopic
+	case *statesyncpb.StateSyncResponse:
+		return _stateSyncResponseTopic
+	default:
+		return ""
+	}
+}
+
+// typifyStateSyncMsg unmarshals the state sync message received on the topic
+func typifyStateSyncMsg(topic string, msg []byte) (proto.Message, error) {
+	var m proto.Message
+	switch topic {
+	case _stateSyncRequestTopic:
+		m = &statesyncpb.StateSyncRequest{}
+	case _stateSyncResponseTopic:
+		m = &statesyncpb.StateSyncResponse{}
+	default:
+		return nil, errors.Errorf("%s is not a state sync topic", topic)
+	}
+	if err := proto.Unmarshal(msg, m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
@@ -0,0 +1,37 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package p2p
+
+import (
+	"testing"
+
+	"github.com/iotexproject/iotex-proto/golang/iotexrpc"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/iotexproject/iotex-core/v2/blocksync/statesyncpb"
+)
+
+func TestStateSyncTopic(t *testing.T) {
+	r := require.New(t)
+	for _, msg := range []proto.Message{
+		&statesyncpb.StateSyncRequest{Height: 5, File: "block-5", Offset: 7},
+		&statesyncpb.StateSyncResponse{Height: 5, File: "block-5", Size: 10, Data: []byte("data")},
+	} {
+		topic := stateSyncTopic(msg)
+		r.NotEmpty(topic)
+		r.NotEqual(_unicastTopic, topic)
+		ser, err := proto.Marshal(msg)
+		r.NoError(err)
+		typified, err := typifyStateSyncMsg(topic, ser)
+		r.NoError(err)
+		r.True(proto.Equal(msg, typified))
+	}
+	// the other messages are sent on the unicast topic with their message types
+	r.Empty(stateSyncTopic(&iotexrpc.BlockSync{Start: 1, End: 2}))
+	_, err := typifyStateSyncMsg(_unicastTopic, nil)
+	r.Error(err)
+}
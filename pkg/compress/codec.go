@@ -0,0 +1,115 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package compress
+
+import (
+	"github.com/klauspost/compress/zstd"
+	"github.com/pkg/errors"
+)
+
+// Codec compresses and decompresses data with a compressor, and an optional zstd dictionary trained on
+// the data, e.g. the serialized blocks and receipts, which improves the compression of small and similar data
+type Codec struct {
+	compressor string
+	encoder    *zstd.Encoder
+	decoder    *zstd.Decoder
+}
+
+// NewCodec creates a codec of the compressor, an empty compressor means no compression
+func NewCodec(compressor string, dict []byte) (*Codec, error) {
+	switch compressor {
+	case "", Gzip, Snappy, Lz4:
+		if len(dict) > 0 {
+			return nil, errors.Errorf("compressor %s doesn't support dictionary", compressor)
+		}
+		return &Codec{compressor: compressor}, nil
+	case Zstd:
+		c := &Codec{compressor: compressor}
+		if len(dict) == 0 {
+			return c, nil
+		}
+		var err error
+		if c.encoder, err = zstd.NewWriter(nil, zstd.WithEncoderDict(dict)); err != nil {
+			return nil, errors.Wrap(err, "failed to load zstd dictionary")
+		}
+		if c.decoder, err = zstd.NewReader(nil, zstd.WithDecoderDicts(dict)); err != nil {
+			return nil, errors.Wrap(err, "failed to load zstd dictionary")
+		}
+		return c, nil
+	default:
+		return nil, errors.Wrap(ErrUnsupportedCompressor, compressor)
+	}
+}
+
+// Compressor returns the compressor of the codec
+func (c *Codec) Compressor() string {
+	return c.compressor
+}
+
+// Compress compresses the input
+func (c *Codec) Compress(value []byte) ([]byte, error) {
+	switch {
+	case c.compressor == "":
+		return value, nil
+	case c.encoder != nil:
+		if value == nil {
+			return nil, ErrInputEmpty
+		}
+		return c.encoder.EncodeAll(value, nil), nil
+	default:
+		return Compress(value, c.compressor)
+	}
+}
+
+// Decompress decompresses the input
+func (c *Codec) Decompress(value []byte) ([]byte, error) {
+	switch {
+	case c.compressor == "":
+		return value, nil
+	case c.decoder != nil:
+		v, err := c.decoder.DecodeAll(value, nil)
+		if err != nil {
+			return nil, err
+		}
+		if len(v) == 0 {
+			v = []byte{}
+		}
+		return v, nil
+	default:
+		return Decompress(value, c.compressor)
+	}
+}
+
+// BuildZstdDict builds a zstd dictionary of at most size bytes from the samples of the data to be compressed.
+// The content of the dictionary is taken from the first half of the samples, and the entropy tables are built
+// by compressing all the samples with the content.
+func BuildZstdDict(id uint32, samples [][]byte, size int) (dict []byte, err error) {
+	if len(samples) < 2 {
+		return nil, errors.New("at least 2 samples are required to build dictionary")
+	}
+	if id == 0 {
+		return nil, errors.New("dictionary id cannot be 0")
+	}
+	var history []byte
+	for _, sample := range samples[:len(samples)/2] {
+		history = append(history, sample...)
+	}
+	if len(history) > size {
+		history = history[len(history)-size:]
+	}
+	defer func() {
+		// BuildDict panics if the samples have no literal left after matching the content
+		if r := recover(); r != nil {
+			dict, err = nil, errors.Errorf("failed to build dictionary: %v", r)
+		}
+	}()
+	return zstd.BuildDict(zstd.BuildDictOptions{
+		ID:       id,
+		Contents: samples,
+		History:  history,
+		Offsets:  [3]int{1, 4, 8},
+	})
+}
@@ -11,6 +11,8 @@ import (
 	"io"
 
 	"github.com/golang/snappy"
+	"github.com/klauspost/compress/zstd"
+	"github.com/pierrec/lz4/v4"
 	"github.com/pkg/errors"
 )
 
@@ -18,11 +20,20 @@ import (
 const (
 	Gzip   = "Gzip"
 	Snappy = "Snappy"
+	Zstd   = "Zstd"
+	Lz4    = "Lz4"
 )
 
 // error definition
 var (
-	ErrInputEmpty = errors.New("input cannot be empty")
+	ErrInputEmpty            = errors.New("input cannot be empty")
+	ErrUnsupportedCompressor = errors.New("unsupported compressor")
+)
+
+var (
+	// EncodeAll and DecodeAll of zstd encoder and decoder can be called concurrently
+	_zstdEncoder, _ = zstd.NewWriter(nil)
+	_zstdDecoder, _ = zstd.NewReader(nil)
 )
 
 // Compress compresses input according to compressor
@@ -36,8 +47,12 @@ func Compress(value []byte, compressor string) ([]byte, error) {
 		return CompGzip(value)
 	case Snappy:
 		return CompSnappy(value)
+	case Zstd:
+		return CompZstd(value)
+	case Lz4:
+		return CompLz4(value)
 	default:
-		panic("unsupported compressor")
+		return nil, errors.Wrap(ErrUnsupportedCompressor, compressor)
 	}
 }
 
@@ -48,8 +63,12 @@ func Decompress(value []byte, compressor string) ([]byte, error) {
 		return DecompGzip(value)
 	case Snappy:
 		return DecompSnappy(value)
+	case Zstd:
+		return DecompZstd(value)
+	case Lz4:
+		return DecompLz4(value)
 	default:
-		panic("unsupported compressor")
+		return nil, errors.Wrap(ErrUnsupportedCompressor, compressor)
 	}
 }
 
@@ -96,3 +115,48 @@ func DecompSnappy(data []byte) ([]byte, error) {
 	}
 	return v, err
 }
+
+// CompZstd uses zstd to compress the input bytes
+func CompZstd(data []byte) ([]byte, error) {
+	return _zstdEncoder.EncodeAll(data, nil), nil
+}
+
+// DecompZstd uses zstd to decompress the input bytes
+func DecompZstd(data []byte) ([]byte, error) {
+	v, err := _zstdDecoder.DecodeAll(data, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(v) == 0 {
+		v = []byte{}
+	}
+	return v, nil
+}
+
+// CompLz4 uses lz4 to compress the input bytes
+func CompLz4(data []byte) ([]byte, error) {
+	var bb bytes.Buffer
+	w := lz4.NewWriter(&bb)
+	if _, err := w.Write(data); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return bb.Bytes(), nil
+}
+
+// DecompLz4 uses lz4 to decompress the input bytes
+func DecompLz4(data []byte) ([]byte, error) {
+	if len(data) == 0 {
+		return nil, ErrInputEmpty
+	}
+	v, err := io.ReadAll(lz4.NewReader(bytes.NewReader(data)))
+	if err != nil {
+		return nil, err
+	}
+	if len(v) == 0 {
+		v = []byte{}
+	}
+	return v, nil
+}
@@ -7,6 +7,7 @@ package compress
 
 import (
 	"encoding/hex"
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -22,8 +23,12 @@ func TestCompressDataBytes(t *testing.T) {
 	r.Error(err)
 	_, err = Decompress([]byte{}, Snappy)
 	r.Error(err)
-	r.Panics(func() { Compress([]byte{}, "invalid") })
-	r.Panics(func() { Decompress([]byte{}, "invalid") })
+	_, err = Decompress([]byte{}, Lz4)
+	r.Error(err)
+	_, err = Compress([]byte{}, "invalid")
+	r.ErrorIs(err, ErrUnsupportedCompressor)
+	_, err = Decompress([]byte{}, "invalid")
+	r.ErrorIs(err, ErrUnsupportedCompressor)
 
 	zero := [32]byte{}
 	blkHash, _ := hex.DecodeString("22cd0c2d1f7d65298cec7599e2d0e3c650dd8b4ed2b1c816d909026c60d785b2")
@@ -35,7 +40,7 @@ func TestCompressDataBytes(t *testing.T) {
 		[]byte("abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ`1234567890-=~!@#$%^&*()_+å∫ç∂´´©˙ˆˆ˚¬µ˜˜πœ®ß†¨¨∑≈¥Ω[]',./{}|:<>?"),
 	}
 	for _, ser := range compressTests {
-		for _, compress := range []string{Gzip, Snappy} {
+		for _, compress := range []string{Gzip, Snappy, Zstd, Lz4} {
 			v, err := Compress(ser, compress)
 			r.NoError(err)
 
@@ -45,3 +50,48 @@ func TestCompressDataBytes(t *testing.T) {
 		}
 	}
 }
+
+func TestCodec(t *testing.T) {
+	r := require.New(t)
+
+	_, err := NewCodec("invalid", nil)
+	r.ErrorIs(err, ErrUnsupportedCompressor)
+	_, err = NewCodec(Snappy, []byte{1})
+	r.ErrorContains(err, "doesn't support dictionary")
+
+	samples := make([][]byte, 64)
+	for i := range samples {
+		samples[i] = []byte(fmt.Sprintf(`{"status":1,"blkHeight":%d,"gasConsumed":10000,"contractAddress":"io1qyqsyqcy6nm58gjd2wr035wz5eyd5uq47zyqpng","logs":[]}`, i))
+	}
+	_, err = BuildZstdDict(0, samples, 1024)
+	r.ErrorContains(err, "id cannot be 0")
+	dict, err := BuildZstdDict(1, samples, 1024)
+	r.NoError(err)
+	_, err = NewCodec(Zstd, []byte("invalid dictionary"))
+	r.Error(err)
+
+	withDict, err := NewCodec(Zstd, dict)
+	r.NoError(err)
+	r.Equal(Zstd, withDict.Compressor())
+	for _, compressor := range []string{"", Gzip, Snappy, Zstd, Lz4} {
+		c, err := NewCodec(compressor, nil)
+		r.NoError(err)
+		for _, c := range []*Codec{c, withDict} {
+			v, err := c.Compress(samples[10])
+			r.NoError(err)
+			ser, err := c.Decompress(v)
+			r.NoError(err)
+			r.Equal(samples[10], ser)
+		}
+	}
+
+	// the dictionary improves the compression of small data
+	v, err := CompZstd(samples[10])
+	r.NoError(err)
+	v1, err := withDict.Compress(samples[10])
+	r.NoError(err)
+	r.Less(len(v1), len(v))
+	// data compressed with dictionary cannot be decompressed without it
+	_, err = DecompZstd(v1)
+	r.Error(err)
+}
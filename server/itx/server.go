@@ -117,6 +117,28 @@ func newServer(cfg config.Config, testing bool) (*Server, error) {
 func (s *Server) Start(ctx context.Context) error {
 	cctx, cancel := context.WithCancel(ctx)
 	s.subModuleCancel = cancel
+	p2pStarted := false
+	for id, cs := range s.chainservices {
+		if cs.StateSyncPending() {
+			s.dispatcher.AddSubscriber(id, &stateSyncSubscriber{cs: cs})
+		}
+	}
+	for id, cs := range s.chainservices {
+		if !cs.StateSyncPending() {
+			continue
+		}
+		// the state snapshot is synced over p2p before the chain service starts
+		if !p2pStarted {
+			if err := s.startP2P(cctx); err != nil {
+				return err
+			}
+			p2pStarted = true
+		}
+		if err := cs.SyncState(cctx); err != nil {
+			return errors.Wrapf(err, "failed to sync state of chain %d", id)
+		}
+		s.dispatcher.AddSubscriber(id, cs)
+	}
 	for id, cs := range s.chainservices {
 		if err := cs.Start(cctx); err != nil {
 			return errors.Wrap(err, "error when starting blockchain")
@@ -127,11 +149,10 @@ func (s *Server) Start(ctx context.Context) error {
 			}
 		}
 	}
-	if err := s.p2pAgent.Start(cctx); err != nil {
-		return errors.Wrap(err, "error when starting P2P agent")
-	}
-	if err := s.dispatcher.Start(cctx); err != nil {
-		return errors.Wrap(err, "error when starting dispatcher")
+	if !p2pStarted {
+		if err := s.startP2P(cctx); err != nil {
+			return err
+		}
 	}
 	if err := s.nodeStats.Start(cctx); err != nil {
 		return errors.Wrap(err, "error when starting node stats")
@@ -139,6 +160,16 @@ func (s *Server) Start(ctx context.Context) error {
 	return nil
 }
 
+func (s *Server) startP2P(ctx context.Context) error {
+	if err := s.p2pAgent.Start(ctx); err != nil {
+		return errors.Wrap(err, "error when starting P2P agent")
+	}
+	if err := s.dispatcher.Start(ctx); err != nil {
+		return errors.Wrap(err, "error when starting dispatcher")
+	}
+	return nil
+}
+
 // Stop stops the server
 func (s *Server) Stop(ctx context.Context) error {
 	defer s.subModuleCancel()
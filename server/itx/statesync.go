@@ -0,0 +1,62 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package itx
+
+import (
+	"context"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-proto/golang/iotexrpc"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/libp2p/go-libp2p/core/peer"
+
+	"github.com/iotexproject/iotex-core/v2/blocksync/statesyncpb"
+	"github.com/iotexproject/iotex-core/v2/chainservice"
+)
+
+// stateSyncSubscriber only handles the state sync messages and the blocks for a chain service syncing its
+// state snapshot, and drops the other messages since the chain service has not started yet
+type stateSyncSubscriber struct {
+	cs *chainservice.ChainService
+}
+
+func (s *stateSyncSubscriber) ReportFullness(context.Context, iotexrpc.MessageType, float32) {}
+
+func (s *stateSyncSubscriber) HandleAction(context.Context, *iotextypes.Action) error { return nil }
+
+func (s *stateSyncSubscriber) HandleBlock(ctx context.Context, peer string, blk *iotextypes.Block) error {
+	return s.cs.HandleStateSyncBlock(ctx, peer, blk)
+}
+
+func (s *stateSyncSubscriber) HandleSyncRequest(context.Context, peer.AddrInfo, *iotexrpc.BlockSync) error {
+	return nil
+}
+
+func (s *stateSyncSubscriber) HandleConsensusMsg(*iotextypes.ConsensusMessage) error { return nil }
+
+func (s *stateSyncSubscriber) HandleNodeInfoRequest(context.Context, peer.AddrInfo, *iotextypes.NodeInfoRequest) error {
+	return nil
+}
+
+func (s *stateSyncSubscriber) HandleNodeInfo(context.Context, string, *iotextypes.NodeInfo) error {
+	return nil
+}
+
+func (s *stateSyncSubscriber) HandleActionRequest(context.Context, peer.AddrInfo, hash.Hash256) error {
+	return nil
+}
+
+func (s *stateSyncSubscriber) HandleActionHash(context.Context, hash.Hash256, string) error {
+	return nil
+}
+
+func (s *stateSyncSubscriber) HandleStateSyncRequest(ctx context.Context, peer peer.AddrInfo, req *statesyncpb.StateSyncRequest) error {
+	return s.cs.HandleStateSyncRequest(ctx, peer, req)
+}
+
+func (s *stateSyncSubscriber) HandleStateSyncResponse(ctx context.Context, peer string, resp *statesyncpb.StateSyncResponse) error {
+	return s.cs.HandleStateSyncResponse(ctx, peer, resp)
+}
@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"strconv"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/pkg/errors"
@@ -30,9 +31,11 @@ import (
 	"github.com/iotexproject/iotex-core/v2/db"
 	"github.com/iotexproject/iotex-core/v2/db/batch"
 	"github.com/iotexproject/iotex-core/v2/db/trie"
+	"github.com/iotexproject/iotex-core/v2/db/trie/mptrie"
 	"github.com/iotexproject/iotex-core/v2/pkg/lifecycle"
 	"github.com/iotexproject/iotex-core/v2/pkg/log"
 	"github.com/iotexproject/iotex-core/v2/pkg/prometheustimer"
+	"github.com/iotexproject/iotex-core/v2/pkg/routine"
 	"github.com/iotexproject/iotex-core/v2/pkg/tracer"
 	"github.com/iotexproject/iotex-core/v2/pkg/util/byteutil"
 	"github.com/iotexproject/iotex-core/v2/state"
@@ -49,6 +52,8 @@ const (
 	ArchiveTrieNamespace = "AccountTrie"
 	// ArchiveTrieRootKey indicates the key of accountTrie root hash in underlying DB
 	ArchiveTrieRootKey = "archiveTrieRoot"
+	// PrunedHeightKey indicates the key of the height below which the state history has been pruned
+	PrunedHeightKey = "prunedHeight"
 )
 
 var (
@@ -56,6 +61,8 @@ var (
 	ErrNotSupported = errors.New("not supported")
 	// ErrNoArchiveData is the error that the node have no archive data
 	ErrNoArchiveData = errors.New("no archive data")
+	// ErrHistoryPruned is the error that the state history at the height has been pruned
+	ErrHistoryPruned = errors.New("history has been pruned")
 
 	_dbBatchSizelMtc = prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
@@ -90,6 +97,11 @@ type (
 		WorkingSetAtHeight(context.Context, uint64, ...*action.SealedEnvelope) (protocol.StateManager, error)
 	}
 
+	// StateProver provides the merkle proof of a state in the state trie
+	StateProver interface {
+		StateProof(...protocol.StateOption) ([]byte, [][]byte, error)
+	}
+
 	// factory implements StateFactory interface, tracks changes to account/contract and batch-commits to DB
 	factory struct {
 		lifecycle                lifecycle.Lifecycle
@@ -105,6 +117,9 @@ type (
 		protocolView             protocol.View
 		skipBlockValidationOnPut bool
 		ps                       *patchStore
+		historyWindow            uint64        // number of recent blocks whose history is retained, 0 means full history
+		prunedHeight             atomic.Uint64 // the history below this height has been pruned
+		historyDBs               []db.VersionedDB
 	}
 
 	// Config contains the config for factory
@@ -141,6 +156,14 @@ func SkipBlockValidationOption() Option {
 	}
 }
 
+// PruneHistoryDBOption prunes the versioned dbs along with the state history
+func PruneHistoryDBOption(vdbs ...db.VersionedDB) Option {
+	return func(sf *factory, cfg *Config) error {
+		sf.historyDBs = append(sf.historyDBs, vdbs...)
+		return nil
+	}
+}
+
 // DefaultTriePatchOption loads patchs
 func DefaultTriePatchOption() Option {
 	return func(sf *factory, cfg *Config) (err error) {
@@ -160,6 +183,10 @@ func NewFactory(cfg Config, dao db.KVStore, opts ...Option) (Factory, error) {
 		workingsets:        cache.NewThreadSafeLruCache(int(cfg.Chain.WorkingSetCacheSize)),
 		dao:                dao,
 	}
+	if sf.saveHistory && cfg.Chain.HistoryWindow > 0 {
+		sf.historyWindow = cfg.Chain.HistoryWindow
+		sf.lifecycle.Add(routine.NewRecurringTask(sf.pruneHistoryTask, cfg.Chain.HistoryPruneInterval))
+	}
 
 	for _, opt := range opts {
 		if err := opt(sf, &cfg); err != nil {
@@ -227,17 +254,35 @@ func (sf *factory) Start(ctx context.Context) error {
 	default:
 		return err
 	}
+	if sf.saveHistory {
+		h, err := sf.dao.Get(AccountKVNamespace, []byte(PrunedHeightKey))
+		switch errors.Cause(err) {
+		case nil:
+			sf.prunedHeight.Store(byteutil.BytesToUint64(h))
+		case db.ErrNotExist:
+		default:
+			return errors.Wrap(err, "failed to get pruned height")
+		}
+	}
 	return sf.lifecycle.OnStart(ctx)
 }
 
 func (sf *factory) Stop(ctx context.Context) error {
+	if err := sf.stopDAO(ctx); err != nil {
+		return err
+	}
+	// the lock is released before stopping the background tasks, which may be waiting for it
+	return sf.lifecycle.OnStop(ctx)
+}
+
+func (sf *factory) stopDAO(ctx context.Context) error {
 	sf.mutex.Lock()
 	defer sf.mutex.Unlock()
 	if err := sf.dao.Stop(ctx); err != nil {
 		return err
 	}
 	sf.workingsets.Clear()
-	return sf.lifecycle.OnStop(ctx)
+	return nil
 }
 
 // Height returns factory's height
@@ -279,7 +324,7 @@ func (sf *factory) newWorkingSetAtHeight(ctx context.Context, height uint64) (*w
 
 	g := genesis.MustExtractGenesisContext(ctx)
 	flusher, err := db.NewKVStoreFlusher(
-		sf.dao,
+		&historyKVStore{KVStore: sf.dao, height: height, prunedHeight: &sf.prunedHeight},
 		batch.NewCachedBatch(),
 		sf.flusherOptions(!g.IsEaster(height))...,
 	)
@@ -417,6 +462,10 @@ func (sf *factory) WorkingSetAtHeight(ctx context.Context, height uint64, preact
 		sf.mutex.Unlock()
 		return nil, errors.Errorf("query height %d is higher than tip height %d", height, sf.currentChainHeight)
 	}
+	if oldest := sf.oldestHistoryHeight(); height < oldest {
+		sf.mutex.Unlock()
+		return nil, errors.Wrapf(ErrHistoryPruned, "query height %d is lower than the oldest retained height %d", height, oldest)
+	}
 	ws, err := sf.newWorkingSetAtHeight(ctx, height)
 	sf.mutex.Unlock()
 	if err != nil {
@@ -552,6 +601,13 @@ func namespaceKey(ns string) []byte {
 	return h[:]
 }
 
+// VerifyStateProof verifies the merkle proof of a state against the root hash of the state trie,
+// and returns the serialized state
+func VerifyStateProof(rootHash []byte, ns string, key []byte, proof [][]byte) ([]byte, error) {
+	nsHash := hash.Hash160b([]byte(ns))
+	return mptrie.VerifyTwoLayerProof(rootHash, nsHash[:], toLegacyKey(key), proof)
+}
+
 func toLegacyKey(input []byte) []byte {
 	key := hash.Hash160b(input)
 	return key[:]
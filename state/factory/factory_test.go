@@ -39,7 +39,9 @@ import (
 	"github.com/iotexproject/iotex-core/v2/blockchain/block"
 	"github.com/iotexproject/iotex-core/v2/blockchain/genesis"
 	"github.com/iotexproject/iotex-core/v2/db"
+	"github.com/iotexproject/iotex-core/v2/db/batch"
 	"github.com/iotexproject/iotex-core/v2/pkg/enc"
+	"github.com/iotexproject/iotex-core/v2/pkg/util/byteutil"
 	"github.com/iotexproject/iotex-core/v2/pkg/util/fileutil"
 	"github.com/iotexproject/iotex-core/v2/state"
 	"github.com/iotexproject/iotex-core/v2/test/identityset"
@@ -507,6 +509,152 @@ func testState(sf Factory, t *testing.T) {
 	require.Equal(t, big.NewInt(90), accountA.Balance)
 }
 
+func TestHistoryPruning(t *testing.T) {
+	r := require.New(t)
+	cfg := DefaultConfig
+	file, err := testutil.PathOfTempFile(_triePath)
+	r.NoError(err)
+	defer testutil.CleanupPath(file)
+	cfg.Chain.TrieDBPath = file
+	cfg.Chain.EnableArchiveMode = true
+	cfg.Chain.HistoryWindow = 3
+	cfg.Chain.HistoryPruneInterval = time.Hour
+	db1, err := db.CreateKVStore(db.DefaultConfig, cfg.Chain.TrieDBPath)
+	r.NoError(err)
+	vfile, err := testutil.PathOfTempFile("versioned")
+	r.NoError(err)
+	defer testutil.CleanupPath(vfile)
+	vns := "vns"
+	vdb, err := db.CreateVersionedDB(db.DefaultConfig, vfile, db.NewNamespace(vns, 4))
+	r.NoError(err)
+	f, err := NewFactory(cfg, db1, SkipBlockValidationOption(), PruneHistoryDBOption(vdb))
+	r.NoError(err)
+	sf := f.(*factory)
+
+	a := identityset.Address(28)
+	b := identityset.Address(31)
+	r.NoError(sf.Register(account.NewProtocol(rewarding.DepositGas)))
+	ge := genesis.TestDefault()
+	ge.InitBalanceMap[a.String()] = "100"
+	ctx := genesis.WithGenesisContext(protocol.WithBlockCtx(
+		context.Background(),
+		protocol.BlockCtx{
+			BlockHeight: 0,
+			Producer:    identityset.Address(27),
+			GasLimit:    1000000,
+		},
+	), ge)
+	r.NoError(sf.Start(ctx))
+	defer func() {
+		r.NoError(sf.Stop(ctx))
+	}()
+	r.NoError(vdb.Start(ctx))
+	defer func() {
+		r.NoError(vdb.Stop(ctx))
+	}()
+	vkey := []byte("vkey")
+	for _, h := range []uint64{1, 2, 4} {
+		r.NoError(vdb.Put(h, vns, vkey, byteutil.Uint64ToBytes(h)))
+	}
+
+	// transfer 10 to b in each block
+	for i := uint64(1); i <= 5; i++ {
+		elp := (&action.EnvelopeBuilder{}).SetAction(action.NewTransfer(big.NewInt(10), b.String(), nil)).
+			SetGasLimit(20000).SetNonce(i).Build()
+		selp, err := action.Sign(elp, identityset.PrivateKey(28))
+		r.NoError(err)
+		blkCtx := protocol.WithFeatureCtx(protocol.WithBlockchainCtx(protocol.WithBlockCtx(ctx, protocol.BlockCtx{
+			BlockHeight: i,
+			Producer:    identityset.Address(27),
+			GasLimit:    1000000,
+		}), protocol.BlockchainCtx{
+			ChainID: 1,
+		}))
+		blk, err := block.NewTestingBuilder().
+			SetHeight(i).
+			SetPrevBlockHash(hash.ZeroHash256).
+			SetTimeStamp(testutil.TimestampNow()).
+			AddActions(selp).
+			SignAndBuild(identityset.PrivateKey(27))
+		r.NoError(err)
+		r.NoError(sf.PutBlock(blkCtx, &blk))
+	}
+	countNodes := func() int {
+		keys, _, err := db1.Filter(ArchiveTrieNamespace, func(k, v []byte) bool { return true }, nil, nil)
+		r.NoError(err)
+		return len(keys)
+	}
+	checkBalance := func() {
+		for h := uint64(3); h <= 5; h++ {
+			ws, err := sf.WorkingSetAtHeight(ctx, h)
+			r.NoError(err)
+			accountB, err := accountutil.AccountState(ctx, ws, b)
+			r.NoError(err)
+			r.Equal(big.NewInt(int64(10*h)), accountB.Balance)
+		}
+		for _, h := range []uint64{0, 2} {
+			_, err := sf.WorkingSetAtHeight(ctx, h)
+			r.Equal(ErrHistoryPruned, errors.Cause(err))
+		}
+	}
+	// the heights out of window are not available even before pruning
+	checkBalance()
+	before := countNodes()
+	r.NoError(sf.pruneHistory())
+	r.Less(countNodes(), before)
+	r.EqualValues(3, sf.prunedHeight.Load())
+	_, err = db1.Get(ArchiveTrieNamespace, []byte(ArchiveTrieRootKey+"-2"))
+	r.Equal(db.ErrNotExist, errors.Cause(err))
+	// the marks are cleared
+	_, _, err = db1.Filter(_pruneMarkNamespace, func(k, v []byte) bool { return true }, nil, nil)
+	r.Equal(db.ErrNotExist, errors.Cause(err))
+	// the versioned db is pruned as well
+	_, err = vdb.Get(1, vns, vkey)
+	r.Equal(db.ErrNotExist, errors.Cause(err))
+	v, err := vdb.Get(3, vns, vkey)
+	r.NoError(err)
+	r.EqualValues(2, byteutil.BytesToUint64(v))
+	checkBalance()
+	accountB, err := accountutil.AccountState(ctx, sf, b)
+	r.NoError(err)
+	r.Equal(big.NewInt(50), accountB.Balance)
+	// nothing to prune within the window
+	after := countNodes()
+	r.NoError(sf.pruneHistory())
+	r.Equal(after, countNodes())
+	h, err := db1.Get(AccountKVNamespace, []byte(PrunedHeightKey))
+	r.NoError(err)
+	r.EqualValues(3, byteutil.BytesToUint64(h))
+	// the working set opened before pruning rejects the reads
+	ws, err := sf.WorkingSetAtHeight(ctx, 3)
+	r.NoError(err)
+	sf.historyWindow = 2
+	r.NoError(sf.pruneHistory())
+	r.EqualValues(4, sf.prunedHeight.Load())
+	_, err = accountutil.AccountState(ctx, ws, b)
+	r.Equal(ErrHistoryPruned, errors.Cause(err))
+
+	// an interrupted pruning resumes at the height it started with, even if out of the window
+	sb := batch.NewBatch()
+	sb.Put(AccountKVNamespace, []byte(PrunedHeightKey), byteutil.Uint64ToBytes(5), "")
+	sb.Put(_pruneMarkNamespace, []byte(_pruneInProgressKey), []byte{1}, "")
+	sb.Put(_pruneMarkNamespace, []byte(_pruneSweptKey), byteutil.Uint64ToBytes(1<<8), "")
+	r.NoError(db1.WriteBatch(sb))
+	sf.prunedHeight.Store(5)
+	sf.historyWindow = 3
+	r.NoError(sf.pruneHistory())
+	r.EqualValues(5, sf.prunedHeight.Load())
+	_, err = db1.Get(ArchiveTrieNamespace, []byte(ArchiveTrieRootKey+"-4"))
+	r.Equal(db.ErrNotExist, errors.Cause(err))
+	_, _, err = db1.Filter(_pruneMarkNamespace, func(k, v []byte) bool { return true }, nil, nil)
+	r.Equal(db.ErrNotExist, errors.Cause(err))
+	ws, err = sf.WorkingSetAtHeight(ctx, 5)
+	r.NoError(err)
+	accountB, err = accountutil.AccountState(ctx, ws, b)
+	r.NoError(err)
+	r.Equal(big.NewInt(50), accountB.Balance)
+}
+
 func testHistoryState(sf Factory, t *testing.T, statetx, archive bool) {
 	// Create a dummy iotex address
 	a := identityset.Address(28)
@@ -1144,12 +1292,18 @@ func testNewBlockBuilder(factory Factory, t *testing.T) {
 		protocol.BlockchainCtx{},
 	)
 	ctx = protocol.WithFeatureCtx(protocol.WithFeatureWithHeightCtx(ctx))
+	// the call traces recorded in building the block are kept with the cached working set
+	ctx = protocol.WithCallTraceCtx(ctx)
 	blkBuilder, err := factory.NewBlockBuilder(ctx, ap, nil)
 	require.NoError(err)
 	require.NotNil(blkBuilder)
 	blk, err := blkBuilder.SignAndBuild(identityset.PrivateKey(27))
 	require.NoError(err)
 	require.NoError(factory.PutBlock(ctx, &blk))
+	require.Len(blk.Receipts, 3)
+	for _, receipt := range blk.Receipts {
+		require.NotEmpty(receipt.CallTraces())
+	}
 }
 
 func TestSimulateExecution(t *testing.T) {
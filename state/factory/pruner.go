@@ -0,0 +1,359 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package factory
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"sync/atomic"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/v2/db"
+	"github.com/iotexproject/iotex-core/v2/db/batch"
+	"github.com/iotexproject/iotex-core/v2/db/trie"
+	"github.com/iotexproject/iotex-core/v2/db/trie/mptrie"
+	"github.com/iotexproject/iotex-core/v2/pkg/log"
+	"github.com/iotexproject/iotex-core/v2/pkg/util/byteutil"
+)
+
+const (
+	// _pruneMarkNamespace keeps the marks of the trie nodes in use while pruning, so that the marks are not held
+	// in memory
+	_pruneMarkNamespace = "PruneMark"
+	// _pruneInProgressKey is kept in the mark namespace until the pruning completes
+	_pruneInProgressKey = "pruneInProgress"
+	// _pruneMarkedKey is the height up to which the trie nodes in use have been marked
+	_pruneMarkedKey = "pruneMarked"
+	// _pruneSweptKey is the number of the key ranges which have been swept
+	_pruneSweptKey = "pruneSwept"
+	// _pruneBatchSize is the max number of marks buffered in memory before written to db
+	_pruneBatchSize = 10000
+	// _pruneRangePrefixLen is the length of the key prefix of a range of keys swept in one batch
+	_pruneRangePrefixLen = 2
+	// _pruneRanges is the number of the ranges of keys
+	_pruneRanges = 1 << (8 * _pruneRangePrefixLen)
+)
+
+var _pruneRangeMaxSuffix = bytes.Repeat([]byte{0xff}, 64)
+
+// historyKVStore is the store of a working set at a historical height, which rejects the reads once the history
+// at the height is pruned, as the trie nodes may have been deleted
+type historyKVStore struct {
+	db.KVStore
+	height       uint64
+	prunedHeight *atomic.Uint64
+}
+
+func (s *historyKVStore) checkPruned() error {
+	if pruned := s.prunedHeight.Load(); s.height < pruned {
+		return errors.Wrapf(ErrHistoryPruned, "query height %d is lower than the pruned height %d", s.height, pruned)
+	}
+	return nil
+}
+
+func (s *historyKVStore) Get(ns string, key []byte) ([]byte, error) {
+	if err := s.checkPruned(); err != nil {
+		return nil, err
+	}
+	value, err := s.KVStore.Get(ns, key)
+	if err != nil {
+		// the key may be deleted by the pruning in the meantime
+		if perr := s.checkPruned(); perr != nil {
+			return nil, perr
+		}
+	}
+	return value, err
+}
+
+func (s *historyKVStore) Filter(ns string, cond db.Condition, minKey, maxKey []byte) ([][]byte, [][]byte, error) {
+	if err := s.checkPruned(); err != nil {
+		return nil, nil, err
+	}
+	keys, values, err := s.KVStore.Filter(ns, cond, minKey, maxKey)
+	if perr := s.checkPruned(); perr != nil {
+		return nil, nil, perr
+	}
+	return keys, values, err
+}
+
+// oldestHistoryHeight returns the oldest height whose state history is retained, caller should hold the lock
+func (sf *factory) oldestHistoryHeight() uint64 {
+	oldest := sf.prunedHeight.Load()
+	if sf.historyWindow > 0 && sf.currentChainHeight >= sf.historyWindow {
+		if h := sf.currentChainHeight - sf.historyWindow + 1; h > oldest {
+			oldest = h
+		}
+	}
+	return oldest
+}
+
+func (sf *factory) pruneHistoryTask() {
+	if err := sf.pruneHistory(); err != nil {
+		log.L().Error("Failed to prune state history.", zap.Error(err))
+	}
+}
+
+// pruneHistory removes the state roots below the history window, and the trie nodes which
+// are not reachable from any state root within the window. The nodes in use are marked in
+// db, and the nodes are swept range by range, to bound the memory in use. The progress of
+// marking and sweeping is kept in db, so an interrupted pruning resumes where it stopped.
+func (sf *factory) pruneHistory() error {
+	sf.mutex.RLock()
+	tip, target := sf.currentChainHeight, sf.oldestHistoryHeight()
+	sf.mutex.RUnlock()
+	interrupted, err := sf.isPruneMarked(nil, []byte(_pruneInProgressKey))
+	if err != nil {
+		return err
+	}
+	var (
+		marked uint64
+		swept  int
+	)
+	if interrupted {
+		// resume the pruning at the height it started with
+		target = sf.prunedHeight.Load()
+		if marked, err = sf.pruneProgress(_pruneMarkedKey); err != nil {
+			return err
+		}
+		var n uint64
+		if n, err = sf.pruneProgress(_pruneSweptKey); err != nil {
+			return err
+		}
+		swept = int(n)
+		log.L().Info("Resuming state history pruning.", zap.Uint64("height", target), zap.Uint64("marked", marked), zap.Int("swept", swept))
+	} else {
+		if target <= sf.prunedHeight.Load() {
+			return nil
+		}
+		// reject the reads below the target before any node is deleted, including the working sets opened already
+		b := batch.NewBatch()
+		b.Put(AccountKVNamespace, []byte(PrunedHeightKey), byteutil.Uint64ToBytes(target), "failed to put pruned height")
+		b.Put(_pruneMarkNamespace, []byte(_pruneInProgressKey), []byte{1}, "failed to put pruning mark")
+		if err := sf.dao.WriteBatch(b); err != nil {
+			return errors.Wrap(err, "failed to start pruning state history")
+		}
+		sf.prunedHeight.Store(target)
+	}
+
+	kvStore, err := trie.NewKVStore(ArchiveTrieNamespace, sf.dao)
+	if err != nil {
+		return err
+	}
+	rootKeyPrefix := []byte(ArchiveTrieRootKey + "-")
+	deleted := 0
+	if swept < _pruneRanges {
+		// mark the nodes in use without holding the lock, as it takes a while. The marking of the height
+		// following the marked one may have been interrupted, so it is walked fully
+		from := max(marked+1, target)
+		if err := sf.markHistory(kvStore, from, tip, interrupted); err != nil {
+			return err
+		}
+		marked = max(marked, tip)
+	}
+	if err := forEachKeyRange(swept, func(i int, minKey, maxKey []byte) error {
+		keys, _, err := sf.dao.Filter(ArchiveTrieNamespace, func(k, _ []byte) bool {
+			if bytes.HasPrefix(k, rootKeyPrefix) {
+				h, err := strconv.ParseUint(string(k[len(rootKeyPrefix):]), 10, 64)
+				return err == nil && h < target
+			}
+			return !bytes.Equal(k, []byte(ArchiveTrieRootKey))
+		}, minKey, maxKey)
+		switch errors.Cause(err) {
+		case nil:
+		case db.ErrNotExist:
+			return nil
+		default:
+			return errors.Wrap(err, "failed to find prunable trie nodes")
+		}
+
+		sf.mutex.Lock()
+		defer sf.mutex.Unlock()
+		// the nodes of blocks committed in the meantime are in use as well
+		if err := sf.markHistory(kvStore, marked+1, sf.currentChainHeight, false); err != nil {
+			return err
+		}
+		marked = max(marked, sf.currentChainHeight)
+		b := batch.NewBatch()
+		for _, k := range keys {
+			if !bytes.HasPrefix(k, rootKeyPrefix) {
+				isMarked, err := sf.isPruneMarked(nil, k)
+				if err != nil {
+					return err
+				}
+				if isMarked {
+					continue
+				}
+			}
+			b.Delete(ArchiveTrieNamespace, k, "failed to delete pruned trie node")
+		}
+		deleted += b.Size()
+		// the progress is written along with the deletes of the range
+		b.Put(_pruneMarkNamespace, []byte(_pruneSweptKey), byteutil.Uint64ToBytes(uint64(i+1)), "failed to put pruning progress")
+		if err := sf.dao.WriteBatch(b); err != nil {
+			return errors.Wrap(err, "failed to prune state history")
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	// the versioned dbs resume their own pruning at the same height
+	for _, vdb := range sf.historyDBs {
+		if err := vdb.Prune(target); err != nil {
+			return errors.Wrap(err, "failed to prune history db")
+		}
+	}
+	if err := sf.clearPruneMarks(); err != nil {
+		return err
+	}
+	log.L().Info("Pruned state history.", zap.Uint64("height", target), zap.Int("keys", deleted))
+	return nil
+}
+
+func (sf *factory) pruneProgress(key string) (uint64, error) {
+	v, err := sf.dao.Get(_pruneMarkNamespace, []byte(key))
+	switch errors.Cause(err) {
+	case nil:
+		return byteutil.BytesToUint64(v), nil
+	case db.ErrNotExist, db.ErrBucketNotExist:
+		return 0, nil
+	default:
+		return 0, errors.Wrap(err, "failed to get pruning progress")
+	}
+}
+
+// markHistory marks the trie nodes reachable from the state roots within [from, to] in db, along with the
+// height marked. If full, the trie at from is walked down to the nodes marked in db, as an interrupted
+// marking may have left a node marked without its children
+func (sf *factory) markHistory(kvStore trie.KVStore, from, to uint64, full bool) error {
+	var (
+		b       = batch.NewBatch()
+		pending = make(map[string]struct{})
+		markErr error
+	)
+	flush := func() error {
+		if err := sf.dao.WriteBatch(b); err != nil {
+			return errors.Wrap(err, "failed to write the marks of trie nodes")
+		}
+		b = batch.NewBatch()
+		pending = make(map[string]struct{})
+		return nil
+	}
+	for h := from; h <= to; h++ {
+		root, err := sf.dao.Get(ArchiveTrieNamespace, []byte(fmt.Sprintf("%s-%d", ArchiveTrieRootKey, h)))
+		if err != nil {
+			return errors.Wrapf(err, "failed to get state root at height %d", h)
+		}
+		if err := mptrie.WalkTwoLayerNodes(kvStore, root, func(key []byte) bool {
+			if markErr != nil {
+				return false
+			}
+			var (
+				isMarked bool
+				err      error
+			)
+			if full && h == from {
+				_, isMarked = pending[string(key)]
+			} else {
+				isMarked, err = sf.isPruneMarked(pending, key)
+			}
+			if err != nil {
+				markErr = err
+				return false
+			}
+			if isMarked {
+				// the subtree has been visited
+				return false
+			}
+			pending[string(key)] = struct{}{}
+			b.Put(_pruneMarkNamespace, key, []byte{1}, "failed to mark trie node")
+			if len(pending) >= _pruneBatchSize {
+				markErr = flush()
+			}
+			return true
+		}); err != nil {
+			return errors.Wrapf(err, "failed to walk state trie at height %d", h)
+		}
+		if markErr != nil {
+			return markErr
+		}
+		// written along with the last marks of the height
+		b.Put(_pruneMarkNamespace, []byte(_pruneMarkedKey), byteutil.Uint64ToBytes(h), "failed to put marked height")
+	}
+	return flush()
+}
+
+func (sf *factory) isPruneMarked(pending map[string]struct{}, key []byte) (bool, error) {
+	if _, ok := pending[string(key)]; ok {
+		return true, nil
+	}
+	_, err := sf.dao.Get(_pruneMarkNamespace, key)
+	switch errors.Cause(err) {
+	case nil:
+		return true, nil
+	case db.ErrNotExist, db.ErrBucketNotExist:
+		return false, nil
+	default:
+		return false, errors.Wrap(err, "failed to get the mark of trie node")
+	}
+}
+
+// clearPruneMarks deletes the marks range by range, and the progress keys at last
+func (sf *factory) clearPruneMarks() error {
+	if err := forEachKeyRange(0, func(_ int, minKey, maxKey []byte) error {
+		keys, _, err := sf.dao.Filter(_pruneMarkNamespace, func(k, _ []byte) bool {
+			return !isPruneProgressKey(k)
+		}, minKey, maxKey)
+		switch errors.Cause(err) {
+		case nil:
+		case db.ErrNotExist:
+			return nil
+		default:
+			return err
+		}
+		b := batch.NewBatch()
+		for _, k := range keys {
+			b.Delete(_pruneMarkNamespace, k, "failed to delete the mark of trie node")
+		}
+		return sf.dao.WriteBatch(b)
+	}); err != nil {
+		if errors.Cause(err) == db.ErrBucketNotExist {
+			return nil
+		}
+		return errors.Wrap(err, "failed to clear the marks of trie nodes")
+	}
+	b := batch.NewBatch()
+	b.Delete(_pruneMarkNamespace, []byte(_pruneMarkedKey), "failed to delete marked height")
+	b.Delete(_pruneMarkNamespace, []byte(_pruneSweptKey), "failed to delete pruning progress")
+	b.Delete(_pruneMarkNamespace, []byte(_pruneInProgressKey), "failed to delete pruning mark")
+	return sf.dao.WriteBatch(b)
+}
+
+func isPruneProgressKey(k []byte) bool {
+	switch string(k) {
+	case _pruneInProgressKey, _pruneMarkedKey, _pruneSweptKey:
+		return true
+	default:
+		return false
+	}
+}
+
+// forEachKeyRange calls fn with the ranges of keys sharing the same prefix in ascending order, starting at
+// the range numbered start
+func forEachKeyRange(start int, fn func(i int, minKey, maxKey []byte) error) error {
+	for i := start; i < _pruneRanges; i++ {
+		prefix := make([]byte, _pruneRangePrefixLen)
+		for j := range prefix {
+			prefix[j] = byte(i >> (8 * (_pruneRangePrefixLen - 1 - j)))
+		}
+		if err := fn(i, prefix, append(prefix, _pruneRangeMaxSuffix...)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,155 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package factory
+
+import (
+	"context"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/iotexproject/iotex-core/v2/action"
+	"github.com/iotexproject/iotex-core/v2/action/protocol"
+	accountutil "github.com/iotexproject/iotex-core/v2/action/protocol/account/util"
+	"github.com/iotexproject/iotex-core/v2/action/protocol/execution/evm"
+	"github.com/iotexproject/iotex-core/v2/pkg/util/byteutil"
+)
+
+// ErrSimulateBlockGasLimit indicates the actions simulated exceed the gas limit of the simulated block
+var ErrSimulateBlockGasLimit = errors.New("simulated block gas limit exceeded")
+
+// Simulator runs a sequence of actions upon a working set in memory, across one or more simulated blocks.
+// Each action sees the state changes of the actions before it, and the working set is never committed.
+type Simulator struct {
+	ws      *workingSet
+	started bool
+	gasUsed uint64
+}
+
+// NewSimulator creates a simulator upon a working set created by WorkingSet or WorkingSetAtHeight
+func NewSimulator(sm protocol.StateManager) (*Simulator, error) {
+	ws, ok := sm.(*workingSet)
+	if !ok {
+		return nil, errors.Errorf("cannot simulate upon state manager of type %T", sm)
+	}
+	return &Simulator{ws: ws}, nil
+}
+
+// StateManager returns the working set being simulated upon
+func (s *Simulator) StateManager() protocol.StateManager {
+	return s.ws
+}
+
+// NextBlock moves the working set to the block in the context, which has to be higher than the previous one
+func (s *Simulator) NextBlock(ctx context.Context) error {
+	blkCtx := protocol.MustGetBlockCtx(ctx)
+	if s.started && blkCtx.BlockHeight <= s.ws.height {
+		return errors.Errorf("simulated block %d is not higher than the previous block %d", blkCtx.BlockHeight, s.ws.height)
+	}
+	s.ws.height = blkCtx.BlockHeight
+	s.started = true
+	s.gasUsed = 0
+	for _, p := range protocol.MustGetRegistry(ctx).All() {
+		if pp, ok := p.(protocol.PreStatesCreator); ok {
+			if err := pp.CreatePreStates(ctx, s.ws); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// RunAction runs the action of the caller in the current block, with the nonce set to the pending nonce of the
+// caller, and the gas limit set to the gas left in the block if not specified. Executions return the output
+// of the evm as well.
+func (s *Simulator) RunAction(ctx context.Context, caller address.Address, elp action.Envelope) ([]byte, *action.Receipt, error) {
+	if !s.started {
+		return nil, nil, errors.New("no simulated block")
+	}
+	if err := s.ws.validate(ctx); err != nil {
+		return nil, nil, err
+	}
+	intrinsicGas, err := elp.IntrinsicGas()
+	if err != nil {
+		return nil, nil, err
+	}
+	var (
+		blkCtx  = protocol.MustGetBlockCtx(ctx)
+		gasLeft uint64
+	)
+	if blkCtx.GasLimit > s.gasUsed {
+		gasLeft = blkCtx.GasLimit - s.gasUsed
+	}
+	if elp.Gas() == 0 {
+		elp.SetGas(gasLeft)
+	}
+	switch {
+	case elp.Gas() > gasLeft || intrinsicGas > gasLeft:
+		return nil, nil, errors.Wrapf(ErrSimulateBlockGasLimit, "gas limit %d, gas left %d", max(elp.Gas(), intrinsicGas), gasLeft)
+	case elp.Gas() < intrinsicGas:
+		return nil, nil, action.ErrIntrinsicGas
+	}
+	sender, err := accountutil.AccountState(ctx, s.ws, caller)
+	if err != nil {
+		return nil, nil, err
+	}
+	if protocol.MustGetFeatureCtx(ctx).UseZeroNonceForFreshAccount {
+		elp.SetNonce(sender.PendingNonceConsideringFreshAccount())
+	} else {
+		elp.SetNonce(sender.PendingNonce())
+	}
+	actCtx := protocol.ActionCtx{
+		Caller:       caller,
+		ActionHash:   hash.Hash256b(append(caller.Bytes(), byteutil.Must(proto.Marshal(elp.Proto()))...)),
+		GasPrice:     elp.GasPrice(),
+		IntrinsicGas: intrinsicGas,
+		Nonce:        elp.Nonce(),
+		ReadOnly:     true,
+	}
+	blkCtx.GasLimit = gasLeft
+	ctx = protocol.WithActionCtx(protocol.WithBlockCtx(ctx, blkCtx), actCtx)
+	defer s.ws.ResetSnapshots()
+	if err := s.ws.freshAccountConversion(ctx, &actCtx); err != nil {
+		return nil, nil, err
+	}
+	var (
+		retval  []byte
+		receipt *action.Receipt
+	)
+	if _, ok := elp.Action().(*action.Execution); ok {
+		retval, receipt, err = evm.ExecuteContract(ctx, s.ws, elp)
+	} else {
+		receipt, err = s.runAction(ctx, elp)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	s.gasUsed += receipt.GasConsumed
+	return retval, receipt, nil
+}
+
+func (s *Simulator) runAction(ctx context.Context, elp action.Envelope) (*action.Receipt, error) {
+	reg := protocol.MustGetRegistry(ctx)
+	for _, p := range reg.All() {
+		if validator, ok := p.(protocol.ActionValidator); ok {
+			if err := validator.Validate(ctx, elp, s.ws); err != nil {
+				return nil, err
+			}
+		}
+	}
+	for _, actionHandler := range reg.All() {
+		receipt, err := actionHandler.Handle(ctx, elp, s.ws)
+		if err != nil {
+			return nil, err
+		}
+		if receipt != nil {
+			return receipt, nil
+		}
+	}
+	return nil, errors.New("receipt is empty")
+}
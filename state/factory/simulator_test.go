@@ -0,0 +1,100 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package factory
+
+import (
+	"context"
+	"math/big"
+	"path/filepath"
+	"testing"
+
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/v2/action"
+	"github.com/iotexproject/iotex-core/v2/action/protocol"
+	"github.com/iotexproject/iotex-core/v2/action/protocol/account"
+	accountutil "github.com/iotexproject/iotex-core/v2/action/protocol/account/util"
+	"github.com/iotexproject/iotex-core/v2/action/protocol/rewarding"
+	"github.com/iotexproject/iotex-core/v2/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/v2/db"
+	"github.com/iotexproject/iotex-core/v2/test/identityset"
+)
+
+func TestSimulator(t *testing.T) {
+	r := require.New(t)
+	var (
+		cfg      = DefaultConfig
+		registry = protocol.NewRegistry()
+		a        = identityset.Address(28)
+		b        = identityset.Address(29)
+	)
+	cfg.Genesis = genesis.TestDefault()
+	cfg.Genesis.InitBalanceMap = map[string]string{a.String(): "100"}
+	r.NoError(account.NewProtocol(rewarding.DepositGas).Register(registry))
+	kv, err := db.CreateKVStore(db.DefaultConfig, filepath.Join(t.TempDir(), "trie.db"))
+	r.NoError(err)
+	sf, err := NewFactory(cfg, kv, RegistryOption(registry), SkipBlockValidationOption())
+	r.NoError(err)
+	ctx := protocol.WithRegistry(protocol.WithBlockchainCtx(protocol.WithBlockCtx(
+		genesis.WithGenesisContext(context.Background(), cfg.Genesis),
+		protocol.BlockCtx{},
+	), protocol.BlockchainCtx{ChainID: 1}), registry)
+	r.NoError(sf.Start(ctx))
+	defer func() {
+		r.NoError(sf.Stop(ctx))
+	}()
+
+	ws, err := sf.WorkingSet(ctx)
+	r.NoError(err)
+	sim, err := NewSimulator(ws)
+	r.NoError(err)
+	_, err = NewSimulator(nil)
+	r.Error(err)
+	blkCtx := func(height uint64) context.Context {
+		return protocol.WithFeatureCtx(protocol.WithBlockCtx(ctx, protocol.BlockCtx{
+			BlockHeight: height,
+			Producer:    identityset.Address(27),
+			GasLimit:    25000,
+		}))
+	}
+	transfer := func(amount int64) action.Envelope {
+		return (&action.EnvelopeBuilder{}).SetChainID(1).SetAction(action.NewTransfer(big.NewInt(amount), b.String(), nil)).Build()
+	}
+	_, _, err = sim.RunAction(blkCtx(1), a, transfer(10))
+	r.ErrorContains(err, "no simulated block")
+
+	// transfer 30 to b in 3 actions across 2 blocks
+	r.NoError(sim.NextBlock(blkCtx(1)))
+	for i := 0; i < 2; i++ {
+		_, receipt, err := sim.RunAction(blkCtx(1), a, transfer(10))
+		r.NoError(err)
+		r.EqualValues(iotextypes.ReceiptStatus_Success, receipt.Status)
+		r.EqualValues(10000, receipt.GasConsumed)
+	}
+	_, _, err = sim.RunAction(blkCtx(1), a, transfer(10))
+	r.ErrorIs(err, ErrSimulateBlockGasLimit)
+	r.ErrorContains(sim.NextBlock(blkCtx(1)), "is not higher than the previous block")
+	r.NoError(sim.NextBlock(blkCtx(3)))
+	elp := transfer(10)
+	_, _, err = sim.RunAction(blkCtx(3), a, elp)
+	r.NoError(err)
+	r.EqualValues(3, elp.Nonce())
+	_, _, err = sim.RunAction(blkCtx(3), a, transfer(100))
+	r.ErrorContains(err, "not enough balance")
+
+	accountA, err := accountutil.AccountState(ctx, sim.StateManager(), a)
+	r.NoError(err)
+	r.Equal(big.NewInt(70), accountA.Balance)
+	accountB, err := accountutil.AccountState(ctx, sim.StateManager(), b)
+	r.NoError(err)
+	r.Equal(big.NewInt(30), accountB.Balance)
+
+	// nothing is committed
+	accountB, err = accountutil.AccountState(ctx, sf, b)
+	r.NoError(err)
+	r.Zero(accountB.Balance.Sign())
+}
@@ -0,0 +1,657 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package factory
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	stdhash "hash"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/sha3"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/iotexproject/iotex-core/v2/blockchain/block"
+	"github.com/iotexproject/iotex-core/v2/db"
+	"github.com/iotexproject/iotex-core/v2/db/batch"
+	"github.com/iotexproject/iotex-core/v2/db/trie"
+	"github.com/iotexproject/iotex-core/v2/db/trie/mptrie"
+	"github.com/iotexproject/iotex-core/v2/pkg/compress"
+	"github.com/iotexproject/iotex-core/v2/pkg/util/byteutil"
+)
+
+const (
+	// SnapshotVersion is the version of the state snapshot format
+	SnapshotVersion = 1
+	// SnapshotManifestFile is the name of the manifest file of a state snapshot
+	SnapshotManifestFile = "manifest.json"
+	// SnapshotStateStore is the name of the state factory's store in a state snapshot
+	SnapshotStateStore = "state"
+	// SnapshotUnverifiedKey is the key of the height of the imported state snapshot, which is kept until the
+	// imported state is verified by the next block
+	SnapshotUnverifiedKey = "snapshotUnverified"
+)
+
+var (
+	// ErrSnapshotInvalid is the error that the state snapshot is invalid
+	ErrSnapshotInvalid = errors.New("invalid state snapshot")
+
+	// _snapshotChunkSize is the size of the records in a chunk before compression
+	_snapshotChunkSize = 16 << 20
+)
+
+type (
+	// SnapshotManifest describes the content of a state snapshot
+	SnapshotManifest struct {
+		Version uint32 `json:"version"`
+		Height  uint64 `json:"height"`
+		// BlockHash is the hash of the block at the snapshot height
+		BlockHash string `json:"blockHash"`
+		// StateRoot is the root hash of the account trie, empty if the state has no account trie
+		StateRoot string `json:"stateRoot,omitempty"`
+		// Blocks are the block at the snapshot height, and the next block, which is used to verify
+		// the imported state against its delta state digest
+		Blocks []*SnapshotFile  `json:"blocks"`
+		Stores []*SnapshotStore `json:"stores"`
+	}
+
+	// SnapshotStore lists the chunks of a store in a state snapshot, and the namespaces in them
+	SnapshotStore struct {
+		Name       string               `json:"name"`
+		Chunks     []*SnapshotFile      `json:"chunks"`
+		Namespaces []*SnapshotNamespace `json:"namespaces"`
+	}
+
+	// SnapshotNamespace is the digest of the records of a namespace in a state snapshot, in the order of
+	// the records in the chunks
+	SnapshotNamespace struct {
+		Name    string `json:"name"`
+		Records uint64 `json:"records"`
+		Digest  string `json:"digest"`
+	}
+
+	// SnapshotFile is a snappy compressed file of a state snapshot
+	SnapshotFile struct {
+		Name    string `json:"name"`
+		Hash    string `json:"hash"`
+		Size    uint64 `json:"size,omitempty"`
+		Records uint64 `json:"records,omitempty"`
+	}
+
+	// namespaceLister lists the namespaces of a store
+	namespaceLister interface {
+		GetBucketByPrefix([]byte) ([][]byte, error)
+	}
+
+	chunkWriter struct {
+		dir        string
+		store      string
+		buf        bytes.Buffer
+		records    uint64
+		chunks     []*SnapshotFile
+		digest     *namespaceDigest
+		namespaces []*SnapshotNamespace
+	}
+
+	namespaceDigest struct {
+		ns      string
+		records uint64
+		hasher  stdhash.Hash
+	}
+)
+
+// ExportSnapshot exports the stores into a state snapshot in dir. The state store, named SnapshotStateStore,
+// must be at the height of blk. The next block is required to verify the imported state.
+func ExportSnapshot(dir string, stores map[string]db.KVStore, blk, next *block.Store) (*SnapshotManifest, error) {
+	stateStore, ok := stores[SnapshotStateStore]
+	if !ok {
+		return nil, errors.Errorf("store %s is required", SnapshotStateStore)
+	}
+	if blk == nil {
+		return nil, errors.New("block of the snapshot height is required")
+	}
+	height := blk.Block.Height()
+	if next == nil {
+		return nil, errors.Errorf("block %d is required to verify the snapshot", height+1)
+	}
+	if next.Block.Height() != height+1 || next.Block.PrevHash() != blk.Block.HashBlock() {
+		return nil, errors.Errorf("block %d is not linked to block %d", next.Block.Height(), height)
+	}
+	h, err := stateStore.Get(AccountKVNamespace, []byte(CurrentHeightKey))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get state height")
+	}
+	if sh := byteutil.BytesToUint64(h); sh != height {
+		return nil, errors.Errorf("state is at height %d, cannot export snapshot at height %d", sh, height)
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, errors.Wrap(err, "failed to create snapshot directory")
+	}
+	blkHash := blk.Block.HashBlock()
+	manifest := &SnapshotManifest{
+		Version:   SnapshotVersion,
+		Height:    height,
+		BlockHash: hex.EncodeToString(blkHash[:]),
+	}
+	for _, s := range []*block.Store{blk, next} {
+		ser, err := s.Serialize()
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to serialize block %d", s.Block.Height())
+		}
+		f, err := writeSnapshotFile(dir, fmt.Sprintf("block-%d", s.Block.Height()), ser)
+		if err != nil {
+			return nil, err
+		}
+		manifest.Blocks = append(manifest.Blocks, f)
+	}
+	names := make([]string, 0, len(stores))
+	for name := range stores {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		store, root, err := exportStore(dir, name, stores[name])
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to export store %s", name)
+		}
+		if name == SnapshotStateStore && root != nil {
+			manifest.StateRoot = hex.EncodeToString(root)
+		}
+		manifest.Stores = append(manifest.Stores, store)
+	}
+	ser, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(filepath.Join(dir, SnapshotManifestFile), ser, 0644); err != nil {
+		return nil, errors.Wrap(err, "failed to write manifest")
+	}
+	return manifest, nil
+}
+
+// exportStore exports all namespaces of the store, and returns the root of the account trie if any
+func exportStore(dir, name string, kv db.KVStore) (*SnapshotStore, []byte, error) {
+	lister, ok := kv.(namespaceLister)
+	if !ok {
+		return nil, nil, errors.Errorf("cannot list the namespaces of store %T", kv)
+	}
+	buckets, err := lister.GetBucketByPrefix(nil)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to list namespaces")
+	}
+	namespaces := make([]string, 0, len(buckets))
+	for _, b := range buckets {
+		namespaces = append(namespaces, string(b))
+	}
+	sort.Strings(namespaces)
+	var (
+		w    = &chunkWriter{dir: dir, store: name}
+		root []byte
+	)
+	for _, ns := range namespaces {
+		switch {
+		case strings.HasPrefix(ns, ArchiveNamespacePrefix):
+			// history data is not part of the state at the snapshot height
+			continue
+		case ns == ArchiveTrieNamespace:
+			if root, err = exportTrie(w, kv); err != nil {
+				return nil, nil, err
+			}
+			continue
+		}
+		var werr error
+		_, _, err := kv.Filter(ns, func(k, v []byte) bool {
+			if werr == nil {
+				werr = w.add(ns, k, v)
+			}
+			return false
+		}, nil, nil)
+		if err != nil && errors.Cause(err) != db.ErrNotExist {
+			return nil, nil, errors.Wrapf(err, "failed to read namespace %s", ns)
+		}
+		if werr != nil {
+			return nil, nil, werr
+		}
+	}
+	if err := w.flush(); err != nil {
+		return nil, nil, err
+	}
+	return &SnapshotStore{Name: name, Chunks: w.chunks, Namespaces: w.endNamespace()}, root, nil
+}
+
+// exportTrie exports the nodes of the account trie reachable from the current root, leaving out the
+// nodes which only belong to the history states
+func exportTrie(w *chunkWriter, kv db.KVStore) ([]byte, error) {
+	ns := ArchiveTrieNamespace
+	root, err := kv.Get(ns, []byte(ArchiveTrieRootKey))
+	switch errors.Cause(err) {
+	case nil:
+	case db.ErrNotExist:
+		return nil, nil
+	default:
+		return nil, errors.Wrap(err, "failed to get trie root")
+	}
+	if err := w.add(ns, []byte(ArchiveTrieRootKey), root); err != nil {
+		return nil, err
+	}
+	h, err := kv.Get(AccountKVNamespace, []byte(CurrentHeightKey))
+	if err != nil {
+		return nil, err
+	}
+	// the root at the height exists in archive mode
+	rootKey := []byte(fmt.Sprintf("%s-%d", ArchiveTrieRootKey, byteutil.BytesToUint64(h)))
+	switch v, err := kv.Get(ns, rootKey); errors.Cause(err) {
+	case nil:
+		if err := w.add(ns, rootKey, v); err != nil {
+			return nil, err
+		}
+	case db.ErrNotExist:
+	default:
+		return nil, err
+	}
+	trieStore, err := trie.NewKVStore(ns, kv)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		visited = make(map[string]struct{})
+		werr    error
+	)
+	if err := mptrie.WalkTwoLayerNodes(trieStore, root, func(key []byte) bool {
+		if _, ok := visited[string(key)]; ok || werr != nil {
+			return false
+		}
+		visited[string(key)] = struct{}{}
+		var v []byte
+		if v, werr = kv.Get(ns, key); werr == nil {
+			werr = w.add(ns, key, v)
+		}
+		return werr == nil
+	}); err != nil {
+		return nil, errors.Wrap(err, "failed to walk account trie")
+	}
+	if werr != nil {
+		return nil, werr
+	}
+	return root, nil
+}
+
+// ReadSnapshotManifest reads the manifest of the state snapshot in dir
+func ReadSnapshotManifest(dir string) (*SnapshotManifest, error) {
+	ser, err := os.ReadFile(filepath.Join(dir, SnapshotManifestFile))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read manifest")
+	}
+	return ParseSnapshotManifest(ser)
+}
+
+// ParseSnapshotManifest parses a serialized manifest of a state snapshot
+func ParseSnapshotManifest(ser []byte) (*SnapshotManifest, error) {
+	manifest := &SnapshotManifest{}
+	if err := json.Unmarshal(ser, manifest); err != nil {
+		return nil, errors.Wrap(ErrSnapshotInvalid, err.Error())
+	}
+	if manifest.Version != SnapshotVersion {
+		return nil, errors.Wrapf(ErrSnapshotInvalid, "unsupported version %d", manifest.Version)
+	}
+	if len(manifest.Blocks) == 0 {
+		return nil, errors.Wrap(ErrSnapshotInvalid, "missing block of the snapshot height")
+	}
+	for _, f := range manifest.Files() {
+		if filepath.Base(f.Name) != f.Name || f.Name == SnapshotManifestFile {
+			return nil, errors.Wrapf(ErrSnapshotInvalid, "invalid file name %s", f.Name)
+		}
+	}
+	return manifest, nil
+}
+
+// Files returns all the files listed in the manifest
+func (m *SnapshotManifest) Files() []*SnapshotFile {
+	files := append([]*SnapshotFile{}, m.Blocks...)
+	for _, s := range m.Stores {
+		files = append(files, s.Chunks...)
+	}
+	return files
+}
+
+// Digest returns the digest of the state snapshot, which covers the height, the block, the state root and the
+// digests of all the namespaces, regardless of how the records are split into chunks. A node only imports the
+// state snapshot matching the trusted digest.
+func (m *SnapshotManifest) Digest() hash.Hash256 {
+	var buf bytes.Buffer
+	writeRecord(&buf, byteutil.Uint64ToBytesBigEndian(m.Height), []byte(m.BlockHash), []byte(m.StateRoot))
+	for _, s := range m.Stores {
+		writeRecord(&buf, []byte(s.Name), byteutil.Uint64ToBytesBigEndian(uint64(len(s.Namespaces))))
+		for _, ns := range s.Namespaces {
+			writeRecord(&buf, []byte(ns.Name), byteutil.Uint64ToBytesBigEndian(ns.Records), []byte(ns.Digest))
+		}
+	}
+	return hash.Hash256b(buf.Bytes())
+}
+
+// VerifyDigest verifies the digest of the state snapshot matches the trusted digest, which is obtained from a
+// source independent of the snapshot
+func (m *SnapshotManifest) VerifyDigest(trusted string) error {
+	if d := m.Digest(); hex.EncodeToString(d[:]) != trusted {
+		return errors.Wrapf(ErrSnapshotInvalid, "digest %x doesn't match the trusted digest %s", d, trusted)
+	}
+	return nil
+}
+
+// Verify verifies the content of the file against its size and hash
+func (f *SnapshotFile) Verify(data []byte) error {
+	if f.Size > 0 && uint64(len(data)) != f.Size {
+		return errors.Wrapf(ErrSnapshotInvalid, "size of %s doesn't match manifest", f.Name)
+	}
+	if h := hash.Hash256b(data); hex.EncodeToString(h[:]) != f.Hash {
+		return errors.Wrapf(ErrSnapshotInvalid, "hash of %s doesn't match manifest", f.Name)
+	}
+	return nil
+}
+
+// ReadSnapshotBlocks reads the blocks of the state snapshot in dir, which are verified against the manifest
+func ReadSnapshotBlocks(dir string, manifest *SnapshotManifest, deser *block.Deserializer) ([]*block.Block, error) {
+	if len(manifest.Blocks) != 2 {
+		return nil, errors.Wrapf(ErrSnapshotInvalid, "snapshot has %d blocks, expecting the block at the snapshot height and the next block", len(manifest.Blocks))
+	}
+	blks := make([]*block.Block, 0, len(manifest.Blocks))
+	for i, f := range manifest.Blocks {
+		ser, err := readSnapshotFile(dir, f)
+		if err != nil {
+			return nil, err
+		}
+		pb := &iotextypes.BlockStore{}
+		if err := proto.Unmarshal(ser, pb); err != nil {
+			return nil, errors.Wrapf(ErrSnapshotInvalid, "failed to unmarshal block in %s: %v", f.Name, err)
+		}
+		blk, err := deser.BlockFromBlockStoreProto(pb)
+		if err != nil {
+			return nil, errors.Wrapf(ErrSnapshotInvalid, "failed to deserialize block in %s: %v", f.Name, err)
+		}
+		if blk.Receipts, err = deser.ReceiptsFromBlockStoreProto(pb); err != nil {
+			return nil, errors.Wrapf(ErrSnapshotInvalid, "failed to deserialize receipts in %s: %v", f.Name, err)
+		}
+		if blk.Height() != manifest.Height+uint64(i) {
+			return nil, errors.Wrapf(ErrSnapshotInvalid, "unexpected height %d of block in %s", blk.Height(), f.Name)
+		}
+		blks = append(blks, blk)
+	}
+	h := blks[0].HashBlock()
+	if hex.EncodeToString(h[:]) != manifest.BlockHash {
+		return nil, errors.Wrapf(ErrSnapshotInvalid, "block hash %x doesn't match manifest %s", h, manifest.BlockHash)
+	}
+	if blks[1].PrevHash() != h {
+		return nil, errors.Wrapf(ErrSnapshotInvalid, "block %d is not linked to block %d", blks[1].Height(), manifest.Height)
+	}
+	return blks, nil
+}
+
+// ImportSnapshot imports the stores of the state snapshot in dir. The state store should be empty, and the
+// height of the imported state is written at last after every imported namespace is verified against its
+// digest in the manifest and the account trie is verified to be complete, so an interrupted import can be
+// retried. The imported state is marked unverified by SnapshotUnverifiedKey, until
+// the next block is committed upon it.
+func ImportSnapshot(dir string, manifest *SnapshotManifest, stores map[string]db.KVStore) error {
+	var stateSnapshot *SnapshotStore
+	for _, s := range manifest.Stores {
+		if _, ok := stores[s.Name]; !ok {
+			return errors.Errorf("store %s of the snapshot is not provided", s.Name)
+		}
+		if s.Name == SnapshotStateStore {
+			stateSnapshot = s
+		}
+	}
+	stateStore, ok := stores[SnapshotStateStore]
+	if !ok || stateSnapshot == nil {
+		return errors.Errorf("store %s is required", SnapshotStateStore)
+	}
+	switch _, err := stateStore.Get(AccountKVNamespace, []byte(CurrentHeightKey)); errors.Cause(err) {
+	case nil:
+		return errors.New("cannot import snapshot into a non-empty state store")
+	case db.ErrNotExist, db.ErrBucketNotExist:
+	default:
+		return err
+	}
+	var height []byte
+	for _, s := range manifest.Stores {
+		var (
+			kv         = stores[s.Name]
+			digest     *namespaceDigest
+			namespaces []*SnapshotNamespace
+		)
+		for _, f := range s.Chunks {
+			ser, err := readSnapshotFile(dir, f)
+			if err != nil {
+				return err
+			}
+			b := batch.NewBatch()
+			var records uint64
+			if err := readRecords(ser, func(ns string, k, v []byte) error {
+				records++
+				if digest == nil || digest.ns != ns {
+					if digest != nil {
+						namespaces = append(namespaces, digest.namespace())
+					}
+					digest = newNamespaceDigest(ns)
+				}
+				digest.add(k, v)
+				if s.Name != SnapshotStateStore {
+					b.Put(ns, k, v, "failed to put record")
+					return nil
+				}
+				switch {
+				case ns == AccountKVNamespace && bytes.Equal(k, []byte(CurrentHeightKey)):
+					height = v
+					return nil
+				case ns == ArchiveTrieNamespace && !bytes.HasPrefix(k, []byte(ArchiveTrieRootKey)):
+					if !bytes.Equal(mptrie.DefaultHashFunc(v), k) {
+						return errors.Wrapf(ErrSnapshotInvalid, "trie node %x doesn't match its hash", k)
+					}
+				}
+				b.Put(ns, k, v, "failed to put record")
+				return nil
+			}); err != nil {
+				return errors.Wrapf(err, "failed to read %s", f.Name)
+			}
+			if records != f.Records {
+				return errors.Wrapf(ErrSnapshotInvalid, "%s has %d records, expecting %d", f.Name, records, f.Records)
+			}
+			if err := kv.WriteBatch(b); err != nil {
+				return errors.Wrapf(err, "failed to import %s", f.Name)
+			}
+		}
+		if digest != nil {
+			namespaces = append(namespaces, digest.namespace())
+		}
+		if err := verifySnapshotNamespaces(s, namespaces); err != nil {
+			return err
+		}
+	}
+	if byteutil.BytesToUint64(height) != manifest.Height {
+		return errors.Wrapf(ErrSnapshotInvalid, "state height %d doesn't match manifest %d", byteutil.BytesToUint64(height), manifest.Height)
+	}
+	if err := verifySnapshotTrie(stateStore, manifest.StateRoot); err != nil {
+		return err
+	}
+	b := batch.NewBatch()
+	b.Put(AccountKVNamespace, []byte(PrunedHeightKey), height, "failed to put pruned height")
+	b.Put(AccountKVNamespace, []byte(CurrentHeightKey), height, "failed to put state height")
+	b.Put(AccountKVNamespace, []byte(SnapshotUnverifiedKey), height, "failed to put unverified snapshot height")
+	return stateStore.WriteBatch(b)
+}
+
+// verifySnapshotNamespaces verifies the namespaces imported into the store match the ones in the manifest
+func verifySnapshotNamespaces(s *SnapshotStore, imported []*SnapshotNamespace) error {
+	if len(imported) != len(s.Namespaces) {
+		return errors.Wrapf(ErrSnapshotInvalid, "store %s has %d namespaces, expecting %d", s.Name, len(imported), len(s.Namespaces))
+	}
+	for i, ns := range imported {
+		if *ns != *s.Namespaces[i] {
+			return errors.Wrapf(ErrSnapshotInvalid, "namespace %s of store %s doesn't match manifest", ns.Name, s.Name)
+		}
+	}
+	return nil
+}
+
+// verifySnapshotTrie verifies the imported account trie has the root and all the nodes
+func verifySnapshotTrie(kv db.KVStore, stateRoot string) error {
+	root, err := kv.Get(ArchiveTrieNamespace, []byte(ArchiveTrieRootKey))
+	switch errors.Cause(err) {
+	case nil:
+	case db.ErrNotExist, db.ErrBucketNotExist:
+		if len(stateRoot) == 0 {
+			return nil
+		}
+		return errors.Wrap(ErrSnapshotInvalid, "missing state root")
+	default:
+		return err
+	}
+	if hex.EncodeToString(root) != stateRoot {
+		return errors.Wrapf(ErrSnapshotInvalid, "state root %x doesn't match manifest %s", root, stateRoot)
+	}
+	trieStore, err := trie.NewKVStore(ArchiveTrieNamespace, kv)
+	if err != nil {
+		return err
+	}
+	if err := mptrie.WalkTwoLayerNodes(trieStore, root, func([]byte) bool { return true }); err != nil {
+		return errors.Wrapf(ErrSnapshotInvalid, "incomplete account trie: %v", err)
+	}
+	return nil
+}
+
+func (w *chunkWriter) add(ns string, k, v []byte) error {
+	if w.digest == nil || w.digest.ns != ns {
+		w.endNamespace()
+		w.digest = newNamespaceDigest(ns)
+	}
+	w.digest.add(k, v)
+	writeRecord(&w.buf, []byte(ns), k, v)
+	w.records++
+	if w.buf.Len() >= _snapshotChunkSize {
+		return w.flush()
+	}
+	return nil
+}
+
+func (w *chunkWriter) flush() error {
+	if w.records == 0 {
+		return nil
+	}
+	f, err := writeSnapshotFile(w.dir, fmt.Sprintf("%s-%06d", w.store, len(w.chunks)), w.buf.Bytes())
+	if err != nil {
+		return err
+	}
+	f.Records = w.records
+	w.chunks = append(w.chunks, f)
+	w.buf.Reset()
+	w.records = 0
+	return nil
+}
+
+// endNamespace ends the digest of the current namespace, and returns the digests of the namespaces written
+func (w *chunkWriter) endNamespace() []*SnapshotNamespace {
+	if w.digest != nil {
+		w.namespaces = append(w.namespaces, w.digest.namespace())
+		w.digest = nil
+	}
+	return w.namespaces
+}
+
+func newNamespaceDigest(ns string) *namespaceDigest {
+	return &namespaceDigest{ns: ns, hasher: sha3.NewLegacyKeccak256()}
+}
+
+func (d *namespaceDigest) add(k, v []byte) {
+	writeRecord(d.hasher, k, v)
+	d.records++
+}
+
+func (d *namespaceDigest) namespace() *SnapshotNamespace {
+	return &SnapshotNamespace{Name: d.ns, Records: d.records, Digest: hex.EncodeToString(d.hasher.Sum(nil))}
+}
+
+// writeRecord writes the fields of a record, each prefixed by its length
+func writeRecord(w io.Writer, fields ...[]byte) {
+	var lenBuf [binary.MaxVarintLen64]byte
+	for _, b := range fields {
+		w.Write(lenBuf[:binary.PutUvarint(lenBuf[:], uint64(len(b)))])
+		w.Write(b)
+	}
+}
+
+func readRecords(ser []byte, fn func(ns string, k, v []byte) error) error {
+	r := bytes.NewReader(ser)
+	next := func() ([]byte, error) {
+		l, err := binary.ReadUvarint(r)
+		if err != nil {
+			return nil, err
+		}
+		if l > uint64(r.Len()) {
+			return nil, errors.Wrap(ErrSnapshotInvalid, "record is truncated")
+		}
+		b := make([]byte, l)
+		_, err = r.Read(b)
+		return b, err
+	}
+	for r.Len() > 0 {
+		ns, err := next()
+		if err != nil {
+			return err
+		}
+		k, err := next()
+		if err != nil {
+			return err
+		}
+		v, err := next()
+		if err != nil {
+			return err
+		}
+		if err := fn(string(ns), k, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeSnapshotFile(dir, name string, data []byte) (*SnapshotFile, error) {
+	compressed, err := compress.CompSnappy(data)
+	if err != nil {
+		return nil, err
+	}
+	h := hash.Hash256b(compressed)
+	if err := os.WriteFile(filepath.Join(dir, name), compressed, 0644); err != nil {
+		return nil, errors.Wrapf(err, "failed to write %s", name)
+	}
+	return &SnapshotFile{Name: name, Hash: hex.EncodeToString(h[:]), Size: uint64(len(compressed))}, nil
+}
+
+func readSnapshotFile(dir string, f *SnapshotFile) ([]byte, error) {
+	if filepath.Base(f.Name) != f.Name {
+		return nil, errors.Wrapf(ErrSnapshotInvalid, "invalid file name %s", f.Name)
+	}
+	compressed, err := os.ReadFile(filepath.Join(dir, f.Name))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read %s", f.Name)
+	}
+	if err := f.Verify(compressed); err != nil {
+		return nil, err
+	}
+	ser, err := compress.DecompSnappy(compressed)
+	if err != nil {
+		return nil, errors.Wrapf(ErrSnapshotInvalid, "failed to decompress %s: %v", f.Name, err)
+	}
+	return ser, nil
+}
@@ -0,0 +1,180 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package factory
+
+import (
+	"context"
+	"encoding/hex"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/v2/action"
+	"github.com/iotexproject/iotex-core/v2/action/protocol"
+	"github.com/iotexproject/iotex-core/v2/action/protocol/account"
+	accountutil "github.com/iotexproject/iotex-core/v2/action/protocol/account/util"
+	"github.com/iotexproject/iotex-core/v2/action/protocol/rewarding"
+	"github.com/iotexproject/iotex-core/v2/blockchain/block"
+	"github.com/iotexproject/iotex-core/v2/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/v2/db"
+	"github.com/iotexproject/iotex-core/v2/pkg/util/byteutil"
+	"github.com/iotexproject/iotex-core/v2/test/identityset"
+	"github.com/iotexproject/iotex-core/v2/testutil"
+)
+
+func TestExportImportSnapshot(t *testing.T) {
+	r := require.New(t)
+	defer func(size int) {
+		_snapshotChunkSize = size
+	}(_snapshotChunkSize)
+	_snapshotChunkSize = 256
+
+	var (
+		dir = t.TempDir()
+		a   = identityset.Address(28)
+		b   = identityset.Address(31)
+		ge  = genesis.TestDefault()
+		ctx = genesis.WithGenesisContext(protocol.WithBlockCtx(
+			context.Background(),
+			protocol.BlockCtx{
+				BlockHeight: 0,
+				Producer:    identityset.Address(27),
+				GasLimit:    1000000,
+			},
+		), ge)
+	)
+	ge.InitBalanceMap[a.String()] = "100"
+	newFactory := func(path string) (*factory, db.KVStore) {
+		kv, err := db.CreateKVStore(db.DefaultConfig, path)
+		r.NoError(err)
+		f, err := NewFactory(DefaultConfig, kv, SkipBlockValidationOption())
+		r.NoError(err)
+		r.NoError(f.Register(account.NewProtocol(rewarding.DepositGas)))
+		return f.(*factory), kv
+	}
+	sf, kv := newFactory(filepath.Join(dir, "trie.db"))
+	r.NoError(sf.Start(ctx))
+	defer func() {
+		r.NoError(sf.Stop(ctx))
+	}()
+
+	// transfer 10 to b in each block
+	var (
+		blks     []*block.Block
+		prevHash = hash.ZeroHash256
+	)
+	for i := uint64(1); i <= 4; i++ {
+		elp := (&action.EnvelopeBuilder{}).SetAction(action.NewTransfer(big.NewInt(10), b.String(), nil)).
+			SetGasLimit(20000).SetNonce(i).Build()
+		selp, err := action.Sign(elp, identityset.PrivateKey(28))
+		r.NoError(err)
+		blk, err := block.NewTestingBuilder().
+			SetHeight(i).
+			SetPrevBlockHash(prevHash).
+			SetTimeStamp(testutil.TimestampNow()).
+			AddActions(selp).
+			SignAndBuild(identityset.PrivateKey(27))
+		r.NoError(err)
+		blks = append(blks, &blk)
+		prevHash = blk.HashBlock()
+	}
+	blkCtx := func(height uint64) context.Context {
+		return protocol.WithFeatureCtx(protocol.WithBlockchainCtx(protocol.WithBlockCtx(ctx, protocol.BlockCtx{
+			BlockHeight: height,
+			Producer:    identityset.Address(27),
+			GasLimit:    1000000,
+		}), protocol.BlockchainCtx{
+			ChainID: 1,
+		}))
+	}
+	for _, blk := range blks[:3] {
+		r.NoError(sf.PutBlock(blkCtx(blk.Height()), blk))
+	}
+
+	// export at height 3
+	snapshotDir := filepath.Join(dir, "snapshot")
+	_, err := ExportSnapshot(snapshotDir, map[string]db.KVStore{SnapshotStateStore: kv}, &block.Store{Block: blks[1]}, &block.Store{Block: blks[2]})
+	r.ErrorContains(err, "state is at height 3, cannot export snapshot at height 2")
+	_, err = ExportSnapshot(snapshotDir, map[string]db.KVStore{SnapshotStateStore: kv}, &block.Store{Block: blks[2]}, nil)
+	r.ErrorContains(err, "block 4 is required to verify the snapshot")
+	manifest, err := ExportSnapshot(snapshotDir, map[string]db.KVStore{SnapshotStateStore: kv}, &block.Store{Block: blks[2]}, &block.Store{Block: blks[3]})
+	r.NoError(err)
+	r.EqualValues(3, manifest.Height)
+	r.NotEmpty(manifest.StateRoot)
+	r.Len(manifest.Stores, 1)
+	r.Greater(len(manifest.Stores[0].Chunks), 1)
+
+	manifest, err = ReadSnapshotManifest(snapshotDir)
+	r.NoError(err)
+	imported, err := ReadSnapshotBlocks(snapshotDir, manifest, block.NewDeserializer(0))
+	r.NoError(err)
+	r.Len(imported, 2)
+	r.Equal(blks[2].HashBlock(), imported[0].HashBlock())
+	r.Equal(blks[3].HashBlock(), imported[1].HashBlock())
+
+	// import into a new state store
+	sf2, kv2 := newFactory(filepath.Join(dir, "trie2.db"))
+	r.NoError(kv2.Start(ctx))
+	r.NoError(ImportSnapshot(snapshotDir, manifest, map[string]db.KVStore{SnapshotStateStore: kv2}))
+	r.ErrorContains(ImportSnapshot(snapshotDir, manifest, map[string]db.KVStore{SnapshotStateStore: kv2}), "non-empty state store")
+	r.NoError(kv2.Stop(ctx))
+	r.NoError(sf2.Start(ctx))
+	defer func() {
+		r.NoError(sf2.Stop(ctx))
+	}()
+	height, err := sf2.Height()
+	r.NoError(err)
+	r.EqualValues(3, height)
+	unverified, err := kv2.Get(AccountKVNamespace, []byte(SnapshotUnverifiedKey))
+	r.NoError(err)
+	r.EqualValues(3, byteutil.BytesToUint64(unverified))
+
+	// both factories continue with the next block
+	for _, f := range []*factory{sf, sf2} {
+		r.NoError(f.PutBlock(blkCtx(4), imported[1]))
+		accountB, err := accountutil.AccountState(ctx, f, b)
+		r.NoError(err)
+		r.Equal(big.NewInt(40), accountB.Balance)
+	}
+
+	// namespace not matching the manifest
+	r.NotEmpty(manifest.Stores[0].Namespaces)
+	kv3, err := db.CreateKVStore(db.DefaultConfig, filepath.Join(dir, "trie3.db"))
+	r.NoError(err)
+	r.NoError(kv3.Start(ctx))
+	defer kv3.Stop(ctx)
+	digest := manifest.Digest()
+	ns := manifest.Stores[0].Namespaces[len(manifest.Stores[0].Namespaces)-1]
+	ns.Digest = hex.EncodeToString(make([]byte, 32))
+	r.NotEqual(digest, manifest.Digest())
+	r.Equal(ErrSnapshotInvalid, errors.Cause(manifest.VerifyDigest(hex.EncodeToString(digest[:]))))
+	err = ImportSnapshot(snapshotDir, manifest, map[string]db.KVStore{SnapshotStateStore: kv3})
+	r.Equal(ErrSnapshotInvalid, errors.Cause(err))
+	r.ErrorContains(err, "namespace "+ns.Name+" of store state doesn't match manifest")
+	manifest.Stores[0].Namespaces = manifest.Stores[0].Namespaces[1:]
+	err = ImportSnapshot(snapshotDir, manifest, map[string]db.KVStore{SnapshotStateStore: kv3})
+	r.Equal(ErrSnapshotInvalid, errors.Cause(err))
+	manifest, err = ReadSnapshotManifest(snapshotDir)
+	r.NoError(err)
+	r.Equal(digest, manifest.Digest())
+	r.NoError(manifest.VerifyDigest(hex.EncodeToString(digest[:])))
+
+	// tampered chunk
+	chunk := filepath.Join(snapshotDir, manifest.Stores[0].Chunks[0].Name)
+	ser, err := os.ReadFile(chunk)
+	r.NoError(err)
+	ser[len(ser)-1] ^= 1
+	r.NoError(os.WriteFile(chunk, ser, 0644))
+	err = ImportSnapshot(snapshotDir, manifest, map[string]db.KVStore{SnapshotStateStore: kv3})
+	r.Equal(ErrSnapshotInvalid, errors.Cause(err))
+	_, err = kv3.Get(AccountKVNamespace, []byte(CurrentHeightKey))
+	r.Equal(db.ErrNotExist, errors.Cause(err))
+}
@@ -336,6 +336,21 @@ func (ws *workingSet) States(opts ...protocol.StateOption) (uint64, state.Iterat
 	return ws.height, iter, nil
 }
 
+// StateProof returns the root hash of the state trie, and the merkle proof of a state
+func (ws *workingSet) StateProof(opts ...protocol.StateOption) ([]byte, [][]byte, error) {
+	cfg, err := processOptions(opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+	prover, ok := ws.store.(interface {
+		Proof(string, []byte) ([]byte, [][]byte, error)
+	})
+	if !ok {
+		return nil, nil, errors.Wrap(ErrNotSupported, "state proof is only supported by state trie")
+	}
+	return prover.Proof(cfg.Namespace, cfg.Key)
+}
+
 // PutState puts a state into DB
 func (ws *workingSet) PutState(s interface{}, opts ...protocol.StateOption) (uint64, error) {
 	_stateDBMtc.WithLabelValues("put").Inc()
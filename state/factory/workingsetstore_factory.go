@@ -94,6 +94,20 @@ func (store *factoryWorkingSetStore) States(ns string, keys [][]byte) ([][]byte,
 	return readStatesFromTLT(store.tlt, ns, keys)
 }
 
+// Proof returns the root hash of the state trie, and the merkle proof of the key in the namespace
+func (store *factoryWorkingSetStore) Proof(ns string, key []byte) ([]byte, [][]byte, error) {
+	rootHash, err := store.tlt.RootHash()
+	if err != nil {
+		return nil, nil, err
+	}
+	nsHash := hash.Hash160b([]byte(ns))
+	proof, err := store.tlt.Proof(nsHash[:], toLegacyKey(key))
+	if err != nil {
+		return nil, nil, err
+	}
+	return rootHash, proof, nil
+}
+
 func (store *factoryWorkingSetStore) Finalize(h uint64) error {
 	rootHash, err := store.tlt.RootHash()
 	if err != nil {
@@ -0,0 +1,191 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+package systemcontractindex
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/v2/db"
+	"github.com/iotexproject/iotex-core/v2/db/batch"
+	"github.com/iotexproject/iotex-core/v2/pkg/util/byteutil"
+)
+
+const (
+	// _historyMetaNS is the versioned namespace of the id bounds and total counts of the indexers
+	_historyMetaNS = "hmn"
+	// _historyStartNS is the namespace of the start heights of the indexers
+	_historyStartNS = "hsn"
+	// _historyKeyLen is the length of the keys in the versioned namespaces, which are 8-byte ids
+	_historyKeyLen = 8
+)
+
+var (
+	// ErrHistoryNotAvailable is the error when the height is not covered by the history
+	ErrHistoryNotAvailable = errors.New("history not available")
+)
+
+// History keeps the states of a contract indexer at every height in a versioned db, to read the states at a
+// historical height on an archive node. The states are stored in the versioned namespaces keyed by 8-byte ids,
+// along with the upper bound of the ids in each namespace and the total count of the indexer at each height
+type History struct {
+	vdb  db.VersionedDB
+	name string
+	nss  map[string]struct{}
+}
+
+// HistoryNamespaces returns the versioned namespaces of the histories of the state namespaces, which are needed to
+// create the versioned db
+func HistoryNamespaces(nss ...string) []db.Namespace {
+	vns := []db.Namespace{db.NewNamespace(_historyMetaNS, _historyKeyLen)}
+	for _, ns := range nss {
+		vns = append(vns, db.NewNamespace(ns, _historyKeyLen))
+	}
+	return vns
+}
+
+// NewHistory creates the history of the state namespaces of an indexer, the name separates the metadata of the
+// indexers sharing the versioned db
+func NewHistory(vdb db.VersionedDB, name string, nss ...string) *History {
+	h := &History{
+		vdb:  vdb,
+		name: name,
+		nss:  make(map[string]struct{}, len(nss)),
+	}
+	for _, ns := range nss {
+		h.nss[ns] = struct{}{}
+	}
+	return h
+}
+
+// StartHeight returns the first height kept in the history, 0 if the history is empty
+func (h *History) StartHeight() (uint64, error) {
+	v, err := h.vdb.Get(0, _historyStartNS, []byte(h.name))
+	switch errors.Cause(err) {
+	case nil:
+		return byteutil.BytesToUint64BigEndian(v), nil
+	case db.ErrNotExist, db.ErrBucketNotExist:
+		return 0, nil
+	default:
+		return 0, err
+	}
+}
+
+// Commit writes the puts and deletes of the state namespaces in the delta, and the total count at the height. The
+// first committed height is the start of the history, so the delta of the first commit should contain all the states
+func (h *History) Commit(height uint64, delta batch.KVStoreBatch, total uint64) error {
+	start, err := h.StartHeight()
+	if err != nil {
+		return err
+	}
+	b := batch.NewBatch()
+	if start == 0 {
+		b.Put(_historyStartNS, []byte(h.name), byteutil.Uint64ToBytesBigEndian(height), "failed to put start height")
+	}
+	bounds := make(map[string]uint64)
+	for i := 0; i < delta.Size(); i++ {
+		entry, err := delta.Entry(i)
+		if err != nil {
+			return err
+		}
+		ns := entry.Namespace()
+		if _, ok := h.nss[ns]; !ok || len(entry.Key()) != _historyKeyLen {
+			continue
+		}
+		switch entry.WriteType() {
+		case batch.Put:
+			b.Put(ns, entry.Key(), entry.Value(), fmt.Sprintf("failed to put state %x", entry.Key()))
+		case batch.Delete:
+			b.Delete(ns, entry.Key(), fmt.Sprintf("failed to delete state %x", entry.Key()))
+		default:
+			continue
+		}
+		if id := byteutil.BytesToUint64BigEndian(entry.Key()); id+1 > bounds[ns] {
+			bounds[ns] = id + 1
+		}
+	}
+	for ns, bound := range bounds {
+		last, err := h.meta(math.MaxUint64, ns)
+		if err != nil {
+			return err
+		}
+		if bound > last {
+			b.Put(_historyMetaNS, metaKey(ns), byteutil.Uint64ToBytesBigEndian(bound), "failed to put bound of "+ns)
+		}
+	}
+	if last, err := h.meta(math.MaxUint64, h.name); err != nil {
+		return err
+	} else if start == 0 || total != last {
+		b.Put(_historyMetaNS, metaKey(h.name), byteutil.Uint64ToBytesBigEndian(total), "failed to put total count")
+	}
+	return h.vdb.CommitBatch(height, b)
+}
+
+// States returns the ids and values of the states in the namespace at the height
+func (h *History) States(height uint64, ns string) ([]uint64, [][]byte, error) {
+	if err := h.checkHeight(height); err != nil {
+		return nil, nil, err
+	}
+	bound, err := h.meta(height, ns)
+	if err != nil {
+		return nil, nil, err
+	}
+	var (
+		ids    []uint64
+		values [][]byte
+	)
+	for id := uint64(0); id < bound; id++ {
+		v, err := h.vdb.Get(height, ns, byteutil.Uint64ToBytesBigEndian(id))
+		switch errors.Cause(err) {
+		case nil:
+			ids = append(ids, id)
+			values = append(values, v)
+		case db.ErrNotExist, db.ErrBucketNotExist:
+		default:
+			return nil, nil, err
+		}
+	}
+	return ids, values, nil
+}
+
+// Total returns the total count at the height
+func (h *History) Total(height uint64) (uint64, error) {
+	if err := h.checkHeight(height); err != nil {
+		return 0, err
+	}
+	return h.meta(height, h.name)
+}
+
+func (h *History) checkHeight(height uint64) error {
+	start, err := h.StartHeight()
+	if err != nil {
+		return err
+	}
+	if start == 0 || height < start {
+		return errors.Wrapf(ErrHistoryNotAvailable, "height %d is lower than the start height %d of %s", height, start, h.name)
+	}
+	return nil
+}
+
+func (h *History) meta(height uint64, name string) (uint64, error) {
+	v, err := h.vdb.Get(height, _historyMetaNS, metaKey(name))
+	switch errors.Cause(err) {
+	case nil:
+		return byteutil.BytesToUint64BigEndian(v), nil
+	case db.ErrNotExist, db.ErrBucketNotExist:
+		return 0, nil
+	default:
+		return 0, err
+	}
+}
+
+func metaKey(name string) []byte {
+	h := hash.Hash160b([]byte(name))
+	return h[:_historyKeyLen]
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/iotexproject/iotex-core/v2/db"
 	"github.com/iotexproject/iotex-core/v2/pkg/util/byteutil"
+	"github.com/iotexproject/iotex-core/v2/systemcontractindex"
 )
 
 // cache is the in-memory cache for staking index
@@ -56,6 +57,29 @@ func (s *cache) Load(kvstore db.KVStore) error {
 	return nil
 }
 
+func (s *cache) LoadFromHistory(history *systemcontractindex.History, height uint64) error {
+	// load total bucket count
+	totalBucketCount, err := history.Total(height)
+	if err != nil {
+		return err
+	}
+	s.totalBucketCount = totalBucketCount
+
+	// load buckets
+	ids, vs, err := history.States(height, s.bucketNS)
+	if err != nil {
+		return err
+	}
+	for i := range vs {
+		var b Bucket
+		if err := b.Deserialize(vs[i]); err != nil {
+			return err
+		}
+		s.PutBucket(ids[i], &b)
+	}
+	return nil
+}
+
 func (s *cache) Copy() *cache {
 	c := newCache(s.ns, s.bucketNS)
 	for k, v := range s.buckets {
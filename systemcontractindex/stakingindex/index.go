@@ -10,8 +10,10 @@ import (
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
 
+	"github.com/iotexproject/iotex-core/v2/action/protocol/staking"
 	"github.com/iotexproject/iotex-core/v2/blockchain/block"
 	"github.com/iotexproject/iotex-core/v2/db"
+	"github.com/iotexproject/iotex-core/v2/db/batch"
 	"github.com/iotexproject/iotex-core/v2/pkg/lifecycle"
 	"github.com/iotexproject/iotex-core/v2/pkg/log"
 	"github.com/iotexproject/iotex-core/v2/systemcontractindex"
@@ -49,20 +51,40 @@ type (
 		blockInterval time.Duration
 		bucketNS      string
 		ns            string
+		history       *systemcontractindex.History // history of buckets, used to query buckets at a historical height
 	}
+	// IndexerOption is the option of staking indexer
+	IndexerOption func(*Indexer)
 )
 
+// WithHistory keeps the history of buckets in the versioned db, to query the buckets at a historical height.
+// The versioned db is shared with other indexers, so it has to be started before and stopped after the indexer.
+func WithHistory(vdb db.VersionedDB) IndexerOption {
+	return func(s *Indexer) {
+		s.history = systemcontractindex.NewHistory(vdb, s.ns, s.bucketNS)
+	}
+}
+
+// HistoryNamespaces returns the versioned namespaces of the history of buckets of the contract
+func HistoryNamespaces(contractAddr string) []db.Namespace {
+	return systemcontractindex.HistoryNamespaces(contractAddr + "#" + stakingBucketNS)
+}
+
 // NewIndexer creates a new staking indexer
-func NewIndexer(kvstore db.KVStore, contractAddr string, startHeight uint64, blockInterval time.Duration) *Indexer {
+func NewIndexer(kvstore db.KVStore, contractAddr string, startHeight uint64, blockInterval time.Duration, opts ...IndexerOption) *Indexer {
 	bucketNS := contractAddr + "#" + stakingBucketNS
 	ns := contractAddr + "#" + stakingNS
-	return &Indexer{
+	s := &Indexer{
 		common:        systemcontractindex.NewIndexerCommon(kvstore, ns, stakingHeightKey, contractAddr, startHeight),
 		cache:         newCache(ns, bucketNS),
 		blockInterval: blockInterval,
 		bucketNS:      bucketNS,
 		ns:            ns,
 	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
 }
 
 // Start starts the indexer
@@ -72,7 +94,13 @@ func (s *Indexer) Start(ctx context.Context) error {
 	if err := s.common.Start(ctx); err != nil {
 		return err
 	}
-	return s.cache.Load(s.common.KVStore())
+	if err := s.cache.Load(s.common.KVStore()); err != nil {
+		return err
+	}
+	if s.history == nil {
+		return nil
+	}
+	return s.initHistory()
 }
 
 // Stop stops the indexer
@@ -82,6 +110,27 @@ func (s *Indexer) Stop(ctx context.Context) error {
 	return s.common.Stop(ctx)
 }
 
+// IndexerAt returns the indexer of the buckets at the height, it reads the history if the height is lower than the
+// tip height, and returns the indexer itself if the height is 0 or the history is not kept
+func (s *Indexer) IndexerAt(height uint64) (staking.ContractStakingIndexer, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	if s.history == nil || height == 0 || height >= s.common.Height() || height < s.common.StartHeight() {
+		return s, nil
+	}
+	cache := newCache(s.ns, s.bucketNS)
+	if err := cache.LoadFromHistory(s.history, height); err != nil {
+		return nil, err
+	}
+	return &Indexer{
+		common:        s.common,
+		cache:         cache,
+		blockInterval: s.blockInterval,
+		bucketNS:      s.bucketNS,
+		ns:            s.ns,
+	}, nil
+}
+
 // Height returns the tip block height
 func (s *Indexer) Height() (uint64, error) {
 	s.mutex.RLock()
@@ -214,6 +263,12 @@ func (s *Indexer) PutBlock(ctx context.Context, blk *block.Block) error {
 
 func (s *Indexer) commit(handler *eventHandler, height uint64) error {
 	delta, dirty := handler.Finalize()
+	// update history
+	if s.history != nil {
+		if err := s.history.Commit(height, delta, dirty.TotalBucketCount()); err != nil {
+			return err
+		}
+	}
 	// update db
 	if err := s.common.Commit(height, delta); err != nil {
 		return err
@@ -223,6 +278,27 @@ func (s *Indexer) commit(handler *eventHandler, height uint64) error {
 	return nil
 }
 
+// initHistory writes all the buckets at the current height as the start of the history, if the history is empty
+func (s *Indexer) initHistory() error {
+	start, err := s.history.StartHeight()
+	if err != nil {
+		return err
+	}
+	height := s.common.Height()
+	if start != 0 || height == 0 {
+		return nil
+	}
+	ks, vs, err := s.common.KVStore().Filter(s.bucketNS, func(k, v []byte) bool { return true }, nil, nil)
+	if err != nil && !errors.Is(err, db.ErrBucketNotExist) {
+		return err
+	}
+	b := batch.NewBatch()
+	for i := range ks {
+		b.Put(s.bucketNS, ks[i], vs[i], "failed to put bucket")
+	}
+	return s.history.Commit(height, b, s.cache.TotalBucketCount())
+}
+
 func (s *Indexer) checkHeight(height uint64) (unstart bool, err error) {
 	if height < s.common.StartHeight() {
 		return true, nil
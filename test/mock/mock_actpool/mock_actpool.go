@@ -207,6 +207,36 @@ func (mr *MockActPoolMockRecorder) PendingActionMap() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PendingActionMap", reflect.TypeOf((*MockActPool)(nil).PendingActionMap))
 }
 
+// PendingAndQueuedActionMap mocks base method.
+func (m *MockActPool) PendingAndQueuedActionMap() (map[string][]*action.SealedEnvelope, map[string][]*action.SealedEnvelope) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PendingAndQueuedActionMap")
+	ret0, _ := ret[0].(map[string][]*action.SealedEnvelope)
+	ret1, _ := ret[1].(map[string][]*action.SealedEnvelope)
+	return ret0, ret1
+}
+
+// PendingAndQueuedActionMap indicates an expected call of PendingAndQueuedActionMap.
+func (mr *MockActPoolMockRecorder) PendingAndQueuedActionMap() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PendingAndQueuedActionMap", reflect.TypeOf((*MockActPool)(nil).PendingAndQueuedActionMap))
+}
+
+// PendingAndQueuedSize mocks base method.
+func (m *MockActPool) PendingAndQueuedSize() (uint64, uint64) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PendingAndQueuedSize")
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(uint64)
+	return ret0, ret1
+}
+
+// PendingAndQueuedSize indicates an expected call of PendingAndQueuedSize.
+func (mr *MockActPoolMockRecorder) PendingAndQueuedSize() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PendingAndQueuedSize", reflect.TypeOf((*MockActPool)(nil).PendingAndQueuedSize))
+}
+
 // ReceiveBlock mocks base method.
 func (m *MockActPool) ReceiveBlock(arg0 *block.Block) error {
 	m.ctrl.T.Helper()
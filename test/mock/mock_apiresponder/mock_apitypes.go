@@ -8,6 +8,7 @@ import (
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
+	action "github.com/iotexproject/iotex-core/v2/action"
 	apitypes "github.com/iotexproject/iotex-core/v2/api/types"
 	block "github.com/iotexproject/iotex-core/v2/blockchain/block"
 )
@@ -193,3 +194,157 @@ func (mr *MockListenerMockRecorder) Stop() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stop", reflect.TypeOf((*MockListener)(nil).Stop))
 }
+
+// MockActionResponder is a mock of ActionResponder interface.
+type MockActionResponder struct {
+	ctrl     *gomock.Controller
+	recorder *MockActionResponderMockRecorder
+}
+
+// MockActionResponderMockRecorder is the mock recorder for MockActionResponder.
+type MockActionResponderMockRecorder struct {
+	mock *MockActionResponder
+}
+
+// NewMockActionResponder creates a new mock instance.
+func NewMockActionResponder(ctrl *gomock.Controller) *MockActionResponder {
+	mock := &MockActionResponder{ctrl: ctrl}
+	mock.recorder = &MockActionResponderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockActionResponder) EXPECT() *MockActionResponderMockRecorder {
+	return m.recorder
+}
+
+// Exit mocks base method.
+func (m *MockActionResponder) Exit() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Exit")
+}
+
+// Exit indicates an expected call of Exit.
+func (mr *MockActionResponderMockRecorder) Exit() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Exit", reflect.TypeOf((*MockActionResponder)(nil).Exit))
+}
+
+// RespondAction mocks base method.
+func (m *MockActionResponder) RespondAction(arg0 string, arg1 *action.SealedEnvelope) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RespondAction", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RespondAction indicates an expected call of RespondAction.
+func (mr *MockActionResponderMockRecorder) RespondAction(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RespondAction", reflect.TypeOf((*MockActionResponder)(nil).RespondAction), arg0, arg1)
+}
+
+// MockActionListener is a mock of ActionListener interface.
+type MockActionListener struct {
+	ctrl     *gomock.Controller
+	recorder *MockActionListenerMockRecorder
+}
+
+// MockActionListenerMockRecorder is the mock recorder for MockActionListener.
+type MockActionListenerMockRecorder struct {
+	mock *MockActionListener
+}
+
+// NewMockActionListener creates a new mock instance.
+func NewMockActionListener(ctrl *gomock.Controller) *MockActionListener {
+	mock := &MockActionListener{ctrl: ctrl}
+	mock.recorder = &MockActionListenerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockActionListener) EXPECT() *MockActionListenerMockRecorder {
+	return m.recorder
+}
+
+// AddResponder mocks base method.
+func (m *MockActionListener) AddResponder(arg0 apitypes.ActionResponder) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddResponder", arg0)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddResponder indicates an expected call of AddResponder.
+func (mr *MockActionListenerMockRecorder) AddResponder(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddResponder", reflect.TypeOf((*MockActionListener)(nil).AddResponder), arg0)
+}
+
+// OnAdded mocks base method.
+func (m *MockActionListener) OnAdded(arg0 *action.SealedEnvelope) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "OnAdded", arg0)
+}
+
+// OnAdded indicates an expected call of OnAdded.
+func (mr *MockActionListenerMockRecorder) OnAdded(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OnAdded", reflect.TypeOf((*MockActionListener)(nil).OnAdded), arg0)
+}
+
+// OnRemoved mocks base method.
+func (m *MockActionListener) OnRemoved(arg0 *action.SealedEnvelope) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "OnRemoved", arg0)
+}
+
+// OnRemoved indicates an expected call of OnRemoved.
+func (mr *MockActionListenerMockRecorder) OnRemoved(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OnRemoved", reflect.TypeOf((*MockActionListener)(nil).OnRemoved), arg0)
+}
+
+// RemoveResponder mocks base method.
+func (m *MockActionListener) RemoveResponder(arg0 string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveResponder", arg0)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveResponder indicates an expected call of RemoveResponder.
+func (mr *MockActionListenerMockRecorder) RemoveResponder(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveResponder", reflect.TypeOf((*MockActionListener)(nil).RemoveResponder), arg0)
+}
+
+// Start mocks base method.
+func (m *MockActionListener) Start() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Start")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Start indicates an expected call of Start.
+func (mr *MockActionListenerMockRecorder) Start() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Start", reflect.TypeOf((*MockActionListener)(nil).Start))
+}
+
+// Stop mocks base method.
+func (m *MockActionListener) Stop() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Stop")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Stop indicates an expected call of Stop.
+func (mr *MockActionListenerMockRecorder) Stop() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stop", reflect.TypeOf((*MockActionListener)(nil).Stop))
+}
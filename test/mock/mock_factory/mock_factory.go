@@ -228,3 +228,46 @@ func (mr *MockFactoryMockRecorder) WorkingSetAtHeight(arg0, arg1 interface{}, ar
 	varargs := append([]interface{}{arg0, arg1}, arg2...)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkingSetAtHeight", reflect.TypeOf((*MockFactory)(nil).WorkingSetAtHeight), varargs...)
 }
+
+// MockStateProver is a mock of StateProver interface.
+type MockStateProver struct {
+	ctrl     *gomock.Controller
+	recorder *MockStateProverMockRecorder
+}
+
+// MockStateProverMockRecorder is the mock recorder for MockStateProver.
+type MockStateProverMockRecorder struct {
+	mock *MockStateProver
+}
+
+// NewMockStateProver creates a new mock instance.
+func NewMockStateProver(ctrl *gomock.Controller) *MockStateProver {
+	mock := &MockStateProver{ctrl: ctrl}
+	mock.recorder = &MockStateProverMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStateProver) EXPECT() *MockStateProverMockRecorder {
+	return m.recorder
+}
+
+// StateProof mocks base method.
+func (m *MockStateProver) StateProof(arg0 ...protocol.StateOption) ([]byte, [][]byte, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{}
+	for _, a := range arg0 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "StateProof", varargs...)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].([][]byte)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// StateProof indicates an expected call of StateProof.
+func (mr *MockStateProverMockRecorder) StateProof(arg0 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateProof", reflect.TypeOf((*MockStateProver)(nil).StateProof), arg0...)
+}
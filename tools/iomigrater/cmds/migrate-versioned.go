@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/iotexproject/iotex-core/v2/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/v2/chainservice"
+	"github.com/iotexproject/iotex-core/v2/db"
+	"github.com/iotexproject/iotex-core/v2/tools/iomigrater/common"
+)
+
+// Multi-language support
+var (
+	migrateVersionedCmdShorts = map[string]string{
+		"english": "Sub-Command for migration of IoTeX versioned db file from BoltDB to PebbleDB.",
+		"chinese": "将IoTeX版本化 db 文件从BoltDB迁移到PebbleDB的子命令",
+	}
+	migrateVersionedCmdLongs = map[string]string{
+		"english": "Sub-Command for migration of IoTeX contract staking history db file from BoltDB to PebbleDB, all versions of the records are migrated. The versioned namespaces are determined by the genesis.",
+		"chinese": "将IoTeX合约质押历史 db 文件从BoltDB迁移到PebbleDB的子命令，迁移所有版本的数据，版本化命名空间由创世块决定",
+	}
+	migrateVersionedCmdUse = map[string]string{
+		"english": "migrate-versioned",
+		"chinese": "migrate-versioned",
+	}
+	migrateVersionedFlagBoltFileUse = map[string]string{
+		"english": "The BoltDB file you want to migrate.",
+		"chinese": "您要迁移的BoltDB文件。",
+	}
+	migrateVersionedFlagPebbleDirUse = map[string]string{
+		"english": "The PebbleDB directory you want to migrate to",
+		"chinese": "您要迁移到的PebbleDB目录。",
+	}
+	migrateVersionedFlagGenesisPathUse = map[string]string{
+		"english": "The genesis file of the node, which determines the versioned namespaces, mainnet genesis if not set.",
+		"chinese": "节点的创世块文件，决定版本化命名空间，未设置时使用主网创世块。",
+	}
+)
+
+var (
+	// MigrateVersioned Used to Sub command.
+	MigrateVersioned = &cobra.Command{
+		Use:   common.TranslateInLang(migrateVersionedCmdUse),
+		Short: common.TranslateInLang(migrateVersionedCmdShorts),
+		Long:  common.TranslateInLang(migrateVersionedCmdLongs),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return migrateVersionedDB()
+		},
+	}
+)
+
+var (
+	boltFile    = ""
+	pebbleDir   = ""
+	genesisPath = ""
+)
+
+func init() {
+	MigrateVersioned.PersistentFlags().StringVarP(&boltFile, "bolt-file", "o", "", common.TranslateInLang(migrateVersionedFlagBoltFileUse))
+	MigrateVersioned.PersistentFlags().StringVarP(&pebbleDir, "pebble-dir", "n", "", common.TranslateInLang(migrateVersionedFlagPebbleDirUse))
+	MigrateVersioned.PersistentFlags().StringVarP(&genesisPath, "genesis-path", "g", "", common.TranslateInLang(migrateVersionedFlagGenesisPathUse))
+}
+
+func migrateVersionedDB() (err error) {
+	// Check flags
+	if boltFile == "" {
+		return fmt.Errorf("--bolt-file is empty")
+	}
+	if pebbleDir == "" {
+		return fmt.Errorf("--pebble-dir is empty")
+	}
+	if boltFile == pebbleDir {
+		return fmt.Errorf("the values of --bolt-file --pebble-dir flags cannot be the same")
+	}
+
+	g, err := genesis.New(genesisPath)
+	if err != nil {
+		return fmt.Errorf("failed to new genesis: %v", err)
+	}
+	// both DBs are opened with the versioned namespaces of the node, so that the metadata of the
+	// namespaces in the source is verified and migrated to the destination
+	vns := chainservice.ContractStakingHistoryNamespaces(g)
+	cfg := db.DefaultConfig
+	cfg.DbPath = boltFile
+	cfg.ReadOnly = true
+	src := db.NewBoltDBVersioned(cfg, db.VnsOption(vns...))
+	cfg.DbPath = pebbleDir
+	cfg.ReadOnly = false
+	dst := db.NewPebbleDBVersioned(cfg, db.PebbleVnsOption(vns...))
+
+	ctx := context.Background()
+	if err := src.Start(ctx); err != nil {
+		return fmt.Errorf("failed to start the bolt db file: %v", err)
+	}
+	defer func() {
+		if e := src.Stop(ctx); e != nil && err == nil {
+			err = e
+		}
+	}()
+	if err := dst.Start(ctx); err != nil {
+		return fmt.Errorf("failed to start the pebble db: %v", err)
+	}
+	defer func() {
+		if e := dst.Stop(ctx); e != nil && err == nil {
+			err = e
+		}
+	}()
+	if err := db.MigrateBoltDBVersioned(src, dst); err != nil {
+		return fmt.Errorf("failed to migrate versioned db: %v", err)
+	}
+	fmt.Printf("Migrated %s to %s.\n", boltFile, pebbleDir)
+	return nil
+}
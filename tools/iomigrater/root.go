@@ -47,6 +47,7 @@ func Execute() {
 func init() {
 	RootCmd.AddCommand(cmd.CheckHeight)
 	RootCmd.AddCommand(cmd.MigrateDb)
+	RootCmd.AddCommand(cmd.MigrateVersioned)
 
 	RootCmd.HelpFunc()
 }
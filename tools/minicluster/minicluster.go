@@ -100,6 +100,8 @@ func main() {
 		dbFilePaths = append(dbFilePaths, bloomfilterIndexDBPath)
 		consensusDBPath := fmt.Sprintf("./consensus%d.db", i+1)
 		dbFilePaths = append(dbFilePaths, consensusDBPath)
+		slashingProtectionDBPath := fmt.Sprintf("./slashingprotection%d.db", i+1)
+		dbFilePaths = append(dbFilePaths, slashingProtectionDBPath)
 		systemLogDBPath := fmt.Sprintf("./systemlog%d.db", i+1)
 		dbFilePaths = append(dbFilePaths, systemLogDBPath)
 		candidateIndexDBPath := fmt.Sprintf("./candidate.index%d.db", i+1)
@@ -123,6 +125,7 @@ func main() {
 		config.Chain.BloomfilterIndexDBPath = bloomfilterIndexDBPath
 		config.Chain.CandidateIndexDBPath = candidateIndexDBPath
 		config.Consensus.RollDPoS.ConsensusDBPath = consensusDBPath
+		config.Consensus.RollDPoS.SlashingProtectionDBPath = slashingProtectionDBPath
 		config.System.SystemLogDBPath = systemLogDBPath
 		config.Chain.ContractStakingIndexDBPath = contractStakingIndexDBPath
 		config.Chain.BlobStoreDBPath = blobDBPath
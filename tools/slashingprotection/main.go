@@ -0,0 +1,102 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+// This is a tool that exports or imports the slashing protection database of a block producer, which is used to
+// move the producer key between nodes safely. The node has to be stopped when the tool runs.
+// To use, run "make build-slashingprotection"
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/v2/config"
+	"github.com/iotexproject/iotex-core/v2/consensus/scheme/rolldpos"
+	"github.com/iotexproject/iotex-core/v2/db"
+	"github.com/iotexproject/iotex-core/v2/pkg/log"
+)
+
+var (
+	// _dbPath is the path of slashing protection db
+	_dbPath string
+	// _overwritePath is the path to the config file which overwrite default values
+	_overwritePath string
+	// _secretPath is the path to the config file store secret values
+	_secretPath string
+	// _exportPath is the path of the file to export to
+	_exportPath string
+	// _importPath is the path of the file to import from
+	_importPath string
+)
+
+func init() {
+	flag.StringVar(&_dbPath, "db-path", "", "Slashing protection DB path")
+	flag.StringVar(&_overwritePath, "config-path", "", "Config path")
+	flag.StringVar(&_secretPath, "secret-path", "", "Secret path")
+	flag.StringVar(&_exportPath, "export", "", "Path of the file to export the signed messages to")
+	flag.StringVar(&_importPath, "import", "", "Path of the file to import the signed messages from")
+	flag.Usage = func() {
+		_, _ = fmt.Fprintf(os.Stderr, "usage: slashingprotection -config-path=[string] (-export=[string] | -import=[string])\n")
+		flag.PrintDefaults()
+		os.Exit(2)
+	}
+	flag.Parse()
+}
+
+func readDBPath() string {
+	if _dbPath != "" {
+		return _dbPath
+	}
+	cfg, err := config.New([]string{_overwritePath, _secretPath}, []string{})
+	if err != nil {
+		log.S().Panic("failed to new config.", zap.Error(err))
+	}
+	return cfg.Consensus.RollDPoS.SlashingProtectionDBPath
+}
+
+func main() {
+	if (_exportPath == "") == (_importPath == "") {
+		flag.Usage()
+	}
+	dbPath := readDBPath()
+	if dbPath == "" {
+		log.S().Panic("slashing protection db path is empty")
+	}
+	cfg := db.DefaultConfig
+	cfg.DbPath = dbPath
+	cfg.ReadOnly = _exportPath != ""
+	sp := rolldpos.NewSlashingProtector(db.NewBoltDB(cfg))
+	if err := sp.Start(context.Background()); err != nil {
+		log.S().Panic("failed to start db", zap.Error(err))
+	}
+	defer func() {
+		if err := sp.Stop(context.Background()); err != nil {
+			log.S().Panic("failed to stop db", zap.Error(err))
+		}
+	}()
+	if _exportPath != "" {
+		f, err := os.Create(_exportPath)
+		if err != nil {
+			log.S().Panic("failed to create export file", zap.Error(err))
+		}
+		defer f.Close()
+		if err := sp.Export(f); err != nil {
+			log.S().Panic("failed to export slashing protection data", zap.Error(err))
+		}
+		return
+	}
+	f, err := os.Open(_importPath)
+	if err != nil {
+		log.S().Panic("failed to open import file", zap.Error(err))
+	}
+	defer f.Close()
+	if err := sp.Import(f); err != nil {
+		log.S().Panic("failed to import slashing protection data", zap.Error(err))
+	}
+}
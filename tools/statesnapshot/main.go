@@ -0,0 +1,186 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+// This is a tool that exports the state of a stopped node into a state snapshot, which a new node can
+// bootstrap from by setting chain.stateSnapshotDir. The snapshot can also be served to the peers enabling
+// blockSync.stateSync, by exporting it into the sub-directory named by its height in blockSync.stateSync.serveDir.
+// The block following the snapshot is required to verify the imported state, which is read from the chain db, or
+// from the API endpoint of a synced node if the chain db doesn't have it yet.
+// To use, run "make build-statesnapshot"
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/iotexproject/iotex-proto/golang/iotexapi"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+
+	"github.com/iotexproject/iotex-core/v2/blockchain/block"
+	"github.com/iotexproject/iotex-core/v2/blockchain/filedao"
+	"github.com/iotexproject/iotex-core/v2/chainservice"
+	"github.com/iotexproject/iotex-core/v2/config"
+	"github.com/iotexproject/iotex-core/v2/db"
+	"github.com/iotexproject/iotex-core/v2/pkg/log"
+	"github.com/iotexproject/iotex-core/v2/pkg/util/byteutil"
+	"github.com/iotexproject/iotex-core/v2/state/factory"
+)
+
+var (
+	// _overwritePath is the path to the config file which overwrite default values
+	_overwritePath string
+	// _secretPath is the path to the config file store secret values
+	_secretPath string
+	// _outputDir is the directory to write the snapshot
+	_outputDir string
+	// _height is the height to export the snapshot at
+	_height uint64
+	// _endpoint is the API endpoint to read the next block from
+	_endpoint string
+)
+
+func init() {
+	flag.StringVar(&_overwritePath, "config-path", "", "Config path")
+	flag.StringVar(&_secretPath, "secret-path", "", "Secret path")
+	flag.StringVar(&_outputDir, "output-dir", "", "Directory to write the snapshot")
+	flag.Uint64Var(&_height, "height", 0, "Snapshot height, which has to be the height of the state db, 0 means the height of the state db")
+	flag.StringVar(&_endpoint, "endpoint", "", "gRPC API endpoint to read the block following the snapshot, if the chain db doesn't have it")
+	flag.Usage = func() {
+		_, _ = fmt.Fprintf(os.Stderr, "usage: statesnapshot -config-path=[string] -output-dir=[string] -height=[uint64] -endpoint=[string]\n")
+		flag.PrintDefaults()
+		os.Exit(2)
+	}
+	flag.Parse()
+}
+
+func main() {
+	if _outputDir == "" {
+		flag.Usage()
+	}
+	cfg, err := config.New([]string{_overwritePath, _secretPath}, []string{})
+	if err != nil {
+		log.S().Panic("failed to new config.", zap.Error(err))
+	}
+	manifest, err := exportSnapshot(cfg)
+	if err != nil {
+		log.S().Panic("failed to export state snapshot.", zap.Error(err))
+	}
+	log.S().Infof("Exported state snapshot at height %d with digest %x to %s", manifest.Height, manifest.Digest(), _outputDir)
+}
+
+func exportSnapshot(cfg config.Config) (*factory.SnapshotManifest, error) {
+	ctx := context.Background()
+	dbConfig := cfg.DB
+	dbConfig.ReadOnly = true
+	factoryDBCfg := dbConfig
+	factoryDBCfg.DBType = cfg.Chain.FactoryDBType
+	stateStore, err := db.CreateKVStore(factoryDBCfg, cfg.Chain.TrieDBPath)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load state db")
+	}
+	stores := map[string]db.KVStore{factory.SnapshotStateStore: stateStore}
+	if cfg.Chain.EnableStakingProtocol {
+		dbConfig.DbPath = cfg.Chain.ContractStakingIndexDBPath
+		stores[chainservice.ContractStakingSnapshotStore] = db.NewBoltDB(dbConfig)
+	}
+	if cfg.Chain.EnableStakingIndexer {
+		dbConfig.DbPath = cfg.Chain.StakingIndexDBPath
+		stores[chainservice.StakingIndexSnapshotStore] = db.NewBoltDB(dbConfig)
+	}
+	for _, kv := range stores {
+		if err := kv.Start(ctx); err != nil {
+			return nil, err
+		}
+		defer kv.Stop(ctx)
+	}
+	h, err := stateStore.Get(factory.AccountKVNamespace, []byte(factory.CurrentHeightKey))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read state height")
+	}
+	height := byteutil.BytesToUint64(h)
+	if _height != 0 && _height != height {
+		return nil, errors.Errorf("state db is at height %d, stop the node at height %d to export the snapshot", height, _height)
+	}
+
+	dbConfig.DbPath = cfg.Chain.ChainDBPath
+	fd, err := filedao.NewFileDAO(dbConfig, block.NewDeserializer(cfg.Chain.EVMNetworkID))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load chain db")
+	}
+	if err := fd.Start(ctx); err != nil {
+		return nil, err
+	}
+	defer fd.Stop(ctx)
+	readBlock := func(height uint64) (*block.Store, error) {
+		blk, err := fd.GetBlockByHeight(height)
+		if err != nil {
+			return nil, err
+		}
+		receipts, err := fd.GetReceipts(height)
+		if err != nil {
+			return nil, err
+		}
+		return &block.Store{Block: blk, Receipts: receipts}, nil
+	}
+	blk, err := readBlock(height)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read block %d", height)
+	}
+	tip, err := fd.Height()
+	if err != nil {
+		return nil, err
+	}
+	// the next block is used by the importing node to verify the state
+	var next *block.Store
+	switch {
+	case tip > height:
+		next, err = readBlock(height + 1)
+	case _endpoint != "":
+		next, err = fetchBlock(ctx, cfg, height+1)
+	default:
+		return nil, errors.Errorf("chain db doesn't have block %d to verify the snapshot, set -endpoint to read it from a synced node", height+1)
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read block %d", height+1)
+	}
+	return factory.ExportSnapshot(_outputDir, stores, blk, next)
+}
+
+// fetchBlock reads the block and its receipts from the API endpoint
+func fetchBlock(ctx context.Context, cfg config.Config, height uint64) (*block.Store, error) {
+	conn, err := grpc.Dial(_endpoint, grpc.WithInsecure())
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	resp, err := iotexapi.NewAPIServiceClient(conn).GetRawBlocks(ctx, &iotexapi.GetRawBlocksRequest{
+		StartHeight:  height,
+		Count:        1,
+		WithReceipts: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Blocks) != 1 {
+		return nil, errors.Errorf("endpoint returns %d blocks", len(resp.Blocks))
+	}
+	var (
+		deser = block.NewDeserializer(cfg.Chain.EVMNetworkID)
+		pb    = &iotextypes.BlockStore{Block: resp.Blocks[0].Block, Receipts: resp.Blocks[0].Receipts}
+	)
+	blk, err := deser.BlockFromBlockStoreProto(pb)
+	if err != nil {
+		return nil, err
+	}
+	if blk.Receipts, err = deser.ReceiptsFromBlockStoreProto(pb); err != nil {
+		return nil, err
+	}
+	return &block.Store{Block: blk, Receipts: blk.Receipts}, nil
+}
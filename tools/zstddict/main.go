@@ -0,0 +1,113 @@
+// Copyright (c) 2025 IoTeX Foundation
+// This source code is provided 'as is' and no warranties are given as to title or non-infringement, merchantability
+// or fitness for purpose and, to the extent permitted by law, all liability for your use of the code is disclaimed.
+// This source code is governed by Apache License 2.0 that can be found in the LICENSE file.
+
+// This is a tool that trains a zstd dictionary on the blocks and receipts in the chain db, which can be used
+// by new chain db files by setting db.compressor to "Zstd" and db.compressorDict to the dictionary path.
+// To use, run "make build-zstddict"
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/v2/blockchain/block"
+	"github.com/iotexproject/iotex-core/v2/blockchain/filedao"
+	"github.com/iotexproject/iotex-core/v2/config"
+	"github.com/iotexproject/iotex-core/v2/pkg/compress"
+	"github.com/iotexproject/iotex-core/v2/pkg/log"
+)
+
+var (
+	// _overwritePath is the path to the config file which overwrite default values
+	_overwritePath string
+	// _secretPath is the path to the config file store secret values
+	_secretPath string
+	// _output is the path to write the dictionary
+	_output string
+	// _samples is the number of blocks to sample, counting back from the tip
+	_samples uint64
+	// _size is the max size of the dictionary
+	_size int
+	// _id is the id of the dictionary
+	_id uint
+)
+
+func init() {
+	flag.StringVar(&_overwritePath, "config-path", "", "Config path")
+	flag.StringVar(&_secretPath, "secret-path", "", "Secret path")
+	flag.StringVar(&_output, "output", "", "Path to write the dictionary")
+	flag.Uint64Var(&_samples, "samples", 10000, "Number of blocks to sample, counting back from the tip")
+	flag.IntVar(&_size, "size", 112640, "Max size of the dictionary")
+	flag.UintVar(&_id, "id", 1, "Id of the dictionary")
+	flag.Usage = func() {
+		_, _ = fmt.Fprintf(os.Stderr, "usage: zstddict -config-path=[string] -output=[string] -samples=[uint64] -size=[int] -id=[uint]\n")
+		flag.PrintDefaults()
+		os.Exit(2)
+	}
+	flag.Parse()
+}
+
+func main() {
+	if _output == "" {
+		flag.Usage()
+	}
+	cfg, err := config.New([]string{_overwritePath, _secretPath}, []string{})
+	if err != nil {
+		log.S().Panic("failed to new config.", zap.Error(err))
+	}
+	dict, err := trainDict(cfg)
+	if err != nil {
+		log.S().Panic("failed to train dictionary.", zap.Error(err))
+	}
+	if err := os.WriteFile(_output, dict, 0644); err != nil {
+		log.S().Panic("failed to write dictionary.", zap.Error(err))
+	}
+	log.S().Infof("Trained zstd dictionary of %d bytes to %s", len(dict), _output)
+}
+
+func trainDict(cfg config.Config) ([]byte, error) {
+	ctx := context.Background()
+	dbConfig := cfg.DB
+	dbConfig.ReadOnly = true
+	dbConfig.DbPath = cfg.Chain.ChainDBPath
+	fd, err := filedao.NewFileDAO(dbConfig, block.NewDeserializer(cfg.Chain.EVMNetworkID))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load chain db")
+	}
+	if err := fd.Start(ctx); err != nil {
+		return nil, err
+	}
+	defer fd.Stop(ctx)
+	tip, err := fd.Height()
+	if err != nil {
+		return nil, err
+	}
+	var samples [][]byte
+	for height := tip; height > 0 && uint64(len(samples)) < _samples; height-- {
+		blk, err := fd.GetBlockByHeight(height)
+		if err != nil {
+			if errors.Cause(err) == filedao.ErrNotSupported {
+				// below the start of the chain db
+				break
+			}
+			return nil, errors.Wrapf(err, "failed to read block %d", height)
+		}
+		receipts, err := fd.GetReceipts(height)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read receipts %d", height)
+		}
+		ser, err := (&block.Store{Block: blk, Receipts: receipts}).Serialize()
+		if err != nil {
+			return nil, err
+		}
+		samples = append(samples, ser)
+	}
+	return compress.BuildZstdDict(uint32(_id), samples, _size)
+}